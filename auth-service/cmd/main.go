@@ -6,10 +6,19 @@ import (
 	"github.com/SamEkb/messenger-app/auth-service/config/env"
 	"github.com/SamEkb/messenger-app/auth-service/internal/app/adapters/in/grpc"
 	"github.com/SamEkb/messenger-app/auth-service/internal/app/adapters/out/kafka"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/adapters/out/mailer"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/adapters/out/oidc"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/adapters/out/password"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/adapters/out/token"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/repositories/auth/cache"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/repositories/auth/in_memory"
 	"github.com/SamEkb/messenger-app/auth-service/internal/app/repositories/auth/postgres"
 	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth"
 	"github.com/SamEkb/messenger-app/pkg/platform/logger"
 	postgreslib "github.com/SamEkb/messenger-app/pkg/platform/postgres"
+	"github.com/redis/go-redis/v9"
 )
 
 func main() {
@@ -29,8 +38,61 @@ func main() {
 		log.Fatal("failed to create DB connection", "error", err)
 	}
 	txManager := postgreslib.NewTxManager(db)
-	authRepository := postgres.NewAuthRepository(txManager, log)
-	tokenRepository := postgres.NewTokenRepository(txManager, log)
+	var authRepository ports.AuthRepository = postgres.NewAuthRepository(txManager, log)
+	var tokenRepository ports.TokenRepository = postgres.NewTokenRepository(txManager, log)
+	refreshTokenRepository := postgres.NewRefreshTokenRepository(txManager, log)
+	oneTimeCodeRepository := postgres.NewOneTimeCodeRepository(txManager, log)
+	totpRepository := postgres.NewTOTPRepository(txManager, log)
+	recoveryCodeRepository := postgres.NewRecoveryCodeRepository(txManager, log)
+	oauthStateRepository := postgres.NewOAuthStateRepository(txManager, log)
+	oauthIdentityRepository := postgres.NewOAuthIdentityRepository(txManager, log)
+	apiKeyRepository := postgres.NewAPIKeyRepository(txManager, log)
+	securityEventRepository := postgres.NewSecurityEventRepository(txManager, log)
+
+	// The sweeper works on Postgres directly; cached entries of expired
+	// tokens fail validation anyway.
+	tokenSweeper := auth.NewTokenSweeper(tokenRepository, auth.TokenSweeperConfig{
+		Interval:  config.TokenSweep.Interval,
+		BatchSize: config.TokenSweep.BatchSize,
+		Retention: config.Auth.RefreshTokenTTL,
+	}, log)
+	go tokenSweeper.Run(ctx)
+
+	switch config.TokenCache.Type {
+	case env.TokenCacheLRU:
+		lruCache, err := cache.NewLRUTokenCache(config.TokenCache.Size)
+		if err != nil {
+			log.Fatal("failed to create token cache", "error", err)
+		}
+		tokenRepository = cache.NewTokenRepository(tokenRepository, lruCache, txManager, config.TokenCache.TTL, config.TokenCache.NegativeTTL, log)
+		accessCache, err := cache.NewLRUUserAccessCache(config.TokenCache.Size)
+		if err != nil {
+			log.Fatal("failed to create user access cache", "error", err)
+		}
+		authRepository = cache.NewAuthRepository(authRepository, accessCache, txManager, config.TokenCache.TTL, config.TokenCache.NegativeTTL, log)
+	case env.TokenCacheRedis:
+		redisClient := redis.NewClient(&redis.Options{
+			Addr:     config.Redis.Addr,
+			Password: config.Redis.Password,
+			DB:       config.Redis.DB,
+		})
+		defer redisClient.Close()
+		tokenRepository = cache.NewTokenRepository(tokenRepository, cache.NewRedisTokenCache(redisClient), txManager, config.TokenCache.TTL, config.TokenCache.NegativeTTL, log)
+		authRepository = cache.NewAuthRepository(authRepository, cache.NewRedisUserAccessCache(redisClient), txManager, config.TokenCache.TTL, config.TokenCache.NegativeTTL, log)
+	}
+
+	var loginAttemptRepository ports.LoginAttemptRepository = postgres.NewLoginAttemptRepository(txManager, log)
+	if config.LoginThrottle.Store == env.LoginAttemptStoreMemory {
+		loginAttemptRepository = in_memory.NewLoginAttemptRepository(log)
+	}
+
+	var mail ports.Mailer = mailer.NewLogMailer(log)
+	if config.Mailer.Type == env.MailerTypeFile {
+		mail, err = mailer.NewFileMailer(config.Mailer.FilePath)
+		if err != nil {
+			log.Fatal("failed to create file mailer", "error", err)
+		}
+	}
 
 	userEventPublisher, err := kafka.NewUserEventsKafkaProducer(config.Kafka, log)
 	if err != nil {
@@ -38,16 +100,116 @@ func main() {
 	}
 	defer userEventPublisher.Close()
 
+	userEventOutbox := kafka.NewUserEventsOutbox(postgreslib.NewOutbox(txManager), config.Kafka.Topic)
+	outboxRelay := postgreslib.NewOutboxRelay(txManager, userEventPublisher, postgreslib.OutboxRelayConfig{
+		PollInterval:    config.Outbox.PollInterval,
+		BatchSize:       config.Outbox.BatchSize,
+		RetryBackoff:    config.Outbox.RetryBackoff,
+		MaxRetryBackoff: config.Outbox.MaxRetryBackoff,
+		Retention:       config.Outbox.Retention,
+		PublishTimeout:  config.Outbox.PublishTimeout,
+	}, log)
+	go outboxRelay.Run(ctx)
+
+	var tokenIssuer ports.TokenIssuer = token.NewOpaqueIssuer()
+	var keySet ports.KeySetProvider
+	if config.Auth.TokenFormat == env.TokenFormatJWT {
+		var signingKeyRepository ports.SigningKeyRepository = postgres.NewSigningKeyRepository(txManager, log)
+		if config.Auth.JWTKeyStore == env.SigningKeyStoreMemory {
+			signingKeyRepository = in_memory.NewSigningKeyRepository(log)
+		}
+
+		keyManager, err := token.NewKeyManager(ctx, token.KeyManagerConfig{
+			Algorithm:      config.Auth.JWTAlgorithm,
+			Rotation:       config.Auth.JWTKeyRotation,
+			Retention:      max(config.Auth.TokenTTL, config.Auth.ImpersonationTTL),
+			ReloadInterval: config.Auth.JWTKeyReload,
+			EncryptionKey:  config.Auth.JWTKeyEncryptionKey,
+		}, signingKeyRepository, txManager, log)
+		if err != nil {
+			log.Fatal("failed to create signing key manager", "error", err)
+		}
+		go keyManager.Run(ctx)
+
+		tokenIssuer = token.NewJWTIssuer(keyManager)
+		keySet = keyManager
+	}
+
+	passwordHasher, err := password.NewHasher(password.Config{
+		Algorithm:  config.Password.HashAlgorithm,
+		BcryptCost: config.Password.BcryptCost,
+		Argon2: password.Argon2Params{
+			Memory:      config.Password.Argon2Memory,
+			Iterations:  config.Password.Argon2Iterations,
+			Parallelism: config.Password.Argon2Parallelism,
+		},
+	})
+	if err != nil {
+		log.Fatal("failed to create password hasher", "error", err)
+	}
+
+	identityProviders := make([]ports.IdentityProvider, 0, len(config.OAuth.Providers))
+	for _, providerConfig := range config.OAuth.Providers {
+		identityProviders = append(identityProviders, oidc.NewProvider(providerConfig, log))
+	}
+
 	usecase := auth.NewAuthUseCase(
-		txManager,
-		authRepository,
-		tokenRepository,
-		userEventPublisher,
-		config.Auth.TokenTTL,
-		log,
+		auth.Dependencies{
+			TxManager:         txManager,
+			AuthRepo:          authRepository,
+			TokenRepo:         tokenRepository,
+			RefreshTokenRepo:  refreshTokenRepository,
+			CodeRepo:          oneTimeCodeRepository,
+			TOTPRepo:          totpRepository,
+			RecoveryCodeRepo:  recoveryCodeRepository,
+			LoginAttemptRepo:  loginAttemptRepository,
+			OAuthStateRepo:    oauthStateRepository,
+			OAuthIdentityRepo: oauthIdentityRepository,
+			APIKeyRepo:        apiKeyRepository,
+			SecurityEventRepo: securityEventRepository,
+			IdentityProviders: identityProviders,
+			TokenIssuer:       tokenIssuer,
+			PasswordHasher:    passwordHasher,
+			Mailer:            mail,
+			UserEventOutbox:   userEventOutbox,
+			Logger:            log,
+		},
+		auth.Config{
+			TokenTTL:             config.Auth.TokenTTL,
+			RefreshTokenTTL:      config.Auth.RefreshTokenTTL,
+			PasswordResetTTL:     config.Auth.PasswordResetTTL,
+			EmailVerificationTTL: config.Auth.EmailVerificationTTL,
+			RequireVerifiedEmail: config.Auth.RequireVerifiedEmail,
+			TOTPIssuer:           config.Auth.TOTPIssuer,
+			SecondFactorTTL:      config.Auth.SecondFactorTTL,
+			LockoutPolicy: models.LockoutPolicy{
+				FreeAttempts:     config.LoginThrottle.FreeAttempts,
+				BackoffBase:      config.LoginThrottle.BackoffBase,
+				BackoffMax:       config.LoginThrottle.BackoffMax,
+				LockoutThreshold: config.LoginThrottle.LockoutThreshold,
+				LockoutDuration:  config.LoginThrottle.LockoutDuration,
+				FailureWindow:    config.LoginThrottle.FailureWindow,
+			},
+			PasswordPolicy: models.PasswordPolicy{
+				MinLength:     config.Password.MinLength,
+				MaxLength:     config.Password.MaxLength,
+				RequireUpper:  config.Password.RequireUpper,
+				RequireLower:  config.Password.RequireLower,
+				RequireDigit:  config.Password.RequireDigit,
+				RequireSymbol: config.Password.RequireSymbol,
+			},
+			LoginCodePolicy: models.LoginCodePolicy{
+				Mode:        models.LoginCodeMode(config.LoginCode.Mode),
+				TTL:         config.LoginCode.TTL,
+				MaxAttempts: config.LoginCode.MaxAttempts,
+				LinkURL:     config.LoginCode.LinkURL,
+			},
+			OAuthStateTTL:    config.OAuth.StateTTL,
+			ImpersonationTTL: config.Auth.ImpersonationTTL,
+		},
 	)
 
-	server, err := grpc.NewServer(config.Server, usecase, log)
+	server, err := grpc.NewServer(config.Server, usecase, keySet, log)
 	if err != nil {
 		log.Fatal("failed to create grpc server", "error", err)
 	}
@@ -1,8 +1,10 @@
 package env
 
 import (
+	"encoding/base64"
 	"fmt"
 	"log"
+	"net/netip"
 	"os"
 	"strconv"
 	"strings"
@@ -18,7 +20,107 @@ const (
 	DefaultKafkaTopic         = "user-events"
 	DefaultKafkaRetryInterval = 5 * time.Second
 	DefaultKafkaMaxRetry      = 3
-	DefaultTokenTTL           = 24 * time.Hour
+	DefaultTokenTTL           = 15 * time.Minute
+	DefaultRefreshTokenTTL    = 30 * 24 * time.Hour
+	DefaultPasswordResetTTL   = time.Hour
+	DefaultEmailVerifyTTL     = 24 * time.Hour
+	DefaultTOTPIssuer         = "Messenger"
+	DefaultSecondFactorTTL    = 5 * time.Minute
+	DefaultLoginAttemptStore  = LoginAttemptStorePostgres
+	DefaultLoginFreeAttempts  = 3
+	DefaultLoginBackoffBase   = time.Second
+	DefaultLoginBackoffMax    = 5 * time.Minute
+	DefaultLoginLockoutAfter  = 10
+	DefaultLoginLockoutTTL    = 15 * time.Minute
+	DefaultLoginFailureWindow = time.Hour
+	DefaultOAuthStateTTL      = 10 * time.Minute
+	DefaultImpersonationTTL   = 15 * time.Minute
+	DefaultOutboxPollInterval = time.Second
+	DefaultOutboxBatchSize    = 100
+	DefaultOutboxRetryBackoff = time.Second
+	DefaultOutboxMaxBackoff   = 5 * time.Minute
+	DefaultOutboxRetention    = 7 * 24 * time.Hour
+	DefaultOutboxSendTimeout  = 5 * time.Second
+	DefaultMailerType         = MailerTypeLog
+	DefaultMailerFilePath     = "./tmp/mail.log"
+	DefaultTokenFormat        = TokenFormatOpaque
+	DefaultJWTAlgorithm       = "EdDSA"
+	DefaultJWTKeyRotation     = 24 * time.Hour
+	DefaultJWTKeyStore        = SigningKeyStorePostgres
+	DefaultJWTKeyReload       = time.Minute
+	DefaultPasswordHash       = PasswordHashArgon2id
+	DefaultBcryptCost         = 12
+	DefaultArgon2Memory       = 64 * 1024
+	DefaultArgon2Iterations   = 3
+	DefaultArgon2Parallelism  = 2
+	DefaultPasswordMinLength  = 8
+	DefaultPasswordMaxLength  = 128
+	DefaultTokenCacheType     = TokenCacheLRU
+	DefaultTokenCacheSize     = 10000
+	DefaultTokenCacheTTL      = 30 * time.Second
+	DefaultTokenCacheNegTTL   = 10 * time.Second
+	DefaultRedisAddr          = "localhost:6379"
+	DefaultTokenSweepInterval = 10 * time.Minute
+	DefaultTokenSweepBatch    = 1000
+	DefaultLoginCodeMode      = LoginCodeOff
+	DefaultLoginCodeTTL       = 10 * time.Minute
+	DefaultLoginCodeAttempts  = 5
+)
+
+const (
+	// LoginCodeOff disables passwordless login.
+	LoginCodeOff = "off"
+	// LoginCodeDigits mails a six-digit login code.
+	LoginCodeDigits = "code"
+	// LoginCodeLink mails a login link to LOGIN_CODE_LINK_URL.
+	LoginCodeLink = "link"
+)
+
+const (
+	// TokenCacheLRU caches token and user access lookups in process memory.
+	// Fine for a single replica; with several, the others keep accepting a
+	// revoked token until their cached entry expires.
+	TokenCacheLRU = "lru"
+	// TokenCacheRedis caches the lookups in Redis, shared by all replicas.
+	TokenCacheRedis = "redis"
+	// TokenCacheNone sends every lookup to Postgres.
+	TokenCacheNone = "none"
+)
+
+const (
+	// PasswordHashBcrypt hashes new passwords with bcrypt.
+	PasswordHashBcrypt = "bcrypt"
+	// PasswordHashArgon2id hashes new passwords with argon2id.
+	PasswordHashArgon2id = "argon2id"
+)
+
+const (
+	// TokenFormatOpaque issues random tokens that are checked against the tokens table.
+	TokenFormatOpaque = "opaque"
+	// TokenFormatJWT issues signed JWTs that other services verify offline via JWKS.
+	TokenFormatJWT = "jwt"
+)
+
+const (
+	// SigningKeyStorePostgres shares the JWT signing keys between replicas.
+	SigningKeyStorePostgres = "postgres"
+	// SigningKeyStoreMemory keeps the JWT signing keys in process memory.
+	// Only for a single replica; the keys are lost on restart.
+	SigningKeyStoreMemory = "memory"
+)
+
+const (
+	// MailerTypeLog writes outgoing mail to the service log.
+	MailerTypeLog = "log"
+	// MailerTypeFile appends outgoing mail to MAILER_FILE_PATH.
+	MailerTypeFile = "file"
+)
+
+const (
+	// LoginAttemptStorePostgres shares failed login counters between replicas.
+	LoginAttemptStorePostgres = "postgres"
+	// LoginAttemptStoreMemory keeps failed login counters in process memory.
+	LoginAttemptStoreMemory = "memory"
 )
 
 type Config struct {
@@ -27,7 +129,22 @@ type Config struct {
 	Server  *ServerConfig
 	Kafka   *KafkaConfig
 	Auth    *AuthConfig
-	DB      *DBConfig
+	Mailer  *MailerConfig
+	// LoginThrottle configures brute-force protection for Login.
+	LoginThrottle *LoginThrottleConfig
+	// Password configures password hashing and the strength policy.
+	Password *PasswordConfig
+	// LoginCode configures passwordless login with emailed codes.
+	LoginCode *LoginCodeConfig
+	OAuth     *OAuthConfig
+	// Outbox configures the relay that publishes events from the outbox table.
+	Outbox *OutboxConfig
+	// TokenCache configures the cache in front of token lookups.
+	TokenCache *TokenCacheConfig
+	// TokenSweep configures the deletion of expired tokens.
+	TokenSweep *TokenSweepConfig
+	Redis      *RedisConfig
+	DB         *DBConfig
 }
 
 type ServerConfig struct {
@@ -35,6 +152,13 @@ type ServerConfig struct {
 	GRPCPort int
 	HTTPHost string
 	HTTPPort int
+	// TrustedProxies lists the proxies allowed to report the client address
+	// in x-forwarded-for. The login throttle keys on that address, so any
+	// other hop's entry is ignored.
+	TrustedProxies []netip.Prefix
+	// ServiceSecrets are accepted from services calling ValidateToken. More
+	// than one may be configured while a secret is rotated.
+	ServiceSecrets []string
 }
 
 type KafkaConfig struct {
@@ -46,7 +170,118 @@ type KafkaConfig struct {
 }
 
 type AuthConfig struct {
-	TokenTTL time.Duration
+	TokenTTL         time.Duration
+	RefreshTokenTTL  time.Duration
+	PasswordResetTTL time.Duration
+	// EmailVerificationTTL is how long a verification code sent on
+	// registration stays valid.
+	EmailVerificationTTL time.Duration
+	// RequireVerifiedEmail blocks Login until the user confirms their email.
+	RequireVerifiedEmail bool
+	// TOTPIssuer is the service name shown in authenticator apps.
+	TOTPIssuer string
+	// SecondFactorTTL is how long a two-step login challenge stays valid.
+	SecondFactorTTL time.Duration
+	TokenFormat     string
+	JWTAlgorithm    string
+	JWTKeyRotation  time.Duration
+	JWTKeyStore     string
+	// JWTKeyReload is how often a replica reads back the shared signing
+	// keys. A key rotated by another replica is published here that much
+	// later at most.
+	JWTKeyReload time.Duration
+	// JWTKeyEncryptionKey is the AES-256 key that encrypts the stored
+	// signing keys. It is required for JWTs.
+	JWTKeyEncryptionKey []byte
+	// ImpersonationTTL is how long a token issued by Impersonate stays valid.
+	ImpersonationTTL time.Duration
+}
+
+type MailerConfig struct {
+	Type     string
+	FilePath string
+}
+
+type PasswordConfig struct {
+	// HashAlgorithm is used for new hashes. Stored hashes of a weaker
+	// algorithm or cost are upgraded on the next successful login.
+	HashAlgorithm     string
+	BcryptCost        int
+	Argon2Memory      uint32
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+	MinLength         int
+	MaxLength         int
+	RequireUpper      bool
+	RequireLower      bool
+	RequireDigit      bool
+	RequireSymbol     bool
+}
+
+type LoginThrottleConfig struct {
+	Store            string
+	FreeAttempts     int
+	BackoffBase      time.Duration
+	BackoffMax       time.Duration
+	LockoutThreshold int
+	LockoutDuration  time.Duration
+	FailureWindow    time.Duration
+}
+
+type LoginCodeConfig struct {
+	Mode        string
+	TTL         time.Duration
+	MaxAttempts int
+	// LinkURL is the page that login links point to in link mode.
+	LinkURL string
+}
+
+type OAuthConfig struct {
+	// StateTTL is how long a started external login can be completed.
+	StateTTL  time.Duration
+	Providers []*OAuthProviderConfig
+}
+
+// OAuthProviderConfig describes an OpenID Connect provider. Providers are
+// listed in OAUTH_PROVIDERS and configured with OAUTH_<NAME>_* variables.
+type OAuthProviderConfig struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+type OutboxConfig struct {
+	PollInterval    time.Duration
+	BatchSize       int
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+	Retention       time.Duration
+	PublishTimeout  time.Duration
+}
+
+type TokenCacheConfig struct {
+	Type string
+	// Size is the number of entries kept by the in-process cache.
+	Size int
+	// TTL bounds how long a revoked token, role or suspension may still be
+	// missed when a lookup races the transaction that changed it.
+	TTL time.Duration
+	// NegativeTTL is how long unknown tokens are remembered.
+	NegativeTTL time.Duration
+}
+
+type TokenSweepConfig struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+type RedisConfig struct {
+	Addr     string
+	Password string
+	DB       int
 }
 
 type DBConfig struct {
@@ -76,17 +311,38 @@ func LoadConfig() (*Config, error) {
 	}
 
 	c := &Config{
-		AppName: getEnv("APP_NAME", "AuthService"),
-		Debug:   getEnv("DEBUG", "dev"),
-		Server:  &ServerConfig{},
-		Kafka:   &KafkaConfig{},
-		Auth:    &AuthConfig{},
+		AppName:       getEnv("APP_NAME", "AuthService"),
+		Debug:         getEnv("DEBUG", "dev"),
+		Server:        &ServerConfig{},
+		Kafka:         &KafkaConfig{},
+		Auth:          &AuthConfig{},
+		Mailer:        &MailerConfig{},
+		LoginThrottle: &LoginThrottleConfig{},
+		Password:      &PasswordConfig{},
+		LoginCode:     &LoginCodeConfig{},
+		OAuth:         &OAuthConfig{},
+		Outbox:        &OutboxConfig{},
+		TokenCache:    &TokenCacheConfig{},
+		TokenSweep:    &TokenSweepConfig{},
+		Redis:         &RedisConfig{},
 	}
 
 	c.Server.GRPCHost = getEnv("GRPC_HOST", "0.0.0.0")
 	c.Server.GRPCPort = getEnvAsInt("GRPC_PORT", DefaultGRPCPort)
 	c.Server.HTTPHost = getEnv("HTTP_HOST", "0.0.0.0")
 	c.Server.HTTPPort = getEnvAsInt("HTTP_PORT", DefaultHTTPPort)
+	for _, raw := range getEnvAsSlice("TRUSTED_PROXIES", nil) {
+		prefix, err := parsePrefix(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("invalid TRUSTED_PROXIES entry %q: %w", raw, err)
+		}
+		c.Server.TrustedProxies = append(c.Server.TrustedProxies, prefix)
+	}
+	for _, secret := range getEnvAsSlice("SERVICE_SECRETS", nil) {
+		if secret = strings.TrimSpace(secret); secret != "" {
+			c.Server.ServiceSecrets = append(c.Server.ServiceSecrets, secret)
+		}
+	}
 
 	c.Kafka.Brokers = getEnvAsSlice("KAFKA_BROKERS", []string{DefaultKafkaBroker})
 	c.Kafka.Topic = getEnv("KAFKA_PRODUCER_TOPIC", DefaultKafkaTopic)
@@ -95,6 +351,128 @@ func LoadConfig() (*Config, error) {
 	c.Kafka.RetryInterval = getEnvAsDuration("KAFKA_RETRY_INTERVAL", DefaultKafkaRetryInterval)
 
 	c.Auth.TokenTTL = getEnvAsDuration("AUTH_TOKEN_TTL", DefaultTokenTTL)
+	c.Auth.RefreshTokenTTL = getEnvAsDuration("AUTH_REFRESH_TOKEN_TTL", DefaultRefreshTokenTTL)
+	c.Auth.PasswordResetTTL = getEnvAsDuration("AUTH_PASSWORD_RESET_TTL", DefaultPasswordResetTTL)
+	c.Auth.EmailVerificationTTL = getEnvAsDuration("AUTH_EMAIL_VERIFICATION_TTL", DefaultEmailVerifyTTL)
+	c.Auth.RequireVerifiedEmail = getEnvAsBool("AUTH_REQUIRE_VERIFIED_EMAIL", false)
+	c.Auth.TOTPIssuer = getEnv("AUTH_TOTP_ISSUER", DefaultTOTPIssuer)
+	c.Auth.SecondFactorTTL = getEnvAsDuration("AUTH_SECOND_FACTOR_TTL", DefaultSecondFactorTTL)
+	c.Auth.TokenFormat = getEnv("AUTH_TOKEN_FORMAT", DefaultTokenFormat)
+	c.Auth.JWTAlgorithm = getEnv("AUTH_JWT_ALGORITHM", DefaultJWTAlgorithm)
+	c.Auth.JWTKeyRotation = getEnvAsDuration("AUTH_JWT_KEY_ROTATION", DefaultJWTKeyRotation)
+	c.Auth.JWTKeyStore = getEnv("AUTH_JWT_KEY_STORE", DefaultJWTKeyStore)
+	c.Auth.JWTKeyReload = getEnvAsDuration("AUTH_JWT_KEY_RELOAD_INTERVAL", DefaultJWTKeyReload)
+	c.Auth.ImpersonationTTL = getEnvAsDuration("AUTH_IMPERSONATION_TTL", DefaultImpersonationTTL)
+
+	if c.Auth.TokenFormat != TokenFormatOpaque && c.Auth.TokenFormat != TokenFormatJWT {
+		return nil, fmt.Errorf("unsupported AUTH_TOKEN_FORMAT %q", c.Auth.TokenFormat)
+	}
+	if c.Auth.JWTKeyStore != SigningKeyStorePostgres && c.Auth.JWTKeyStore != SigningKeyStoreMemory {
+		return nil, fmt.Errorf("unsupported AUTH_JWT_KEY_STORE %q", c.Auth.JWTKeyStore)
+	}
+	kek, err := base64.StdEncoding.DecodeString(getEnv("AUTH_JWT_KEY_ENCRYPTION_KEY", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid AUTH_JWT_KEY_ENCRYPTION_KEY: %w", err)
+	}
+	if c.Auth.TokenFormat == TokenFormatJWT && len(kek) != 32 {
+		return nil, fmt.Errorf("AUTH_JWT_KEY_ENCRYPTION_KEY must be 32 base64-encoded bytes, got %d", len(kek))
+	}
+	c.Auth.JWTKeyEncryptionKey = kek
+
+	c.Mailer.Type = getEnv("MAILER_TYPE", DefaultMailerType)
+	c.Mailer.FilePath = getEnv("MAILER_FILE_PATH", DefaultMailerFilePath)
+
+	c.LoginThrottle.Store = getEnv("LOGIN_THROTTLE_STORE", DefaultLoginAttemptStore)
+	c.LoginThrottle.FreeAttempts = getEnvAsInt("LOGIN_THROTTLE_FREE_ATTEMPTS", DefaultLoginFreeAttempts)
+	c.LoginThrottle.BackoffBase = getEnvAsDuration("LOGIN_THROTTLE_BACKOFF_BASE", DefaultLoginBackoffBase)
+	c.LoginThrottle.BackoffMax = getEnvAsDuration("LOGIN_THROTTLE_BACKOFF_MAX", DefaultLoginBackoffMax)
+	c.LoginThrottle.LockoutThreshold = getEnvAsInt("LOGIN_THROTTLE_LOCKOUT_THRESHOLD", DefaultLoginLockoutAfter)
+	c.LoginThrottle.LockoutDuration = getEnvAsDuration("LOGIN_THROTTLE_LOCKOUT_DURATION", DefaultLoginLockoutTTL)
+	c.LoginThrottle.FailureWindow = getEnvAsDuration("LOGIN_THROTTLE_FAILURE_WINDOW", DefaultLoginFailureWindow)
+
+	if c.LoginThrottle.Store != LoginAttemptStorePostgres && c.LoginThrottle.Store != LoginAttemptStoreMemory {
+		return nil, fmt.Errorf("unsupported LOGIN_THROTTLE_STORE %q", c.LoginThrottle.Store)
+	}
+
+	c.Password.HashAlgorithm = getEnv("PASSWORD_HASH_ALGORITHM", DefaultPasswordHash)
+	c.Password.BcryptCost = getEnvAsInt("PASSWORD_BCRYPT_COST", DefaultBcryptCost)
+	c.Password.Argon2Memory = uint32(getEnvAsInt("PASSWORD_ARGON2_MEMORY_KIB", DefaultArgon2Memory))
+	c.Password.Argon2Iterations = uint32(getEnvAsInt("PASSWORD_ARGON2_ITERATIONS", DefaultArgon2Iterations))
+	c.Password.Argon2Parallelism = uint8(getEnvAsInt("PASSWORD_ARGON2_PARALLELISM", DefaultArgon2Parallelism))
+	c.Password.MinLength = getEnvAsInt("PASSWORD_MIN_LENGTH", DefaultPasswordMinLength)
+	c.Password.MaxLength = getEnvAsInt("PASSWORD_MAX_LENGTH", DefaultPasswordMaxLength)
+	c.Password.RequireUpper = getEnvAsBool("PASSWORD_REQUIRE_UPPER", false)
+	c.Password.RequireLower = getEnvAsBool("PASSWORD_REQUIRE_LOWER", false)
+	c.Password.RequireDigit = getEnvAsBool("PASSWORD_REQUIRE_DIGIT", false)
+	c.Password.RequireSymbol = getEnvAsBool("PASSWORD_REQUIRE_SYMBOL", false)
+
+	if c.Password.HashAlgorithm != PasswordHashBcrypt && c.Password.HashAlgorithm != PasswordHashArgon2id {
+		return nil, fmt.Errorf("unsupported PASSWORD_HASH_ALGORITHM %q", c.Password.HashAlgorithm)
+	}
+
+	c.LoginCode.Mode = getEnv("LOGIN_CODE_MODE", DefaultLoginCodeMode)
+	c.LoginCode.TTL = getEnvAsDuration("LOGIN_CODE_TTL", DefaultLoginCodeTTL)
+	c.LoginCode.MaxAttempts = getEnvAsInt("LOGIN_CODE_MAX_ATTEMPTS", DefaultLoginCodeAttempts)
+	c.LoginCode.LinkURL = getEnv("LOGIN_CODE_LINK_URL", "")
+
+	switch c.LoginCode.Mode {
+	case LoginCodeOff, LoginCodeDigits:
+	case LoginCodeLink:
+		if c.LoginCode.LinkURL == "" {
+			return nil, fmt.Errorf("LOGIN_CODE_LINK_URL is required when LOGIN_CODE_MODE is %q", LoginCodeLink)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported LOGIN_CODE_MODE %q", c.LoginCode.Mode)
+	}
+
+	if c.Mailer.Type != MailerTypeLog && c.Mailer.Type != MailerTypeFile {
+		return nil, fmt.Errorf("unsupported MAILER_TYPE %q", c.Mailer.Type)
+	}
+
+	c.OAuth.StateTTL = getEnvAsDuration("OAUTH_STATE_TTL", DefaultOAuthStateTTL)
+	for _, name := range getEnvAsSlice("OAUTH_PROVIDERS", nil) {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		prefix := "OAUTH_" + strings.ToUpper(name) + "_"
+		provider := &OAuthProviderConfig{
+			Name:         name,
+			Issuer:       getEnv(prefix+"ISSUER", ""),
+			ClientID:     getEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret: getEnv(prefix+"CLIENT_SECRET", ""),
+			RedirectURL:  getEnv(prefix+"REDIRECT_URL", ""),
+			Scopes:       getEnvAsSlice(prefix+"SCOPES", []string{"openid", "email", "profile"}),
+		}
+		if provider.Issuer == "" || provider.ClientID == "" || provider.RedirectURL == "" {
+			return nil, fmt.Errorf("%sISSUER, %sCLIENT_ID and %sREDIRECT_URL are required", prefix, prefix, prefix)
+		}
+		c.OAuth.Providers = append(c.OAuth.Providers, provider)
+	}
+
+	c.Outbox.PollInterval = getEnvAsDuration("OUTBOX_POLL_INTERVAL", DefaultOutboxPollInterval)
+	c.Outbox.BatchSize = getEnvAsInt("OUTBOX_BATCH_SIZE", DefaultOutboxBatchSize)
+	c.Outbox.RetryBackoff = getEnvAsDuration("OUTBOX_RETRY_BACKOFF", DefaultOutboxRetryBackoff)
+	c.Outbox.MaxRetryBackoff = getEnvAsDuration("OUTBOX_MAX_RETRY_BACKOFF", DefaultOutboxMaxBackoff)
+	c.Outbox.Retention = getEnvAsDuration("OUTBOX_RETENTION", DefaultOutboxRetention)
+	c.Outbox.PublishTimeout = getEnvAsDuration("OUTBOX_PUBLISH_TIMEOUT", DefaultOutboxSendTimeout)
+
+	c.TokenCache.Type = getEnv("TOKEN_CACHE_TYPE", DefaultTokenCacheType)
+	c.TokenCache.Size = getEnvAsInt("TOKEN_CACHE_SIZE", DefaultTokenCacheSize)
+	c.TokenCache.TTL = getEnvAsDuration("TOKEN_CACHE_TTL", DefaultTokenCacheTTL)
+	c.TokenCache.NegativeTTL = getEnvAsDuration("TOKEN_CACHE_NEGATIVE_TTL", DefaultTokenCacheNegTTL)
+
+	if c.TokenCache.Type != TokenCacheLRU && c.TokenCache.Type != TokenCacheRedis && c.TokenCache.Type != TokenCacheNone {
+		return nil, fmt.Errorf("unsupported TOKEN_CACHE_TYPE %q", c.TokenCache.Type)
+	}
+
+	c.TokenSweep.Interval = getEnvAsDuration("TOKEN_SWEEP_INTERVAL", DefaultTokenSweepInterval)
+	c.TokenSweep.BatchSize = getEnvAsInt("TOKEN_SWEEP_BATCH_SIZE", DefaultTokenSweepBatch)
+
+	c.Redis.Addr = getEnv("REDIS_ADDR", DefaultRedisAddr)
+	c.Redis.Password = getEnv("REDIS_PASSWORD", "")
+	c.Redis.DB = getEnvAsInt("REDIS_DB", 0)
 
 	c.DB = &DBConfig{
 		Host:     getEnv("POSTGRES_HOST", "localhost"),
@@ -150,3 +528,15 @@ func getEnvAsSlice(key string, defaultValue []string) []string {
 	}
 	return defaultValue
 }
+
+// parsePrefix accepts a CIDR range or a single address.
+func parsePrefix(raw string) (netip.Prefix, error) {
+	if strings.Contains(raw, "/") {
+		return netip.ParsePrefix(raw)
+	}
+	addr, err := netip.ParseAddr(raw)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
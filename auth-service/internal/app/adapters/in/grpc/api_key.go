@@ -0,0 +1,29 @@
+package grpc
+
+import (
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+)
+
+func apiKeyToProto(key *ports.APIKeyDto) *auth.ApiKey {
+	return &auth.ApiKey{
+		KeyId:      key.ID.String(),
+		Name:       key.Name,
+		Hint:       key.Hint,
+		Scopes:     models.ScopeNames(key.Scopes),
+		CreatedAt:  key.CreatedAt.Unix(),
+		LastUsedAt: unixOrZero(key.LastUsedAt),
+	}
+}
+
+// unixOrZero converts t to a Unix timestamp, keeping the zero time as zero
+// the way the API documents unset times.
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
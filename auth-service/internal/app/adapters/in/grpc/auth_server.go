@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net"
 	"net/http"
@@ -13,6 +14,7 @@ import (
 	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
 	middlewaregrpc "github.com/SamEkb/messenger-app/auth-service/internal/middleware/grpc"
 	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
 	apperrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
 	"github.com/SamEkb/messenger-app/pkg/platform/logger"
 	protovalidatemw "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/protovalidate"
@@ -23,15 +25,43 @@ import (
 
 var _ ports.UserGrpcServer = (*Server)(nil)
 
+var publicMethods = []string{
+	auth.AuthService_Register_FullMethodName,
+	auth.AuthService_Login_FullMethodName,
+	auth.AuthService_RequestLoginCode_FullMethodName,
+	auth.AuthService_LoginWithCode_FullMethodName,
+	auth.AuthService_Logout_FullMethodName,
+	auth.AuthService_RefreshToken_FullMethodName,
+	auth.AuthService_RequestPasswordReset_FullMethodName,
+	auth.AuthService_ConfirmPasswordReset_FullMethodName,
+	auth.AuthService_VerifyEmail_FullMethodName,
+	auth.AuthService_ResendVerification_FullMethodName,
+	auth.AuthService_ConfirmEmailChange_FullMethodName,
+	auth.AuthService_VerifySecondFactor_FullMethodName,
+	auth.AuthService_StartOAuthLogin_FullMethodName,
+	auth.AuthService_CompleteOAuthLogin_FullMethodName,
+}
+
+// serviceMethods are called by other services rather than users and are
+// authenticated with a service secret. Token introspection must not be open
+// to anyone who can reach the endpoint (RFC 7662, section 2.1).
+var serviceMethods = []string{
+	auth.AuthService_ValidateToken_FullMethodName,
+}
+
 type Server struct {
 	auth.UnimplementedAuthServiceServer
 	validator   protovalidate.Validator
 	authUseCase ports.AuthUseCase
+	keySet      ports.KeySetProvider
+	clients     clientResolver
 	cfg         *env.ServerConfig
 	logger      logger.Logger
 }
 
-func NewServer(cfg *env.ServerConfig, authUseCase ports.AuthUseCase, logger logger.Logger) (*Server, error) {
+// NewServer creates the auth gRPC and HTTP servers. keySet may be nil when
+// opaque tokens are used, in which case the JWKS endpoint serves an empty set.
+func NewServer(cfg *env.ServerConfig, authUseCase ports.AuthUseCase, keySet ports.KeySetProvider, logger logger.Logger) (*Server, error) {
 	validator, err := protovalidate.New()
 	if err != nil {
 		return nil, apperrors.NewInternalError(err, "failed to initialize validator")
@@ -40,6 +70,8 @@ func NewServer(cfg *env.ServerConfig, authUseCase ports.AuthUseCase, logger logg
 	return &Server{
 		validator:   validator,
 		authUseCase: authUseCase,
+		keySet:      keySet,
+		clients:     newClientResolver(cfg.TrustedProxies),
 		cfg:         cfg,
 		logger:      logger,
 	}, nil
@@ -55,9 +87,31 @@ func readyHandler(w http.ResponseWriter, _ *http.Request) {
 	w.Write([]byte("Ready"))
 }
 
+func (s *Server) jwksHandler(w http.ResponseWriter, _ *http.Request) {
+	set := platformauth.JWKSet{Keys: []platformauth.JWK{}}
+	if s.keySet != nil {
+		set = s.keySet.JWKS()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	if err := json.NewEncoder(w).Encode(set); err != nil {
+		s.logger.Error("failed to write JWKS", "error", err)
+	}
+}
+
 func (s *Server) RunServers(ctx context.Context) error {
 	var wg sync.WaitGroup
 
+	verifier := &tokenVerifier{authUseCase: s.authUseCase, clients: s.clients}
+	authOptions := []platformauth.Option{
+		platformauth.WithPublicMethods(publicMethods...),
+		platformauth.WithServiceMethods(s.cfg.ServiceSecrets, serviceMethods...),
+	}
+	if len(s.cfg.ServiceSecrets) == 0 {
+		s.logger.Warn("no service secrets configured, token introspection is disabled")
+	}
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
@@ -65,6 +119,10 @@ func (s *Server) RunServers(ctx context.Context) error {
 			grpc.ChainUnaryInterceptor(
 				protovalidatemw.UnaryServerInterceptor(s.validator),
 				middlewaregrpc.ErrorsUnaryServerInterceptor(),
+				platformauth.UnaryServerInterceptor(verifier, authOptions...),
+			),
+			grpc.ChainStreamInterceptor(
+				platformauth.StreamServerInterceptor(verifier, authOptions...),
 			),
 		)
 		auth.RegisterAuthServiceServer(grpcServer, s)
@@ -99,7 +157,9 @@ func (s *Server) RunServers(ctx context.Context) error {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		mux := runtime.NewServeMux()
+		mux := runtime.NewServeMux(runtime.WithMiddlewares(
+			platformauth.HTTPMiddleware(verifier, auth.File_auth_service_v1_service_proto.Services().ByName("AuthService"), authOptions...),
+		))
 		if err := auth.RegisterAuthServiceHandlerServer(ctx, mux, s); err != nil {
 			s.logger.Error("gateway registration error", "error", err)
 			return
@@ -109,6 +169,7 @@ func (s *Server) RunServers(ctx context.Context) error {
 		root.Handle("/", mux)
 		root.HandleFunc("/live", liveHandler)
 		root.HandleFunc("/ready", readyHandler)
+		root.HandleFunc("/.well-known/jwks.json", s.jwksHandler)
 
 		addr := s.cfg.HttpAddr()
 		httpServer := &http.Server{
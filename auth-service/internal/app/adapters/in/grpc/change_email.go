@@ -0,0 +1,39 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (s *Server) ChangeEmail(ctx context.Context, req *auth.ChangeEmailRequest) (*auth.ChangeEmailResponse, error) {
+	if err := platformauth.RejectImpersonation(ctx); err != nil {
+		s.logger.Warn("change email denied", "error", err)
+		return nil, err
+	}
+
+	if err := s.validator.Validate(req); err != nil {
+		s.logger.Error("validation error", "error", err)
+		return nil, errors.NewValidationError("invalid request: %v", err)
+	}
+
+	s.logger.Info("change email request received")
+
+	expiresAt, err := s.authUseCase.ChangeEmail(s.clients.withClient(ctx), &ports.ChangeEmailDto{
+		NewEmail:        req.GetNewEmail(),
+		CurrentPassword: req.GetCurrentPassword(),
+	})
+	if err != nil {
+		s.logger.Error("change email failed", "error", err)
+		return nil, err
+	}
+
+	return &auth.ChangeEmailResponse{
+		Success:   true,
+		Message:   "A confirmation code has been sent to the new address",
+		ExpiresAt: expiresAt.Unix(),
+	}, nil
+}
@@ -0,0 +1,38 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (s *Server) ChangePassword(ctx context.Context, req *auth.ChangePasswordRequest) (*auth.ChangePasswordResponse, error) {
+	if err := platformauth.RejectImpersonation(ctx); err != nil {
+		s.logger.Warn("change password denied", "error", err)
+		return nil, err
+	}
+
+	if err := s.validator.Validate(req); err != nil {
+		s.logger.Error("validation error", "error", err)
+		return nil, errors.NewValidationError("invalid request: %v", err)
+	}
+
+	s.logger.Info("change password request received")
+
+	err := s.authUseCase.ChangePassword(s.clients.withClient(ctx), &ports.ChangePasswordDto{
+		CurrentPassword: req.GetCurrentPassword(),
+		NewPassword:     req.GetNewPassword(),
+	})
+	if err != nil {
+		s.logger.Error("change password failed", "error", err)
+		return nil, err
+	}
+
+	return &auth.ChangePasswordResponse{
+		Success: true,
+		Message: "Password changed successfully",
+	}, nil
+}
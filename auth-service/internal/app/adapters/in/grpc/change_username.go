@@ -0,0 +1,33 @@
+package grpc
+
+import (
+	"context"
+
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (s *Server) ChangeUsername(ctx context.Context, req *auth.ChangeUsernameRequest) (*auth.ChangeUsernameResponse, error) {
+	if err := platformauth.RejectImpersonation(ctx); err != nil {
+		s.logger.Warn("change username denied", "error", err)
+		return nil, err
+	}
+
+	if err := s.validator.Validate(req); err != nil {
+		s.logger.Error("validation error", "error", err)
+		return nil, errors.NewValidationError("invalid request: %v", err)
+	}
+
+	s.logger.Info("change username request received")
+
+	if err := s.authUseCase.ChangeUsername(s.clients.withClient(ctx), req.GetNewUsername()); err != nil {
+		s.logger.Error("change username failed", "error", err)
+		return nil, err
+	}
+
+	return &auth.ChangeUsernameResponse{
+		Success: true,
+		Message: "Username has been changed",
+	}, nil
+}
@@ -0,0 +1,51 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (s *Server) CompleteOAuthLogin(ctx context.Context, req *auth.CompleteOAuthLoginRequest) (*auth.CompleteOAuthLoginResponse, error) {
+	if err := s.validator.Validate(req); err != nil {
+		s.logger.Error("validation error", "error", err)
+		return nil, errors.NewValidationError("invalid request: %v", err)
+	}
+
+	s.logger.Info("oauth login callback received", "provider", req.GetProvider())
+
+	result, err := s.authUseCase.CompleteOAuthLogin(ctx, &ports.CompleteOAuthLoginDto{
+		Provider: req.GetProvider(),
+		State:    models.Token(req.GetState()),
+		Code:     req.GetCode(),
+		Session:  s.clients.sessionMetadata(ctx, req.GetDeviceName()),
+	})
+	if err != nil {
+		s.logger.Error("oauth login failed", "provider", req.GetProvider(), "error", err)
+		return nil, err
+	}
+
+	if result.Challenge != nil {
+		return &auth.CompleteOAuthLoginResponse{
+			Success:              true,
+			Message:              "Second factor required",
+			SecondFactorRequired: true,
+			ChallengeToken:       string(result.Challenge.Token),
+			ChallengeExpiresAt:   result.Challenge.ExpiresAt.Unix(),
+		}, nil
+	}
+
+	tokens := result.Tokens
+	return &auth.CompleteOAuthLoginResponse{
+		Token:            string(tokens.AccessToken),
+		UserId:           tokens.UserID.String(),
+		ExpiresAt:        tokens.AccessTokenExpiresAt.Unix(),
+		Success:          true,
+		Message:          "Login successful",
+		RefreshToken:     string(tokens.RefreshToken),
+		RefreshExpiresAt: tokens.RefreshTokenExpiresAt.Unix(),
+	}, nil
+}
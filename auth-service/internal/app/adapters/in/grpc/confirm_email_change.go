@@ -0,0 +1,28 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (s *Server) ConfirmEmailChange(ctx context.Context, req *auth.ConfirmEmailChangeRequest) (*auth.ConfirmEmailChangeResponse, error) {
+	if err := s.validator.Validate(req); err != nil {
+		s.logger.Error("validation error", "error", err)
+		return nil, errors.NewValidationError("invalid request: %v", err)
+	}
+
+	s.logger.Info("email change confirmation request received")
+
+	if err := s.authUseCase.ConfirmEmailChange(s.clients.withClient(ctx), models.Token(req.GetCode())); err != nil {
+		s.logger.Error("email change confirmation failed", "error", err)
+		return nil, err
+	}
+
+	return &auth.ConfirmEmailChangeResponse{
+		Success: true,
+		Message: "Email address has been changed",
+	}, nil
+}
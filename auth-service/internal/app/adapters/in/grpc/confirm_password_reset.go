@@ -0,0 +1,33 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (s *Server) ConfirmPasswordReset(ctx context.Context, req *auth.ConfirmPasswordResetRequest) (*auth.ConfirmPasswordResetResponse, error) {
+	if err := s.validator.Validate(req); err != nil {
+		s.logger.Error("validation error", "error", err)
+		return nil, errors.NewValidationError("invalid request: %v", err)
+	}
+
+	s.logger.Info("password reset confirmation received")
+
+	err := s.authUseCase.ConfirmPasswordReset(s.clients.withClient(ctx), &ports.ConfirmPasswordResetDto{
+		Code:        models.Token(req.GetCode()),
+		NewPassword: req.GetNewPassword(),
+	})
+	if err != nil {
+		s.logger.Error("password reset confirmation failed", "error", err)
+		return nil, err
+	}
+
+	return &auth.ConfirmPasswordResetResponse{
+		Success: true,
+		Message: "Password has been reset",
+	}, nil
+}
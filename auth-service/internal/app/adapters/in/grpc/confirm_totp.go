@@ -0,0 +1,40 @@
+package grpc
+
+import (
+	"context"
+
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (s *Server) ConfirmTOTP(ctx context.Context, req *auth.ConfirmTOTPRequest) (*auth.ConfirmTOTPResponse, error) {
+	if err := platformauth.RejectImpersonation(ctx); err != nil {
+		s.logger.Warn("totp confirmation denied", "error", err)
+		return nil, err
+	}
+
+	if err := s.validator.Validate(req); err != nil {
+		s.logger.Error("validation error", "error", err)
+		return nil, errors.NewValidationError("invalid request: %v", err)
+	}
+
+	s.logger.Info("totp confirmation request received")
+
+	recoveryCodes, err := s.authUseCase.ConfirmTOTP(ctx, req.GetCode())
+	if err != nil {
+		s.logger.Error("totp confirmation failed", "error", err)
+		return nil, err
+	}
+
+	codes := make([]string, 0, len(recoveryCodes))
+	for _, code := range recoveryCodes {
+		codes = append(codes, string(code))
+	}
+
+	return &auth.ConfirmTOTPResponse{
+		Success:       true,
+		Message:       "Two-factor authentication enabled",
+		RecoveryCodes: codes,
+	}, nil
+}
@@ -0,0 +1,38 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (s *Server) CreateApiKey(ctx context.Context, req *auth.CreateApiKeyRequest) (*auth.CreateApiKeyResponse, error) {
+	if err := platformauth.RejectImpersonation(ctx); err != nil {
+		s.logger.Warn("create api key denied", "error", err)
+		return nil, err
+	}
+
+	if err := s.validator.Validate(req); err != nil {
+		s.logger.Error("validation error", "error", err)
+		return nil, errors.NewValidationError("invalid request: %v", err)
+	}
+
+	s.logger.Info("create API key request received", "scopes", req.GetScopes())
+
+	created, err := s.authUseCase.CreateAPIKey(ctx, &ports.CreateAPIKeyDto{
+		Name:   req.GetName(),
+		Scopes: req.GetScopes(),
+	})
+	if err != nil {
+		s.logger.Error("create API key failed", "error", err)
+		return nil, err
+	}
+
+	return &auth.CreateApiKeyResponse{
+		ApiKey: apiKeyToProto(created.APIKey),
+		Key:    string(created.Key),
+	}, nil
+}
@@ -0,0 +1,51 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (s *Server) DeleteAccount(ctx context.Context, req *auth.DeleteAccountRequest) (*auth.DeleteAccountResponse, error) {
+	if err := platformauth.RejectImpersonation(ctx); err != nil {
+		s.logger.Warn("delete account denied", "error", err)
+		return nil, err
+	}
+
+	if err := s.validator.Validate(req); err != nil {
+		s.logger.Error("validation error", "error", err)
+		return nil, errors.NewValidationError("invalid request: %v", err)
+	}
+
+	// The admin role is only needed for someone else's account, so the use
+	// case checks it rather than this handler.
+	var userID models.UserID
+	if req.GetUserId() != "" {
+		var err error
+		userID, err = models.UserIDFromString(req.GetUserId())
+		if err != nil {
+			return nil, errors.NewValidationError("invalid user id").
+				WithDetails("user_id", req.GetUserId())
+		}
+	}
+
+	s.logger.Info("delete account request received", "user_id", req.GetUserId())
+
+	err := s.authUseCase.DeleteAccount(ctx, &ports.DeleteAccountDto{
+		UserID:   userID,
+		Password: req.GetPassword(),
+	})
+	if err != nil {
+		s.logger.Error("delete account failed", "error", err)
+		return nil, err
+	}
+
+	return &auth.DeleteAccountResponse{
+		Success: true,
+		Message: "Account deleted successfully",
+	}, nil
+}
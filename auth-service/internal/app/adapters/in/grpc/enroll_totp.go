@@ -0,0 +1,28 @@
+package grpc
+
+import (
+	"context"
+
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+)
+
+func (s *Server) EnrollTOTP(ctx context.Context, req *auth.EnrollTOTPRequest) (*auth.EnrollTOTPResponse, error) {
+	if err := platformauth.RejectImpersonation(ctx); err != nil {
+		s.logger.Warn("totp enrollment denied", "error", err)
+		return nil, err
+	}
+
+	s.logger.Info("totp enrollment request received")
+
+	enrollment, err := s.authUseCase.EnrollTOTP(ctx)
+	if err != nil {
+		s.logger.Error("totp enrollment failed", "error", err)
+		return nil, err
+	}
+
+	return &auth.EnrollTOTPResponse{
+		Secret:     enrollment.Secret,
+		OtpauthUri: enrollment.URI,
+	}, nil
+}
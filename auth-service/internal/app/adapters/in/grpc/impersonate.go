@@ -0,0 +1,48 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (s *Server) Impersonate(ctx context.Context, req *auth.ImpersonateRequest) (*auth.ImpersonateResponse, error) {
+	if err := platformauth.RejectImpersonation(ctx); err != nil {
+		s.logger.Warn("impersonate denied", "error", err)
+		return nil, err
+	}
+
+	if err := s.validator.Validate(req); err != nil {
+		s.logger.Error("validation error", "error", err)
+		return nil, errors.NewValidationError("invalid request: %v", err).
+			WithDetails("user_id", req.GetUserId())
+	}
+
+	userID, err := models.UserIDFromString(req.GetUserId())
+	if err != nil {
+		return nil, errors.NewValidationError("invalid user id").
+			WithDetails("user_id", req.GetUserId())
+	}
+
+	s.logger.Info("impersonate request received", "user_id", userID)
+
+	impersonation, err := s.authUseCase.Impersonate(s.clients.withClient(ctx), &ports.ImpersonateDto{
+		UserID: userID,
+		Reason: req.GetReason(),
+	})
+	if err != nil {
+		s.logger.Error("impersonate failed", "error", err)
+		return nil, err
+	}
+
+	return &auth.ImpersonateResponse{
+		Token:     string(impersonation.AccessToken),
+		UserId:    impersonation.UserID.String(),
+		ActorId:   impersonation.ActorID.String(),
+		ExpiresAt: impersonation.ExpiresAt.Unix(),
+	}, nil
+}
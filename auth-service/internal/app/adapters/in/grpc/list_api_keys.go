@@ -0,0 +1,26 @@
+package grpc
+
+import (
+	"context"
+
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+)
+
+func (s *Server) ListApiKeys(ctx context.Context, _ *auth.ListApiKeysRequest) (*auth.ListApiKeysResponse, error) {
+	s.logger.Debug("list API keys request received")
+
+	keys, err := s.authUseCase.ListAPIKeys(ctx)
+	if err != nil {
+		s.logger.Error("list API keys failed", "error", err)
+		return nil, err
+	}
+
+	resp := &auth.ListApiKeysResponse{
+		ApiKeys: make([]*auth.ApiKey, 0, len(keys)),
+	}
+	for _, key := range keys {
+		resp.ApiKeys = append(resp.ApiKeys, apiKeyToProto(key))
+	}
+
+	return resp, nil
+}
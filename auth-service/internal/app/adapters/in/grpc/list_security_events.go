@@ -0,0 +1,78 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (s *Server) ListSecurityEvents(ctx context.Context, req *auth.ListSecurityEventsRequest) (*auth.ListSecurityEventsResponse, error) {
+	if err := s.validator.Validate(req); err != nil {
+		s.logger.Error("validation error", "error", err)
+		return nil, errors.NewValidationError("invalid request: %v", err)
+	}
+
+	// Users may list their own events, so the use case checks the admin role
+	// only when another user is queried.
+	var userID models.UserID
+	if req.GetUserId() != "" {
+		var err error
+		userID, err = models.UserIDFromString(req.GetUserId())
+		if err != nil {
+			return nil, errors.NewValidationError("invalid user id").
+				WithDetails("user_id", req.GetUserId())
+		}
+	}
+
+	s.logger.Debug("list security events request received", "user_id", req.GetUserId())
+
+	events, err := s.authUseCase.ListSecurityEvents(ctx, &ports.ListSecurityEventsDto{
+		UserID: userID,
+		Since:  timeFromUnix(req.GetSince()),
+		Until:  timeFromUnix(req.GetUntil()),
+		Limit:  int(req.GetLimit()),
+	})
+	if err != nil {
+		s.logger.Error("list security events failed", "error", err)
+		return nil, err
+	}
+
+	resp := &auth.ListSecurityEventsResponse{
+		Events: make([]*auth.SecurityEvent, 0, len(events)),
+	}
+	for _, event := range events {
+		var eventUserID string
+		if !event.UserID.IsEmpty() {
+			eventUserID = event.UserID.String()
+		}
+		var actorID string
+		if !event.ActorID.IsEmpty() {
+			actorID = event.ActorID.String()
+		}
+		resp.Events = append(resp.Events, &auth.SecurityEvent{
+			EventId:    event.ID.String(),
+			UserId:     eventUserID,
+			Type:       event.Type.String(),
+			Email:      event.Email,
+			Ip:         event.IP,
+			UserAgent:  event.UserAgent,
+			Reason:     event.Reason,
+			OccurredAt: event.OccurredAt.Unix(),
+			ActorId:    actorID,
+		})
+	}
+
+	return resp, nil
+}
+
+// timeFromUnix converts an optional Unix timestamp, where zero means unset.
+func timeFromUnix(seconds int64) time.Time {
+	if seconds == 0 {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0)
+}
@@ -0,0 +1,35 @@
+package grpc
+
+import (
+	"context"
+
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+)
+
+func (s *Server) ListSessions(ctx context.Context, _ *auth.ListSessionsRequest) (*auth.ListSessionsResponse, error) {
+	s.logger.Debug("list sessions request received")
+
+	sessions, err := s.authUseCase.ListSessions(ctx)
+	if err != nil {
+		s.logger.Error("list sessions failed", "error", err)
+		return nil, err
+	}
+
+	resp := &auth.ListSessionsResponse{
+		Sessions: make([]*auth.Session, 0, len(sessions)),
+	}
+	for _, session := range sessions {
+		resp.Sessions = append(resp.Sessions, &auth.Session{
+			SessionId:  session.ID.String(),
+			DeviceName: session.DeviceName,
+			UserAgent:  session.UserAgent,
+			Ip:         session.IP,
+			CreatedAt:  session.CreatedAt.Unix(),
+			LastUsedAt: session.LastUsedAt.Unix(),
+			ExpiresAt:  session.ExpiresAt.Unix(),
+			Current:    session.Current,
+		})
+	}
+
+	return resp, nil
+}
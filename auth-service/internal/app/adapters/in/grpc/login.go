@@ -2,7 +2,6 @@ package grpc
 
 import (
 	"context"
-	"time"
 
 	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
 	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
@@ -21,21 +20,35 @@ func (s *Server) Login(ctx context.Context, req *auth.LoginRequest) (*auth.Login
 	loginDTO := &ports.LoginDto{
 		Email:    req.GetEmail(),
 		Password: req.GetPassword(),
+		Session:  s.clients.sessionMetadata(ctx, req.GetDeviceName()),
 	}
 
-	token, err := s.authUseCase.Login(ctx, loginDTO)
+	result, err := s.authUseCase.Login(ctx, loginDTO)
 	if err != nil {
 		s.logger.Error("login failed", "error", err)
 		return nil, err
 	}
 
-	s.logger.Info("login successful", "token", token)
+	if result.Challenge != nil {
+		return &auth.LoginResponse{
+			Success:              true,
+			Message:              "Second factor required",
+			SecondFactorRequired: true,
+			ChallengeToken:       string(result.Challenge.Token),
+			ChallengeExpiresAt:   result.Challenge.ExpiresAt.Unix(),
+		}, nil
+	}
+
+	tokens := result.Tokens
+	s.logger.Info("login successful", "user_id", tokens.UserID)
 
 	return &auth.LoginResponse{
-		Token:     string(token),
-		UserId:    "",
-		ExpiresAt: time.Now().Add(24 * time.Hour).Unix(),
-		Success:   true,
-		Message:   "Login successful",
+		Token:            string(tokens.AccessToken),
+		UserId:           tokens.UserID.String(),
+		ExpiresAt:        tokens.AccessTokenExpiresAt.Unix(),
+		Success:          true,
+		Message:          "Login successful",
+		RefreshToken:     string(tokens.RefreshToken),
+		RefreshExpiresAt: tokens.RefreshTokenExpiresAt.Unix(),
 	}, nil
 }
@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (s *Server) LoginWithCode(ctx context.Context, req *auth.LoginWithCodeRequest) (*auth.LoginWithCodeResponse, error) {
+	if err := s.validator.Validate(req); err != nil {
+		s.logger.Error("validation error", "error", err)
+		return nil, errors.NewValidationError("invalid request: %v", err).
+			WithDetails("email", req.GetEmail())
+	}
+
+	s.logger.Info("login with code request received", "email", req.GetEmail())
+
+	result, err := s.authUseCase.LoginWithCode(ctx, &ports.LoginWithCodeDto{
+		Email:   req.GetEmail(),
+		Code:    models.Token(req.GetCode()),
+		Session: s.clients.sessionMetadata(ctx, req.GetDeviceName()),
+	})
+	if err != nil {
+		s.logger.Error("login with code failed", "error", err)
+		return nil, err
+	}
+
+	if result.Challenge != nil {
+		return &auth.LoginWithCodeResponse{
+			Success:              true,
+			Message:              "Second factor required",
+			SecondFactorRequired: true,
+			ChallengeToken:       string(result.Challenge.Token),
+			ChallengeExpiresAt:   result.Challenge.ExpiresAt.Unix(),
+		}, nil
+	}
+
+	tokens := result.Tokens
+	s.logger.Info("login with code successful", "user_id", tokens.UserID)
+
+	return &auth.LoginWithCodeResponse{
+		Token:            string(tokens.AccessToken),
+		UserId:           tokens.UserID.String(),
+		ExpiresAt:        tokens.AccessTokenExpiresAt.Unix(),
+		Success:          true,
+		Message:          "Login successful",
+		RefreshToken:     string(tokens.RefreshToken),
+		RefreshExpiresAt: tokens.RefreshTokenExpiresAt.Unix(),
+	}, nil
+}
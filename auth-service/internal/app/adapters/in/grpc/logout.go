@@ -11,15 +11,14 @@ import (
 func (s *Server) Logout(ctx context.Context, req *auth.LogoutRequest) (*auth.LogoutResponse, error) {
 	if err := s.validator.Validate(req); err != nil {
 		s.logger.Error("validation error", "error", err)
-		return nil, errors.NewValidationError("invalid request: %v", err).
-			WithDetails("token", req.GetToken())
+		return nil, errors.NewValidationError("invalid request: %v", err)
 	}
 
 	token := models.Token(req.GetToken())
 
-	s.logger.Info("logout request received", "token", token)
+	s.logger.Info("logout request received")
 
-	if err := s.authUseCase.Logout(ctx, token); err != nil {
+	if err := s.authUseCase.Logout(s.clients.withClient(ctx), token); err != nil {
 		s.logger.Error("logout failed", "error", err)
 		return nil, err
 	}
@@ -0,0 +1,27 @@
+package grpc
+
+import (
+	"context"
+
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+)
+
+func (s *Server) LogoutEverywhere(ctx context.Context, _ *auth.LogoutEverywhereRequest) (*auth.LogoutEverywhereResponse, error) {
+	if err := platformauth.RejectImpersonation(ctx); err != nil {
+		s.logger.Warn("logout everywhere denied", "error", err)
+		return nil, err
+	}
+
+	s.logger.Info("logout everywhere request received")
+
+	if err := s.authUseCase.LogoutEverywhere(s.clients.withClient(ctx)); err != nil {
+		s.logger.Error("logout everywhere failed", "error", err)
+		return nil, err
+	}
+
+	return &auth.LogoutEverywhereResponse{
+		Success: true,
+		Message: "Logged out from all sessions",
+	}, nil
+}
@@ -0,0 +1,37 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (s *Server) RefreshToken(ctx context.Context, req *auth.RefreshTokenRequest) (*auth.RefreshTokenResponse, error) {
+	if err := s.validator.Validate(req); err != nil {
+		s.logger.Error("validation error", "error", err)
+		return nil, errors.NewValidationError("invalid request: %v", err)
+	}
+
+	if req.GetRefreshToken() == "" {
+		return nil, errors.NewValidationError("refresh token is required").
+			WithDetails("field", "refresh_token")
+	}
+
+	s.logger.Info("refresh token request received")
+
+	tokens, err := s.authUseCase.RefreshToken(ctx, models.Token(req.GetRefreshToken()))
+	if err != nil {
+		s.logger.Error("refresh token failed", "error", err)
+		return nil, err
+	}
+
+	return &auth.RefreshTokenResponse{
+		Token:            string(tokens.AccessToken),
+		UserId:           tokens.UserID.String(),
+		ExpiresAt:        tokens.AccessTokenExpiresAt.Unix(),
+		RefreshToken:     string(tokens.RefreshToken),
+		RefreshExpiresAt: tokens.RefreshTokenExpiresAt.Unix(),
+	}, nil
+}
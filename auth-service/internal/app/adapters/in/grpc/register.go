@@ -26,7 +26,7 @@ func (s *Server) Register(ctx context.Context, req *auth.RegisterRequest) (*auth
 		Password: req.GetPassword(),
 	}
 
-	userID, err := s.authUseCase.Register(ctx, registerDTO)
+	userID, err := s.authUseCase.Register(s.clients.withClient(ctx), registerDTO)
 	if err != nil {
 		s.logger.Error("failed to register user", "error", err)
 		return nil, err
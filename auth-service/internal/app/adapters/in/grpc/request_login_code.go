@@ -0,0 +1,28 @@
+package grpc
+
+import (
+	"context"
+
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (s *Server) RequestLoginCode(ctx context.Context, req *auth.RequestLoginCodeRequest) (*auth.RequestLoginCodeResponse, error) {
+	if err := s.validator.Validate(req); err != nil {
+		s.logger.Error("validation error", "error", err)
+		return nil, errors.NewValidationError("invalid request: %v", err).
+			WithDetails("email", req.GetEmail())
+	}
+
+	s.logger.Info("login code request received", "email", req.GetEmail())
+
+	if err := s.authUseCase.RequestLoginCode(ctx, req.GetEmail()); err != nil {
+		s.logger.Error("login code request failed", "error", err)
+		return nil, err
+	}
+
+	return &auth.RequestLoginCodeResponse{
+		Success: true,
+		Message: "If the email is registered, a login code has been sent",
+	}, nil
+}
@@ -0,0 +1,28 @@
+package grpc
+
+import (
+	"context"
+
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (s *Server) RequestPasswordReset(ctx context.Context, req *auth.RequestPasswordResetRequest) (*auth.RequestPasswordResetResponse, error) {
+	if err := s.validator.Validate(req); err != nil {
+		s.logger.Error("validation error", "error", err)
+		return nil, errors.NewValidationError("invalid request: %v", err).
+			WithDetails("email", req.GetEmail())
+	}
+
+	s.logger.Info("password reset request received", "email", req.GetEmail())
+
+	if err := s.authUseCase.RequestPasswordReset(ctx, req.GetEmail()); err != nil {
+		s.logger.Error("password reset request failed", "error", err)
+		return nil, err
+	}
+
+	return &auth.RequestPasswordResetResponse{
+		Success: true,
+		Message: "If the email is registered, a reset code has been sent",
+	}, nil
+}
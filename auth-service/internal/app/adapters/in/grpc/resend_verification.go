@@ -0,0 +1,28 @@
+package grpc
+
+import (
+	"context"
+
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (s *Server) ResendVerification(ctx context.Context, req *auth.ResendVerificationRequest) (*auth.ResendVerificationResponse, error) {
+	if err := s.validator.Validate(req); err != nil {
+		s.logger.Error("validation error", "error", err)
+		return nil, errors.NewValidationError("invalid request: %v", err).
+			WithDetails("email", req.GetEmail())
+	}
+
+	s.logger.Info("verification resend request received", "email", req.GetEmail())
+
+	if err := s.authUseCase.ResendVerification(ctx, req.GetEmail()); err != nil {
+		s.logger.Error("verification resend failed", "error", err)
+		return nil, err
+	}
+
+	return &auth.ResendVerificationResponse{
+		Success: true,
+		Message: "If the email awaits verification, a new code has been sent",
+	}, nil
+}
@@ -0,0 +1,41 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (s *Server) RevokeApiKey(ctx context.Context, req *auth.RevokeApiKeyRequest) (*auth.RevokeApiKeyResponse, error) {
+	if err := platformauth.RejectImpersonation(ctx); err != nil {
+		s.logger.Warn("revoke api key denied", "error", err)
+		return nil, err
+	}
+
+	if err := s.validator.Validate(req); err != nil {
+		s.logger.Error("validation error", "error", err)
+		return nil, errors.NewValidationError("invalid request: %v", err).
+			WithDetails("key_id", req.GetKeyId())
+	}
+
+	keyID, err := models.APIKeyIDFromString(req.GetKeyId())
+	if err != nil {
+		return nil, errors.NewValidationError("invalid key id").
+			WithDetails("key_id", req.GetKeyId())
+	}
+
+	s.logger.Info("revoke API key request received", "key_id", keyID)
+
+	if err := s.authUseCase.RevokeAPIKey(ctx, keyID); err != nil {
+		s.logger.Error("revoke API key failed", "error", err)
+		return nil, err
+	}
+
+	return &auth.RevokeApiKeyResponse{
+		Success: true,
+		Message: "API key revoked successfully",
+	}, nil
+}
@@ -0,0 +1,41 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (s *Server) RevokeSession(ctx context.Context, req *auth.RevokeSessionRequest) (*auth.RevokeSessionResponse, error) {
+	if err := platformauth.RejectImpersonation(ctx); err != nil {
+		s.logger.Warn("revoke session denied", "error", err)
+		return nil, err
+	}
+
+	if err := s.validator.Validate(req); err != nil {
+		s.logger.Error("validation error", "error", err)
+		return nil, errors.NewValidationError("invalid request: %v", err).
+			WithDetails("session_id", req.GetSessionId())
+	}
+
+	sessionID, err := models.FamilyIDFromString(req.GetSessionId())
+	if err != nil {
+		return nil, errors.NewValidationError("invalid session id").
+			WithDetails("session_id", req.GetSessionId())
+	}
+
+	s.logger.Info("revoke session request received", "session_id", sessionID)
+
+	if err := s.authUseCase.RevokeSession(s.clients.withClient(ctx), sessionID); err != nil {
+		s.logger.Error("revoke session failed", "error", err)
+		return nil, err
+	}
+
+	return &auth.RevokeSessionResponse{
+		Success: true,
+		Message: "Session revoked successfully",
+	}, nil
+}
@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"strings"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	userAgentHeader        = "user-agent"
+	gatewayUserAgentHeader = "grpcgateway-user-agent"
+	forwardedForHeader     = "x-forwarded-for"
+)
+
+// clientResolver describes the client of the current request. The client
+// address keys the login throttle, so it must not be taken from headers the
+// client controls.
+type clientResolver struct {
+	trustedProxies []netip.Prefix
+}
+
+func newClientResolver(trustedProxies []netip.Prefix) clientResolver {
+	return clientResolver{trustedProxies: trustedProxies}
+}
+
+// sessionMetadata describes the client of the current request for session
+// bookkeeping. Requests proxied by grpc-gateway carry the original HTTP user
+// agent in metadata.
+func (r clientResolver) sessionMetadata(ctx context.Context, deviceName string) models.SessionMetadata {
+	session := models.SessionMetadata{DeviceName: strings.TrimSpace(deviceName)}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	session.UserAgent = firstMetadataValue(md, gatewayUserAgentHeader, userAgentHeader)
+	session.IP = r.clientAddress(ctx, md)
+
+	return session
+}
+
+// withClient attaches the client of the current request to ctx, so the use
+// case can record it in security events.
+func (r clientResolver) withClient(ctx context.Context) context.Context {
+	return models.ContextWithClient(ctx, r.sessionMetadata(ctx, ""))
+}
+
+// clientAddress starts from the address the request arrived from and follows
+// x-forwarded-for from the right for as long as the current hop is a trusted
+// proxy. Entries left of the first untrusted hop are written by the client and
+// ignored.
+//
+// Calls through the in-process gateway have no peer; the gateway appends the
+// address of the HTTP client to x-forwarded-for, so the right-most entry is
+// the first hop there.
+func (r clientResolver) clientAddress(ctx context.Context, md metadata.MD) string {
+	var hops []string
+	for _, value := range md.Get(forwardedForHeader) {
+		for _, hop := range strings.Split(value, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+
+	var addr string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		addr = hostOnly(p.Addr.String())
+	} else if len(hops) > 0 {
+		addr, hops = hops[len(hops)-1], hops[:len(hops)-1]
+	}
+
+	for len(hops) > 0 && r.trusted(addr) {
+		addr, hops = hops[len(hops)-1], hops[:len(hops)-1]
+	}
+
+	return addr
+}
+
+func (r clientResolver) trusted(addr string) bool {
+	ip, err := netip.ParseAddr(addr)
+	if err != nil {
+		return false
+	}
+	ip = ip.Unmap()
+	for _, prefix := range r.trustedProxies {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func hostOnly(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+func firstMetadataValue(md metadata.MD, keys ...string) string {
+	for _, key := range keys {
+		if values := md.Get(key); len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+	}
+	return ""
+}
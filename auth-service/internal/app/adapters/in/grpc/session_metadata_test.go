@@ -0,0 +1,75 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestClientResolver_ClientAddress(t *testing.T) {
+	proxy := netip.MustParsePrefix("10.0.0.0/8")
+
+	type args struct {
+		peer         string
+		forwardedFor string
+	}
+	tests := map[string]struct {
+		args           args
+		trustedProxies []netip.Prefix
+		want           string
+	}{
+		"direct call uses the peer": {
+			args: args{peer: "203.0.113.7:51000"},
+			want: "203.0.113.7",
+		},
+		"forwarded header from an untrusted peer is ignored": {
+			args: args{peer: "203.0.113.7:51000", forwardedFor: "198.51.100.1"},
+			want: "203.0.113.7",
+		},
+		"trusted proxy reports the client": {
+			args:           args{peer: "10.0.0.2:51000", forwardedFor: "198.51.100.1"},
+			trustedProxies: []netip.Prefix{proxy},
+			want:           "198.51.100.1",
+		},
+		"entries left of the first untrusted hop are ignored": {
+			args:           args{peer: "10.0.0.2:51000", forwardedFor: "192.0.2.99, 198.51.100.1, 10.0.0.3"},
+			trustedProxies: []netip.Prefix{proxy},
+			want:           "198.51.100.1",
+		},
+		"gateway call uses the address it appended": {
+			args: args{forwardedFor: "192.0.2.99, 198.51.100.1"},
+			want: "198.51.100.1",
+		},
+		"gateway behind a trusted proxy": {
+			args:           args{forwardedFor: "192.0.2.99, 198.51.100.1, 10.0.0.2"},
+			trustedProxies: []netip.Prefix{proxy},
+			want:           "198.51.100.1",
+		},
+		"no address": {
+			want: "",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.args.peer != "" {
+				addr, err := net.ResolveTCPAddr("tcp", tc.args.peer)
+				assert.NoError(t, err)
+				ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+			}
+			md := metadata.MD{}
+			if tc.args.forwardedFor != "" {
+				md.Set(forwardedForHeader, tc.args.forwardedFor)
+			}
+
+			resolver := newClientResolver(tc.trustedProxies)
+			assert.Equal(t, tc.want, resolver.clientAddress(ctx, md))
+		})
+	}
+}
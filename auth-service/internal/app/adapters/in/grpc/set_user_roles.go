@@ -0,0 +1,39 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (s *Server) SetUserRoles(ctx context.Context, req *auth.SetUserRolesRequest) (*auth.SetUserRolesResponse, error) {
+	if err := s.validator.Validate(req); err != nil {
+		s.logger.Error("validation error", "error", err)
+		return nil, errors.NewValidationError("invalid request: %v", err).
+			WithDetails("user_id", req.GetUserId())
+	}
+
+	userID, err := models.UserIDFromString(req.GetUserId())
+	if err != nil {
+		return nil, errors.NewValidationError("invalid user id").
+			WithDetails("user_id", req.GetUserId())
+	}
+
+	s.logger.Info("set user roles request received", "user_id", userID, "roles", req.GetRoles())
+
+	roles, err := s.authUseCase.SetUserRoles(ctx, &ports.SetUserRolesDto{
+		UserID: userID,
+		Roles:  req.GetRoles(),
+	})
+	if err != nil {
+		s.logger.Error("set user roles failed", "error", err)
+		return nil, err
+	}
+
+	return &auth.SetUserRolesResponse{
+		Roles: models.RoleNames(roles),
+	}, nil
+}
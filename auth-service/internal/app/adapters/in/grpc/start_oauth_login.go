@@ -0,0 +1,29 @@
+package grpc
+
+import (
+	"context"
+
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (s *Server) StartOAuthLogin(ctx context.Context, req *auth.StartOAuthLoginRequest) (*auth.StartOAuthLoginResponse, error) {
+	if err := s.validator.Validate(req); err != nil {
+		s.logger.Error("validation error", "error", err)
+		return nil, errors.NewValidationError("invalid request: %v", err)
+	}
+
+	s.logger.Info("oauth login start received", "provider", req.GetProvider())
+
+	start, err := s.authUseCase.StartOAuthLogin(ctx, req.GetProvider())
+	if err != nil {
+		s.logger.Error("oauth login start failed", "provider", req.GetProvider(), "error", err)
+		return nil, err
+	}
+
+	return &auth.StartOAuthLoginResponse{
+		AuthorizationUrl: start.AuthorizationURL,
+		State:            string(start.State),
+		ExpiresAt:        start.ExpiresAt.Unix(),
+	}, nil
+}
@@ -0,0 +1,40 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (s *Server) SuspendAccount(ctx context.Context, req *auth.SuspendAccountRequest) (*auth.SuspendAccountResponse, error) {
+	if err := s.validator.Validate(req); err != nil {
+		s.logger.Error("validation error", "error", err)
+		return nil, errors.NewValidationError("invalid request: %v", err).
+			WithDetails("user_id", req.GetUserId())
+	}
+
+	userID, err := models.UserIDFromString(req.GetUserId())
+	if err != nil {
+		return nil, errors.NewValidationError("invalid user id").
+			WithDetails("user_id", req.GetUserId())
+	}
+
+	s.logger.Info("suspend account request received", "user_id", userID)
+
+	err = s.authUseCase.SuspendAccount(ctx, &ports.SuspendAccountDto{
+		UserID: userID,
+		Reason: req.GetReason(),
+	})
+	if err != nil {
+		s.logger.Error("suspend account failed", "error", err)
+		return nil, err
+	}
+
+	return &auth.SuspendAccountResponse{
+		Success: true,
+		Message: "Account suspended successfully",
+	}, nil
+}
@@ -0,0 +1,43 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (s *Server) ValidateToken(ctx context.Context, req *auth.ValidateTokenRequest) (*auth.ValidateTokenResponse, error) {
+	if err := s.validator.Validate(req); err != nil {
+		s.logger.Error("validation error", "error", err)
+		return nil, errors.NewValidationError("invalid request: %v", err)
+	}
+
+	s.logger.Debug("validate token request received")
+
+	info, err := s.authUseCase.ValidateToken(s.clients.withClient(ctx), models.Token(req.GetToken()))
+	if err != nil {
+		s.logger.Error("token validation failed", "error", err)
+		return nil, err
+	}
+
+	if !info.Active {
+		return &auth.ValidateTokenResponse{Active: false}, nil
+	}
+
+	var actorID string
+	if !info.ActorID.IsEmpty() {
+		actorID = info.ActorID.String()
+	}
+
+	return &auth.ValidateTokenResponse{
+		UserId:    info.UserID.String(),
+		ExpiresAt: unixOrZero(info.ExpiresAt),
+		Active:    true,
+		SessionId: info.SessionID.String(),
+		Roles:     models.RoleNames(info.Roles),
+		Scopes:    models.ScopeNames(info.Scopes),
+		ActorId:   actorID,
+	}, nil
+}
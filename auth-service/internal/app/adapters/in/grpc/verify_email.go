@@ -0,0 +1,28 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (s *Server) VerifyEmail(ctx context.Context, req *auth.VerifyEmailRequest) (*auth.VerifyEmailResponse, error) {
+	if err := s.validator.Validate(req); err != nil {
+		s.logger.Error("validation error", "error", err)
+		return nil, errors.NewValidationError("invalid request: %v", err)
+	}
+
+	s.logger.Info("email verification request received")
+
+	if err := s.authUseCase.VerifyEmail(ctx, models.Token(req.GetCode())); err != nil {
+		s.logger.Error("email verification failed", "error", err)
+		return nil, err
+	}
+
+	return &auth.VerifyEmailResponse{
+		Success: true,
+		Message: "Email address has been verified",
+	}, nil
+}
@@ -0,0 +1,39 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (s *Server) VerifySecondFactor(ctx context.Context, req *auth.VerifySecondFactorRequest) (*auth.VerifySecondFactorResponse, error) {
+	if err := s.validator.Validate(req); err != nil {
+		s.logger.Error("validation error", "error", err)
+		return nil, errors.NewValidationError("invalid request: %v", err)
+	}
+
+	s.logger.Info("second factor verification request received")
+
+	tokens, err := s.authUseCase.VerifySecondFactor(ctx, &ports.VerifySecondFactorDto{
+		ChallengeToken: models.Token(req.GetChallengeToken()),
+		Code:           req.GetCode(),
+		Session:        s.clients.sessionMetadata(ctx, req.GetDeviceName()),
+	})
+	if err != nil {
+		s.logger.Error("second factor verification failed", "error", err)
+		return nil, err
+	}
+
+	return &auth.VerifySecondFactorResponse{
+		Token:            string(tokens.AccessToken),
+		UserId:           tokens.UserID.String(),
+		ExpiresAt:        tokens.AccessTokenExpiresAt.Unix(),
+		Success:          true,
+		Message:          "Login successful",
+		RefreshToken:     string(tokens.RefreshToken),
+		RefreshExpiresAt: tokens.RefreshTokenExpiresAt.Unix(),
+	}, nil
+}
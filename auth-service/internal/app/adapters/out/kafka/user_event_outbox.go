@@ -0,0 +1,63 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/api/events/v1"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/postgres"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ ports.UserEventsOutbox = (*UserEventsOutbox)(nil)
+
+// UserEventsOutbox writes user events to the transactional outbox. They are
+// published by the outbox relay through UserEventsKafkaProducer.Publish.
+type UserEventsOutbox struct {
+	outbox *postgres.Outbox
+	topic  string
+}
+
+func NewUserEventsOutbox(outbox *postgres.Outbox, topic string) *UserEventsOutbox {
+	return &UserEventsOutbox{outbox: outbox, topic: topic}
+}
+
+func (o *UserEventsOutbox) EnqueueUserRegisteredEvent(ctx context.Context, event *events.UserRegisteredEvent) error {
+	return o.enqueue(ctx, event.GetUserId(), event)
+}
+
+func (o *UserEventsOutbox) EnqueueUserEmailVerifiedEvent(ctx context.Context, event *events.UserEmailVerifiedEvent) error {
+	return o.enqueue(ctx, event.GetUserId(), event)
+}
+
+func (o *UserEventsOutbox) EnqueueUserDeletedEvent(ctx context.Context, event *events.UserDeletedEvent) error {
+	return o.enqueue(ctx, event.GetUserId(), event)
+}
+
+func (o *UserEventsOutbox) EnqueueUserSuspendedEvent(ctx context.Context, event *events.UserSuspendedEvent) error {
+	return o.enqueue(ctx, event.GetUserId(), event)
+}
+
+func (o *UserEventsOutbox) EnqueueUserCredentialsChangedEvent(ctx context.Context, event *events.UserCredentialsChangedEvent) error {
+	return o.enqueue(ctx, event.GetUserId(), event)
+}
+
+func (o *UserEventsOutbox) enqueue(ctx context.Context, userID string, event proto.Message) error {
+	eventType, data, err := encodeEvent(event)
+	if err != nil {
+		return err
+	}
+
+	err = o.outbox.Enqueue(ctx, &postgres.OutboxMessage{
+		Topic:   o.topic,
+		Key:     userID,
+		Headers: map[string]string{EventTypeHeader: eventType},
+		Payload: data,
+	})
+	if err != nil {
+		return errors.NewInternalError(err, "failed to enqueue %s", eventType).
+			WithDetails("user_id", userID)
+	}
+	return nil
+}
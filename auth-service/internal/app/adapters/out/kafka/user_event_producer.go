@@ -5,14 +5,14 @@ import (
 	"encoding/json"
 
 	"github.com/SamEkb/messenger-app/auth-service/config/env"
-	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
-	"github.com/SamEkb/messenger-app/pkg/api/events/v1"
 	"github.com/SamEkb/messenger-app/pkg/platform/errors"
 	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/SamEkb/messenger-app/pkg/platform/postgres"
 	"github.com/Shopify/sarama"
+	"google.golang.org/protobuf/proto"
 )
 
-var _ ports.UserEventsKafkaProducer = (*UserEventsKafkaProducer)(nil)
+var _ postgres.OutboxPublisher = (*UserEventsKafkaProducer)(nil)
 
 type UserEventsKafkaProducer struct {
 	producer sarama.SyncProducer
@@ -39,21 +39,31 @@ func NewUserEventsKafkaProducer(kafkaCfg *env.KafkaConfig, logger logger.Logger)
 	}, nil
 }
 
-func (p *UserEventsKafkaProducer) ProduceUserRegisteredEvent(ctx context.Context, event *events.UserRegisteredEvent) error {
-	p.logger.Debug("preparing to produce user registered event", "user_id", event.GetUserId())
+// EventTypeHeader carries the protobuf message name of the event, so that
+// consumers of the shared user-events topic can tell event types apart.
+const EventTypeHeader = "event-type"
 
-	data, err := json.Marshal(event)
-	if err != nil {
-		p.logger.Error("failed to marshal event", "error", err)
-		return errors.NewInternalError(err, "failed to marshal event")
+// Publish sends a message relayed from the transactional outbox.
+func (p *UserEventsKafkaProducer) Publish(ctx context.Context, msg *postgres.OutboxMessage) error {
+	topic := msg.Topic
+	if topic == "" {
+		topic = p.topic
 	}
 
-	msg := &sarama.ProducerMessage{
-		Topic: p.topic,
-		Key:   sarama.StringEncoder(event.GetUserId()),
-		Value: sarama.ByteEncoder(data),
+	headers := make([]sarama.RecordHeader, 0, len(msg.Headers))
+	for key, value := range msg.Headers {
+		headers = append(headers, sarama.RecordHeader{Key: []byte(key), Value: []byte(value)})
 	}
 
+	return p.send(ctx, msg.Key, msg.Headers[EventTypeHeader], &sarama.ProducerMessage{
+		Topic:   topic,
+		Key:     sarama.StringEncoder(msg.Key),
+		Value:   sarama.ByteEncoder(msg.Payload),
+		Headers: headers,
+	})
+}
+
+func (p *UserEventsKafkaProducer) send(ctx context.Context, userID, eventType string, msg *sarama.ProducerMessage) error {
 	doneCh := make(chan struct{})
 	var sendErr error
 	var partition int32
@@ -68,22 +78,31 @@ func (p *UserEventsKafkaProducer) ProduceUserRegisteredEvent(ctx context.Context
 	case <-ctx.Done():
 		p.logger.Warn("message sending aborted", "error", ctx.Err())
 		return errors.NewTimeoutError("message sending aborted: %v", ctx.Err()).
-			WithDetails("user_id", event.GetUserId())
+			WithDetails("user_id", userID)
 	case <-doneCh:
 		if sendErr != nil {
 			p.logger.Error("failed to send message", "error", sendErr)
 			return errors.NewServiceError(sendErr, "failed to send message").
-				WithDetails("user_id", event.GetUserId())
+				WithDetails("user_id", userID)
 		}
-		p.logger.Info("published UserRegisteredEvent",
-			"user_id", event.GetUserId(),
+		p.logger.Info("published "+eventType,
+			"user_id", userID,
 			"partition", partition,
 			"offset", offset,
-			"topic", p.topic)
+			"topic", msg.Topic)
 		return nil
 	}
 }
 
+// encodeEvent returns the event type name and the JSON payload of an event.
+func encodeEvent(event proto.Message) (string, []byte, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return "", nil, errors.NewInternalError(err, "failed to marshal event")
+	}
+	return string(event.ProtoReflect().Descriptor().Name()), data, nil
+}
+
 func (p *UserEventsKafkaProducer) Close() error {
 	p.logger.Info("closing kafka producer")
 	err := p.producer.Close()
@@ -0,0 +1,48 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+var _ ports.Mailer = (*FileMailer)(nil)
+
+// FileMailer appends outgoing mail to a file, so that local tooling and
+// end-to-end tests can read codes sent to users.
+type FileMailer struct {
+	mx   sync.Mutex
+	path string
+}
+
+func NewFileMailer(path string) (*FileMailer, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, errors.NewInternalError(err, "failed to create mail directory")
+	}
+	return &FileMailer{path: path}, nil
+}
+
+func (m *FileMailer) Send(_ context.Context, message *ports.MailMessage) error {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	f, err := os.OpenFile(m.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errors.NewInternalError(err, "failed to open mail file")
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "Date: %s\nTo: %s\nSubject: %s\n\n%s\n\n",
+		time.Now().UTC().Format(time.RFC1123Z), message.To, message.Subject, message.Body)
+	if err != nil {
+		return errors.NewInternalError(err, "failed to write mail")
+	}
+
+	return nil
+}
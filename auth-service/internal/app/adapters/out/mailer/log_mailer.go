@@ -0,0 +1,29 @@
+package mailer
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+)
+
+var _ ports.Mailer = (*LogMailer)(nil)
+
+// LogMailer writes outgoing mail to the service log instead of delivering it.
+// It is meant for local development.
+type LogMailer struct {
+	logger logger.Logger
+}
+
+func NewLogMailer(logger logger.Logger) *LogMailer {
+	return &LogMailer{logger: logger.With("component", "log_mailer")}
+}
+
+func (m *LogMailer) Send(_ context.Context, message *ports.MailMessage) error {
+	m.logger.Info("mail sent",
+		"to", message.To,
+		"subject", message.Subject,
+		"body", message.Body,
+	)
+	return nil
+}
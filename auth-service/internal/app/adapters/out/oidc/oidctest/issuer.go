@@ -0,0 +1,230 @@
+// Package oidctest provides a local OpenID Connect issuer for tests. It
+// serves discovery, JWKS and a token endpoint that enforces PKCE, and signs
+// ID tokens with an Ed25519 key.
+package oidctest
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const keyID = "oidctest"
+
+// Identity is the user who signs in at the stub issuer.
+type Identity struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+}
+
+type pendingCode struct {
+	identity      Identity
+	clientID      string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+}
+
+// Issuer is a running stub OpenID Connect issuer.
+type Issuer struct {
+	ClientID     string
+	ClientSecret string
+
+	server     *httptest.Server
+	privateKey ed25519.PrivateKey
+	publicKey  ed25519.PublicKey
+
+	mx    sync.Mutex
+	codes map[string]pendingCode
+}
+
+// NewIssuer starts an issuer that accepts the given client credentials.
+// Close it when done.
+func NewIssuer(clientID, clientSecret string) *Issuer {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		panic(fmt.Sprintf("oidctest: failed to generate key: %v", err))
+	}
+
+	issuer := &Issuer{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		privateKey:   privateKey,
+		publicKey:    publicKey,
+		codes:        make(map[string]pendingCode),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", issuer.handleDiscovery)
+	mux.HandleFunc("GET /jwks", issuer.handleJWKS)
+	mux.HandleFunc("POST /token", issuer.handleToken)
+	issuer.server = httptest.NewServer(mux)
+
+	return issuer
+}
+
+// URL is the issuer identifier.
+func (i *Issuer) URL() string {
+	return i.server.URL
+}
+
+func (i *Issuer) Close() {
+	i.server.Close()
+}
+
+// Authorize simulates the user signing in at the authorization URL built by
+// the relying party and returns the code the issuer would redirect with.
+func (i *Issuer) Authorize(authorizationURL string, identity Identity) (string, error) {
+	parsed, err := url.Parse(authorizationURL)
+	if err != nil {
+		return "", err
+	}
+
+	query := parsed.Query()
+	if query.Get("response_type") != "code" {
+		return "", fmt.Errorf("unsupported response_type %q", query.Get("response_type"))
+	}
+	if query.Get("client_id") != i.ClientID {
+		return "", fmt.Errorf("unknown client_id %q", query.Get("client_id"))
+	}
+	if query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		return "", fmt.Errorf("PKCE S256 challenge is required")
+	}
+
+	code := randomString()
+
+	i.mx.Lock()
+	i.codes[code] = pendingCode{
+		identity:      identity,
+		clientID:      query.Get("client_id"),
+		redirectURI:   query.Get("redirect_uri"),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+	}
+	i.mx.Unlock()
+
+	return code, nil
+}
+
+func (i *Issuer) handleDiscovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                i.URL(),
+		"authorization_endpoint":                i.URL() + "/authorize",
+		"token_endpoint":                        i.URL() + "/token",
+		"jwks_uri":                              i.URL() + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{platformauth.AlgorithmEdDSA},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (i *Issuer) handleJWKS(w http.ResponseWriter, _ *http.Request) {
+	jwk, err := platformauth.NewJWK(keyID, i.publicKey)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, platformauth.JWKSet{Keys: []platformauth.JWK{jwk}})
+}
+
+func (i *Issuer) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeTokenError(w, "invalid_request")
+		return
+	}
+
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		writeTokenError(w, "unsupported_grant_type")
+		return
+	}
+
+	if r.PostForm.Get("client_id") != i.ClientID || r.PostForm.Get("client_secret") != i.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	code := r.PostForm.Get("code")
+	i.mx.Lock()
+	pending, ok := i.codes[code]
+	delete(i.codes, code)
+	i.mx.Unlock()
+
+	if !ok || pending.clientID != i.ClientID || pending.redirectURI != r.PostForm.Get("redirect_uri") {
+		writeTokenError(w, "invalid_grant")
+		return
+	}
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(sum[:]) != pending.codeChallenge {
+		writeTokenError(w, "invalid_grant")
+		return
+	}
+
+	idToken, err := i.signIDToken(pending)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+func (i *Issuer) signIDToken(pending pendingCode) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            i.URL(),
+		"sub":            pending.identity.Subject,
+		"aud":            pending.clientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"nonce":          pending.nonce,
+		"email":          pending.identity.Email,
+		"email_verified": pending.identity.EmailVerified,
+	}
+	if pending.identity.Name != "" {
+		claims["name"] = pending.identity.Name
+	}
+	if pending.identity.PreferredUsername != "" {
+		claims["preferred_username"] = pending.identity.PreferredUsername
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	token.Header["kid"] = keyID
+	return token.SignedString(i.privateKey)
+}
+
+func writeTokenError(w http.ResponseWriter, code string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func randomString() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
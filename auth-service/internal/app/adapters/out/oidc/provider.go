@@ -0,0 +1,250 @@
+package oidc
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/config/env"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const discoveryPath = "/.well-known/openid-configuration"
+
+var _ ports.IdentityProvider = (*Provider)(nil)
+
+// discoveryDocument is the subset of the OpenID Provider Metadata the
+// authorization code flow needs.
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce             string       `json:"nonce"`
+	Email             string       `json:"email"`
+	EmailVerified     flexibleBool `json:"email_verified"`
+	Name              string       `json:"name"`
+	PreferredUsername string       `json:"preferred_username"`
+}
+
+// flexibleBool accepts both JSON booleans and the "true"/"false" strings
+// some providers send for email_verified.
+type flexibleBool bool
+
+func (b *flexibleBool) UnmarshalJSON(data []byte) error {
+	value, err := strconv.ParseBool(strings.Trim(string(data), `"`))
+	if err != nil {
+		return fmt.Errorf("invalid boolean %s", data)
+	}
+	*b = flexibleBool(value)
+	return nil
+}
+
+// Provider talks to a single OpenID Connect issuer. Endpoints are
+// discovered lazily on first use, and ID tokens are verified against the
+// issuer's published keys.
+type Provider struct {
+	cfg    *env.OAuthProviderConfig
+	client *http.Client
+	logger logger.Logger
+
+	mx        sync.Mutex
+	discovery *discoveryDocument
+	keys      *platformauth.RemoteKeySet
+}
+
+func NewProvider(cfg *env.OAuthProviderConfig, logger logger.Logger) *Provider {
+	return &Provider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+		logger: logger.With("component", "oidc_provider", "provider", cfg.Name),
+	}
+}
+
+func (p *Provider) Name() string {
+	return p.cfg.Name
+}
+
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	doc, _, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(doc.AuthorizationEndpoint)
+	if err != nil {
+		return "", errors.NewServiceError(err, "invalid authorization endpoint of %s", p.cfg.Name)
+	}
+
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.cfg.ClientID)
+	query.Set("redirect_uri", p.cfg.RedirectURL)
+	query.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	return authURL.String(), nil
+}
+
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*ports.ExternalIdentityDto, error) {
+	doc, keys, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("client_id", p.cfg.ClientID)
+	form.Set("client_secret", p.cfg.ClientSecret)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, doc.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, errors.NewInternalError(err, "failed to build token request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, errors.NewServiceError(err, "failed to reach token endpoint of %s", p.cfg.Name)
+	}
+	defer resp.Body.Close()
+
+	var body tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, errors.NewServiceError(err, "failed to decode token response of %s", p.cfg.Name)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		p.logger.Warn("authorization code rejected", "status", resp.StatusCode, "error", body.Error, "description", body.ErrorDescription)
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return nil, errors.NewServiceError(nil, "token endpoint of %s failed with status %d", p.cfg.Name, resp.StatusCode)
+		}
+		return nil, errors.NewUnauthorizedError("authorization code was rejected: %s", body.Error)
+	}
+
+	if body.IDToken == "" {
+		return nil, errors.NewServiceError(nil, "token response of %s has no ID token", p.cfg.Name)
+	}
+
+	claims, err := p.verifyIDToken(ctx, keys, doc.Issuer, body.IDToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, errors.NewUnauthorizedError("ID token nonce does not match")
+	}
+
+	return &ports.ExternalIdentityDto{
+		Provider:          p.cfg.Name,
+		Subject:           claims.Subject,
+		Email:             claims.Email,
+		EmailVerified:     bool(claims.EmailVerified),
+		Name:              claims.Name,
+		PreferredUsername: claims.PreferredUsername,
+	}, nil
+}
+
+func (p *Provider) verifyIDToken(ctx context.Context, keys *platformauth.RemoteKeySet, issuer, idToken string) (*idTokenClaims, error) {
+	claims := &idTokenClaims{}
+
+	parsed, err := jwt.ParseWithClaims(idToken, claims, func(t *jwt.Token) (any, error) {
+		keyID, _ := t.Header["kid"].(string)
+		if keyID == "" {
+			return nil, fmt.Errorf("ID token has no key ID")
+		}
+		return keys.Key(ctx, keyID)
+	},
+		jwt.WithValidMethods([]string{platformauth.AlgorithmRS256, platformauth.AlgorithmEdDSA}),
+		jwt.WithIssuer(issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		return nil, errors.NewUnauthorizedError("ID token is invalid: %v", err)
+	}
+
+	if !parsed.Valid || claims.Subject == "" {
+		return nil, errors.NewUnauthorizedError("ID token is invalid")
+	}
+
+	return claims, nil
+}
+
+// discover fetches the provider metadata once and caches it together with
+// the key set it points to. A failed fetch is retried on the next call.
+func (p *Provider) discover(ctx context.Context) (*discoveryDocument, *platformauth.RemoteKeySet, error) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, p.keys, nil
+	}
+
+	issuer := strings.TrimSuffix(p.cfg.Issuer, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+discoveryPath, nil)
+	if err != nil {
+		return nil, nil, errors.NewInternalError(err, "failed to build discovery request")
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, nil, errors.NewServiceError(err, "failed to discover %s", p.cfg.Name)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, errors.NewServiceError(nil, "failed to discover %s: unexpected status %d", p.cfg.Name, resp.StatusCode)
+	}
+
+	var doc discoveryDocument
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, nil, errors.NewServiceError(err, "failed to decode discovery document of %s", p.cfg.Name)
+	}
+
+	// The issuer in the metadata must match the configured one exactly,
+	// otherwise ID tokens from another issuer could be accepted.
+	if strings.TrimSuffix(doc.Issuer, "/") != issuer {
+		return nil, nil, errors.NewServiceError(nil, "issuer mismatch for %s: got %q", p.cfg.Name, doc.Issuer)
+	}
+
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.JWKSURI == "" {
+		return nil, nil, errors.NewServiceError(nil, "discovery document of %s is incomplete", p.cfg.Name)
+	}
+
+	p.discovery = &doc
+	p.keys = platformauth.NewRemoteKeySet(doc.JWKSURI, platformauth.DefaultJWKSRefreshInterval)
+	p.logger.Info("identity provider discovered", "issuer", doc.Issuer)
+
+	return p.discovery, p.keys, nil
+}
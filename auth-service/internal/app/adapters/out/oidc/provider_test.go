@@ -0,0 +1,105 @@
+package oidc
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/SamEkb/messenger-app/auth-service/config/env"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/adapters/out/oidc/oidctest"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvider_AuthorizationCodeFlow(t *testing.T) {
+	ctx := context.Background()
+
+	issuer := oidctest.NewIssuer("messenger", "secret")
+	t.Cleanup(issuer.Close)
+
+	identity := oidctest.Identity{
+		Subject:           "subject-1",
+		Email:             "test@test.ru",
+		EmailVerified:     true,
+		PreferredUsername: "testuser",
+	}
+
+	newProvider := func(clientSecret string) *Provider {
+		return NewProvider(&env.OAuthProviderConfig{
+			Name:         "stub",
+			Issuer:       issuer.URL(),
+			ClientID:     "messenger",
+			ClientSecret: clientSecret,
+			RedirectURL:  "http://localhost/callback",
+			Scopes:       []string{"openid", "email", "profile"},
+		}, logger.NewMockLogger())
+	}
+
+	tests := map[string]struct {
+		clientSecret string
+		verifier     models.Token
+		nonce        string
+		expectedErr  error
+	}{
+		"identity returned": {
+			clientSecret: "secret",
+			verifier:     "verifier-verifier-verifier-verifier-verifier",
+			nonce:        "nonce",
+		},
+		"wrong code verifier": {
+			clientSecret: "secret",
+			verifier:     "another-verifier-another-verifier-another",
+			nonce:        "nonce",
+			expectedErr:  customerrors.NewUnauthorizedError("authorization code was rejected"),
+		},
+		"nonce mismatch": {
+			clientSecret: "secret",
+			verifier:     "verifier-verifier-verifier-verifier-verifier",
+			nonce:        "other-nonce",
+			expectedErr:  customerrors.NewUnauthorizedError("ID token nonce does not match"),
+		},
+		"wrong client secret": {
+			clientSecret: "wrong",
+			verifier:     "verifier-verifier-verifier-verifier-verifier",
+			nonce:        "nonce",
+			expectedErr:  customerrors.NewUnauthorizedError("authorization code was rejected"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			provider := newProvider(tc.clientSecret)
+
+			challenge := models.PKCEChallenge("verifier-verifier-verifier-verifier-verifier")
+			authURL, err := provider.AuthCodeURL(ctx, "state", "nonce", challenge)
+			require.NoError(t, err)
+
+			parsed, err := url.Parse(authURL)
+			require.NoError(t, err)
+			assert.Equal(t, "state", parsed.Query().Get("state"))
+			assert.Equal(t, "S256", parsed.Query().Get("code_challenge_method"))
+
+			code, err := issuer.Authorize(authURL, identity)
+			require.NoError(t, err)
+
+			got, err := provider.Exchange(ctx, code, string(tc.verifier), tc.nonce)
+			if tc.expectedErr != nil {
+				assert.Error(t, err)
+				assert.True(t, customerrors.Is(err, customerrors.ErrUnauthorized))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "stub", got.Provider)
+			assert.Equal(t, identity.Subject, got.Subject)
+			assert.Equal(t, identity.Email, got.Email)
+			assert.True(t, got.EmailVerified)
+			assert.Equal(t, identity.PreferredUsername, got.PreferredUsername)
+		})
+	}
+}
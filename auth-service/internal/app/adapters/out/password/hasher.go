@@ -0,0 +1,175 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var _ ports.PasswordHasher = (*Hasher)(nil)
+
+const (
+	// AlgorithmBcrypt stores hashes in the bcrypt format, "$2a$<cost>$...".
+	AlgorithmBcrypt = "bcrypt"
+	// AlgorithmArgon2id stores hashes in the PHC string format,
+	// "$argon2id$v=19$m=<KiB>,t=<iterations>,p=<threads>$<salt>$<key>".
+	AlgorithmArgon2id = "argon2id"
+)
+
+const (
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+	// bcryptMaxPasswordLength is the number of bytes bcrypt looks at.
+	bcryptMaxPasswordLength = 72
+)
+
+// Argon2Params tunes argon2id. Memory is in KiB.
+type Argon2Params struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+}
+
+type Config struct {
+	// Algorithm is used for new hashes, AlgorithmBcrypt or AlgorithmArgon2id.
+	Algorithm  string
+	BcryptCost int
+	Argon2     Argon2Params
+}
+
+// Hasher hashes new passwords with the configured algorithm and verifies
+// hashes of any supported algorithm. argon2id is considered stronger than
+// bcrypt, so bcrypt hashes are upgraded when argon2id is configured, but not
+// the other way round.
+type Hasher struct {
+	cfg Config
+}
+
+func NewHasher(cfg Config) (*Hasher, error) {
+	switch cfg.Algorithm {
+	case AlgorithmBcrypt:
+		if cfg.BcryptCost < bcrypt.MinCost || cfg.BcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+	case AlgorithmArgon2id:
+		if cfg.Argon2.Memory == 0 || cfg.Argon2.Iterations == 0 || cfg.Argon2.Parallelism == 0 {
+			return nil, fmt.Errorf("argon2id memory, iterations and parallelism must be positive")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported password hash algorithm %q", cfg.Algorithm)
+	}
+	return &Hasher{cfg: cfg}, nil
+}
+
+func (h *Hasher) Hash(password string) ([]byte, error) {
+	if h.cfg.Algorithm == AlgorithmBcrypt {
+		if len(password) > bcryptMaxPasswordLength {
+			return nil, errors.NewInvalidInputError("password is longer than %d bytes", bcryptMaxPasswordLength).
+				WithDetails("field", "password")
+		}
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cfg.BcryptCost)
+		if err != nil {
+			return nil, errors.NewInternalError(err, "failed to hash password")
+		}
+		return hash, nil
+	}
+
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, errors.NewInternalError(err, "failed to generate salt")
+	}
+	return encodeArgon2(h.cfg.Argon2, salt, deriveArgon2(h.cfg.Argon2, password, salt, argon2KeyLength)), nil
+}
+
+func (h *Hasher) Compare(hash []byte, password string) error {
+	if isArgon2(hash) {
+		params, salt, key, err := decodeArgon2(hash)
+		if err != nil {
+			return err
+		}
+		derived := deriveArgon2(params, password, salt, uint32(len(key)))
+		if subtle.ConstantTimeCompare(derived, key) != 1 {
+			return errors.NewUnauthorizedError("password does not match")
+		}
+		return nil
+	}
+
+	if err := bcrypt.CompareHashAndPassword(hash, []byte(password)); err != nil {
+		return errors.NewUnauthorizedError("password does not match")
+	}
+	return nil
+}
+
+func (h *Hasher) NeedsRehash(hash []byte) bool {
+	if isArgon2(hash) {
+		if h.cfg.Algorithm != AlgorithmArgon2id {
+			return false
+		}
+		params, _, _, err := decodeArgon2(hash)
+		if err != nil {
+			return true
+		}
+		return params.Memory < h.cfg.Argon2.Memory ||
+			params.Iterations < h.cfg.Argon2.Iterations ||
+			params.Parallelism < h.cfg.Argon2.Parallelism
+	}
+
+	if h.cfg.Algorithm == AlgorithmArgon2id {
+		return true
+	}
+	cost, err := bcrypt.Cost(hash)
+	return err != nil || cost < h.cfg.BcryptCost
+}
+
+func isArgon2(hash []byte) bool {
+	return strings.HasPrefix(string(hash), "$"+AlgorithmArgon2id+"$")
+}
+
+func deriveArgon2(params Argon2Params, password string, salt []byte, keyLength uint32) []byte {
+	return argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, keyLength)
+}
+
+func encodeArgon2(params Argon2Params, salt, key []byte) []byte {
+	return []byte(fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		AlgorithmArgon2id, argon2.Version, params.Memory, params.Iterations, params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)))
+}
+
+func decodeArgon2(hash []byte) (Argon2Params, []byte, []byte, error) {
+	invalid := errors.NewInvalidInputError("malformed argon2id hash")
+
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, key
+	parts := strings.Split(string(hash), "$")
+	if len(parts) != 6 {
+		return Argon2Params{}, nil, nil, invalid
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return Argon2Params{}, nil, nil, invalid
+	}
+
+	var params Argon2Params
+	_, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism)
+	if err != nil || params.Memory == 0 || params.Iterations == 0 || params.Parallelism == 0 {
+		return Argon2Params{}, nil, nil, invalid
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return Argon2Params{}, nil, nil, invalid
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return Argon2Params{}, nil, nil, invalid
+	}
+
+	return params, salt, key, nil
+}
@@ -0,0 +1,81 @@
+package password
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// weakArgon2 keeps the tests fast.
+var weakArgon2 = Argon2Params{Memory: 64, Iterations: 1, Parallelism: 1}
+
+func TestHasher_HashAndCompare(t *testing.T) {
+	tests := map[string]Config{
+		"bcrypt":   {Algorithm: AlgorithmBcrypt, BcryptCost: bcrypt.MinCost},
+		"argon2id": {Algorithm: AlgorithmArgon2id, Argon2: weakArgon2},
+	}
+
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			hasher, err := NewHasher(cfg)
+			require.NoError(t, err)
+
+			hash, err := hasher.Hash("correct horse")
+			require.NoError(t, err)
+
+			assert.Contains(t, string(hash), "$")
+			assert.NoError(t, hasher.Compare(hash, "correct horse"))
+			assert.Error(t, hasher.Compare(hash, "battery staple"))
+			assert.False(t, hasher.NeedsRehash(hash))
+		})
+	}
+}
+
+func TestHasher_NeedsRehash(t *testing.T) {
+	bcryptHasher, err := NewHasher(Config{Algorithm: AlgorithmBcrypt, BcryptCost: bcrypt.MinCost + 1})
+	require.NoError(t, err)
+	argon2Hasher, err := NewHasher(Config{Algorithm: AlgorithmArgon2id, Argon2: weakArgon2})
+	require.NoError(t, err)
+	strongerArgon2Hasher, err := NewHasher(Config{
+		Algorithm: AlgorithmArgon2id,
+		Argon2:    Argon2Params{Memory: 128, Iterations: 2, Parallelism: 1},
+	})
+	require.NoError(t, err)
+
+	lowCostBcrypt, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	require.NoError(t, err)
+	argon2Hash, err := argon2Hasher.Hash("password")
+	require.NoError(t, err)
+
+	// Hashes made under any configuration keep verifying.
+	assert.NoError(t, argon2Hasher.Compare(lowCostBcrypt, "password"))
+	assert.NoError(t, bcryptHasher.Compare(argon2Hash, "password"))
+
+	assert.True(t, bcryptHasher.NeedsRehash(lowCostBcrypt), "bcrypt cost below configured")
+	assert.True(t, argon2Hasher.NeedsRehash(lowCostBcrypt), "bcrypt is weaker than argon2id")
+	assert.True(t, strongerArgon2Hasher.NeedsRehash(argon2Hash), "argon2id parameters below configured")
+	assert.False(t, bcryptHasher.NeedsRehash(argon2Hash), "argon2id is not downgraded to bcrypt")
+}
+
+func TestHasher_CompareMalformed(t *testing.T) {
+	hasher, err := NewHasher(Config{Algorithm: AlgorithmArgon2id, Argon2: weakArgon2})
+	require.NoError(t, err)
+
+	assert.Error(t, hasher.Compare([]byte("$argon2id$v=19$m=0,t=0,p=0$c2FsdA$a2V5"), "password"))
+	assert.Error(t, hasher.Compare([]byte("plain"), "password"))
+}
+
+func TestNewHasher_InvalidConfig(t *testing.T) {
+	_, err := NewHasher(Config{Algorithm: "md5"})
+	assert.Error(t, err)
+
+	_, err = NewHasher(Config{Algorithm: AlgorithmBcrypt, BcryptCost: 100})
+	assert.Error(t, err)
+
+	_, err = NewHasher(Config{Algorithm: AlgorithmArgon2id})
+	assert.Error(t, err)
+}
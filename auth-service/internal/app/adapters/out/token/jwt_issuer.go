@@ -0,0 +1,69 @@
+package token
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+var _ ports.TokenIssuer = (*JWTIssuer)(nil)
+
+type JWTIssuer struct {
+	keys *KeyManager
+}
+
+func NewJWTIssuer(keys *KeyManager) *JWTIssuer {
+	return &JWTIssuer{keys: keys}
+}
+
+// accessTokenClaims are the claims of a signed access token. Roles and the
+// actor are read back by platformauth.JWKSVerifier.
+type accessTokenClaims struct {
+	jwt.RegisteredClaims
+	Roles []string    `json:"roles"`
+	Act   *actorClaim `json:"act,omitempty"`
+}
+
+// actorClaim names the administrator behind an impersonation token, as in
+// RFC 8693.
+type actorClaim struct {
+	Subject string `json:"sub"`
+}
+
+func (i *JWTIssuer) Issue(_ context.Context, user *models.User, expiresAt time.Time) (models.Token, error) {
+	return i.sign(user, nil, expiresAt)
+}
+
+func (i *JWTIssuer) IssueImpersonation(_ context.Context, user *models.User, actorID models.UserID, expiresAt time.Time) (models.Token, error) {
+	return i.sign(user, &actorClaim{Subject: actorID.String()}, expiresAt)
+}
+
+func (i *JWTIssuer) sign(user *models.User, actor *actorClaim, expiresAt time.Time) (models.Token, error) {
+	key := i.keys.signingKey()
+
+	claims := accessTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   user.ID().String(),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ID:        uuid.New().String(),
+		},
+		Roles: models.RoleNames(user.Roles()),
+		Act:   actor,
+	}
+
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.id
+
+	signed, err := token.SignedString(key.private)
+	if err != nil {
+		return "", errors.NewInternalError(err, "failed to sign access token")
+	}
+
+	return models.Token(signed), nil
+}
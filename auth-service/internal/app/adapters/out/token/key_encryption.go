@@ -0,0 +1,59 @@
+package token
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// KeyEncryptionKeySize is the size of the AES-256 key that encrypts the
+// stored signing keys.
+const KeyEncryptionKeySize = 32
+
+// keyCipher encrypts private signing keys at rest with AES-256-GCM. The key
+// ID is authenticated along with each key, so a stored key cannot be moved
+// to another row undetected.
+type keyCipher struct {
+	aead cipher.AEAD
+}
+
+func newKeyCipher(kek []byte) (*keyCipher, error) {
+	if len(kek) != KeyEncryptionKeySize {
+		return nil, errors.NewInvalidInputError("key encryption key must be %d bytes, got %d", KeyEncryptionKeySize, len(kek))
+	}
+
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, errors.NewInternalError(err, "failed to create key cipher")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.NewInternalError(err, "failed to create key cipher")
+	}
+
+	return &keyCipher{aead: aead}, nil
+}
+
+// seal returns the nonce followed by the encrypted key.
+func (c *keyCipher) seal(keyID string, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.NewInternalError(err, "failed to generate nonce")
+	}
+	return c.aead.Seal(nonce, nonce, plaintext, []byte(keyID)), nil
+}
+
+func (c *keyCipher) open(keyID string, sealed []byte) ([]byte, error) {
+	nonceSize := c.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return nil, errors.NewInvalidInputError("encrypted signing key is too short")
+	}
+
+	plaintext, err := c.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], []byte(keyID))
+	if err != nil {
+		return nil, errors.NewInternalError(err, "failed to decrypt signing key")
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,258 @@
+package token
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"sync"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const rsaKeyBits = 2048
+
+var _ ports.KeySetProvider = (*KeyManager)(nil)
+
+type signingKey struct {
+	id      string
+	method  jwt.SigningMethod
+	private crypto.Signer
+}
+
+type KeyManagerConfig struct {
+	// Algorithm is used for new keys, so a change takes effect at the next
+	// rotation. Replicas with different settings thus cannot keep replacing
+	// each other's keys during a rollout.
+	Algorithm string
+	// Rotation is the age at which the current key is replaced. Zero keeps
+	// it for good.
+	Rotation time.Duration
+	// Retention is how long a retired key stays published, i.e. the lifetime
+	// of the tokens it may have signed.
+	Retention time.Duration
+	// ReloadInterval is how often the key set is read back from the
+	// repository and rotation is checked.
+	ReloadInterval time.Duration
+	// EncryptionKey encrypts the private keys before they are stored. All
+	// replicas need the same one to read each other's keys.
+	EncryptionKey []byte
+}
+
+// KeyManager owns the JWT signing keys. The keys live in a repository shared
+// by all replicas, so each of them signs with the same current key and
+// publishes the same JWKS. One replica at a time generates a new key once the
+// current one is older than the rotation interval; the others pick it up on
+// their next reload and keep signing with the previous key until then.
+// Private keys are encrypted before they reach the repository.
+type KeyManager struct {
+	mx        sync.RWMutex
+	cfg       KeyManagerConfig
+	repo      ports.SigningKeyRepository
+	txManager ports.TxManager
+	cipher    *keyCipher
+	current   *signingKey
+	published []*signingKey
+	logger    logger.Logger
+}
+
+func NewKeyManager(ctx context.Context, cfg KeyManagerConfig, repo ports.SigningKeyRepository, txManager ports.TxManager, logger logger.Logger) (*KeyManager, error) {
+	if cfg.Algorithm != platformauth.AlgorithmEdDSA && cfg.Algorithm != platformauth.AlgorithmRS256 {
+		return nil, errors.NewInvalidInputError("unsupported JWT algorithm %q", cfg.Algorithm)
+	}
+
+	cipher, err := newKeyCipher(cfg.EncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &KeyManager{
+		cfg:       cfg,
+		repo:      repo,
+		txManager: txManager,
+		cipher:    cipher,
+		logger:    logger.With("component", "key_manager"),
+	}
+
+	if err := m.Sync(ctx); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Run reloads the keys on the configured interval until the context is
+// cancelled.
+func (m *KeyManager) Run(ctx context.Context) {
+	if m.cfg.ReloadInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(m.cfg.ReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.Sync(ctx); err != nil {
+				m.logger.Error("failed to sync signing keys", "error", err)
+			}
+		}
+	}
+}
+
+// Sync rotates the current key if it is due and loads the published keys.
+func (m *KeyManager) Sync(ctx context.Context) error {
+	now := time.Now()
+	// A replica that has not reloaded yet keeps signing with a retired key
+	// for up to one reload interval.
+	cutoff := now.Add(-(m.cfg.Retention + m.cfg.ReloadInterval))
+
+	err := m.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		if err := m.repo.Lock(txCtx); err != nil {
+			return err
+		}
+
+		current, err := m.repo.GetCurrent(txCtx)
+		if err != nil && !errors.Is(err, errors.ErrNotFound) {
+			return err
+		}
+		if current != nil && !m.rotationDue(current, now) {
+			return nil
+		}
+
+		key, err := m.generate(now)
+		if err != nil {
+			return err
+		}
+		if err := m.repo.Rotate(txCtx, key); err != nil {
+			return err
+		}
+		m.logger.Info("signing key rotated", "kid", key.ID(), "algorithm", key.Algorithm())
+
+		return m.repo.DeleteRetired(txCtx, cutoff)
+	})
+	if err != nil {
+		return err
+	}
+
+	keys, err := m.repo.ListPublished(ctx, cutoff)
+	if err != nil {
+		return err
+	}
+
+	var current *signingKey
+	published := make([]*signingKey, 0, len(keys))
+	for _, k := range keys {
+		key, err := m.decode(k)
+		if err != nil {
+			m.logger.Error("failed to decode signing key", "kid", k.ID(), "error", err)
+			continue
+		}
+		if !k.IsRetired() {
+			current = key
+		}
+		published = append(published, key)
+	}
+	if current == nil {
+		return errors.NewInternalError(nil, "no current signing key")
+	}
+
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	m.current = current
+	m.published = published
+	return nil
+}
+
+func (m *KeyManager) JWKS() platformauth.JWKSet {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+
+	keys := make([]platformauth.JWK, 0, len(m.published))
+	for _, k := range m.published {
+		jwk, err := platformauth.NewJWK(k.id, k.private.Public())
+		if err != nil {
+			m.logger.Error("failed to encode public key", "kid", k.id, "error", err)
+			continue
+		}
+		keys = append(keys, jwk)
+	}
+
+	return platformauth.JWKSet{Keys: keys}
+}
+
+func (m *KeyManager) signingKey() *signingKey {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+	return m.current
+}
+
+func (m *KeyManager) rotationDue(current *models.SigningKey, now time.Time) bool {
+	return m.cfg.Rotation > 0 && now.Sub(current.CreatedAt()) >= m.cfg.Rotation
+}
+
+func (m *KeyManager) generate(now time.Time) (*models.SigningKey, error) {
+	var private crypto.Signer
+
+	switch m.cfg.Algorithm {
+	case platformauth.AlgorithmRS256:
+		key, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, errors.NewInternalError(err, "failed to generate RSA key")
+		}
+		private = key
+	default:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, errors.NewInternalError(err, "failed to generate Ed25519 key")
+		}
+		private = key
+	}
+
+	encoded, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, errors.NewInternalError(err, "failed to encode signing key")
+	}
+
+	id := uuid.New().String()
+	encrypted, err := m.cipher.seal(id, encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewSigningKey(id, m.cfg.Algorithm, encrypted, now)
+}
+
+func (m *KeyManager) decode(key *models.SigningKey) (*signingKey, error) {
+	encoded, err := m.cipher.open(key.ID(), key.PrivateKey())
+	if err != nil {
+		return nil, err
+	}
+
+	private, err := x509.ParsePKCS8PrivateKey(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	switch private := private.(type) {
+	case *rsa.PrivateKey:
+		return &signingKey{id: key.ID(), method: jwt.SigningMethodRS256, private: private}, nil
+	case ed25519.PrivateKey:
+		return &signingKey{id: key.ID(), method: jwt.SigningMethodEdDSA, private: private}, nil
+	default:
+		return nil, errors.NewInvalidInputError("unsupported signing key type %T", private)
+	}
+}
@@ -0,0 +1,153 @@
+package token
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/repositories/auth/in_memory"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// noTx runs the function directly; the in-memory repository needs no
+// transaction.
+type noTx struct{}
+
+func (noTx) RunTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+var testKEK = bytes.Repeat([]byte{1}, KeyEncryptionKeySize)
+
+func newKeyManager(t *testing.T, cfg KeyManagerConfig, repo *in_memory.SigningKeyRepository) *KeyManager {
+	t.Helper()
+
+	if cfg.Algorithm == "" {
+		cfg.Algorithm = platformauth.AlgorithmEdDSA
+	}
+	if cfg.EncryptionKey == nil {
+		cfg.EncryptionKey = testKEK
+	}
+	m, err := NewKeyManager(context.Background(), cfg, repo, noTx{}, logger.NewMockLogger())
+	require.NoError(t, err)
+	return m
+}
+
+func newTestUser(t *testing.T) *models.User {
+	t.Helper()
+
+	user, err := models.NewUser(models.UserID(uuid.New()), "testuser", "test@test.ru", []byte("hashed"))
+	require.NoError(t, err)
+	return user
+}
+
+// verify checks token against a fresh verifier reading the current JWKS of
+// m, the way other services do.
+func verify(t *testing.T, m *KeyManager, token models.Token) (*platformauth.Principal, error) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(m.JWKS())
+	}))
+	defer server.Close()
+
+	return platformauth.NewJWKSVerifier(server.URL, time.Minute).Verify(context.Background(), string(token))
+}
+
+func TestJWTIssuer_Issue(t *testing.T) {
+	tests := map[string]string{
+		"EdDSA": platformauth.AlgorithmEdDSA,
+		"RS256": platformauth.AlgorithmRS256,
+	}
+
+	for name, algorithm := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			m := newKeyManager(t, KeyManagerConfig{Algorithm: algorithm}, in_memory.NewSigningKeyRepository(logger.NewMockLogger()))
+			user := newTestUser(t)
+			expiresAt := time.Now().Add(time.Hour)
+
+			token, err := NewJWTIssuer(m).Issue(context.Background(), user, expiresAt)
+			require.NoError(t, err)
+
+			principal, err := verify(t, m, token)
+			require.NoError(t, err)
+			assert.Equal(t, user.ID().String(), principal.UserID)
+			assert.Equal(t, []platformauth.Role{platformauth.RoleUser}, principal.Roles)
+			assert.WithinDuration(t, expiresAt, principal.ExpiresAt, time.Second)
+		})
+	}
+}
+
+func TestKeyManager_EncryptsStoredKeys(t *testing.T) {
+	repo := in_memory.NewSigningKeyRepository(logger.NewMockLogger())
+	newKeyManager(t, KeyManagerConfig{}, repo)
+
+	stored, err := repo.GetCurrent(context.Background())
+	require.NoError(t, err)
+	_, err = x509.ParsePKCS8PrivateKey(stored.PrivateKey())
+	assert.Error(t, err, "private key is stored in the clear")
+
+	// A replica with another key encryption key cannot use the stored key.
+	_, err = NewKeyManager(context.Background(), KeyManagerConfig{
+		Algorithm:     platformauth.AlgorithmEdDSA,
+		EncryptionKey: bytes.Repeat([]byte{2}, KeyEncryptionKeySize),
+	}, repo, noTx{}, logger.NewMockLogger())
+	assert.Error(t, err)
+}
+
+func TestNewKeyManager_InvalidEncryptionKey(t *testing.T) {
+	_, err := NewKeyManager(context.Background(), KeyManagerConfig{
+		Algorithm:     platformauth.AlgorithmEdDSA,
+		EncryptionKey: []byte("too short"),
+	}, in_memory.NewSigningKeyRepository(logger.NewMockLogger()), noTx{}, logger.NewMockLogger())
+	assert.Error(t, err)
+}
+
+func TestKeyManager_Rotation(t *testing.T) {
+	ctx := context.Background()
+	user := newTestUser(t)
+
+	// Every sync rotates.
+	m := newKeyManager(t, KeyManagerConfig{Rotation: time.Nanosecond, Retention: time.Hour},
+		in_memory.NewSigningKeyRepository(logger.NewMockLogger()))
+	issuer := NewJWTIssuer(m)
+
+	oldToken, err := issuer.Issue(ctx, user, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	oldKeyID := m.signingKey().id
+
+	require.NoError(t, m.Sync(ctx))
+	assert.NotEqual(t, oldKeyID, m.signingKey().id)
+	assert.Len(t, m.JWKS().Keys, 2)
+
+	// During the overlap tokens of the retired key keep verifying next to
+	// those of the new one.
+	newToken, err := issuer.Issue(ctx, user, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	_, err = verify(t, m, oldToken)
+	assert.NoError(t, err)
+	_, err = verify(t, m, newToken)
+	assert.NoError(t, err)
+
+	// Once the retention has passed, the retired keys are no longer published
+	// and their tokens are rejected.
+	m.cfg.Retention = 0
+	require.NoError(t, m.Sync(ctx))
+	assert.Len(t, m.JWKS().Keys, 1)
+	_, err = verify(t, m, oldToken)
+	assert.Error(t, err)
+	_, err = verify(t, m, newToken)
+	assert.Error(t, err)
+}
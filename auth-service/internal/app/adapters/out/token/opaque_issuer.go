@@ -0,0 +1,28 @@
+package token
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/google/uuid"
+)
+
+var _ ports.TokenIssuer = (*OpaqueIssuer)(nil)
+
+type OpaqueIssuer struct{}
+
+func NewOpaqueIssuer() *OpaqueIssuer {
+	return &OpaqueIssuer{}
+}
+
+func (i *OpaqueIssuer) Issue(_ context.Context, _ *models.User, _ time.Time) (models.Token, error) {
+	return models.Token(uuid.New().String()), nil
+}
+
+// IssueImpersonation returns a random token like Issue. The actor is kept
+// with the stored token.
+func (i *OpaqueIssuer) IssueImpersonation(_ context.Context, _ *models.User, _ models.UserID, _ time.Time) (models.Token, error) {
+	return models.Token(uuid.New().String()), nil
+}
@@ -0,0 +1,207 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/google/uuid"
+)
+
+// APIKeyPrefix starts every API key, which tells them apart from session
+// tokens during validation.
+const APIKeyPrefix = "mk_"
+
+// apiKeyHintLength is how many trailing characters of a key are kept to
+// help the owner recognize it.
+const apiKeyHintLength = 4
+
+// ParseScopes validates scope names and returns them sorted and without
+// duplicates. At least one scope is required.
+func ParseScopes(names []string) ([]platformauth.Scope, error) {
+	if len(names) == 0 {
+		return nil, errors.NewInvalidInputError("at least one scope is required").
+			WithDetails("field", "scopes")
+	}
+
+	scopes := make([]platformauth.Scope, 0, len(names))
+	for _, name := range names {
+		scope := platformauth.Scope(name)
+		if !scope.IsKnown() {
+			return nil, errors.NewInvalidInputError("unknown scope %q", name).
+				WithDetails("field", "scopes")
+		}
+		scopes = append(scopes, scope)
+	}
+
+	slices.Sort(scopes)
+	return slices.Compact(scopes), nil
+}
+
+// ScopeNames converts scopes to the strings stored and sent over the wire.
+func ScopeNames(scopes []platformauth.Scope) []string {
+	names := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		names = append(names, scope.String())
+	}
+	return names
+}
+
+type APIKeyID uuid.UUID
+
+func APIKeyIDFromString(id string) (APIKeyID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return APIKeyID{}, errors.NewInvalidInputError("invalid API key ID")
+	}
+	return APIKeyID(parsed), nil
+}
+
+func (k APIKeyID) IsEmpty() bool {
+	return k == APIKeyID(uuid.Nil)
+}
+
+func (k APIKeyID) String() string {
+	return uuid.UUID(k).String()
+}
+
+// GenerateAPIKey returns a new random API key.
+func GenerateAPIKey() (Token, error) {
+	secret, err := GenerateSecretToken()
+	if err != nil {
+		return "", err
+	}
+	return APIKeyPrefix + secret, nil
+}
+
+// IsAPIKey reports whether the token looks like an API key rather than a
+// session token.
+func IsAPIKey(token Token) bool {
+	return strings.HasPrefix(string(token), APIKeyPrefix)
+}
+
+// APIKey is a long-lived credential a user creates for bots and
+// integrations. Only the hash of the key is stored.
+type APIKey struct {
+	id         APIKeyID
+	userID     UserID
+	name       string
+	hash       string
+	hint       string
+	scopes     []platformauth.Scope
+	createdAt  time.Time
+	lastUsedAt time.Time
+	revokedAt  time.Time
+}
+
+// NewAPIKey describes the given key. The key itself is not kept.
+func NewAPIKey(userID UserID, name string, key Token, scopes []platformauth.Scope) (*APIKey, error) {
+	if userID.IsEmpty() {
+		return nil, errors.NewInvalidInputError("userID cannot be empty").
+			WithDetails("field", "userID")
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.NewInvalidInputError("name cannot be empty").
+			WithDetails("field", "name")
+	}
+
+	if !IsAPIKey(key) || len(key) <= len(APIKeyPrefix)+apiKeyHintLength {
+		return nil, errors.NewInvalidInputError("invalid API key").
+			WithDetails("field", "key")
+	}
+
+	if len(scopes) == 0 {
+		return nil, errors.NewInvalidInputError("at least one scope is required").
+			WithDetails("field", "scopes")
+	}
+
+	return &APIKey{
+		id:        APIKeyID(uuid.New()),
+		userID:    userID,
+		name:      name,
+		hash:      HashToken(key),
+		hint:      string(key[len(key)-apiKeyHintLength:]),
+		scopes:    slices.Clone(scopes),
+		createdAt: time.Now(),
+	}, nil
+}
+
+// RestoreAPIKey rebuilds an API key from storage.
+func RestoreAPIKey(
+	id APIKeyID,
+	userID UserID,
+	name, hash, hint string,
+	scopes []string,
+	createdAt, lastUsedAt, revokedAt time.Time,
+) (*APIKey, error) {
+	if id.IsEmpty() {
+		return nil, errors.NewInvalidInputError("id cannot be empty").
+			WithDetails("field", "id")
+	}
+
+	if hash == "" {
+		return nil, errors.NewInvalidInputError("hash cannot be empty").
+			WithDetails("field", "hash")
+	}
+
+	parsed, err := ParseScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &APIKey{
+		id:         id,
+		userID:     userID,
+		name:       name,
+		hash:       hash,
+		hint:       hint,
+		scopes:     parsed,
+		createdAt:  createdAt,
+		lastUsedAt: lastUsedAt,
+		revokedAt:  revokedAt,
+	}, nil
+}
+
+func (k *APIKey) ID() APIKeyID {
+	return k.id
+}
+
+func (k *APIKey) UserID() UserID {
+	return k.userID
+}
+
+func (k *APIKey) Name() string {
+	return k.name
+}
+
+func (k *APIKey) Hash() string {
+	return k.hash
+}
+
+func (k *APIKey) Hint() string {
+	return k.hint
+}
+
+func (k *APIKey) Scopes() []platformauth.Scope {
+	return slices.Clone(k.scopes)
+}
+
+func (k *APIKey) CreatedAt() time.Time {
+	return k.createdAt
+}
+
+func (k *APIKey) LastUsedAt() time.Time {
+	return k.lastUsedAt
+}
+
+func (k *APIKey) RevokedAt() time.Time {
+	return k.revokedAt
+}
+
+func (k *APIKey) IsRevoked() bool {
+	return !k.revokedAt.IsZero()
+}
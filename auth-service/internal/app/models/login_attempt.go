@@ -0,0 +1,104 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// LoginAttempt counts recent failed logins for a throttling key, such as an
+// email address or a client IP, and when the key may be used again.
+type LoginAttempt struct {
+	key           string
+	failures      int
+	lastFailureAt time.Time
+	lockedUntil   time.Time
+}
+
+func RestoreLoginAttempt(key string, failures int, lastFailureAt, lockedUntil time.Time) (*LoginAttempt, error) {
+	if key == "" {
+		return nil, errors.NewInvalidInputError("key cannot be empty").
+			WithDetails("field", "key")
+	}
+
+	return &LoginAttempt{
+		key:           key,
+		failures:      failures,
+		lastFailureAt: lastFailureAt,
+		lockedUntil:   lockedUntil,
+	}, nil
+}
+
+// LoginAttemptKeyForEmail returns the throttling key of an account.
+func LoginAttemptKeyForEmail(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+// LoginAttemptKeyForIP returns the throttling key of a client address.
+func LoginAttemptKeyForIP(ip string) string {
+	return "ip:" + ip
+}
+
+func (a *LoginAttempt) Key() string {
+	return a.key
+}
+
+func (a *LoginAttempt) Failures() int {
+	return a.failures
+}
+
+func (a *LoginAttempt) LastFailureAt() time.Time {
+	return a.lastFailureAt
+}
+
+func (a *LoginAttempt) LockedUntil() time.Time {
+	return a.lockedUntil
+}
+
+func (a *LoginAttempt) IsLocked(at time.Time) bool {
+	return at.Before(a.lockedUntil)
+}
+
+// RetryAfter returns how long the key stays locked after at.
+func (a *LoginAttempt) RetryAfter(at time.Time) time.Duration {
+	if !a.IsLocked(at) {
+		return 0
+	}
+	return a.lockedUntil.Sub(at)
+}
+
+// LockoutPolicy decides how long a key is locked after repeated failures.
+// The first FreeAttempts failures are not delayed, later ones are delayed
+// exponentially from BackoffBase up to BackoffMax, and reaching
+// LockoutThreshold locks the key for LockoutDuration. Failures older than
+// FailureWindow are forgotten.
+type LockoutPolicy struct {
+	FreeAttempts     int
+	BackoffBase      time.Duration
+	BackoffMax       time.Duration
+	LockoutThreshold int
+	LockoutDuration  time.Duration
+	FailureWindow    time.Duration
+}
+
+// LockFor returns how long to lock a key that has failed the given number of
+// times, or zero when no delay applies.
+func (p LockoutPolicy) LockFor(failures int) time.Duration {
+	if p.LockoutThreshold > 0 && failures >= p.LockoutThreshold {
+		return p.LockoutDuration
+	}
+
+	if failures <= p.FreeAttempts || p.BackoffBase <= 0 {
+		return 0
+	}
+
+	delay := p.BackoffBase
+	for i := p.FreeAttempts + 1; i < failures; i++ {
+		delay *= 2
+		if delay >= p.BackoffMax {
+			return p.BackoffMax
+		}
+	}
+	return min(delay, p.BackoffMax)
+}
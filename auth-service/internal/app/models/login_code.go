@@ -0,0 +1,81 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// LoginCodeMode selects how passwordless login codes are delivered.
+type LoginCodeMode string
+
+const (
+	// LoginCodeOff disables passwordless login.
+	LoginCodeOff LoginCodeMode = "off"
+	// LoginCodeDigits mails a six-digit code to type in.
+	LoginCodeDigits LoginCodeMode = "code"
+	// LoginCodeLink mails a link carrying a long random code.
+	LoginCodeLink LoginCodeMode = "link"
+)
+
+const loginCodeDigits = 6
+
+// LoginCodePolicy configures passwordless login.
+type LoginCodePolicy struct {
+	Mode LoginCodeMode
+	TTL  time.Duration
+	// MaxAttempts caps wrong guesses per code, after which the code is burnt.
+	MaxAttempts int
+	// LinkURL is the page the mailed link points to. The email and code are
+	// appended as query parameters.
+	LinkURL string
+}
+
+func (p LoginCodePolicy) Enabled() bool {
+	return p.Mode == LoginCodeDigits || p.Mode == LoginCodeLink
+}
+
+// GenerateLoginCode returns a new code for the given mode.
+func GenerateLoginCode(mode LoginCodeMode) (Token, error) {
+	if mode == LoginCodeLink {
+		return GenerateSecretToken()
+	}
+
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return "", errors.NewInternalError(err, "failed to generate login code")
+	}
+	return Token(fmt.Sprintf("%0*d", loginCodeDigits, n.Int64())), nil
+}
+
+// HashLoginCode hashes a login code with a random salt. Six-digit codes
+// repeat, so unlike other one-time codes they cannot be looked up by a plain
+// hash; they are found by user and checked with LoginCodeMatches instead.
+func HashLoginCode(code Token) (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", errors.NewInternalError(err, "failed to generate salt")
+	}
+	return saltedHash(hex.EncodeToString(salt), code), nil
+}
+
+// LoginCodeMatches reports whether code hashes to the stored hash.
+func LoginCodeMatches(hash string, code Token) bool {
+	salt, _, ok := strings.Cut(hash, "$")
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(saltedHash(salt, code)), []byte(hash)) == 1
+}
+
+func saltedHash(salt string, code Token) string {
+	sum := sha256.Sum256([]byte(salt + string(code)))
+	return salt + "$" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,161 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"time"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// OAuthState is a pending authorization code flow. The state parameter sent
+// to the identity provider is stored only as a hash; the PKCE verifier and
+// the nonce stay on our side until the callback redeems them.
+type OAuthState struct {
+	hash         string
+	provider     string
+	codeVerifier Token
+	nonce        Token
+	expiresAt    time.Time
+}
+
+func NewOAuthState(hash, provider string, codeVerifier, nonce Token, expiresAt time.Time) (*OAuthState, error) {
+	if hash == "" {
+		return nil, errors.NewInvalidInputError("hash cannot be empty").
+			WithDetails("field", "hash")
+	}
+
+	if provider == "" {
+		return nil, errors.NewInvalidInputError("provider cannot be empty").
+			WithDetails("field", "provider")
+	}
+
+	if codeVerifier.IsEmpty() {
+		return nil, errors.NewInvalidInputError("codeVerifier cannot be empty").
+			WithDetails("field", "codeVerifier")
+	}
+
+	if nonce.IsEmpty() {
+		return nil, errors.NewInvalidInputError("nonce cannot be empty").
+			WithDetails("field", "nonce")
+	}
+
+	if expiresAt.IsZero() {
+		return nil, errors.NewInvalidInputError("expiresAt cannot be zero").
+			WithDetails("field", "expiresAt")
+	}
+
+	return &OAuthState{
+		hash:         hash,
+		provider:     provider,
+		codeVerifier: codeVerifier,
+		nonce:        nonce,
+		expiresAt:    expiresAt,
+	}, nil
+}
+
+func (s *OAuthState) Hash() string {
+	return s.hash
+}
+
+func (s *OAuthState) Provider() string {
+	return s.provider
+}
+
+func (s *OAuthState) CodeVerifier() Token {
+	return s.codeVerifier
+}
+
+func (s *OAuthState) Nonce() Token {
+	return s.nonce
+}
+
+func (s *OAuthState) ExpiresAt() time.Time {
+	return s.expiresAt
+}
+
+func (s *OAuthState) IsExpired(at time.Time) bool {
+	return !at.Before(s.expiresAt)
+}
+
+// PKCEChallenge derives the S256 code challenge for a PKCE code verifier
+// (RFC 7636).
+func PKCEChallenge(codeVerifier Token) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// OAuthIdentity links an account at an external identity provider to a
+// local user. The provider's subject is stable for the lifetime of the
+// external account, unlike its email address.
+type OAuthIdentity struct {
+	provider    string
+	subject     string
+	userID      UserID
+	email       string
+	createdAt   time.Time
+	lastLoginAt time.Time
+}
+
+func NewOAuthIdentity(provider, subject string, userID UserID, email string) (*OAuthIdentity, error) {
+	if provider == "" {
+		return nil, errors.NewInvalidInputError("provider cannot be empty").
+			WithDetails("field", "provider")
+	}
+
+	if subject == "" {
+		return nil, errors.NewInvalidInputError("subject cannot be empty").
+			WithDetails("field", "subject")
+	}
+
+	if userID.IsEmpty() {
+		return nil, errors.NewInvalidInputError("userID cannot be empty").
+			WithDetails("field", "userID")
+	}
+
+	now := time.Now()
+	return &OAuthIdentity{
+		provider:    provider,
+		subject:     subject,
+		userID:      userID,
+		email:       email,
+		createdAt:   now,
+		lastLoginAt: now,
+	}, nil
+}
+
+// RestoreOAuthIdentity rebuilds an identity link from storage.
+func RestoreOAuthIdentity(provider, subject string, userID UserID, email string, createdAt, lastLoginAt time.Time) (*OAuthIdentity, error) {
+	identity, err := NewOAuthIdentity(provider, subject, userID, email)
+	if err != nil {
+		return nil, err
+	}
+
+	identity.createdAt = createdAt
+	identity.lastLoginAt = lastLoginAt
+	return identity, nil
+}
+
+func (i *OAuthIdentity) Provider() string {
+	return i.provider
+}
+
+func (i *OAuthIdentity) Subject() string {
+	return i.subject
+}
+
+func (i *OAuthIdentity) UserID() UserID {
+	return i.userID
+}
+
+func (i *OAuthIdentity) Email() string {
+	return i.email
+}
+
+func (i *OAuthIdentity) CreatedAt() time.Time {
+	return i.createdAt
+}
+
+func (i *OAuthIdentity) LastLoginAt() time.Time {
+	return i.lastLoginAt
+}
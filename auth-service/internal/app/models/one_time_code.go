@@ -0,0 +1,110 @@
+package models
+
+import (
+	"time"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// CodePurpose scopes a one-time code to the flow that issued it, so a code
+// sent for one purpose cannot be redeemed in another.
+type CodePurpose string
+
+const (
+	CodePurposePasswordReset     CodePurpose = "password_reset"
+	CodePurposeEmailVerification CodePurpose = "email_verification"
+	CodePurposeSecondFactor      CodePurpose = "second_factor"
+	CodePurposeEmailChange       CodePurpose = "email_change"
+	CodePurposeLogin             CodePurpose = "login"
+)
+
+// OneTimeCode is a single-use, expiring secret sent to a user out of band.
+// Only its hash is stored.
+type OneTimeCode struct {
+	hash      string
+	userID    UserID
+	purpose   CodePurpose
+	expiresAt time.Time
+	usedAt    time.Time
+	// email is the address the code was sent to, for codes confirming a
+	// new email address.
+	email string
+}
+
+func NewOneTimeCode(hash string, userID UserID, purpose CodePurpose, expiresAt time.Time) (*OneTimeCode, error) {
+	if hash == "" {
+		return nil, errors.NewInvalidInputError("hash cannot be empty").
+			WithDetails("field", "hash")
+	}
+
+	if userID.IsEmpty() {
+		return nil, errors.NewInvalidInputError("userID cannot be empty").
+			WithDetails("field", "userID")
+	}
+
+	if purpose == "" {
+		return nil, errors.NewInvalidInputError("purpose cannot be empty").
+			WithDetails("field", "purpose")
+	}
+
+	if expiresAt.IsZero() {
+		return nil, errors.NewInvalidInputError("expiresAt cannot be zero").
+			WithDetails("field", "expiresAt")
+	}
+
+	return &OneTimeCode{
+		hash:      hash,
+		userID:    userID,
+		purpose:   purpose,
+		expiresAt: expiresAt,
+	}, nil
+}
+
+// RestoreOneTimeCode rebuilds a code from storage, including its usage time.
+func RestoreOneTimeCode(hash string, userID UserID, purpose CodePurpose, expiresAt, usedAt time.Time) (*OneTimeCode, error) {
+	code, err := NewOneTimeCode(hash, userID, purpose, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	code.usedAt = usedAt
+	return code, nil
+}
+
+// WithEmail returns a copy of the code bound to the given address.
+func (c *OneTimeCode) WithEmail(email string) *OneTimeCode {
+	updated := *c
+	updated.email = email
+	return &updated
+}
+
+func (c *OneTimeCode) Hash() string {
+	return c.hash
+}
+
+func (c *OneTimeCode) UserID() UserID {
+	return c.userID
+}
+
+func (c *OneTimeCode) Purpose() CodePurpose {
+	return c.purpose
+}
+
+func (c *OneTimeCode) ExpiresAt() time.Time {
+	return c.expiresAt
+}
+
+func (c *OneTimeCode) UsedAt() time.Time {
+	return c.usedAt
+}
+
+func (c *OneTimeCode) Email() string {
+	return c.email
+}
+
+func (c *OneTimeCode) IsExpired() bool {
+	return time.Now().After(c.expiresAt)
+}
+
+func (c *OneTimeCode) IsUsed() bool {
+	return !c.usedAt.IsZero()
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// PasswordPolicy lists the requirements a new password must meet. Lengths
+// count characters, not bytes; zero disables a length limit.
+type PasswordPolicy struct {
+	MinLength     int
+	MaxLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+}
+
+// Validate checks password against the policy. A weak password is reported
+// as a validation error listing every unmet requirement, so that clients can
+// show them all at once.
+func (p PasswordPolicy) Validate(field, password string) error {
+	var (
+		hasUpper, hasLower, hasDigit, hasSymbol bool
+		violations                              []errors.FieldViolation
+	)
+
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	violate := func(format string, args ...any) {
+		violations = append(violations, errors.FieldViolation{Field: field, Description: fmt.Sprintf(format, args...)})
+	}
+
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		violate("must be at least %d characters long", p.MinLength)
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		violate("must be at most %d characters long", p.MaxLength)
+	}
+	if p.RequireUpper && !hasUpper {
+		violate("must contain an uppercase letter")
+	}
+	if p.RequireLower && !hasLower {
+		violate("must contain a lowercase letter")
+	}
+	if p.RequireDigit && !hasDigit {
+		violate("must contain a digit")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		violate("must contain a symbol")
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	return errors.NewValidationError("password does not meet the strength policy").
+		WithDetails("field", field).
+		WithDetails(errors.DetailFieldViolations, violations)
+}
@@ -0,0 +1,145 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/google/uuid"
+)
+
+// FamilyID groups an access token and every refresh token rotated from the
+// same login, so that the whole chain can be revoked at once.
+type FamilyID uuid.UUID
+
+type RefreshToken struct {
+	hash      string
+	userID    UserID
+	familyID  FamilyID
+	expiresAt time.Time
+	usedAt    time.Time
+	revokedAt time.Time
+}
+
+func NewRefreshToken(hash string, userID UserID, familyID FamilyID, expiresAt time.Time) (*RefreshToken, error) {
+	if hash == "" {
+		return nil, errors.NewInvalidInputError("hash cannot be empty").
+			WithDetails("field", "hash")
+	}
+
+	if userID.IsEmpty() {
+		return nil, errors.NewInvalidInputError("userID cannot be empty").
+			WithDetails("field", "userID")
+	}
+
+	if familyID.IsEmpty() {
+		return nil, errors.NewInvalidInputError("familyID cannot be empty").
+			WithDetails("field", "familyID")
+	}
+
+	if expiresAt.IsZero() {
+		return nil, errors.NewInvalidInputError("expiresAt cannot be zero").
+			WithDetails("field", "expiresAt")
+	}
+
+	return &RefreshToken{
+		hash:      hash,
+		userID:    userID,
+		familyID:  familyID,
+		expiresAt: expiresAt,
+	}, nil
+}
+
+// RestoreRefreshToken rebuilds a refresh token from storage, including its
+// usage and revocation timestamps.
+func RestoreRefreshToken(
+	hash string,
+	userID UserID,
+	familyID FamilyID,
+	expiresAt, usedAt, revokedAt time.Time,
+) (*RefreshToken, error) {
+	token, err := NewRefreshToken(hash, userID, familyID, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	token.usedAt = usedAt
+	token.revokedAt = revokedAt
+	return token, nil
+}
+
+// NewFamilyID returns a random token family identifier.
+func NewFamilyID() FamilyID {
+	return FamilyID(uuid.New())
+}
+
+func FamilyIDFromString(id string) (FamilyID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return FamilyID{}, errors.NewInvalidInputError("invalid family ID")
+	}
+	return FamilyID(parsed), nil
+}
+
+func (f FamilyID) IsEmpty() bool {
+	return f == FamilyID(uuid.Nil)
+}
+
+func (f FamilyID) String() string {
+	return uuid.UUID(f).String()
+}
+
+// GenerateSecretToken returns a random URL-safe token suitable for refresh
+// tokens and other bearer secrets that are stored only as a hash.
+func GenerateSecretToken() (Token, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.NewInternalError(err, "failed to generate token")
+	}
+	return Token(base64.RawURLEncoding.EncodeToString(b)), nil
+}
+
+// HashToken returns the hex-encoded SHA-256 of a token. Only the hash is
+// persisted, so a database leak does not expose usable tokens.
+func HashToken(token Token) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (r *RefreshToken) Hash() string {
+	return r.hash
+}
+
+func (r *RefreshToken) UserID() UserID {
+	return r.userID
+}
+
+func (r *RefreshToken) FamilyID() FamilyID {
+	return r.familyID
+}
+
+func (r *RefreshToken) ExpiresAt() time.Time {
+	return r.expiresAt
+}
+
+func (r *RefreshToken) UsedAt() time.Time {
+	return r.usedAt
+}
+
+func (r *RefreshToken) RevokedAt() time.Time {
+	return r.revokedAt
+}
+
+func (r *RefreshToken) IsExpired() bool {
+	return time.Now().After(r.expiresAt)
+}
+
+func (r *RefreshToken) IsUsed() bool {
+	return !r.usedAt.IsZero()
+}
+
+func (r *RefreshToken) IsRevoked() bool {
+	return !r.revokedAt.IsZero()
+}
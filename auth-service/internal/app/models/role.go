@@ -0,0 +1,49 @@
+package models
+
+import (
+	"slices"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// Role grants a user access to privileged operations. Every user holds
+// RoleUser; the others are granted by an administrator.
+type Role string
+
+const (
+	RoleUser      Role = "user"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+var knownRoles = []Role{RoleUser, RoleModerator, RoleAdmin}
+
+// ParseRoles validates role names and returns them sorted and without
+// duplicates. RoleUser is always included.
+func ParseRoles(names []string) ([]Role, error) {
+	roles := []Role{RoleUser}
+	for _, name := range names {
+		role := Role(name)
+		if !slices.Contains(knownRoles, role) {
+			return nil, errors.NewInvalidInputError("unknown role %q", name).
+				WithDetails("field", "roles")
+		}
+		roles = append(roles, role)
+	}
+
+	slices.Sort(roles)
+	return slices.Compact(roles), nil
+}
+
+func (r Role) String() string {
+	return string(r)
+}
+
+// RoleNames converts roles to the strings stored and sent over the wire.
+func RoleNames(roles []Role) []string {
+	names := make([]string, 0, len(roles))
+	for _, role := range roles {
+		names = append(names, role.String())
+	}
+	return names
+}
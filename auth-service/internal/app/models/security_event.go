@@ -0,0 +1,179 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/google/uuid"
+)
+
+// SecurityEventType names an entry of the security audit log.
+type SecurityEventType string
+
+const (
+	SecurityEventRegistered      SecurityEventType = "registered"
+	SecurityEventLoginSucceeded  SecurityEventType = "login_succeeded"
+	SecurityEventLoginFailed     SecurityEventType = "login_failed"
+	SecurityEventLoggedOut       SecurityEventType = "logged_out"
+	SecurityEventPasswordChanged SecurityEventType = "password_changed"
+	SecurityEventSessionRevoked  SecurityEventType = "session_revoked"
+	SecurityEventEmailChanged    SecurityEventType = "email_changed"
+	SecurityEventUsernameChanged SecurityEventType = "username_changed"
+	// SecurityEventImpersonationStarted is recorded when an administrator
+	// obtains a token to act as the user.
+	SecurityEventImpersonationStarted SecurityEventType = "impersonation_started"
+	// SecurityEventImpersonationUsed is recorded every time such a token is
+	// presented.
+	SecurityEventImpersonationUsed SecurityEventType = "impersonation_used"
+)
+
+func (t SecurityEventType) String() string {
+	return string(t)
+}
+
+type SecurityEventID uuid.UUID
+
+func SecurityEventIDFromString(id string) (SecurityEventID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return SecurityEventID{}, errors.NewInvalidInputError("invalid security event ID")
+	}
+	return SecurityEventID(parsed), nil
+}
+
+func (e SecurityEventID) String() string {
+	return uuid.UUID(e).String()
+}
+
+// SecurityEvent is an entry of the append-only security audit log. Events
+// are never changed once recorded.
+type SecurityEvent struct {
+	id         SecurityEventID
+	userID     UserID
+	eventType  SecurityEventType
+	email      string
+	client     SessionMetadata
+	reason     string
+	actorID    UserID
+	occurredAt time.Time
+}
+
+// NewSecurityEvent describes something that just happened to the account of
+// userID. The user is empty for failed logins to unknown accounts.
+func NewSecurityEvent(eventType SecurityEventType, userID UserID, email string, client SessionMetadata) *SecurityEvent {
+	return &SecurityEvent{
+		id:         SecurityEventID(uuid.New()),
+		userID:     userID,
+		eventType:  eventType,
+		email:      email,
+		client:     client,
+		occurredAt: time.Now(),
+	}
+}
+
+// RestoreSecurityEvent rebuilds an event from storage.
+func RestoreSecurityEvent(
+	id SecurityEventID,
+	userID UserID,
+	eventType SecurityEventType,
+	email string,
+	client SessionMetadata,
+	reason string,
+	occurredAt time.Time,
+) *SecurityEvent {
+	return &SecurityEvent{
+		id:         id,
+		userID:     userID,
+		eventType:  eventType,
+		email:      email,
+		client:     client,
+		reason:     reason,
+		occurredAt: occurredAt,
+	}
+}
+
+// WithReason returns a copy of the event explaining why an attempt failed.
+func (e *SecurityEvent) WithReason(reason string) *SecurityEvent {
+	updated := *e
+	updated.reason = reason
+	return &updated
+}
+
+// WithActor returns a copy of the event attributed to an administrator
+// acting on the account.
+func (e *SecurityEvent) WithActor(actorID UserID) *SecurityEvent {
+	updated := *e
+	updated.actorID = actorID
+	return &updated
+}
+
+func (e *SecurityEvent) ID() SecurityEventID {
+	return e.id
+}
+
+func (e *SecurityEvent) UserID() UserID {
+	return e.userID
+}
+
+func (e *SecurityEvent) Type() SecurityEventType {
+	return e.eventType
+}
+
+func (e *SecurityEvent) Email() string {
+	return e.email
+}
+
+func (e *SecurityEvent) Client() SessionMetadata {
+	return e.client
+}
+
+func (e *SecurityEvent) Reason() string {
+	return e.reason
+}
+
+func (e *SecurityEvent) ActorID() UserID {
+	return e.actorID
+}
+
+func (e *SecurityEvent) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+// SecurityEventFilter selects entries of the audit log. Zero values leave a
+// criterion out.
+type SecurityEventFilter struct {
+	UserID UserID
+	Since  time.Time
+	Until  time.Time
+	Limit  int
+}
+
+// Matches reports whether the event passes the filter, ignoring Limit.
+func (f SecurityEventFilter) Matches(event *SecurityEvent) bool {
+	if !f.UserID.IsEmpty() && event.UserID() != f.UserID {
+		return false
+	}
+	if !f.Since.IsZero() && event.OccurredAt().Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !event.OccurredAt().Before(f.Until) {
+		return false
+	}
+	return true
+}
+
+type clientContextKey struct{}
+
+// ContextWithClient attaches the client of the current request, which is
+// recorded in security events.
+func ContextWithClient(ctx context.Context, client SessionMetadata) context.Context {
+	return context.WithValue(ctx, clientContextKey{}, client)
+}
+
+// ClientFromContext returns the client attached by ContextWithClient, or an
+// empty description when there is none.
+func ClientFromContext(ctx context.Context) SessionMetadata {
+	client, _ := ctx.Value(clientContextKey{}).(SessionMetadata)
+	return client
+}
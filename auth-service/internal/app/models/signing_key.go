@@ -0,0 +1,83 @@
+package models
+
+import (
+	"time"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// SigningKey is a private key that signs access tokens, kept PKCS #8
+// encoded and encrypted with the key encryption key. A key is current until
+// its successor retires it.
+type SigningKey struct {
+	id         string
+	algorithm  string
+	privateKey []byte
+	createdAt  time.Time
+	retiredAt  time.Time
+}
+
+func NewSigningKey(id, algorithm string, privateKey []byte, createdAt time.Time) (*SigningKey, error) {
+	if id == "" {
+		return nil, errors.NewInvalidInputError("id cannot be empty").
+			WithDetails("field", "id")
+	}
+	if algorithm == "" {
+		return nil, errors.NewInvalidInputError("algorithm cannot be empty").
+			WithDetails("field", "algorithm")
+	}
+	if len(privateKey) == 0 {
+		return nil, errors.NewInvalidInputError("private key cannot be empty").
+			WithDetails("field", "privateKey")
+	}
+
+	return &SigningKey{
+		id:         id,
+		algorithm:  algorithm,
+		privateKey: privateKey,
+		createdAt:  createdAt,
+	}, nil
+}
+
+// RestoreSigningKey rebuilds a key from storage. A zero retiredAt marks the
+// current key.
+func RestoreSigningKey(id, algorithm string, privateKey []byte, createdAt, retiredAt time.Time) *SigningKey {
+	return &SigningKey{
+		id:         id,
+		algorithm:  algorithm,
+		privateKey: privateKey,
+		createdAt:  createdAt,
+		retiredAt:  retiredAt,
+	}
+}
+
+func (k *SigningKey) ID() string {
+	return k.id
+}
+
+func (k *SigningKey) Algorithm() string {
+	return k.algorithm
+}
+
+func (k *SigningKey) PrivateKey() []byte {
+	return k.privateKey
+}
+
+func (k *SigningKey) CreatedAt() time.Time {
+	return k.createdAt
+}
+
+func (k *SigningKey) RetiredAt() time.Time {
+	return k.retiredAt
+}
+
+func (k *SigningKey) IsRetired() bool {
+	return !k.retiredAt.IsZero()
+}
+
+// WithRetired returns a copy of the key retired at the given time.
+func (k *SigningKey) WithRetired(at time.Time) *SigningKey {
+	key := *k
+	key.retiredAt = at
+	return &key
+}
@@ -8,13 +8,27 @@ import (
 
 type Token string
 
+// SessionMetadata describes the client a session was opened from.
+type SessionMetadata struct {
+	DeviceName string
+	UserAgent  string
+	IP         string
+}
+
+// AuthToken is the current access token of a login session. The session is
+// identified by its family ID, which stays stable across refreshes.
 type AuthToken struct {
-	token     Token
-	userID    UserID
-	expiresAt time.Time
+	token      Token
+	userID     UserID
+	familyID   FamilyID
+	expiresAt  time.Time
+	session    SessionMetadata
+	actorID    UserID
+	createdAt  time.Time
+	lastUsedAt time.Time
 }
 
-func NewAuthToken(token Token, userID UserID, expiresAt time.Time) (*AuthToken, error) {
+func NewAuthToken(token Token, userID UserID, familyID FamilyID, expiresAt time.Time) (*AuthToken, error) {
 	if token.IsEmpty() {
 		return nil, errors.NewInvalidInputError("token cannot be empty").
 			WithDetails("field", "token")
@@ -30,13 +44,56 @@ func NewAuthToken(token Token, userID UserID, expiresAt time.Time) (*AuthToken,
 			WithDetails("field", "userID")
 	}
 
+	if familyID.IsEmpty() {
+		return nil, errors.NewInvalidInputError("familyID cannot be empty").
+			WithDetails("field", "familyID")
+	}
+
+	now := time.Now()
 	return &AuthToken{
-		token:     token,
-		userID:    userID,
-		expiresAt: expiresAt,
+		token:      token,
+		userID:     userID,
+		familyID:   familyID,
+		expiresAt:  expiresAt,
+		createdAt:  now,
+		lastUsedAt: now,
 	}, nil
 }
 
+// RestoreAuthToken rebuilds a session token from storage.
+func RestoreAuthToken(
+	token Token,
+	userID UserID,
+	familyID FamilyID,
+	expiresAt time.Time,
+	session SessionMetadata,
+	createdAt, lastUsedAt time.Time,
+) (*AuthToken, error) {
+	authToken, err := NewAuthToken(token, userID, familyID, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	authToken.session = session
+	authToken.createdAt = createdAt
+	authToken.lastUsedAt = lastUsedAt
+	return authToken, nil
+}
+
+// WithSession returns a copy of the token carrying the given client metadata.
+func (a *AuthToken) WithSession(session SessionMetadata) *AuthToken {
+	c := *a
+	c.session = session
+	return &c
+}
+
+// WithActor returns a copy of the token marked as used by actorID on behalf
+// of the token's user.
+func (a *AuthToken) WithActor(actorID UserID) *AuthToken {
+	c := *a
+	c.actorID = actorID
+	return &c
+}
+
 func (a *AuthToken) Token() Token {
 	return a.token
 }
@@ -45,10 +102,38 @@ func (a *AuthToken) UserID() UserID {
 	return a.userID
 }
 
+func (a *AuthToken) FamilyID() FamilyID {
+	return a.familyID
+}
+
 func (a *AuthToken) ExpiresAt() time.Time {
 	return a.expiresAt
 }
 
+func (a *AuthToken) Session() SessionMetadata {
+	return a.session
+}
+
+// ActorID is the administrator acting as the user when the token was issued
+// by Impersonate. It is empty for the user's own sessions.
+func (a *AuthToken) ActorID() UserID {
+	return a.actorID
+}
+
+// IsImpersonation reports whether the token was issued to an administrator
+// acting as the user.
+func (a *AuthToken) IsImpersonation() bool {
+	return !a.actorID.IsEmpty()
+}
+
+func (a *AuthToken) CreatedAt() time.Time {
+	return a.createdAt
+}
+
+func (a *AuthToken) LastUsedAt() time.Time {
+	return a.lastUsedAt
+}
+
 func (t Token) IsEmpty() bool {
 	return t == ""
 }
@@ -0,0 +1,205 @@
+package models
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+const (
+	// TOTPPeriod is the RFC 6238 time step.
+	TOTPPeriod = 30 * time.Second
+	// TOTPDigits is the length of generated codes.
+	TOTPDigits = 6
+	// totpSkew is the number of steps accepted on either side of the current
+	// one, to tolerate clock drift between server and authenticator.
+	totpSkew = 1
+	// totpSecretSize is the length of the shared secret, as recommended by
+	// RFC 4226 for HMAC-SHA1.
+	totpSecretSize = 20
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TOTPFactor is a time-based one-time password second factor. It becomes
+// effective only after the user proves possession with a first code.
+type TOTPFactor struct {
+	userID       UserID
+	secret       string
+	confirmedAt  time.Time
+	lastUsedStep int64
+}
+
+func NewTOTPFactor(userID UserID, secret string) (*TOTPFactor, error) {
+	if userID.IsEmpty() {
+		return nil, errors.NewInvalidInputError("userID cannot be empty").
+			WithDetails("field", "userID")
+	}
+
+	if _, err := totpEncoding.DecodeString(secret); err != nil || secret == "" {
+		return nil, errors.NewInvalidInputError("secret must be base32 encoded").
+			WithDetails("field", "secret")
+	}
+
+	return &TOTPFactor{
+		userID: userID,
+		secret: secret,
+	}, nil
+}
+
+// RestoreTOTPFactor rebuilds a factor from storage, including its activation
+// state and the last accepted time step.
+func RestoreTOTPFactor(userID UserID, secret string, confirmedAt time.Time, lastUsedStep int64) (*TOTPFactor, error) {
+	factor, err := NewTOTPFactor(userID, secret)
+	if err != nil {
+		return nil, err
+	}
+	factor.confirmedAt = confirmedAt
+	factor.lastUsedStep = lastUsedStep
+	return factor, nil
+}
+
+// GenerateTOTPSecret returns a random base32 secret for a new factor.
+func GenerateTOTPSecret() (string, error) {
+	b := make([]byte, totpSecretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.NewInternalError(err, "failed to generate secret")
+	}
+	return totpEncoding.EncodeToString(b), nil
+}
+
+func (f *TOTPFactor) UserID() UserID {
+	return f.userID
+}
+
+func (f *TOTPFactor) Secret() string {
+	return f.secret
+}
+
+func (f *TOTPFactor) ConfirmedAt() time.Time {
+	return f.confirmedAt
+}
+
+func (f *TOTPFactor) LastUsedStep() int64 {
+	return f.lastUsedStep
+}
+
+func (f *TOTPFactor) IsConfirmed() bool {
+	return !f.confirmedAt.IsZero()
+}
+
+// URI returns the otpauth:// key URI understood by authenticator apps.
+func (f *TOTPFactor) URI(issuer, account string) string {
+	label := url.PathEscape(issuer + ":" + account)
+	params := url.Values{}
+	params.Set("secret", f.secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(TOTPDigits))
+	params.Set("period", fmt.Sprint(int(TOTPPeriod.Seconds())))
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+// Verify checks a code against the steps around at. It returns the matched
+// step, which callers persist with WithLastUsedStep so that a code cannot be
+// replayed within its validity window.
+func (f *TOTPFactor) Verify(code string, at time.Time) (int64, bool) {
+	if len(code) != TOTPDigits {
+		return 0, false
+	}
+
+	key, err := totpEncoding.DecodeString(f.secret)
+	if err != nil {
+		return 0, false
+	}
+
+	current := at.Unix() / int64(TOTPPeriod.Seconds())
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if step <= f.lastUsedStep {
+			continue
+		}
+		if hmac.Equal([]byte(hotp(key, step)), []byte(code)) {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// Confirm returns a copy of the factor activated with the code of the given step.
+func (f *TOTPFactor) Confirm(at time.Time, step int64) *TOTPFactor {
+	updated := *f
+	updated.confirmedAt = at
+	updated.lastUsedStep = step
+	return &updated
+}
+
+// WithLastUsedStep returns a copy of the factor that rejects codes up to step.
+func (f *TOTPFactor) WithLastUsedStep(step int64) *TOTPFactor {
+	updated := *f
+	updated.lastUsedStep = step
+	return &updated
+}
+
+// TOTPCode returns the code for the step containing at. It exists for tests
+// and tooling; verification goes through Verify.
+func TOTPCode(secret string, at time.Time) (string, error) {
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil {
+		return "", errors.NewInvalidInputError("secret must be base32 encoded")
+	}
+	return hotp(key, at.Unix()/int64(TOTPPeriod.Seconds())), nil
+}
+
+// hotp implements RFC 4226 with HMAC-SHA1 and dynamic truncation.
+func hotp(key []byte, counter int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(counter))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < TOTPDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", TOTPDigits, value%mod)
+}
+
+// recoveryCodeLength is the number of characters in a recovery code,
+// excluding the separator added for readability.
+const recoveryCodeLength = 10
+
+// GenerateRecoveryCodes returns n single-use codes formatted as xxxxx-xxxxx.
+// Callers store HashToken(NormalizeRecoveryCode(code)).
+func GenerateRecoveryCodes(n int) ([]Token, error) {
+	codes := make([]Token, 0, n)
+	for i := 0; i < n; i++ {
+		b := make([]byte, 8)
+		if _, err := rand.Read(b); err != nil {
+			return nil, errors.NewInternalError(err, "failed to generate recovery code")
+		}
+		raw := strings.ToLower(totpEncoding.EncodeToString(b))[:recoveryCodeLength]
+		codes = append(codes, Token(raw[:recoveryCodeLength/2]+"-"+raw[recoveryCodeLength/2:]))
+	}
+	return codes, nil
+}
+
+// NormalizeRecoveryCode strips separators and case so that a code is
+// accepted however the user typed it.
+func NormalizeRecoveryCode(code Token) Token {
+	normalized := strings.ToLower(string(code))
+	normalized = strings.NewReplacer("-", "", " ", "").Replace(normalized)
+	return Token(normalized)
+}
@@ -1,6 +1,9 @@
 package models
 
 import (
+	"slices"
+	"time"
+
 	"github.com/SamEkb/messenger-app/pkg/platform/errors"
 	"github.com/google/uuid"
 )
@@ -12,6 +15,12 @@ type User struct {
 	username string
 	email    string
 	password string
+	// emailVerified is set once the user confirms the verification code
+	// mailed at registration.
+	emailVerified bool
+	roles         []Role
+	// suspendedAt is set while an administrator has suspended the account.
+	suspendedAt time.Time
 }
 
 func NewUser(id UserID, username string, email string, password []byte) (*User, error) {
@@ -34,9 +43,26 @@ func NewUser(id UserID, username string, email string, password []byte) (*User,
 		username: username,
 		email:    email,
 		password: string(password),
+		roles:    []Role{RoleUser},
 	}, nil
 }
 
+// RestoreUser rebuilds a user from storage, including state that NewUser
+// always starts with its zero value.
+func RestoreUser(id UserID, username string, email string, password []byte, emailVerified bool, roles []string, suspendedAt time.Time) (*User, error) {
+	user, err := NewUser(id, username, email, password)
+	if err != nil {
+		return nil, err
+	}
+	user.emailVerified = emailVerified
+	user.roles, err = ParseRoles(roles)
+	if err != nil {
+		return nil, err
+	}
+	user.suspendedAt = suspendedAt
+	return user, nil
+}
+
 func UserIDFromString(id string) (UserID, error) {
 	parsed, err := uuid.Parse(id)
 	if err != nil {
@@ -68,3 +94,85 @@ func (u *User) Email() string {
 func (u *User) Password() string {
 	return u.password
 }
+
+func (u *User) EmailVerified() bool {
+	return u.emailVerified
+}
+
+func (u *User) Roles() []Role {
+	return slices.Clone(u.roles)
+}
+
+func (u *User) SuspendedAt() time.Time {
+	return u.suspendedAt
+}
+
+func (u *User) IsSuspended() bool {
+	return !u.suspendedAt.IsZero()
+}
+
+// HasRole reports whether the user holds role.
+func (u *User) HasRole(role Role) bool {
+	return slices.Contains(u.roles, role)
+}
+
+// WithPassword returns a copy of the user with a new password hash.
+func (u *User) WithPassword(password []byte) (*User, error) {
+	if len(password) == 0 {
+		return nil, errors.NewInvalidInputError("password cannot be empty").
+			WithDetails("field", "password")
+	}
+	updated := *u
+	updated.password = string(password)
+	return &updated, nil
+}
+
+// WithRoles returns a copy of the user with the given roles. RoleUser is
+// kept even when it is not listed.
+func (u *User) WithRoles(roles []string) (*User, error) {
+	parsed, err := ParseRoles(roles)
+	if err != nil {
+		return nil, err
+	}
+	updated := *u
+	updated.roles = parsed
+	return &updated, nil
+}
+
+// WithSuspended returns a copy of the user suspended at the given time.
+func (u *User) WithSuspended(at time.Time) *User {
+	updated := *u
+	updated.suspendedAt = at
+	return &updated
+}
+
+// WithEmailVerified returns a copy of the user with the email marked verified.
+func (u *User) WithEmailVerified() *User {
+	updated := *u
+	updated.emailVerified = true
+	return &updated
+}
+
+// WithUsername returns a copy of the user with a new username.
+func (u *User) WithUsername(username string) (*User, error) {
+	if username == "" {
+		return nil, errors.NewInvalidInputError("username cannot be empty").
+			WithDetails("field", "username")
+	}
+	updated := *u
+	updated.username = username
+	return &updated, nil
+}
+
+// WithEmail returns a copy of the user with a new email address. The address
+// counts as verified, since it is only changed after the user confirms it.
+func (u *User) WithEmail(email string) (*User, error) {
+	if email == "" {
+		return nil, errors.NewInvalidInputError("email cannot be empty").
+			WithDetails("field", "email")
+	}
+	updated := *u
+	updated.email = email
+	updated.emailVerified = true
+	return &updated, nil
+}
@@ -0,0 +1,36 @@
+package models
+
+import "slices"
+
+// UserAccess is what token validation checks about the owner of a token on
+// every request: the roles it grants and whether the account is suspended.
+type UserAccess struct {
+	userID    UserID
+	roles     []Role
+	suspended bool
+}
+
+func RestoreUserAccess(userID UserID, roles []string, suspended bool) (*UserAccess, error) {
+	parsed, err := ParseRoles(roles)
+	if err != nil {
+		return nil, err
+	}
+	return &UserAccess{userID: userID, roles: parsed, suspended: suspended}, nil
+}
+
+// Access returns the part of the user that token validation needs.
+func (u *User) Access() *UserAccess {
+	return &UserAccess{userID: u.id, roles: u.Roles(), suspended: u.IsSuspended()}
+}
+
+func (a *UserAccess) UserID() UserID {
+	return a.userID
+}
+
+func (a *UserAccess) Roles() []Role {
+	return slices.Clone(a.roles)
+}
+
+func (a *UserAccess) IsSuspended() bool {
+	return a.suspended
+}
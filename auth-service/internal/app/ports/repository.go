@@ -2,20 +2,170 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
 )
 
+type TxManager interface {
+	RunTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// CommitHooks defers work until the transaction in ctx has committed.
+type CommitHooks interface {
+	AfterCommit(ctx context.Context, fn func(ctx context.Context))
+}
+
 type AuthRepository interface {
 	Create(ctx context.Context, user *models.User) error
 	FindUserByID(ctx context.Context, userID models.UserID) (*models.User, error)
+	// FindUserAccess returns the roles and suspension of a user. Token
+	// validation calls it on every request, so it may be served from a cache
+	// and must not be used to read a user that is about to be updated.
+	FindUserAccess(ctx context.Context, userID models.UserID) (*models.UserAccess, error)
 	FindUserByEmail(ctx context.Context, email string) (*models.User, error)
+	FindUserByUsername(ctx context.Context, username string) (*models.User, error)
 	Update(ctx context.Context, user *models.User) error
+	Delete(ctx context.Context, userID models.UserID) error
 }
 
 type TokenRepository interface {
 	Create(ctx context.Context, token *models.AuthToken) (*models.AuthToken, error)
 	FindToken(ctx context.Context, token string) (models.Token, error)
+	GetToken(ctx context.Context, token models.Token) (*models.AuthToken, error)
 	ValidateToken(ctx context.Context, token string) (bool, error)
 	DeleteToken(ctx context.Context, token models.Token) error
+	DeleteTokensByFamily(ctx context.Context, familyID models.FamilyID) error
+	DeleteTokensByUser(ctx context.Context, userID models.UserID) error
+	GetSession(ctx context.Context, familyID models.FamilyID) (*models.AuthToken, error)
+	ListSessions(ctx context.Context, userID models.UserID) ([]*models.AuthToken, error)
+	// RotateToken replaces the access token of a session in place, keeping its
+	// metadata and creation time.
+	RotateToken(ctx context.Context, familyID models.FamilyID, token models.Token, expiresAt time.Time) error
+	TouchToken(ctx context.Context, token models.Token, usedAt time.Time) error
+	// DeleteExpired removes up to limit tokens that expired before the given
+	// time and returns how many were removed.
+	DeleteExpired(ctx context.Context, before time.Time, limit int) (int, error)
+}
+
+// TokenCache keeps the results of access token lookups for a short time. A
+// nil token records that the token does not exist.
+type TokenCache interface {
+	// Get returns the cached lookup result and whether there was one.
+	Get(ctx context.Context, token models.Token) (*models.AuthToken, bool, error)
+	Set(ctx context.Context, token models.Token, authToken *models.AuthToken, ttl time.Duration) error
+	Delete(ctx context.Context, tokens ...models.Token) error
+}
+
+// UserAccessCache keeps the results of user access lookups for a short time.
+// A nil access records that the user does not exist.
+type UserAccessCache interface {
+	// Get returns the cached lookup result and whether there was one.
+	Get(ctx context.Context, userID models.UserID) (*models.UserAccess, bool, error)
+	Set(ctx context.Context, userID models.UserID, access *models.UserAccess, ttl time.Duration) error
+	Delete(ctx context.Context, userIDs ...models.UserID) error
+}
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *models.RefreshToken) error
+	GetByHash(ctx context.Context, hash string) (*models.RefreshToken, error)
+	// MarkUsed flags an unused refresh token as consumed. It returns an
+	// ErrInvalidToken error when the token has already been used.
+	MarkUsed(ctx context.Context, hash string, usedAt time.Time) error
+	RevokeFamily(ctx context.Context, familyID models.FamilyID, revokedAt time.Time) error
+	RevokeByUser(ctx context.Context, userID models.UserID, revokedAt time.Time) error
+}
+
+type OneTimeCodeRepository interface {
+	Create(ctx context.Context, code *models.OneTimeCode) error
+	GetByHash(ctx context.Context, hash string) (*models.OneTimeCode, error)
+	// MarkUsed redeems an unused code. It returns an ErrInvalidToken error
+	// when the code has already been used.
+	MarkUsed(ctx context.Context, hash string, usedAt time.Time) error
+	// GetActiveByUser returns the most recently issued unused code of the
+	// given purpose, expired or not.
+	GetActiveByUser(ctx context.Context, userID models.UserID, purpose models.CodePurpose) (*models.OneTimeCode, error)
+	// InvalidateByUser marks every outstanding code of the given purpose as
+	// used, so that only the most recently issued code stays valid.
+	InvalidateByUser(ctx context.Context, userID models.UserID, purpose models.CodePurpose, at time.Time) error
+	// RecordFailedAttempt counts a wrong guess against a code and returns the
+	// number of failed attempts so far.
+	RecordFailedAttempt(ctx context.Context, hash string) (int, error)
+}
+
+type TOTPRepository interface {
+	// Save stores a factor, replacing any existing factor of the user.
+	Save(ctx context.Context, factor *models.TOTPFactor) error
+	GetByUser(ctx context.Context, userID models.UserID) (*models.TOTPFactor, error)
+	Update(ctx context.Context, factor *models.TOTPFactor) error
+}
+
+type RecoveryCodeRepository interface {
+	// ReplaceAll discards the user's recovery codes and stores the given hashes.
+	ReplaceAll(ctx context.Context, userID models.UserID, hashes []string) error
+	// Use redeems an unused recovery code. It returns an ErrInvalidToken error
+	// when no such unused code exists.
+	Use(ctx context.Context, userID models.UserID, hash string, usedAt time.Time) error
+}
+
+// LoginAttemptRepository stores failed login counters used for throttling.
+type LoginAttemptRepository interface {
+	Get(ctx context.Context, key string) (*models.LoginAttempt, error)
+	// RecordFailure counts a failed login for the key and returns the updated
+	// counter. Counters whose last failure is older than window start over.
+	RecordFailure(ctx context.Context, key string, at time.Time, window time.Duration) (*models.LoginAttempt, error)
+	// Lock rejects logins for the key until the given time.
+	Lock(ctx context.Context, key string, until time.Time) error
+	// Reset forgets all failures of the key.
+	Reset(ctx context.Context, key string) error
+}
+
+type OAuthStateRepository interface {
+	Create(ctx context.Context, state *models.OAuthState) error
+	// Consume removes a pending state and returns it, so that a state can be
+	// redeemed only once.
+	Consume(ctx context.Context, hash string) (*models.OAuthState, error)
+}
+
+type OAuthIdentityRepository interface {
+	Create(ctx context.Context, identity *models.OAuthIdentity) error
+	FindBySubject(ctx context.Context, provider, subject string) (*models.OAuthIdentity, error)
+	TouchLogin(ctx context.Context, provider, subject string, at time.Time) error
+}
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *models.APIKey) error
+	GetByHash(ctx context.Context, hash string) (*models.APIKey, error)
+	// ListByUser returns the keys of the user that have not been revoked,
+	// newest first.
+	ListByUser(ctx context.Context, userID models.UserID) ([]*models.APIKey, error)
+	// Revoke revokes a key of the user. It returns a not found error when the
+	// user has no such active key.
+	Revoke(ctx context.Context, userID models.UserID, keyID models.APIKeyID, revokedAt time.Time) error
+	Touch(ctx context.Context, keyID models.APIKeyID, usedAt time.Time) error
+}
+
+// SigningKeyRepository stores the JWT signing keys shared by all replicas.
+type SigningKeyRepository interface {
+	// Lock serializes key rotation between replicas until the transaction in
+	// ctx ends.
+	Lock(ctx context.Context) error
+	// GetCurrent returns the key that is not retired yet.
+	GetCurrent(ctx context.Context) (*models.SigningKey, error)
+	// Rotate retires the current key, if any, as of the creation of key and
+	// stores key as the new current one.
+	Rotate(ctx context.Context, key *models.SigningKey) error
+	// ListPublished returns the current key and the keys retired after the
+	// given time, newest first.
+	ListPublished(ctx context.Context, retiredAfter time.Time) ([]*models.SigningKey, error)
+	// DeleteRetired removes the keys retired before the given time.
+	DeleteRetired(ctx context.Context, before time.Time) error
+}
+
+// SecurityEventRepository stores the security audit log. It is append-only:
+// events cannot be changed or deleted through it.
+type SecurityEventRepository interface {
+	Append(ctx context.Context, event *models.SecurityEvent) error
+	// List returns the events matching the filter, newest first.
+	List(ctx context.Context, filter models.SecurityEventFilter) ([]*models.SecurityEvent, error)
 }
@@ -2,17 +2,112 @@ package ports
 
 import (
 	"context"
+	"time"
 
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
 	auth "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
 	"github.com/SamEkb/messenger-app/pkg/api/events/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
 )
 
-type UserEventsKafkaProducer interface {
-	ProduceUserRegisteredEvent(ctx context.Context, event *events.UserRegisteredEvent) error
+// UserEventsOutbox records user events in the caller's transaction. They
+// reach Kafka after commit, so an event is published if and only if the
+// change that produced it is committed.
+type UserEventsOutbox interface {
+	EnqueueUserRegisteredEvent(ctx context.Context, event *events.UserRegisteredEvent) error
+	EnqueueUserEmailVerifiedEvent(ctx context.Context, event *events.UserEmailVerifiedEvent) error
+	EnqueueUserDeletedEvent(ctx context.Context, event *events.UserDeletedEvent) error
+	EnqueueUserSuspendedEvent(ctx context.Context, event *events.UserSuspendedEvent) error
+	EnqueueUserCredentialsChangedEvent(ctx context.Context, event *events.UserCredentialsChangedEvent) error
+}
+
+type MailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, message *MailMessage) error
+}
+
+// TokenIssuer creates access tokens. Self-contained tokens carry the user's
+// roles so that services can authorize calls without asking auth-service.
+type TokenIssuer interface {
+	Issue(ctx context.Context, user *models.User, expiresAt time.Time) (models.Token, error)
+	// IssueImpersonation creates an access token of user that names actorID
+	// as the administrator acting as the user.
+	IssueImpersonation(ctx context.Context, user *models.User, actorID models.UserID, expiresAt time.Time) (models.Token, error)
+}
+
+// PasswordHasher hashes passwords for storage. Every hash names its
+// algorithm and parameters, so hashes made under an older configuration can
+// still be verified.
+type PasswordHasher interface {
+	Hash(password string) ([]byte, error)
+	// Compare returns nil when password matches hash.
+	Compare(hash []byte, password string) error
+	// NeedsRehash reports whether hash uses a weaker algorithm or weaker
+	// parameters than the hasher is configured with.
+	NeedsRehash(hash []byte) bool
+}
+
+// ExternalIdentityDto is the verified identity an OpenID Connect provider
+// returned for a completed authorization code flow.
+type ExternalIdentityDto struct {
+	Provider          string
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+}
+
+// IdentityProvider runs the authorization code flow with PKCE against an
+// external OpenID Connect issuer.
+type IdentityProvider interface {
+	Name() string
+	// AuthCodeURL builds the URL the user is sent to in order to sign in.
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+	// Exchange redeems an authorization code and returns the identity from
+	// the verified ID token. The token must carry the given nonce.
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*ExternalIdentityDto, error)
+}
+
+type KeySetProvider interface {
+	JWKS() platformauth.JWKSet
 }
 
 type UserGrpcServer interface {
 	Login(ctx context.Context, req *auth.LoginRequest) (*auth.LoginResponse, error)
+	RequestLoginCode(ctx context.Context, req *auth.RequestLoginCodeRequest) (*auth.RequestLoginCodeResponse, error)
+	LoginWithCode(ctx context.Context, req *auth.LoginWithCodeRequest) (*auth.LoginWithCodeResponse, error)
 	Register(ctx context.Context, req *auth.RegisterRequest) (*auth.RegisterResponse, error)
 	Logout(ctx context.Context, req *auth.LogoutRequest) (*auth.LogoutResponse, error)
+	ValidateToken(ctx context.Context, req *auth.ValidateTokenRequest) (*auth.ValidateTokenResponse, error)
+	RefreshToken(ctx context.Context, req *auth.RefreshTokenRequest) (*auth.RefreshTokenResponse, error)
+	ListSessions(ctx context.Context, req *auth.ListSessionsRequest) (*auth.ListSessionsResponse, error)
+	RevokeSession(ctx context.Context, req *auth.RevokeSessionRequest) (*auth.RevokeSessionResponse, error)
+	LogoutEverywhere(ctx context.Context, req *auth.LogoutEverywhereRequest) (*auth.LogoutEverywhereResponse, error)
+	ChangePassword(ctx context.Context, req *auth.ChangePasswordRequest) (*auth.ChangePasswordResponse, error)
+	RequestPasswordReset(ctx context.Context, req *auth.RequestPasswordResetRequest) (*auth.RequestPasswordResetResponse, error)
+	ConfirmPasswordReset(ctx context.Context, req *auth.ConfirmPasswordResetRequest) (*auth.ConfirmPasswordResetResponse, error)
+	VerifyEmail(ctx context.Context, req *auth.VerifyEmailRequest) (*auth.VerifyEmailResponse, error)
+	ResendVerification(ctx context.Context, req *auth.ResendVerificationRequest) (*auth.ResendVerificationResponse, error)
+	ChangeEmail(ctx context.Context, req *auth.ChangeEmailRequest) (*auth.ChangeEmailResponse, error)
+	ConfirmEmailChange(ctx context.Context, req *auth.ConfirmEmailChangeRequest) (*auth.ConfirmEmailChangeResponse, error)
+	ChangeUsername(ctx context.Context, req *auth.ChangeUsernameRequest) (*auth.ChangeUsernameResponse, error)
+	EnrollTOTP(ctx context.Context, req *auth.EnrollTOTPRequest) (*auth.EnrollTOTPResponse, error)
+	ConfirmTOTP(ctx context.Context, req *auth.ConfirmTOTPRequest) (*auth.ConfirmTOTPResponse, error)
+	VerifySecondFactor(ctx context.Context, req *auth.VerifySecondFactorRequest) (*auth.VerifySecondFactorResponse, error)
+	StartOAuthLogin(ctx context.Context, req *auth.StartOAuthLoginRequest) (*auth.StartOAuthLoginResponse, error)
+	CompleteOAuthLogin(ctx context.Context, req *auth.CompleteOAuthLoginRequest) (*auth.CompleteOAuthLoginResponse, error)
+	SetUserRoles(ctx context.Context, req *auth.SetUserRolesRequest) (*auth.SetUserRolesResponse, error)
+	DeleteAccount(ctx context.Context, req *auth.DeleteAccountRequest) (*auth.DeleteAccountResponse, error)
+	SuspendAccount(ctx context.Context, req *auth.SuspendAccountRequest) (*auth.SuspendAccountResponse, error)
+	Impersonate(ctx context.Context, req *auth.ImpersonateRequest) (*auth.ImpersonateResponse, error)
+	CreateApiKey(ctx context.Context, req *auth.CreateApiKeyRequest) (*auth.CreateApiKeyResponse, error)
+	ListApiKeys(ctx context.Context, req *auth.ListApiKeysRequest) (*auth.ListApiKeysResponse, error)
+	RevokeApiKey(ctx context.Context, req *auth.RevokeApiKeyRequest) (*auth.RevokeApiKeyResponse, error)
+	ListSecurityEvents(ctx context.Context, req *auth.ListSecurityEventsRequest) (*auth.ListSecurityEventsResponse, error)
 }
@@ -2,19 +2,60 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
 )
 
 type AuthUseCase interface {
-	Login(ctx context.Context, dto *LoginDto) (models.Token, error)
+	Login(ctx context.Context, dto *LoginDto) (*LoginResultDto, error)
+	// RequestLoginCode mails a passwordless login code or link. It is an
+	// error when passwordless login is disabled.
+	RequestLoginCode(ctx context.Context, email string) error
+	LoginWithCode(ctx context.Context, dto *LoginWithCodeDto) (*LoginResultDto, error)
 	Register(ctx context.Context, dto *RegisterDto) (models.UserID, error)
 	Logout(ctx context.Context, token models.Token) error
+	ValidateToken(ctx context.Context, token models.Token) (*TokenInfoDto, error)
+	RefreshToken(ctx context.Context, refreshToken models.Token) (*AuthTokensDto, error)
+	ListSessions(ctx context.Context) ([]*SessionDto, error)
+	RevokeSession(ctx context.Context, sessionID models.FamilyID) error
+	LogoutEverywhere(ctx context.Context) error
+	ChangePassword(ctx context.Context, dto *ChangePasswordDto) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	ConfirmPasswordReset(ctx context.Context, dto *ConfirmPasswordResetDto) error
+	VerifyEmail(ctx context.Context, code models.Token) error
+	ResendVerification(ctx context.Context, email string) error
+	// ChangeEmail mails a confirmation code to the new address and returns
+	// when the code expires.
+	ChangeEmail(ctx context.Context, dto *ChangeEmailDto) (time.Time, error)
+	ConfirmEmailChange(ctx context.Context, code models.Token) error
+	ChangeUsername(ctx context.Context, username string) error
+	EnrollTOTP(ctx context.Context) (*TOTPEnrollmentDto, error)
+	ConfirmTOTP(ctx context.Context, code string) ([]models.Token, error)
+	VerifySecondFactor(ctx context.Context, dto *VerifySecondFactorDto) (*AuthTokensDto, error)
+	StartOAuthLogin(ctx context.Context, provider string) (*OAuthLoginStartDto, error)
+	CompleteOAuthLogin(ctx context.Context, dto *CompleteOAuthLoginDto) (*LoginResultDto, error)
+	SetUserRoles(ctx context.Context, dto *SetUserRolesDto) ([]models.Role, error)
+	DeleteAccount(ctx context.Context, dto *DeleteAccountDto) error
+	SuspendAccount(ctx context.Context, dto *SuspendAccountDto) error
+	Impersonate(ctx context.Context, dto *ImpersonateDto) (*ImpersonationDto, error)
+	CreateAPIKey(ctx context.Context, dto *CreateAPIKeyDto) (*CreatedAPIKeyDto, error)
+	ListAPIKeys(ctx context.Context) ([]*APIKeyDto, error)
+	RevokeAPIKey(ctx context.Context, keyID models.APIKeyID) error
+	ListSecurityEvents(ctx context.Context, dto *ListSecurityEventsDto) ([]*SecurityEventDto, error)
 }
 
 type LoginDto struct {
 	Email    string
 	Password string
+	Session  models.SessionMetadata
+}
+
+type LoginWithCodeDto struct {
+	Email   string
+	Code    models.Token
+	Session models.SessionMetadata
 }
 
 type RegisterDto struct {
@@ -22,3 +63,163 @@ type RegisterDto struct {
 	Email    string
 	Password string
 }
+
+// TokenInfoDto describes a validated token. API keys have an APIKeyID and
+// scopes instead of a session and do not expire.
+type TokenInfoDto struct {
+	UserID    models.UserID
+	SessionID models.FamilyID
+	APIKeyID  models.APIKeyID
+	Roles     []models.Role
+	Scopes    []platformauth.Scope
+	// ActorID is set for impersonation tokens.
+	ActorID   models.UserID
+	ExpiresAt time.Time
+	Active    bool
+}
+
+type AuthTokensDto struct {
+	UserID                models.UserID
+	AccessToken           models.Token
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          models.Token
+	RefreshTokenExpiresAt time.Time
+}
+
+type SessionDto struct {
+	ID         models.FamilyID
+	DeviceName string
+	UserAgent  string
+	IP         string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	ExpiresAt  time.Time
+	Current    bool
+}
+
+type ChangePasswordDto struct {
+	CurrentPassword string
+	NewPassword     string
+}
+
+type ChangeEmailDto struct {
+	NewEmail        string
+	CurrentPassword string
+}
+
+type SetUserRolesDto struct {
+	UserID models.UserID
+	Roles  []string
+}
+
+// DeleteAccountDto selects the account to delete. An empty UserID means the
+// caller's own account, which requires the current password.
+type DeleteAccountDto struct {
+	UserID   models.UserID
+	Password string
+}
+
+type SuspendAccountDto struct {
+	UserID models.UserID
+	Reason string
+}
+
+type CreateAPIKeyDto struct {
+	Name   string
+	Scopes []string
+}
+
+type APIKeyDto struct {
+	ID         models.APIKeyID
+	Name       string
+	Hint       string
+	Scopes     []platformauth.Scope
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+}
+
+// CreatedAPIKeyDto carries a new API key. Key is never available again.
+type CreatedAPIKeyDto struct {
+	APIKey *APIKeyDto
+	Key    models.Token
+}
+
+type ConfirmPasswordResetDto struct {
+	Code        models.Token
+	NewPassword string
+}
+
+// LoginResultDto holds either the issued tokens or, when the account has a
+// second factor enabled, a challenge to be completed with VerifySecondFactor.
+type LoginResultDto struct {
+	Tokens    *AuthTokensDto
+	Challenge *SecondFactorChallengeDto
+}
+
+type SecondFactorChallengeDto struct {
+	Token     models.Token
+	ExpiresAt time.Time
+}
+
+type TOTPEnrollmentDto struct {
+	Secret string
+	URI    string
+}
+
+type VerifySecondFactorDto struct {
+	ChallengeToken models.Token
+	// Code is either a current TOTP code or one of the recovery codes.
+	Code    string
+	Session models.SessionMetadata
+}
+
+type OAuthLoginStartDto struct {
+	// AuthorizationURL is where the client sends the user to sign in with
+	// the identity provider.
+	AuthorizationURL string
+	State            models.Token
+	ExpiresAt        time.Time
+}
+
+type CompleteOAuthLoginDto struct {
+	Provider string
+	State    models.Token
+	// Code is the authorization code the identity provider redirected with.
+	Code    string
+	Session models.SessionMetadata
+}
+
+// ListSecurityEventsDto queries the audit log. Zero values leave a criterion
+// out; an empty UserID means the caller, or every user for administrators.
+type ListSecurityEventsDto struct {
+	UserID models.UserID
+	Since  time.Time
+	Until  time.Time
+	Limit  int
+}
+
+type SecurityEventDto struct {
+	ID         models.SecurityEventID
+	UserID     models.UserID
+	Type       models.SecurityEventType
+	Email      string
+	IP         string
+	UserAgent  string
+	Reason     string
+	ActorID    models.UserID
+	OccurredAt time.Time
+}
+
+type ImpersonateDto struct {
+	UserID models.UserID
+	// Reason is recorded in the audit log, e.g. a support ticket.
+	Reason string
+}
+
+// ImpersonationDto holds a token for acting as UserID on behalf of ActorID.
+type ImpersonationDto struct {
+	UserID      models.UserID
+	ActorID     models.UserID
+	AccessToken models.Token
+	ExpiresAt   time.Time
+}
@@ -0,0 +1,123 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+)
+
+var _ ports.AuthRepository = (*AuthRepository)(nil)
+
+// AuthRepository serves user access lookups, which token validation makes on
+// every request, from a cache in front of another repository. Other reads go
+// straight through, so flows that update a user never start from a cached
+// copy.
+//
+// Updates and deletions invalidate the user's entry once their transaction
+// has committed, with the same caveats as TokenRepository: a role change or
+// suspension may take up to the positive TTL to reach a lookup that raced
+// the commit, and an in-process cache does not see other replicas' writes.
+type AuthRepository struct {
+	next        ports.AuthRepository
+	cache       ports.UserAccessCache
+	hooks       ports.CommitHooks
+	ttl         time.Duration
+	negativeTTL time.Duration
+	logger      logger.Logger
+}
+
+func NewAuthRepository(
+	next ports.AuthRepository,
+	cache ports.UserAccessCache,
+	hooks ports.CommitHooks,
+	ttl, negativeTTL time.Duration,
+	logger logger.Logger,
+) *AuthRepository {
+	return &AuthRepository{
+		next:        next,
+		cache:       cache,
+		hooks:       hooks,
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+		logger:      logger.With("component", "user_access_cache"),
+	}
+}
+
+func (r *AuthRepository) Create(ctx context.Context, user *models.User) error {
+	if err := r.next.Create(ctx, user); err != nil {
+		return err
+	}
+	r.invalidate(ctx, user.ID())
+	return nil
+}
+
+func (r *AuthRepository) FindUserByID(ctx context.Context, userID models.UserID) (*models.User, error) {
+	return r.next.FindUserByID(ctx, userID)
+}
+
+func (r *AuthRepository) FindUserAccess(ctx context.Context, userID models.UserID) (*models.UserAccess, error) {
+	cached, ok, err := r.cache.Get(ctx, userID)
+	if err != nil {
+		r.logger.Warn("failed to read user access cache", "error", err)
+	}
+	if ok {
+		if cached == nil {
+			return nil, errors.NewNotFoundError("user with ID %s not found", userID.String())
+		}
+		return cached, nil
+	}
+
+	access, err := r.next.FindUserAccess(ctx, userID)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			r.store(ctx, userID, nil, r.negativeTTL)
+		}
+		return nil, err
+	}
+
+	r.store(ctx, userID, access, r.ttl)
+	return access, nil
+}
+
+func (r *AuthRepository) FindUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	return r.next.FindUserByEmail(ctx, email)
+}
+
+func (r *AuthRepository) FindUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	return r.next.FindUserByUsername(ctx, username)
+}
+
+func (r *AuthRepository) Update(ctx context.Context, user *models.User) error {
+	err := r.next.Update(ctx, user)
+	r.invalidate(ctx, user.ID())
+	return err
+}
+
+func (r *AuthRepository) Delete(ctx context.Context, userID models.UserID) error {
+	err := r.next.Delete(ctx, userID)
+	r.invalidate(ctx, userID)
+	return err
+}
+
+func (r *AuthRepository) store(ctx context.Context, userID models.UserID, access *models.UserAccess, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	if err := r.cache.Set(ctx, userID, access, ttl); err != nil {
+		r.logger.Warn("failed to write user access cache", "error", err)
+	}
+}
+
+// invalidate drops the users from the cache once the transaction in ctx, if
+// any, has committed. Nothing is dropped when it rolls back.
+func (r *AuthRepository) invalidate(ctx context.Context, userIDs ...models.UserID) {
+	r.hooks.AfterCommit(ctx, func(ctx context.Context) {
+		if err := r.cache.Delete(context.WithoutCancel(ctx), userIDs...); err != nil {
+			r.logger.Error("failed to invalidate user access cache", "error", err)
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/repositories/auth/in_memory"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type authFixture struct {
+	next  *in_memory.AuthRepository
+	hooks *commitHooks
+	repo  *AuthRepository
+	user  *models.User
+}
+
+func newAuthFixture(t *testing.T) *authFixture {
+	t.Helper()
+
+	accessCache, err := NewLRUUserAccessCache(16)
+	require.NoError(t, err)
+
+	user, err := models.NewUser(models.UserID(uuid.New()), "testuser", "test@test.ru", []byte("hashed"))
+	require.NoError(t, err)
+
+	next := in_memory.NewAuthRepository(logger.NewMockLogger())
+	require.NoError(t, next.Create(context.Background(), user))
+
+	hooks := &commitHooks{}
+	return &authFixture{
+		next:  next,
+		hooks: hooks,
+		repo:  NewAuthRepository(next, accessCache, hooks, time.Minute, time.Minute, logger.NewMockLogger()),
+		user:  user,
+	}
+}
+
+func TestAuthRepository_SuspensionTakesEffectAfterCommit(t *testing.T) {
+	ctx := context.Background()
+	f := newAuthFixture(t)
+
+	access, err := f.repo.FindUserAccess(ctx, f.user.ID())
+	require.NoError(t, err)
+	assert.False(t, access.IsSuspended())
+
+	require.NoError(t, f.repo.Update(ctx, f.user.WithSuspended(time.Now())))
+
+	access, err = f.repo.FindUserAccess(ctx, f.user.ID())
+	require.NoError(t, err)
+	assert.False(t, access.IsSuspended(), "served from cache before commit")
+
+	f.hooks.commit(ctx)
+
+	access, err = f.repo.FindUserAccess(ctx, f.user.ID())
+	require.NoError(t, err)
+	assert.True(t, access.IsSuspended())
+}
+
+func TestAuthRepository_RoleChangeInvalidates(t *testing.T) {
+	ctx := context.Background()
+	f := newAuthFixture(t)
+
+	_, err := f.repo.FindUserAccess(ctx, f.user.ID())
+	require.NoError(t, err)
+
+	admin, err := f.user.WithRoles([]string{models.RoleAdmin.String()})
+	require.NoError(t, err)
+	require.NoError(t, f.repo.Update(ctx, admin))
+	f.hooks.commit(ctx)
+
+	access, err := f.repo.FindUserAccess(ctx, f.user.ID())
+	require.NoError(t, err)
+	assert.Contains(t, access.Roles(), models.RoleAdmin)
+}
+
+func TestAuthRepository_DeleteInvalidates(t *testing.T) {
+	ctx := context.Background()
+	f := newAuthFixture(t)
+
+	_, err := f.repo.FindUserAccess(ctx, f.user.ID())
+	require.NoError(t, err)
+
+	require.NoError(t, f.repo.Delete(ctx, f.user.ID()))
+	f.hooks.commit(ctx)
+
+	_, err = f.repo.FindUserAccess(ctx, f.user.ID())
+	assert.True(t, customerrors.Is(err, customerrors.ErrNotFound))
+}
+
+func TestAuthRepository_CreateClearsUnknownUser(t *testing.T) {
+	ctx := context.Background()
+	f := newAuthFixture(t)
+
+	user, err := models.NewUser(models.UserID(uuid.New()), "newuser", "new@test.ru", []byte("hashed"))
+	require.NoError(t, err)
+
+	_, err = f.repo.FindUserAccess(ctx, user.ID())
+	assert.True(t, customerrors.Is(err, customerrors.ErrNotFound))
+
+	require.NoError(t, f.repo.Create(ctx, user))
+	f.hooks.commit(ctx)
+
+	_, err = f.repo.FindUserAccess(ctx, user.ID())
+	assert.NoError(t, err)
+}
+
+func TestAuthRepository_DoesNotCacheUsers(t *testing.T) {
+	ctx := context.Background()
+	f := newAuthFixture(t)
+
+	_, err := f.repo.FindUserByID(ctx, f.user.ID())
+	require.NoError(t, err)
+
+	// A flow about to update the user must see the latest copy even before
+	// the cache is invalidated.
+	require.NoError(t, f.next.Update(ctx, f.user.WithEmailVerified()))
+	user, err := f.repo.FindUserByID(ctx, f.user.ID())
+	require.NoError(t, err)
+	assert.True(t, user.EmailVerified())
+}
@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+var _ ports.TokenCache = (*LRUTokenCache)(nil)
+
+type lruEntry struct {
+	authToken *models.AuthToken
+	expiresAt time.Time
+}
+
+// LRUTokenCache keeps token lookups in process memory, evicting the least
+// recently used entries beyond its size. It is not shared between replicas.
+type LRUTokenCache struct {
+	entries *lru.Cache[models.Token, lruEntry]
+}
+
+func NewLRUTokenCache(size int) (*LRUTokenCache, error) {
+	entries, err := lru.New[models.Token, lruEntry](size)
+	if err != nil {
+		return nil, err
+	}
+	return &LRUTokenCache{entries: entries}, nil
+}
+
+func (c *LRUTokenCache) Get(ctx context.Context, token models.Token) (*models.AuthToken, bool, error) {
+	entry, ok := c.entries.Get(token)
+	if !ok {
+		return nil, false, nil
+	}
+	if time.Now().After(entry.expiresAt) {
+		c.entries.Remove(token)
+		return nil, false, nil
+	}
+	return entry.authToken, true, nil
+}
+
+func (c *LRUTokenCache) Set(ctx context.Context, token models.Token, authToken *models.AuthToken, ttl time.Duration) error {
+	c.entries.Add(token, lruEntry{authToken: authToken, expiresAt: time.Now().Add(ttl)})
+	return nil
+}
+
+func (c *LRUTokenCache) Delete(ctx context.Context, tokens ...models.Token) error {
+	for _, token := range tokens {
+		c.entries.Remove(token)
+	}
+	return nil
+}
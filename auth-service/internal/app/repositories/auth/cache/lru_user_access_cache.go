@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+var _ ports.UserAccessCache = (*LRUUserAccessCache)(nil)
+
+type lruAccessEntry struct {
+	access    *models.UserAccess
+	expiresAt time.Time
+}
+
+// LRUUserAccessCache keeps user access lookups in process memory, evicting
+// the least recently used entries beyond its size. It is not shared between
+// replicas.
+type LRUUserAccessCache struct {
+	entries *lru.Cache[models.UserID, lruAccessEntry]
+}
+
+func NewLRUUserAccessCache(size int) (*LRUUserAccessCache, error) {
+	entries, err := lru.New[models.UserID, lruAccessEntry](size)
+	if err != nil {
+		return nil, err
+	}
+	return &LRUUserAccessCache{entries: entries}, nil
+}
+
+func (c *LRUUserAccessCache) Get(ctx context.Context, userID models.UserID) (*models.UserAccess, bool, error) {
+	entry, ok := c.entries.Get(userID)
+	if !ok {
+		return nil, false, nil
+	}
+	if time.Now().After(entry.expiresAt) {
+		c.entries.Remove(userID)
+		return nil, false, nil
+	}
+	return entry.access, true, nil
+}
+
+func (c *LRUUserAccessCache) Set(ctx context.Context, userID models.UserID, access *models.UserAccess, ttl time.Duration) error {
+	c.entries.Add(userID, lruAccessEntry{access: access, expiresAt: time.Now().Add(ttl)})
+	return nil
+}
+
+func (c *LRUUserAccessCache) Delete(ctx context.Context, userIDs ...models.UserID) error {
+	for _, userID := range userIDs {
+		c.entries.Remove(userID)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ ports.TokenCache = (*RedisTokenCache)(nil)
+
+// redisKeyPrefix namespaces cache keys. Keys carry the token hash, so raw
+// tokens never reach Redis.
+const redisKeyPrefix = "auth:token:"
+
+// redisEntry is the cached form of a token. An empty value records that the
+// token does not exist.
+type redisEntry struct {
+	UserID     string    `json:"user_id"`
+	FamilyID   string    `json:"family_id"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	DeviceName string    `json:"device_name"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	ActorID    string    `json:"actor_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+}
+
+// RedisTokenCache keeps token lookups in Redis, where every replica sees the
+// same entries and invalidations.
+type RedisTokenCache struct {
+	client redis.UniversalClient
+}
+
+func NewRedisTokenCache(client redis.UniversalClient) *RedisTokenCache {
+	return &RedisTokenCache{client: client}
+}
+
+func (c *RedisTokenCache) Get(ctx context.Context, token models.Token) (*models.AuthToken, bool, error) {
+	value, err := c.client.Get(ctx, redisKey(token)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+		return nil, false, errors.NewInternalError(err, "failed to read token cache")
+	}
+	if len(value) == 0 {
+		return nil, true, nil
+	}
+
+	var entry redisEntry
+	if err := json.Unmarshal(value, &entry); err != nil {
+		return nil, false, errors.NewInternalError(err, "failed to decode cached token")
+	}
+
+	userID, err := models.UserIDFromString(entry.UserID)
+	if err != nil {
+		return nil, false, err
+	}
+	familyID, err := models.FamilyIDFromString(entry.FamilyID)
+	if err != nil {
+		return nil, false, err
+	}
+	session := models.SessionMetadata{
+		DeviceName: entry.DeviceName,
+		UserAgent:  entry.UserAgent,
+		IP:         entry.IP,
+	}
+	authToken, err := models.RestoreAuthToken(token, userID, familyID, entry.ExpiresAt, session, entry.CreatedAt, entry.LastUsedAt)
+	if err != nil {
+		return nil, false, err
+	}
+	if entry.ActorID != "" {
+		actorID, err := models.UserIDFromString(entry.ActorID)
+		if err != nil {
+			return nil, false, err
+		}
+		authToken = authToken.WithActor(actorID)
+	}
+	return authToken, true, nil
+}
+
+func (c *RedisTokenCache) Set(ctx context.Context, token models.Token, authToken *models.AuthToken, ttl time.Duration) error {
+	var value []byte
+	if authToken != nil {
+		session := authToken.Session()
+		entry := redisEntry{
+			UserID:     authToken.UserID().String(),
+			FamilyID:   authToken.FamilyID().String(),
+			ExpiresAt:  authToken.ExpiresAt(),
+			DeviceName: session.DeviceName,
+			UserAgent:  session.UserAgent,
+			IP:         session.IP,
+			CreatedAt:  authToken.CreatedAt(),
+			LastUsedAt: authToken.LastUsedAt(),
+		}
+		if authToken.IsImpersonation() {
+			entry.ActorID = authToken.ActorID().String()
+		}
+		encoded, err := json.Marshal(entry)
+		if err != nil {
+			return errors.NewInternalError(err, "failed to encode token")
+		}
+		value = encoded
+	}
+
+	if err := c.client.Set(ctx, redisKey(token), value, ttl).Err(); err != nil {
+		return errors.NewInternalError(err, "failed to write token cache")
+	}
+	return nil
+}
+
+func (c *RedisTokenCache) Delete(ctx context.Context, tokens ...models.Token) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		keys = append(keys, redisKey(token))
+	}
+	if err := c.client.Del(ctx, keys...).Err(); err != nil {
+		return errors.NewInternalError(err, "failed to invalidate token cache")
+	}
+	return nil
+}
+
+func redisKey(token models.Token) string {
+	return redisKeyPrefix + models.HashToken(token)
+}
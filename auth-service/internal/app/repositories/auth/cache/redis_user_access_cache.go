@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ ports.UserAccessCache = (*RedisUserAccessCache)(nil)
+
+const redisAccessKeyPrefix = "auth:user-access:"
+
+// redisAccessEntry is the cached form of a user's access. An empty value
+// records that the user does not exist.
+type redisAccessEntry struct {
+	Roles     []string `json:"roles"`
+	Suspended bool     `json:"suspended"`
+}
+
+// RedisUserAccessCache keeps user access lookups in Redis, where every
+// replica sees the same entries and invalidations.
+type RedisUserAccessCache struct {
+	client redis.UniversalClient
+}
+
+func NewRedisUserAccessCache(client redis.UniversalClient) *RedisUserAccessCache {
+	return &RedisUserAccessCache{client: client}
+}
+
+func (c *RedisUserAccessCache) Get(ctx context.Context, userID models.UserID) (*models.UserAccess, bool, error) {
+	value, err := c.client.Get(ctx, redisAccessKey(userID)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+		return nil, false, errors.NewInternalError(err, "failed to read user access cache")
+	}
+	if len(value) == 0 {
+		return nil, true, nil
+	}
+
+	var entry redisAccessEntry
+	if err := json.Unmarshal(value, &entry); err != nil {
+		return nil, false, errors.NewInternalError(err, "failed to decode cached user access")
+	}
+
+	access, err := models.RestoreUserAccess(userID, entry.Roles, entry.Suspended)
+	if err != nil {
+		return nil, false, err
+	}
+	return access, true, nil
+}
+
+func (c *RedisUserAccessCache) Set(ctx context.Context, userID models.UserID, access *models.UserAccess, ttl time.Duration) error {
+	var value []byte
+	if access != nil {
+		encoded, err := json.Marshal(redisAccessEntry{
+			Roles:     models.RoleNames(access.Roles()),
+			Suspended: access.IsSuspended(),
+		})
+		if err != nil {
+			return errors.NewInternalError(err, "failed to encode user access")
+		}
+		value = encoded
+	}
+
+	if err := c.client.Set(ctx, redisAccessKey(userID), value, ttl).Err(); err != nil {
+		return errors.NewInternalError(err, "failed to write user access cache")
+	}
+	return nil
+}
+
+func (c *RedisUserAccessCache) Delete(ctx context.Context, userIDs ...models.UserID) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		keys = append(keys, redisAccessKey(userID))
+	}
+	if err := c.client.Del(ctx, keys...).Err(); err != nil {
+		return errors.NewInternalError(err, "failed to invalidate user access cache")
+	}
+	return nil
+}
+
+func redisAccessKey(userID models.UserID) string {
+	return redisAccessKeyPrefix + userID.String()
+}
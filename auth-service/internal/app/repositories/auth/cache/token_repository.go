@@ -0,0 +1,208 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+)
+
+var _ ports.TokenRepository = (*TokenRepository)(nil)
+
+// TokenRepository serves token lookups from a cache in front of another
+// repository. Unknown tokens are cached as well, so that a client retrying
+// with a revoked token does not reach the database on every request.
+//
+// Writes invalidate the affected tokens once their transaction has committed;
+// until then the cached entries still match what other readers see. A revoked
+// token therefore stops validating right after the revoking transaction
+// commits, except when a lookup read it from the database just before the
+// commit and cached it just after: that entry lives for at most the positive
+// TTL. With several replicas the cache must be shared (Redis); an in-process
+// cache only sees the writes of its own replica, so elsewhere a revocation
+// would only take effect once the cached entry expires.
+type TokenRepository struct {
+	next        ports.TokenRepository
+	cache       ports.TokenCache
+	hooks       ports.CommitHooks
+	ttl         time.Duration
+	negativeTTL time.Duration
+	logger      logger.Logger
+}
+
+func NewTokenRepository(
+	next ports.TokenRepository,
+	cache ports.TokenCache,
+	hooks ports.CommitHooks,
+	ttl, negativeTTL time.Duration,
+	logger logger.Logger,
+) *TokenRepository {
+	return &TokenRepository{
+		next:        next,
+		cache:       cache,
+		hooks:       hooks,
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+		logger:      logger.With("component", "token_cache"),
+	}
+}
+
+func (r *TokenRepository) Create(ctx context.Context, token *models.AuthToken) (*models.AuthToken, error) {
+	created, err := r.next.Create(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, token.Token())
+	return created, nil
+}
+
+func (r *TokenRepository) FindToken(ctx context.Context, token string) (models.Token, error) {
+	authToken, err := r.lookup(ctx, models.Token(token))
+	if err != nil {
+		return "", err
+	}
+	return authToken.Token(), nil
+}
+
+func (r *TokenRepository) GetToken(ctx context.Context, token models.Token) (*models.AuthToken, error) {
+	return r.lookup(ctx, token)
+}
+
+func (r *TokenRepository) ValidateToken(ctx context.Context, token string) (bool, error) {
+	authToken, err := r.lookup(ctx, models.Token(token))
+	if err != nil {
+		return false, err
+	}
+	if authToken.IsExpired() {
+		return false, errors.NewTokenError(errors.ErrTokenExpired, "token is expired").
+			WithDetails("expires_at", authToken.ExpiresAt())
+	}
+	return true, nil
+}
+
+func (r *TokenRepository) DeleteToken(ctx context.Context, token models.Token) error {
+	err := r.next.DeleteToken(ctx, token)
+	r.invalidate(ctx, token)
+	return err
+}
+
+func (r *TokenRepository) DeleteTokensByFamily(ctx context.Context, familyID models.FamilyID) error {
+	session, err := r.next.GetSession(ctx, familyID)
+	if err != nil && !errors.Is(err, errors.ErrNotFound) {
+		return err
+	}
+
+	if err := r.next.DeleteTokensByFamily(ctx, familyID); err != nil {
+		return err
+	}
+	if session != nil {
+		r.invalidate(ctx, session.Token())
+	}
+	return nil
+}
+
+func (r *TokenRepository) DeleteTokensByUser(ctx context.Context, userID models.UserID) error {
+	sessions, err := r.next.ListSessions(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := r.next.DeleteTokensByUser(ctx, userID); err != nil {
+		return err
+	}
+
+	tokens := make([]models.Token, 0, len(sessions))
+	for _, session := range sessions {
+		tokens = append(tokens, session.Token())
+	}
+	r.invalidate(ctx, tokens...)
+	return nil
+}
+
+func (r *TokenRepository) GetSession(ctx context.Context, familyID models.FamilyID) (*models.AuthToken, error) {
+	return r.next.GetSession(ctx, familyID)
+}
+
+func (r *TokenRepository) ListSessions(ctx context.Context, userID models.UserID) ([]*models.AuthToken, error) {
+	return r.next.ListSessions(ctx, userID)
+}
+
+func (r *TokenRepository) RotateToken(ctx context.Context, familyID models.FamilyID, token models.Token, expiresAt time.Time) error {
+	session, err := r.next.GetSession(ctx, familyID)
+	if err != nil && !errors.Is(err, errors.ErrNotFound) {
+		return err
+	}
+
+	if err := r.next.RotateToken(ctx, familyID, token, expiresAt); err != nil {
+		return err
+	}
+
+	tokens := []models.Token{token}
+	if session != nil {
+		tokens = append(tokens, session.Token())
+	}
+	r.invalidate(ctx, tokens...)
+	return nil
+}
+
+func (r *TokenRepository) TouchToken(ctx context.Context, token models.Token, usedAt time.Time) error {
+	if err := r.next.TouchToken(ctx, token, usedAt); err != nil {
+		return err
+	}
+	r.invalidate(ctx, token)
+	return nil
+}
+
+// DeleteExpired is not reflected in the cache. Cached entries of expired
+// tokens already fail validation and run out on their own.
+func (r *TokenRepository) DeleteExpired(ctx context.Context, before time.Time, limit int) (int, error) {
+	return r.next.DeleteExpired(ctx, before, limit)
+}
+
+// lookup returns the token from the cache, loading it from the underlying
+// repository on a miss. A cache failure falls back to the repository.
+func (r *TokenRepository) lookup(ctx context.Context, token models.Token) (*models.AuthToken, error) {
+	cached, ok, err := r.cache.Get(ctx, token)
+	if err != nil {
+		r.logger.Warn("failed to read token cache", "error", err)
+	}
+	if ok {
+		if cached == nil {
+			return nil, errors.NewNotFoundError("token not found")
+		}
+		return cached, nil
+	}
+
+	authToken, err := r.next.GetToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			r.store(ctx, token, nil, r.negativeTTL)
+		}
+		return nil, err
+	}
+
+	r.store(ctx, token, authToken, r.ttl)
+	return authToken, nil
+}
+
+func (r *TokenRepository) store(ctx context.Context, token models.Token, authToken *models.AuthToken, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	if err := r.cache.Set(ctx, token, authToken, ttl); err != nil {
+		r.logger.Warn("failed to write token cache", "error", err)
+	}
+}
+
+// invalidate drops the tokens from the cache once the transaction in ctx, if
+// any, has committed. Nothing is dropped when it rolls back.
+func (r *TokenRepository) invalidate(ctx context.Context, tokens ...models.Token) {
+	r.hooks.AfterCommit(ctx, func(ctx context.Context) {
+		if err := r.cache.Delete(context.WithoutCancel(ctx), tokens...); err != nil {
+			r.logger.Error("failed to invalidate token cache", "error", err)
+		}
+	})
+}
@@ -0,0 +1,205 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/repositories/auth/in_memory"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// commitHooks holds AfterCommit callbacks until the test commits or rolls
+// back the pretend transaction.
+type commitHooks struct {
+	mx      sync.Mutex
+	pending []func(ctx context.Context)
+}
+
+func (h *commitHooks) AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+	h.pending = append(h.pending, fn)
+}
+
+func (h *commitHooks) commit(ctx context.Context) {
+	h.mx.Lock()
+	pending := h.pending
+	h.pending = nil
+	h.mx.Unlock()
+
+	for _, fn := range pending {
+		fn(ctx)
+	}
+}
+
+func (h *commitHooks) rollback() {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+	h.pending = nil
+}
+
+type tokenFixture struct {
+	next  *in_memory.TokenRepository
+	hooks *commitHooks
+	repo  *TokenRepository
+}
+
+func newTokenFixture(t *testing.T, ttl, negativeTTL time.Duration) *tokenFixture {
+	t.Helper()
+
+	tokenCache, err := NewLRUTokenCache(16)
+	require.NoError(t, err)
+
+	next := in_memory.NewTokenRepository(logger.NewMockLogger())
+	hooks := &commitHooks{}
+	return &tokenFixture{
+		next:  next,
+		hooks: hooks,
+		repo:  NewTokenRepository(next, tokenCache, hooks, ttl, negativeTTL, logger.NewMockLogger()),
+	}
+}
+
+func newAuthToken(t *testing.T, token models.Token, userID models.UserID) *models.AuthToken {
+	t.Helper()
+
+	authToken, err := models.NewAuthToken(token, userID, models.FamilyID(uuid.New()), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	return authToken
+}
+
+func TestTokenRepository_ServesLookupsFromCache(t *testing.T) {
+	ctx := context.Background()
+	f := newTokenFixture(t, time.Minute, time.Minute)
+
+	authToken := newAuthToken(t, "token", models.UserID(uuid.New()))
+	_, err := f.next.Create(ctx, authToken)
+	require.NoError(t, err)
+
+	got, err := f.repo.GetToken(ctx, "token")
+	require.NoError(t, err)
+	assert.Equal(t, authToken.UserID(), got.UserID())
+
+	// Removed behind the cache's back: the cached entry still answers.
+	require.NoError(t, f.next.DeleteToken(ctx, "token"))
+	got, err = f.repo.GetToken(ctx, "token")
+	require.NoError(t, err)
+	assert.Equal(t, authToken.UserID(), got.UserID())
+}
+
+func TestTokenRepository_CachesUnknownTokens(t *testing.T) {
+	ctx := context.Background()
+	f := newTokenFixture(t, time.Minute, time.Minute)
+
+	_, err := f.repo.GetToken(ctx, "token")
+	assert.True(t, customerrors.Is(err, customerrors.ErrNotFound))
+
+	_, err = f.next.Create(ctx, newAuthToken(t, "token", models.UserID(uuid.New())))
+	require.NoError(t, err)
+
+	_, err = f.repo.GetToken(ctx, "token")
+	assert.True(t, customerrors.Is(err, customerrors.ErrNotFound))
+}
+
+func TestTokenRepository_InvalidatesAfterCommit(t *testing.T) {
+	ctx := context.Background()
+	f := newTokenFixture(t, time.Minute, time.Minute)
+
+	_, err := f.next.Create(ctx, newAuthToken(t, "token", models.UserID(uuid.New())))
+	require.NoError(t, err)
+	_, err = f.repo.GetToken(ctx, "token")
+	require.NoError(t, err)
+
+	require.NoError(t, f.repo.DeleteToken(ctx, "token"))
+
+	// Until the revoking transaction commits, other readers still see the
+	// token in the database, and the cache agrees with them.
+	_, err = f.repo.GetToken(ctx, "token")
+	assert.NoError(t, err)
+
+	f.hooks.commit(ctx)
+
+	_, err = f.repo.GetToken(ctx, "token")
+	assert.True(t, customerrors.Is(err, customerrors.ErrNotFound))
+}
+
+func TestTokenRepository_KeepsCacheOnRollback(t *testing.T) {
+	ctx := context.Background()
+	f := newTokenFixture(t, time.Minute, time.Minute)
+
+	_, err := f.next.Create(ctx, newAuthToken(t, "token", models.UserID(uuid.New())))
+	require.NoError(t, err)
+	_, err = f.repo.GetToken(ctx, "token")
+	require.NoError(t, err)
+
+	require.NoError(t, f.repo.TouchToken(ctx, "token", time.Now()))
+	f.hooks.rollback()
+
+	// The entry must survive: had it been dropped, the lookup below would
+	// go to the repository and see the token gone.
+	require.NoError(t, f.next.DeleteToken(ctx, "token"))
+	_, err = f.repo.GetToken(ctx, "token")
+	assert.NoError(t, err)
+}
+
+func TestTokenRepository_DeleteTokensByUserInvalidatesEverySession(t *testing.T) {
+	ctx := context.Background()
+	f := newTokenFixture(t, time.Minute, time.Minute)
+
+	userID := models.UserID(uuid.New())
+	for _, token := range []models.Token{"first", "second"} {
+		_, err := f.next.Create(ctx, newAuthToken(t, token, userID))
+		require.NoError(t, err)
+		_, err = f.repo.GetToken(ctx, token)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, f.repo.DeleteTokensByUser(ctx, userID))
+	f.hooks.commit(ctx)
+
+	for _, token := range []models.Token{"first", "second"} {
+		_, err := f.repo.GetToken(ctx, token)
+		assert.True(t, customerrors.Is(err, customerrors.ErrNotFound), "token %s", token)
+	}
+}
+
+func TestTokenRepository_RotateTokenInvalidatesBothTokens(t *testing.T) {
+	ctx := context.Background()
+	f := newTokenFixture(t, time.Minute, time.Minute)
+
+	authToken := newAuthToken(t, "old", models.UserID(uuid.New()))
+	_, err := f.next.Create(ctx, authToken)
+	require.NoError(t, err)
+	_, err = f.repo.GetToken(ctx, "old")
+	require.NoError(t, err)
+	_, err = f.repo.GetToken(ctx, "new")
+	require.Error(t, err)
+
+	require.NoError(t, f.repo.RotateToken(ctx, authToken.FamilyID(), "new", time.Now().Add(time.Hour)))
+	f.hooks.commit(ctx)
+
+	_, err = f.repo.GetToken(ctx, "old")
+	assert.True(t, customerrors.Is(err, customerrors.ErrNotFound))
+	_, err = f.repo.GetToken(ctx, "new")
+	assert.NoError(t, err)
+}
+
+func TestTokenRepository_ZeroTTLDisablesCaching(t *testing.T) {
+	ctx := context.Background()
+	f := newTokenFixture(t, 0, 0)
+
+	_, err := f.next.Create(ctx, newAuthToken(t, "token", models.UserID(uuid.New())))
+	require.NoError(t, err)
+	_, err = f.repo.GetToken(ctx, "token")
+	require.NoError(t, err)
+
+	require.NoError(t, f.next.DeleteToken(ctx, "token"))
+	_, err = f.repo.GetToken(ctx, "token")
+	assert.True(t, customerrors.Is(err, customerrors.ErrNotFound))
+}
@@ -0,0 +1,101 @@
+package in_memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+)
+
+var _ ports.APIKeyRepository = (*APIKeyRepository)(nil)
+
+type APIKeyRepository struct {
+	mx     sync.Mutex
+	keys   map[models.APIKeyID]*models.APIKey
+	logger logger.Logger
+}
+
+func NewAPIKeyRepository(logger logger.Logger) *APIKeyRepository {
+	return &APIKeyRepository{
+		keys:   make(map[models.APIKeyID]*models.APIKey),
+		logger: logger.With("component", "api_key_repository"),
+	}
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	for _, existing := range r.keys {
+		if existing.ID() == key.ID() || existing.Hash() == key.Hash() {
+			return errors.NewAlreadyExistsError("API key already exists").WithDetails("key_id", key.ID().String())
+		}
+	}
+	r.keys[key.ID()] = key
+	return nil
+}
+
+func (r *APIKeyRepository) GetByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	for _, key := range r.keys {
+		if key.Hash() == hash {
+			return key, nil
+		}
+	}
+	return nil, errors.NewNotFoundError("API key not found")
+}
+
+func (r *APIKeyRepository) ListByUser(ctx context.Context, userID models.UserID) ([]*models.APIKey, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	keys := make([]*models.APIKey, 0)
+	for _, key := range r.keys {
+		if key.UserID() == userID && !key.IsRevoked() {
+			keys = append(keys, key)
+		}
+	}
+	slices.SortFunc(keys, func(a, b *models.APIKey) int {
+		return b.CreatedAt().Compare(a.CreatedAt())
+	})
+	return keys, nil
+}
+
+func (r *APIKeyRepository) Revoke(ctx context.Context, userID models.UserID, keyID models.APIKeyID, revokedAt time.Time) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	key, ok := r.keys[keyID]
+	if !ok || key.UserID() != userID || key.IsRevoked() {
+		return errors.NewNotFoundError("API key not found").WithDetails("key_id", keyID.String())
+	}
+	return r.replace(key, key.LastUsedAt(), revokedAt)
+}
+
+func (r *APIKeyRepository) Touch(ctx context.Context, keyID models.APIKeyID, usedAt time.Time) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	key, ok := r.keys[keyID]
+	if !ok {
+		return errors.NewNotFoundError("API key not found").WithDetails("key_id", keyID.String())
+	}
+	return r.replace(key, usedAt, key.RevokedAt())
+}
+
+func (r *APIKeyRepository) replace(key *models.APIKey, lastUsedAt, revokedAt time.Time) error {
+	updated, err := models.RestoreAPIKey(key.ID(), key.UserID(), key.Name(), key.Hash(), key.Hint(),
+		models.ScopeNames(key.Scopes()), key.CreatedAt(), lastUsedAt, revokedAt)
+	if err != nil {
+		return err
+	}
+	r.keys[key.ID()] = updated
+	return nil
+}
@@ -31,10 +31,9 @@ func (r *AuthRepository) Create(ctx context.Context, user *models.User) error {
 
 	r.logger.Debug("attempting to create new user", "user_id", user.ID(), "email", user.Email())
 
-	existingUser, _ := r.FindUserByEmail(ctx, user.Email())
-	if existingUser != nil {
-		r.logger.Warn("user with this email already exists", "email", user.Email())
-		return errors.NewAlreadyExistsError("user with email %s already exists", user.Email()).
+	if r.taken(user) {
+		r.logger.Warn("user with this email or username already exists", "email", user.Email(), "username", user.Username())
+		return errors.NewAlreadyExistsError("user with email %s or username %s already exists", user.Email(), user.Username()).
 			WithDetails("email", user.Email())
 	}
 
@@ -60,6 +59,14 @@ func (r *AuthRepository) FindUserByID(ctx context.Context, userID models.UserID)
 	return user, nil
 }
 
+func (r *AuthRepository) FindUserAccess(ctx context.Context, userID models.UserID) (*models.UserAccess, error) {
+	user, err := r.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return user.Access(), nil
+}
+
 func (r *AuthRepository) FindUserByEmail(ctx context.Context, email string) (*models.User, error) {
 	r.mx.Lock()
 	defer r.mx.Unlock()
@@ -85,6 +92,23 @@ func (r *AuthRepository) FindUserByEmail(ctx context.Context, email string) (*mo
 	return user, nil
 }
 
+func (r *AuthRepository) FindUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	r.logger.Debug("looking for user by username", "username", username)
+
+	for _, user := range r.storage {
+		if user.Username() == username {
+			return user, nil
+		}
+	}
+
+	r.logger.Debug("user not found", "username", username)
+	return nil, errors.NewNotFoundError("user with username %s not found", username).
+		WithDetails("username", username)
+}
+
 func (r *AuthRepository) Update(ctx context.Context, user *models.User) error {
 	r.mx.Lock()
 	defer r.mx.Unlock()
@@ -96,9 +120,43 @@ func (r *AuthRepository) Update(ctx context.Context, user *models.User) error {
 		return errors.NewNotFoundError("user with ID %s not found", user.ID().String()).
 			WithDetails("user_id", user.ID().String())
 	}
+	if r.taken(user) {
+		r.logger.Warn("email or username already taken", "user_id", user.ID())
+		return errors.NewAlreadyExistsError("email %s or username %s is already taken", user.Email(), user.Username())
+	}
 
 	r.storage[user.ID()] = user
 	r.logger.Info("user updated successfully", "user_id", user.ID())
 
 	return nil
 }
+
+func (r *AuthRepository) Delete(ctx context.Context, userID models.UserID) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	r.logger.Debug("attempting to delete user", "user_id", userID)
+
+	if _, ok := r.storage[userID]; !ok {
+		r.logger.Debug("user not found for delete", "user_id", userID)
+		return errors.NewNotFoundError("user with ID %s not found", userID.String()).
+			WithDetails("user_id", userID.String())
+	}
+
+	delete(r.storage, userID)
+	r.logger.Info("user deleted successfully", "user_id", userID)
+
+	return nil
+}
+
+// taken reports whether another user has the email or username of user, as
+// the unique indexes of the postgres repository would. The caller must hold
+// r.mx.
+func (r *AuthRepository) taken(user *models.User) bool {
+	for id, other := range r.storage {
+		if id != user.ID() && (other.Email() == user.Email() || other.Username() == user.Username()) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package in_memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+)
+
+var _ ports.LoginAttemptRepository = (*LoginAttemptRepository)(nil)
+
+// LoginAttemptRepository keeps counters in process memory. Counters are lost
+// on restart and are not shared between replicas.
+type LoginAttemptRepository struct {
+	mx       sync.Mutex
+	attempts map[string]*models.LoginAttempt
+	logger   logger.Logger
+}
+
+func NewLoginAttemptRepository(logger logger.Logger) *LoginAttemptRepository {
+	return &LoginAttemptRepository{
+		attempts: make(map[string]*models.LoginAttempt),
+		logger:   logger.With("component", "login_attempt_repository"),
+	}
+}
+
+func (r *LoginAttemptRepository) Get(ctx context.Context, key string) (*models.LoginAttempt, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	attempt, ok := r.attempts[key]
+	if !ok {
+		return nil, errors.NewNotFoundError("no failed login attempts")
+	}
+	return attempt, nil
+}
+
+func (r *LoginAttemptRepository) RecordFailure(ctx context.Context, key string, at time.Time, window time.Duration) (*models.LoginAttempt, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	failures := 1
+	var lockedUntil time.Time
+	if existing, ok := r.attempts[key]; ok {
+		lockedUntil = existing.LockedUntil()
+		if !existing.LastFailureAt().Before(at.Add(-window)) {
+			failures = existing.Failures() + 1
+		}
+	}
+
+	attempt, err := models.RestoreLoginAttempt(key, failures, at, lockedUntil)
+	if err != nil {
+		return nil, err
+	}
+	r.attempts[key] = attempt
+	return attempt, nil
+}
+
+func (r *LoginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	existing, ok := r.attempts[key]
+	if !ok {
+		return nil
+	}
+
+	attempt, err := models.RestoreLoginAttempt(key, existing.Failures(), existing.LastFailureAt(), until)
+	if err != nil {
+		return err
+	}
+	r.attempts[key] = attempt
+	return nil
+}
+
+func (r *LoginAttemptRepository) Reset(ctx context.Context, key string) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	delete(r.attempts, key)
+	return nil
+}
@@ -0,0 +1,74 @@
+package in_memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+)
+
+var _ ports.OAuthIdentityRepository = (*OAuthIdentityRepository)(nil)
+
+type oauthIdentityKey struct {
+	provider string
+	subject  string
+}
+
+type OAuthIdentityRepository struct {
+	mx         sync.Mutex
+	identities map[oauthIdentityKey]*models.OAuthIdentity
+	logger     logger.Logger
+}
+
+func NewOAuthIdentityRepository(logger logger.Logger) *OAuthIdentityRepository {
+	return &OAuthIdentityRepository{
+		identities: make(map[oauthIdentityKey]*models.OAuthIdentity),
+		logger:     logger.With("component", "oauth_identity_repository"),
+	}
+}
+
+func (r *OAuthIdentityRepository) Create(ctx context.Context, identity *models.OAuthIdentity) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	key := oauthIdentityKey{provider: identity.Provider(), subject: identity.Subject()}
+	if _, ok := r.identities[key]; ok {
+		return errors.NewAlreadyExistsError("external identity is already linked").
+			WithDetails("provider", identity.Provider())
+	}
+	r.identities[key] = identity
+	return nil
+}
+
+func (r *OAuthIdentityRepository) FindBySubject(ctx context.Context, provider, subject string) (*models.OAuthIdentity, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	identity, ok := r.identities[oauthIdentityKey{provider: provider, subject: subject}]
+	if !ok {
+		return nil, errors.NewNotFoundError("external identity not found")
+	}
+	return identity, nil
+}
+
+func (r *OAuthIdentityRepository) TouchLogin(ctx context.Context, provider, subject string, at time.Time) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	key := oauthIdentityKey{provider: provider, subject: subject}
+	identity, ok := r.identities[key]
+	if !ok {
+		return errors.NewNotFoundError("external identity not found")
+	}
+
+	touched, err := models.RestoreOAuthIdentity(identity.Provider(), identity.Subject(), identity.UserID(), identity.Email(), identity.CreatedAt(), at)
+	if err != nil {
+		return err
+	}
+	r.identities[key] = touched
+	return nil
+}
@@ -0,0 +1,46 @@
+package in_memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+)
+
+var _ ports.OAuthStateRepository = (*OAuthStateRepository)(nil)
+
+type OAuthStateRepository struct {
+	mx     sync.Mutex
+	states map[string]*models.OAuthState
+	logger logger.Logger
+}
+
+func NewOAuthStateRepository(logger logger.Logger) *OAuthStateRepository {
+	return &OAuthStateRepository{
+		states: make(map[string]*models.OAuthState),
+		logger: logger.With("component", "oauth_state_repository"),
+	}
+}
+
+func (r *OAuthStateRepository) Create(ctx context.Context, state *models.OAuthState) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	r.states[state.Hash()] = state
+	return nil
+}
+
+func (r *OAuthStateRepository) Consume(ctx context.Context, hash string) (*models.OAuthState, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	state, ok := r.states[hash]
+	if !ok {
+		return nil, errors.NewNotFoundError("oauth state not found")
+	}
+	delete(r.states, hash)
+	return state, nil
+}
@@ -0,0 +1,124 @@
+package in_memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+)
+
+var _ ports.OneTimeCodeRepository = (*OneTimeCodeRepository)(nil)
+
+type OneTimeCodeRepository struct {
+	mx       sync.Mutex
+	codes    map[string]*models.OneTimeCode
+	attempts map[string]int
+	logger   logger.Logger
+}
+
+func NewOneTimeCodeRepository(logger logger.Logger) *OneTimeCodeRepository {
+	return &OneTimeCodeRepository{
+		codes:    make(map[string]*models.OneTimeCode),
+		attempts: make(map[string]int),
+		logger:   logger.With("component", "one_time_code_repository"),
+	}
+}
+
+func (r *OneTimeCodeRepository) Create(ctx context.Context, code *models.OneTimeCode) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if _, ok := r.codes[code.Hash()]; ok {
+		r.logger.Warn("one-time code already exists", "user_id", code.UserID())
+		return errors.NewAlreadyExistsError("one-time code already exists").
+			WithDetails("user_id", code.UserID().String())
+	}
+
+	r.codes[code.Hash()] = code
+	return nil
+}
+
+func (r *OneTimeCodeRepository) GetByHash(ctx context.Context, hash string) (*models.OneTimeCode, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	code, ok := r.codes[hash]
+	if !ok {
+		return nil, errors.NewNotFoundError("code not found")
+	}
+
+	return code, nil
+}
+
+func (r *OneTimeCodeRepository) GetActiveByUser(ctx context.Context, userID models.UserID, purpose models.CodePurpose) (*models.OneTimeCode, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	var latest *models.OneTimeCode
+	for _, code := range r.codes {
+		if code.UserID() != userID || code.Purpose() != purpose || code.IsUsed() {
+			continue
+		}
+		if latest == nil || code.ExpiresAt().After(latest.ExpiresAt()) {
+			latest = code
+		}
+	}
+
+	if latest == nil {
+		return nil, errors.NewNotFoundError("code not found")
+	}
+	return latest, nil
+}
+
+func (r *OneTimeCodeRepository) MarkUsed(ctx context.Context, hash string, usedAt time.Time) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	code, ok := r.codes[hash]
+	if !ok || code.IsUsed() {
+		return errors.NewTokenError(errors.ErrInvalidToken, "code already used")
+	}
+
+	return r.markUsed(code, usedAt)
+}
+
+func (r *OneTimeCodeRepository) InvalidateByUser(ctx context.Context, userID models.UserID, purpose models.CodePurpose, at time.Time) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	for _, code := range r.codes {
+		if code.UserID() != userID || code.Purpose() != purpose || code.IsUsed() {
+			continue
+		}
+		if err := r.markUsed(code, at); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *OneTimeCodeRepository) RecordFailedAttempt(ctx context.Context, hash string) (int, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if _, ok := r.codes[hash]; !ok {
+		return 0, errors.NewNotFoundError("code not found")
+	}
+
+	r.attempts[hash]++
+	return r.attempts[hash], nil
+}
+
+func (r *OneTimeCodeRepository) markUsed(code *models.OneTimeCode, usedAt time.Time) error {
+	updated, err := models.RestoreOneTimeCode(code.Hash(), code.UserID(), code.Purpose(), code.ExpiresAt(), usedAt)
+	if err != nil {
+		return err
+	}
+	r.codes[code.Hash()] = updated
+	return nil
+}
@@ -0,0 +1,51 @@
+package in_memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+)
+
+var _ ports.RecoveryCodeRepository = (*RecoveryCodeRepository)(nil)
+
+type RecoveryCodeRepository struct {
+	mx sync.Mutex
+	// codes maps a user to the hashes of their unused recovery codes.
+	codes  map[models.UserID]map[string]struct{}
+	logger logger.Logger
+}
+
+func NewRecoveryCodeRepository(logger logger.Logger) *RecoveryCodeRepository {
+	return &RecoveryCodeRepository{
+		codes:  make(map[models.UserID]map[string]struct{}),
+		logger: logger.With("component", "recovery_code_repository"),
+	}
+}
+
+func (r *RecoveryCodeRepository) ReplaceAll(ctx context.Context, userID models.UserID, hashes []string) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	codes := make(map[string]struct{}, len(hashes))
+	for _, hash := range hashes {
+		codes[hash] = struct{}{}
+	}
+	r.codes[userID] = codes
+	return nil
+}
+
+func (r *RecoveryCodeRepository) Use(ctx context.Context, userID models.UserID, hash string, usedAt time.Time) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if _, ok := r.codes[userID][hash]; !ok {
+		return errors.NewTokenError(errors.ErrInvalidToken, "recovery code is invalid")
+	}
+	delete(r.codes[userID], hash)
+	return nil
+}
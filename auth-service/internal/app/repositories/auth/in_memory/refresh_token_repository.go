@@ -0,0 +1,124 @@
+package in_memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+)
+
+var _ ports.RefreshTokenRepository = (*RefreshTokenRepository)(nil)
+
+type RefreshTokenRepository struct {
+	mx     sync.Mutex
+	tokens map[string]*models.RefreshToken
+	logger logger.Logger
+}
+
+func NewRefreshTokenRepository(logger logger.Logger) *RefreshTokenRepository {
+	return &RefreshTokenRepository{
+		tokens: make(map[string]*models.RefreshToken),
+		logger: logger.With("component", "refresh_token_repository"),
+	}
+}
+
+func (r *RefreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	r.logger.Debug("attempting to create refresh token", "user_id", token.UserID(), "family_id", token.FamilyID())
+
+	if _, ok := r.tokens[token.Hash()]; ok {
+		r.logger.Warn("refresh token already exists", "family_id", token.FamilyID())
+		return errors.NewAlreadyExistsError("refresh token already exists").
+			WithDetails("family_id", token.FamilyID().String())
+	}
+
+	r.tokens[token.Hash()] = token
+	r.logger.Debug("refresh token created successfully", "user_id", token.UserID(), "family_id", token.FamilyID())
+	return nil
+}
+
+func (r *RefreshTokenRepository) GetByHash(ctx context.Context, hash string) (*models.RefreshToken, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	token, ok := r.tokens[hash]
+	if !ok {
+		r.logger.Debug("refresh token not found")
+		return nil, errors.NewNotFoundError("refresh token not found")
+	}
+
+	return token, nil
+}
+
+func (r *RefreshTokenRepository) MarkUsed(ctx context.Context, hash string, usedAt time.Time) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	token, ok := r.tokens[hash]
+	if !ok || token.IsUsed() || token.IsRevoked() {
+		r.logger.Warn("refresh token already used or revoked")
+		return errors.NewTokenError(errors.ErrInvalidToken, "refresh token already used")
+	}
+
+	updated, err := models.RestoreRefreshToken(
+		token.Hash(), token.UserID(), token.FamilyID(), token.ExpiresAt(), usedAt, token.RevokedAt(),
+	)
+	if err != nil {
+		return err
+	}
+
+	r.tokens[hash] = updated
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, familyID models.FamilyID, revokedAt time.Time) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	r.logger.Debug("revoking refresh token family", "family_id", familyID)
+
+	for hash, token := range r.tokens {
+		if token.FamilyID() != familyID || token.IsRevoked() {
+			continue
+		}
+		updated, err := models.RestoreRefreshToken(
+			token.Hash(), token.UserID(), token.FamilyID(), token.ExpiresAt(), token.UsedAt(), revokedAt,
+		)
+		if err != nil {
+			return err
+		}
+		r.tokens[hash] = updated
+	}
+
+	r.logger.Info("refresh token family revoked", "family_id", familyID)
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeByUser(ctx context.Context, userID models.UserID, revokedAt time.Time) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	r.logger.Debug("revoking refresh tokens of user", "user_id", userID)
+
+	for hash, token := range r.tokens {
+		if token.UserID() != userID || token.IsRevoked() {
+			continue
+		}
+		updated, err := models.RestoreRefreshToken(
+			token.Hash(), token.UserID(), token.FamilyID(), token.ExpiresAt(), token.UsedAt(), revokedAt,
+		)
+		if err != nil {
+			return err
+		}
+		r.tokens[hash] = updated
+	}
+
+	r.logger.Info("refresh tokens of user revoked", "user_id", userID)
+	return nil
+}
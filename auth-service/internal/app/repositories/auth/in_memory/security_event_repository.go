@@ -0,0 +1,52 @@
+package in_memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+)
+
+var _ ports.SecurityEventRepository = (*SecurityEventRepository)(nil)
+
+type SecurityEventRepository struct {
+	mx     sync.Mutex
+	events []*models.SecurityEvent
+	logger logger.Logger
+}
+
+func NewSecurityEventRepository(logger logger.Logger) *SecurityEventRepository {
+	return &SecurityEventRepository{
+		logger: logger.With("component", "security_event_repository"),
+	}
+}
+
+func (r *SecurityEventRepository) Append(ctx context.Context, event *models.SecurityEvent) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *SecurityEventRepository) List(ctx context.Context, filter models.SecurityEventFilter) ([]*models.SecurityEvent, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	events := make([]*models.SecurityEvent, 0)
+	for _, event := range r.events {
+		if filter.Matches(event) {
+			events = append(events, event)
+		}
+	}
+	slices.SortStableFunc(events, func(a, b *models.SecurityEvent) int {
+		return b.OccurredAt().Compare(a.OccurredAt())
+	})
+	if filter.Limit > 0 && len(events) > filter.Limit {
+		events = events[:filter.Limit]
+	}
+	return events, nil
+}
@@ -0,0 +1,90 @@
+package in_memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+)
+
+var _ ports.SigningKeyRepository = (*SigningKeyRepository)(nil)
+
+// SigningKeyRepository keeps the signing keys in process memory. Tokens
+// signed by one replica cannot be verified with the JWKS of another, and all
+// of them become unverifiable on restart, so it suits a single replica only.
+type SigningKeyRepository struct {
+	mx     sync.Mutex
+	keys   []*models.SigningKey
+	logger logger.Logger
+}
+
+func NewSigningKeyRepository(logger logger.Logger) *SigningKeyRepository {
+	return &SigningKeyRepository{
+		logger: logger.With("component", "signing_key_repository"),
+	}
+}
+
+// Lock is a no-op: a single process has nobody to coordinate with.
+func (r *SigningKeyRepository) Lock(ctx context.Context) error {
+	return nil
+}
+
+func (r *SigningKeyRepository) GetCurrent(ctx context.Context) (*models.SigningKey, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	for _, key := range r.keys {
+		if !key.IsRetired() {
+			return key, nil
+		}
+	}
+	return nil, errors.NewNotFoundError("signing key not found")
+}
+
+func (r *SigningKeyRepository) Rotate(ctx context.Context, key *models.SigningKey) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	for i, k := range r.keys {
+		if !k.IsRetired() {
+			r.keys[i] = k.WithRetired(key.CreatedAt())
+		}
+	}
+	r.keys = append(r.keys, key)
+	return nil
+}
+
+func (r *SigningKeyRepository) ListPublished(ctx context.Context, retiredAfter time.Time) ([]*models.SigningKey, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	keys := make([]*models.SigningKey, 0, len(r.keys))
+	for _, key := range r.keys {
+		if !key.IsRetired() || key.RetiredAt().After(retiredAfter) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt().After(keys[j].CreatedAt())
+	})
+	return keys, nil
+}
+
+func (r *SigningKeyRepository) DeleteRetired(ctx context.Context, before time.Time) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	kept := r.keys[:0]
+	for _, key := range r.keys {
+		if !key.IsRetired() || !key.RetiredAt().Before(before) {
+			kept = append(kept, key)
+		}
+	}
+	r.keys = kept
+	return nil
+}
@@ -2,7 +2,9 @@ package in_memory
 
 import (
 	"context"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
 	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
@@ -29,24 +31,27 @@ func (t *TokenRepository) Create(ctx context.Context, auth *models.AuthToken) (*
 	t.mx.Lock()
 	defer t.mx.Unlock()
 
-	t.logger.Debug("attempting to create token", "token", auth.Token(), "user_id", auth.UserID())
+	t.logger.Debug("attempting to create token", "user_id", auth.UserID())
 
 	token, ok := t.tokens[auth.Token()]
 	if ok {
-		t.logger.Warn("token already exists", "token", auth.Token())
+		t.logger.Warn("token already exists")
 		return nil, errors.NewAlreadyExistsError("token already exists").
-			WithDetails("token", auth.Token()).
 			WithDetails("user_id", auth.UserID().String())
 	}
 
-	token, err := models.NewAuthToken(auth.Token(), auth.UserID(), auth.ExpiresAt())
+	token, err := models.RestoreAuthToken(
+		auth.Token(), auth.UserID(), auth.FamilyID(), auth.ExpiresAt(),
+		auth.Session(), auth.CreatedAt(), auth.LastUsedAt(),
+	)
 	if err != nil {
 		t.logger.Error("failed to create token", "error", err)
 		return nil, err
 	}
+	token = token.WithActor(auth.ActorID())
 
 	t.tokens[token.Token()] = token
-	t.logger.Info("token created successfully", "token", token.Token(), "user_id", token.UserID(), "expires_at", token.ExpiresAt())
+	t.logger.Info("token created successfully", "user_id", token.UserID(), "expires_at", token.ExpiresAt())
 
 	return token, nil
 }
@@ -55,40 +60,52 @@ func (t *TokenRepository) FindToken(ctx context.Context, token string) (models.T
 	t.mx.Lock()
 	defer t.mx.Unlock()
 
-	t.logger.Debug("looking for token", "token", token)
+	t.logger.Debug("looking for token")
 
 	authToken, ok := t.tokens[models.Token(token)]
 	if !ok {
-		t.logger.Debug("token not found", "token", token)
-		return "", errors.NewNotFoundError("token not found").
-			WithDetails("token", token)
+		t.logger.Debug("token not found")
+		return "", errors.NewNotFoundError("token not found")
 	}
 
-	t.logger.Debug("token found", "token", token, "user_id", authToken.UserID())
+	t.logger.Debug("token found", "user_id", authToken.UserID())
 	return authToken.Token(), nil
 }
 
+func (t *TokenRepository) GetToken(ctx context.Context, token models.Token) (*models.AuthToken, error) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	t.logger.Debug("getting token")
+
+	authToken, ok := t.tokens[token]
+	if !ok {
+		t.logger.Debug("token not found")
+		return nil, errors.NewNotFoundError("token not found")
+	}
+
+	return authToken, nil
+}
+
 func (t *TokenRepository) ValidateToken(ctx context.Context, token string) (bool, error) {
 	t.mx.Lock()
 	defer t.mx.Unlock()
 
-	t.logger.Debug("validating token", "token", token)
+	t.logger.Debug("validating token")
 
 	authToken, ok := t.tokens[models.Token(token)]
 	if !ok {
-		t.logger.Debug("token not found during validation", "token", token)
-		return false, errors.NewNotFoundError("token not found").
-			WithDetails("token", token)
+		t.logger.Debug("token not found during validation")
+		return false, errors.NewNotFoundError("token not found")
 	}
 
 	if authToken.IsExpired() {
-		t.logger.Debug("token is expired", "token", token, "expires_at", authToken.ExpiresAt())
+		t.logger.Debug("token is expired", "expires_at", authToken.ExpiresAt())
 		return false, errors.NewTokenError(errors.ErrTokenExpired, "token is expired").
-			WithDetails("token", token).
 			WithDetails("expires_at", authToken.ExpiresAt())
 	}
 
-	t.logger.Debug("token is valid", "token", token, "user_id", authToken.UserID())
+	t.logger.Debug("token is valid", "user_id", authToken.UserID())
 	return true, nil
 }
 
@@ -96,16 +113,145 @@ func (t *TokenRepository) DeleteToken(ctx context.Context, token models.Token) e
 	t.mx.Lock()
 	defer t.mx.Unlock()
 
-	t.logger.Debug("attempting to delete token", "token", token)
+	t.logger.Debug("attempting to delete token")
 
 	_, ok := t.tokens[token]
 	if !ok {
-		t.logger.Debug("token not found for deletion", "token", token)
-		return errors.NewNotFoundError("token not found").
-			WithDetails("token", token)
+		t.logger.Debug("token not found for deletion")
+		return errors.NewNotFoundError("token not found")
 	}
 
 	delete(t.tokens, token)
-	t.logger.Info("token deleted successfully", "token", token)
+	t.logger.Info("token deleted successfully")
+	return nil
+}
+
+func (t *TokenRepository) DeleteTokensByFamily(ctx context.Context, familyID models.FamilyID) error {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	t.logger.Debug("deleting tokens by family", "family_id", familyID)
+
+	for token, authToken := range t.tokens {
+		if authToken.FamilyID() == familyID {
+			delete(t.tokens, token)
+		}
+	}
+
+	t.logger.Debug("tokens deleted by family", "family_id", familyID)
 	return nil
 }
+
+func (t *TokenRepository) DeleteTokensByUser(ctx context.Context, userID models.UserID) error {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	t.logger.Debug("deleting tokens by user", "user_id", userID)
+
+	for token, authToken := range t.tokens {
+		if authToken.UserID() == userID {
+			delete(t.tokens, token)
+		}
+	}
+
+	t.logger.Info("tokens deleted by user", "user_id", userID)
+	return nil
+}
+
+func (t *TokenRepository) GetSession(ctx context.Context, familyID models.FamilyID) (*models.AuthToken, error) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	for _, authToken := range t.tokens {
+		if authToken.FamilyID() == familyID {
+			return authToken, nil
+		}
+	}
+
+	t.logger.Debug("session not found", "family_id", familyID)
+	return nil, errors.NewNotFoundError("session not found").
+		WithDetails("session_id", familyID.String())
+}
+
+func (t *TokenRepository) ListSessions(ctx context.Context, userID models.UserID) ([]*models.AuthToken, error) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	sessions := make([]*models.AuthToken, 0)
+	for _, authToken := range t.tokens {
+		if authToken.UserID() == userID {
+			sessions = append(sessions, authToken)
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastUsedAt().After(sessions[j].LastUsedAt())
+	})
+
+	return sessions, nil
+}
+
+func (t *TokenRepository) RotateToken(ctx context.Context, familyID models.FamilyID, token models.Token, expiresAt time.Time) error {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	for current, authToken := range t.tokens {
+		if authToken.FamilyID() != familyID {
+			continue
+		}
+
+		rotated, err := models.RestoreAuthToken(
+			token, authToken.UserID(), familyID, expiresAt,
+			authToken.Session(), authToken.CreatedAt(), time.Now(),
+		)
+		if err != nil {
+			return err
+		}
+
+		delete(t.tokens, current)
+		t.tokens[token] = rotated.WithActor(authToken.ActorID())
+		return nil
+	}
+
+	t.logger.Warn("session not found for rotation", "family_id", familyID)
+	return errors.NewNotFoundError("session not found").
+		WithDetails("session_id", familyID.String())
+}
+
+func (t *TokenRepository) TouchToken(ctx context.Context, token models.Token, usedAt time.Time) error {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	authToken, ok := t.tokens[token]
+	if !ok {
+		return nil
+	}
+
+	touched, err := models.RestoreAuthToken(
+		authToken.Token(), authToken.UserID(), authToken.FamilyID(), authToken.ExpiresAt(),
+		authToken.Session(), authToken.CreatedAt(), usedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	t.tokens[token] = touched.WithActor(authToken.ActorID())
+	return nil
+}
+
+func (t *TokenRepository) DeleteExpired(ctx context.Context, before time.Time, limit int) (int, error) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	deleted := 0
+	for token, authToken := range t.tokens {
+		if deleted >= limit {
+			break
+		}
+		if authToken.ExpiresAt().Before(before) {
+			delete(t.tokens, token)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
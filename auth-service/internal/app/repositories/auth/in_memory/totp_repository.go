@@ -0,0 +1,56 @@
+package in_memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+)
+
+var _ ports.TOTPRepository = (*TOTPRepository)(nil)
+
+type TOTPRepository struct {
+	mx      sync.Mutex
+	factors map[models.UserID]*models.TOTPFactor
+	logger  logger.Logger
+}
+
+func NewTOTPRepository(logger logger.Logger) *TOTPRepository {
+	return &TOTPRepository{
+		factors: make(map[models.UserID]*models.TOTPFactor),
+		logger:  logger.With("component", "totp_repository"),
+	}
+}
+
+func (r *TOTPRepository) Save(ctx context.Context, factor *models.TOTPFactor) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	r.factors[factor.UserID()] = factor
+	return nil
+}
+
+func (r *TOTPRepository) GetByUser(ctx context.Context, userID models.UserID) (*models.TOTPFactor, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	factor, ok := r.factors[userID]
+	if !ok {
+		return nil, errors.NewNotFoundError("totp factor not found")
+	}
+	return factor, nil
+}
+
+func (r *TOTPRepository) Update(ctx context.Context, factor *models.TOTPFactor) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if _, ok := r.factors[factor.UserID()]; !ok {
+		return errors.NewNotFoundError("totp factor not found")
+	}
+	r.factors[factor.UserID()] = factor
+	return nil
+}
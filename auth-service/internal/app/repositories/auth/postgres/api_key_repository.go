@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/SamEkb/messenger-app/pkg/platform/postgres"
+)
+
+var _ ports.APIKeyRepository = (*APIKeyRepository)(nil)
+
+type APIKeyRepository struct {
+	txManager *postgres.TxManager
+	logger    logger.Logger
+}
+
+func NewAPIKeyRepository(txManager *postgres.TxManager, logger logger.Logger) *APIKeyRepository {
+	return &APIKeyRepository{txManager: txManager, logger: logger.With("component", "api_key_repository")}
+}
+
+type apiKeyRow struct {
+	ID         string       `db:"id"`
+	UserID     string       `db:"user_id"`
+	Name       string       `db:"name"`
+	Hash       string       `db:"key_hash"`
+	Hint       string       `db:"hint"`
+	Scopes     string       `db:"scopes"`
+	CreatedAt  time.Time    `db:"created_at"`
+	LastUsedAt sql.NullTime `db:"last_used_at"`
+	RevokedAt  sql.NullTime `db:"revoked_at"`
+}
+
+const apiKeyColumns = `id, user_id, name, key_hash, hint, array_to_string(scopes, ',') AS scopes,
+		created_at, last_used_at, revoked_at`
+
+func (r *APIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	r.logger.Debug("attempting to create API key", "user_id", key.UserID(), "key_id", key.ID())
+	q := r.txManager.GetQueryEngine(ctx)
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO api_keys (id, user_id, name, key_hash, hint, scopes, created_at)
+		VALUES ($1, $2, $3, $4, $5, string_to_array($6, ','), $7)
+	`, key.ID().String(), key.UserID().String(), key.Name(), key.Hash(), key.Hint(),
+		strings.Join(models.ScopeNames(key.Scopes()), ","), key.CreatedAt())
+	if err != nil {
+		r.logger.Warn("failed to create API key", "key_id", key.ID(), "error", err)
+		return errors.NewAlreadyExistsError("API key already exists").WithDetails("key_id", key.ID().String())
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) GetByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	q := r.txManager.GetQueryEngine(ctx)
+	var row apiKeyRow
+	err := q.GetContext(ctx, &row, `
+		SELECT `+apiKeyColumns+`
+		FROM api_keys WHERE key_hash = $1
+	`, hash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			r.logger.Debug("API key not found")
+			return nil, errors.NewNotFoundError("API key not found")
+		}
+		r.logger.Error("failed to get API key", "error", err)
+		return nil, errors.NewInternalError(err, "failed to get API key")
+	}
+	return r.toModel(row)
+}
+
+func (r *APIKeyRepository) ListByUser(ctx context.Context, userID models.UserID) ([]*models.APIKey, error) {
+	q := r.txManager.GetQueryEngine(ctx)
+	var rows []apiKeyRow
+	err := q.SelectContext(ctx, &rows, `
+		SELECT `+apiKeyColumns+`
+		FROM api_keys WHERE user_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC
+	`, userID.String())
+	if err != nil {
+		r.logger.Error("failed to list API keys", "user_id", userID, "error", err)
+		return nil, errors.NewInternalError(err, "failed to list API keys")
+	}
+
+	keys := make([]*models.APIKey, 0, len(rows))
+	for _, row := range rows {
+		key, err := r.toModel(row)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (r *APIKeyRepository) Revoke(ctx context.Context, userID models.UserID, keyID models.APIKeyID, revokedAt time.Time) error {
+	q := r.txManager.GetQueryEngine(ctx)
+	res, err := q.ExecContext(ctx, `
+		UPDATE api_keys SET revoked_at = $3
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+	`, keyID.String(), userID.String(), revokedAt)
+	if err != nil {
+		r.logger.Error("failed to revoke API key", "key_id", keyID, "error", err)
+		return errors.NewInternalError(err, "failed to revoke API key")
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return errors.NewInternalError(err, "failed to get rows affected")
+	}
+	if rows == 0 {
+		return errors.NewNotFoundError("API key not found").WithDetails("key_id", keyID.String())
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) Touch(ctx context.Context, keyID models.APIKeyID, usedAt time.Time) error {
+	q := r.txManager.GetQueryEngine(ctx)
+	_, err := q.ExecContext(ctx, `
+		UPDATE api_keys SET last_used_at = $2 WHERE id = $1
+	`, keyID.String(), usedAt)
+	if err != nil {
+		r.logger.Error("failed to touch API key", "key_id", keyID, "error", err)
+		return errors.NewInternalError(err, "failed to update API key")
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) toModel(row apiKeyRow) (*models.APIKey, error) {
+	keyID, err := models.APIKeyIDFromString(row.ID)
+	if err != nil {
+		r.logger.Warn("failed to parse UUID", "UUID", row.ID)
+		return nil, err
+	}
+	userID, err := models.UserIDFromString(row.UserID)
+	if err != nil {
+		r.logger.Warn("failed to parse UUID", "UUID", row.UserID)
+		return nil, err
+	}
+	return models.RestoreAPIKey(keyID, userID, row.Name, row.Hash, row.Hint, strings.Split(row.Scopes, ","),
+		row.CreatedAt, row.LastUsedAt.Time, row.RevokedAt.Time)
+}
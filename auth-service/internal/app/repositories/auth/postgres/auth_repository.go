@@ -2,6 +2,8 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"strings"
 
 	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
 	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
@@ -29,12 +31,16 @@ func (r *AuthRepository) Create(ctx context.Context, user *models.User) error {
 
 	q := r.txManager.GetQueryEngine(ctx)
 	_, err := q.ExecContext(ctx, `
-		INSERT INTO users (id, username, email, password)
-		VALUES ($1, $2, $3, $4)
-	`, user.ID(), user.Username(), user.Email(), user.Password())
+		INSERT INTO users (id, username, email, password, email_verified, roles)
+		VALUES ($1, $2, $3, $4, $5, string_to_array($6, ','))
+	`, user.ID(), user.Username(), user.Email(), user.Password(), user.EmailVerified(), rolesColumn(user))
+	if postgres.IsUniqueViolation(err) {
+		r.logger.Warn("user with this email or username already exists", "email", user.Email(), "username", user.Username())
+		return errors.NewAlreadyExistsError("user with email %s or username %s already exists", user.Email(), user.Username())
+	}
 	if err != nil {
-		r.logger.Warn("user with this email already exists", "email", user.Email())
-		return errors.NewAlreadyExistsError("user with email %s already exists", user.Email())
+		r.logger.Error("failed to create user", "user_id", user.ID(), "error", err)
+		return errors.NewInternalError(err, "failed to create user")
 	}
 
 	r.logger.Debug("user created successfully", "user_id", user.ID(), "email", user.Email())
@@ -46,17 +52,26 @@ func (r *AuthRepository) FindUserByID(ctx context.Context, userID models.UserID)
 	r.logger.Debug("looking for user by ID", "user_id", userID)
 	q := r.txManager.GetQueryEngine(ctx)
 	var user struct {
-		ID       string `db:"id"`
-		Username string `db:"username"`
-		Email    string `db:"email"`
-		Password string `db:"password"`
+		ID            string       `db:"id"`
+		Username      string       `db:"username"`
+		Email         string       `db:"email"`
+		Password      string       `db:"password"`
+		EmailVerified bool         `db:"email_verified"`
+		Roles         string       `db:"roles"`
+		SuspendedAt   sql.NullTime `db:"suspended_at"`
 	}
 	err := q.GetContext(ctx, &user, `
-		SELECT id, username, email, password FROM users WHERE id = $1
+		SELECT id, username, email, password, email_verified, array_to_string(roles, ',') AS roles, suspended_at
+		FROM users
+		WHERE id = $1
 	`, userID)
 	if err != nil {
-		r.logger.Warn("user not found", "user_id", userID)
-		return nil, errors.NewNotFoundError("user with ID %s not found", userID.String())
+		if errors.Is(err, sql.ErrNoRows) {
+			r.logger.Warn("user not found", "user_id", userID)
+			return nil, errors.NewNotFoundError("user with ID %s not found", userID.String())
+		}
+		r.logger.Error("failed to get user", "user_id", userID, "error", err)
+		return nil, errors.NewInternalError(err, "failed to get user").WithDetails("user_id", userID.String())
 	}
 	uid, err := models.UserIDFromString(user.ID)
 	if err != nil {
@@ -64,20 +79,48 @@ func (r *AuthRepository) FindUserByID(ctx context.Context, userID models.UserID)
 		return nil, err
 	}
 	r.logger.Debug("user found", "user_id", userID, "email", user.Email)
-	return models.NewUser(uid, user.Username, user.Email, []byte(user.Password))
+	return models.RestoreUser(uid, user.Username, user.Email, []byte(user.Password), user.EmailVerified, rolesFromColumn(user.Roles), user.SuspendedAt.Time)
+}
+
+func (r *AuthRepository) FindUserAccess(ctx context.Context, userID models.UserID) (*models.UserAccess, error) {
+	r.logger.Debug("looking up user access", "user_id", userID)
+	q := r.txManager.GetQueryEngine(ctx)
+	var access struct {
+		Roles       string       `db:"roles"`
+		SuspendedAt sql.NullTime `db:"suspended_at"`
+	}
+	err := q.GetContext(ctx, &access, `
+		SELECT array_to_string(roles, ',') AS roles, suspended_at
+		FROM users
+		WHERE id = $1
+	`, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			r.logger.Debug("user not found", "user_id", userID)
+			return nil, errors.NewNotFoundError("user with ID %s not found", userID.String())
+		}
+		r.logger.Error("failed to get user access", "user_id", userID, "error", err)
+		return nil, errors.NewInternalError(err, "failed to get user").WithDetails("user_id", userID.String())
+	}
+	return models.RestoreUserAccess(userID, rolesFromColumn(access.Roles), access.SuspendedAt.Valid)
 }
 
 func (r *AuthRepository) FindUserByEmail(ctx context.Context, email string) (*models.User, error) {
 	r.logger.Debug("looking for user by email", "email", email)
 	q := r.txManager.GetQueryEngine(ctx)
 	var user struct {
-		ID       string `db:"id"`
-		Username string `db:"username"`
-		Email    string `db:"email"`
-		Password string `db:"password"`
+		ID            string       `db:"id"`
+		Username      string       `db:"username"`
+		Email         string       `db:"email"`
+		Password      string       `db:"password"`
+		EmailVerified bool         `db:"email_verified"`
+		Roles         string       `db:"roles"`
+		SuspendedAt   sql.NullTime `db:"suspended_at"`
 	}
 	err := q.GetContext(ctx, &user, `
-		SELECT id, username, email, password FROM users WHERE email = $1
+		SELECT id, username, email, password, email_verified, array_to_string(roles, ',') AS roles, suspended_at
+		FROM users
+		WHERE email = $1
 	`, email)
 	if err != nil {
 		r.logger.Warn("user not found", "email", email)
@@ -90,18 +133,97 @@ func (r *AuthRepository) FindUserByEmail(ctx context.Context, email string) (*mo
 	}
 
 	r.logger.Debug("user found", "user_id", user.ID, "email", email)
-	return models.NewUser(uid, user.Username, user.Email, []byte(user.Password))
+	return models.RestoreUser(uid, user.Username, user.Email, []byte(user.Password), user.EmailVerified, rolesFromColumn(user.Roles), user.SuspendedAt.Time)
+}
+
+func (r *AuthRepository) FindUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	r.logger.Debug("looking for user by username", "username", username)
+	q := r.txManager.GetQueryEngine(ctx)
+	var user struct {
+		ID            string       `db:"id"`
+		Username      string       `db:"username"`
+		Email         string       `db:"email"`
+		Password      string       `db:"password"`
+		EmailVerified bool         `db:"email_verified"`
+		Roles         string       `db:"roles"`
+		SuspendedAt   sql.NullTime `db:"suspended_at"`
+	}
+	err := q.GetContext(ctx, &user, `
+		SELECT id, username, email, password, email_verified, array_to_string(roles, ',') AS roles, suspended_at
+		FROM users
+		WHERE username = $1
+		LIMIT 1
+	`, username)
+	if err != nil {
+		r.logger.Debug("user not found", "username", username)
+		return nil, errors.NewNotFoundError("user with username %s not found", username)
+	}
+	uid, err := models.UserIDFromString(user.ID)
+	if err != nil {
+		r.logger.Warn("failed to parse UUID", "UUID", user.ID)
+		return nil, err
+	}
+
+	return models.RestoreUser(uid, user.Username, user.Email, []byte(user.Password), user.EmailVerified, rolesFromColumn(user.Roles), user.SuspendedAt.Time)
 }
 
 func (r *AuthRepository) Update(ctx context.Context, user *models.User) error {
 	r.logger.Debug("attempting to update user", "user_id", user.ID())
 	q := r.txManager.GetQueryEngine(ctx)
-	_, err := q.ExecContext(ctx, `
-		UPDATE users SET username = $1, email = $2, password = $3 WHERE id = $4
-	`, user.Username(), user.Email(), user.Password(), user.ID())
+	res, err := q.ExecContext(ctx, `
+		UPDATE users
+		SET username = $1, email = $2, password = $3, email_verified = $4, roles = string_to_array($5, ','),
+		    suspended_at = $6
+		WHERE id = $7
+	`, user.Username(), user.Email(), user.Password(), user.EmailVerified(), rolesColumn(user), nullTime(user.SuspendedAt()), user.ID())
+	if postgres.IsUniqueViolation(err) {
+		r.logger.Warn("email or username already taken", "user_id", user.ID())
+		return errors.NewAlreadyExistsError("email %s or username %s is already taken", user.Email(), user.Username())
+	}
 	if err != nil {
+		r.logger.Error("failed to update user", "user_id", user.ID(), "error", err)
+		return errors.NewInternalError(err, "failed to update user").WithDetails("user_id", user.ID().String())
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
 		r.logger.Warn("user not found for update", "user_id", user.ID())
 		return errors.NewNotFoundError("user with ID %s not found", user.ID().String())
 	}
 	return nil
 }
+
+// Delete removes the user. Tokens, sessions, second factors and linked
+// identities are removed with it by ON DELETE CASCADE.
+func (r *AuthRepository) Delete(ctx context.Context, userID models.UserID) error {
+	r.logger.Debug("attempting to delete user", "user_id", userID)
+	q := r.txManager.GetQueryEngine(ctx)
+	result, err := q.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, userID)
+	if err != nil {
+		return errors.NewInternalError(err, "failed to delete user").
+			WithDetails("user_id", userID.String())
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return errors.NewInternalError(err, "failed to delete user").
+			WithDetails("user_id", userID.String())
+	}
+	if rows == 0 {
+		return errors.NewNotFoundError("user with ID %s not found", userID.String())
+	}
+
+	r.logger.Info("user deleted", "user_id", userID)
+	return nil
+}
+
+// rolesColumn encodes the roles for string_to_array; role names never
+// contain commas.
+func rolesColumn(user *models.User) string {
+	return strings.Join(models.RoleNames(user.Roles()), ",")
+}
+
+func rolesFromColumn(column string) []string {
+	if column == "" {
+		return nil
+	}
+	return strings.Split(column, ",")
+}
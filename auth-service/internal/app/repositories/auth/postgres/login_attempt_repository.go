@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/SamEkb/messenger-app/pkg/platform/postgres"
+)
+
+var _ ports.LoginAttemptRepository = (*LoginAttemptRepository)(nil)
+
+type LoginAttemptRepository struct {
+	txManager *postgres.TxManager
+	logger    logger.Logger
+}
+
+func NewLoginAttemptRepository(txManager *postgres.TxManager, logger logger.Logger) *LoginAttemptRepository {
+	return &LoginAttemptRepository{txManager: txManager, logger: logger.With("component", "login_attempt_repository")}
+}
+
+type loginAttemptRow struct {
+	Key           string       `db:"key"`
+	Failures      int          `db:"failures"`
+	LastFailureAt time.Time    `db:"last_failure_at"`
+	LockedUntil   sql.NullTime `db:"locked_until"`
+}
+
+func (r loginAttemptRow) toModel() (*models.LoginAttempt, error) {
+	return models.RestoreLoginAttempt(r.Key, r.Failures, r.LastFailureAt, r.LockedUntil.Time)
+}
+
+func (r *LoginAttemptRepository) Get(ctx context.Context, key string) (*models.LoginAttempt, error) {
+	q := r.txManager.GetQueryEngine(ctx)
+	var row loginAttemptRow
+	err := q.GetContext(ctx, &row, `
+		SELECT key, failures, last_failure_at, locked_until
+		FROM login_attempts WHERE key = $1
+	`, key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.NewNotFoundError("no failed login attempts")
+		}
+		r.logger.Error("failed to get login attempts", "error", err)
+		return nil, errors.NewInternalError(err, "failed to get login attempts")
+	}
+	return row.toModel()
+}
+
+func (r *LoginAttemptRepository) RecordFailure(ctx context.Context, key string, at time.Time, window time.Duration) (*models.LoginAttempt, error) {
+	q := r.txManager.GetQueryEngine(ctx)
+	var row loginAttemptRow
+	err := q.GetContext(ctx, &row, `
+		INSERT INTO login_attempts (key, failures, last_failure_at)
+		VALUES ($1, 1, $2)
+		ON CONFLICT (key) DO UPDATE
+		SET failures = CASE
+		        WHEN login_attempts.last_failure_at < $2 - make_interval(secs => $3) THEN 1
+		        ELSE login_attempts.failures + 1
+		    END,
+		    last_failure_at = EXCLUDED.last_failure_at
+		RETURNING key, failures, last_failure_at, locked_until
+	`, key, at, window.Seconds())
+	if err != nil {
+		r.logger.Error("failed to record login failure", "error", err)
+		return nil, errors.NewInternalError(err, "failed to record login failure")
+	}
+	return row.toModel()
+}
+
+func (r *LoginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	q := r.txManager.GetQueryEngine(ctx)
+	_, err := q.ExecContext(ctx, `
+		UPDATE login_attempts SET locked_until = $2 WHERE key = $1
+	`, key, until)
+	if err != nil {
+		r.logger.Error("failed to lock login key", "error", err)
+		return errors.NewInternalError(err, "failed to lock login key")
+	}
+	return nil
+}
+
+func (r *LoginAttemptRepository) Reset(ctx context.Context, key string) error {
+	q := r.txManager.GetQueryEngine(ctx)
+	_, err := q.ExecContext(ctx, `DELETE FROM login_attempts WHERE key = $1`, key)
+	if err != nil {
+		r.logger.Error("failed to reset login attempts", "error", err)
+		return errors.NewInternalError(err, "failed to reset login attempts")
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/SamEkb/messenger-app/pkg/platform/postgres"
+)
+
+var _ ports.OAuthIdentityRepository = (*OAuthIdentityRepository)(nil)
+
+type OAuthIdentityRepository struct {
+	txManager *postgres.TxManager
+	logger    logger.Logger
+}
+
+func NewOAuthIdentityRepository(txManager *postgres.TxManager, logger logger.Logger) *OAuthIdentityRepository {
+	return &OAuthIdentityRepository{txManager: txManager, logger: logger.With("component", "oauth_identity_repository")}
+}
+
+func (r *OAuthIdentityRepository) Create(ctx context.Context, identity *models.OAuthIdentity) error {
+	r.logger.Debug("attempting to link external identity", "provider", identity.Provider(), "user_id", identity.UserID())
+	q := r.txManager.GetQueryEngine(ctx)
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO oauth_identities (provider, subject, user_id, email, created_at, last_login_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, identity.Provider(), identity.Subject(), identity.UserID().String(), identity.Email(), identity.CreatedAt(), identity.LastLoginAt())
+	if err != nil {
+		r.logger.Warn("failed to link external identity", "provider", identity.Provider(), "user_id", identity.UserID(), "error", err)
+		return errors.NewAlreadyExistsError("external identity is already linked").
+			WithDetails("provider", identity.Provider())
+	}
+	return nil
+}
+
+func (r *OAuthIdentityRepository) FindBySubject(ctx context.Context, provider, subject string) (*models.OAuthIdentity, error) {
+	q := r.txManager.GetQueryEngine(ctx)
+	var i struct {
+		UserID      string    `db:"user_id"`
+		Email       string    `db:"email"`
+		CreatedAt   time.Time `db:"created_at"`
+		LastLoginAt time.Time `db:"last_login_at"`
+	}
+	err := q.GetContext(ctx, &i, `
+		SELECT user_id, email, created_at, last_login_at
+		FROM oauth_identities WHERE provider = $1 AND subject = $2
+	`, provider, subject)
+	if err != nil {
+		r.logger.Debug("external identity not found", "provider", provider)
+		return nil, errors.NewNotFoundError("external identity not found")
+	}
+
+	userID, err := models.UserIDFromString(i.UserID)
+	if err != nil {
+		r.logger.Warn("failed to parse UUID", "UUID", i.UserID)
+		return nil, err
+	}
+	return models.RestoreOAuthIdentity(provider, subject, userID, i.Email, i.CreatedAt, i.LastLoginAt)
+}
+
+func (r *OAuthIdentityRepository) TouchLogin(ctx context.Context, provider, subject string, at time.Time) error {
+	q := r.txManager.GetQueryEngine(ctx)
+	_, err := q.ExecContext(ctx, `
+		UPDATE oauth_identities SET last_login_at = $3
+		WHERE provider = $1 AND subject = $2
+	`, provider, subject, at)
+	if err != nil {
+		r.logger.Error("failed to touch external identity", "provider", provider, "error", err)
+		return errors.NewInternalError(err, "failed to update external identity")
+	}
+	return nil
+}
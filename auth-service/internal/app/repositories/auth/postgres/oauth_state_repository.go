@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/SamEkb/messenger-app/pkg/platform/postgres"
+)
+
+var _ ports.OAuthStateRepository = (*OAuthStateRepository)(nil)
+
+type OAuthStateRepository struct {
+	txManager *postgres.TxManager
+	logger    logger.Logger
+}
+
+func NewOAuthStateRepository(txManager *postgres.TxManager, logger logger.Logger) *OAuthStateRepository {
+	return &OAuthStateRepository{txManager: txManager, logger: logger.With("component", "oauth_state_repository")}
+}
+
+func (r *OAuthStateRepository) Create(ctx context.Context, state *models.OAuthState) error {
+	r.logger.Debug("attempting to create oauth state", "provider", state.Provider())
+	q := r.txManager.GetQueryEngine(ctx)
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO oauth_states (state_hash, provider, code_verifier, nonce, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, state.Hash(), state.Provider(), string(state.CodeVerifier()), string(state.Nonce()), state.ExpiresAt())
+	if err != nil {
+		r.logger.Error("failed to create oauth state", "provider", state.Provider(), "error", err)
+		return errors.NewInternalError(err, "failed to create oauth state")
+	}
+	return nil
+}
+
+func (r *OAuthStateRepository) Consume(ctx context.Context, hash string) (*models.OAuthState, error) {
+	q := r.txManager.GetQueryEngine(ctx)
+	var s struct {
+		Provider     string    `db:"provider"`
+		CodeVerifier string    `db:"code_verifier"`
+		Nonce        string    `db:"nonce"`
+		ExpiresAt    time.Time `db:"expires_at"`
+	}
+	err := q.GetContext(ctx, &s, `
+		DELETE FROM oauth_states WHERE state_hash = $1
+		RETURNING provider, code_verifier, nonce, expires_at
+	`, hash)
+	if err != nil {
+		r.logger.Debug("oauth state not found")
+		return nil, errors.NewNotFoundError("oauth state not found")
+	}
+	return models.NewOAuthState(hash, s.Provider, models.Token(s.CodeVerifier), models.Token(s.Nonce), s.ExpiresAt)
+}
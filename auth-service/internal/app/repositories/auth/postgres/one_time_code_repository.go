@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/SamEkb/messenger-app/pkg/platform/postgres"
+)
+
+var _ ports.OneTimeCodeRepository = (*OneTimeCodeRepository)(nil)
+
+type OneTimeCodeRepository struct {
+	txManager *postgres.TxManager
+	logger    logger.Logger
+}
+
+func NewOneTimeCodeRepository(txManager *postgres.TxManager, logger logger.Logger) *OneTimeCodeRepository {
+	return &OneTimeCodeRepository{txManager: txManager, logger: logger.With("component", "one_time_code_repository")}
+}
+
+func (r *OneTimeCodeRepository) Create(ctx context.Context, code *models.OneTimeCode) error {
+	r.logger.Debug("attempting to create one-time code", "user_id", code.UserID(), "purpose", code.Purpose())
+	q := r.txManager.GetQueryEngine(ctx)
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO one_time_codes (code_hash, user_id, purpose, expires_at, email)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+	`, code.Hash(), code.UserID().String(), string(code.Purpose()), code.ExpiresAt(), code.Email())
+	if err != nil {
+		r.logger.Warn("one-time code already exists", "user_id", code.UserID())
+		return errors.NewAlreadyExistsError("one-time code already exists").WithDetails("user_id", code.UserID().String())
+	}
+	return nil
+}
+
+func (r *OneTimeCodeRepository) GetByHash(ctx context.Context, hash string) (*models.OneTimeCode, error) {
+	q := r.txManager.GetQueryEngine(ctx)
+	var c struct {
+		Hash      string         `db:"code_hash"`
+		UserID    string         `db:"user_id"`
+		Purpose   string         `db:"purpose"`
+		ExpiresAt time.Time      `db:"expires_at"`
+		UsedAt    sql.NullTime   `db:"used_at"`
+		Email     sql.NullString `db:"email"`
+	}
+	err := q.GetContext(ctx, &c, `
+		SELECT code_hash, user_id, purpose, expires_at, used_at, email
+		FROM one_time_codes WHERE code_hash = $1
+	`, hash)
+	if err != nil {
+		r.logger.Debug("one-time code not found")
+		return nil, errors.NewNotFoundError("code not found")
+	}
+	userID, err := models.UserIDFromString(c.UserID)
+	if err != nil {
+		r.logger.Warn("failed to parse UUID", "UUID", c.UserID)
+		return nil, err
+	}
+	code, err := models.RestoreOneTimeCode(c.Hash, userID, models.CodePurpose(c.Purpose), c.ExpiresAt, c.UsedAt.Time)
+	if err != nil {
+		return nil, err
+	}
+	return code.WithEmail(c.Email.String), nil
+}
+
+func (r *OneTimeCodeRepository) GetActiveByUser(ctx context.Context, userID models.UserID, purpose models.CodePurpose) (*models.OneTimeCode, error) {
+	q := r.txManager.GetQueryEngine(ctx)
+	var c struct {
+		Hash      string         `db:"code_hash"`
+		ExpiresAt time.Time      `db:"expires_at"`
+		Email     sql.NullString `db:"email"`
+	}
+	err := q.GetContext(ctx, &c, `
+		SELECT code_hash, expires_at, email
+		FROM one_time_codes
+		WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, userID.String(), string(purpose))
+	if err != nil {
+		r.logger.Debug("no active one-time code", "user_id", userID, "purpose", purpose)
+		return nil, errors.NewNotFoundError("code not found")
+	}
+	code, err := models.NewOneTimeCode(c.Hash, userID, purpose, c.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return code.WithEmail(c.Email.String), nil
+}
+
+func (r *OneTimeCodeRepository) MarkUsed(ctx context.Context, hash string, usedAt time.Time) error {
+	q := r.txManager.GetQueryEngine(ctx)
+	res, err := q.ExecContext(ctx, `
+		UPDATE one_time_codes SET used_at = $2
+		WHERE code_hash = $1 AND used_at IS NULL
+	`, hash, usedAt)
+	if err != nil {
+		r.logger.Error("failed to mark one-time code as used", "error", err)
+		return errors.NewInternalError(err, "failed to mark code as used")
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		r.logger.Warn("one-time code already used")
+		return errors.NewTokenError(errors.ErrInvalidToken, "code already used")
+	}
+	return nil
+}
+
+func (r *OneTimeCodeRepository) InvalidateByUser(ctx context.Context, userID models.UserID, purpose models.CodePurpose, at time.Time) error {
+	q := r.txManager.GetQueryEngine(ctx)
+	_, err := q.ExecContext(ctx, `
+		UPDATE one_time_codes SET used_at = $3
+		WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL
+	`, userID.String(), string(purpose), at)
+	if err != nil {
+		r.logger.Error("failed to invalidate one-time codes", "user_id", userID, "error", err)
+		return errors.NewInternalError(err, "failed to invalidate codes").WithDetails("user_id", userID.String())
+	}
+	return nil
+}
+
+func (r *OneTimeCodeRepository) RecordFailedAttempt(ctx context.Context, hash string) (int, error) {
+	q := r.txManager.GetQueryEngine(ctx)
+	var attempts int
+	err := q.GetContext(ctx, &attempts, `
+		UPDATE one_time_codes SET failed_attempts = failed_attempts + 1
+		WHERE code_hash = $1
+		RETURNING failed_attempts
+	`, hash)
+	if err != nil {
+		r.logger.Debug("one-time code not found")
+		return 0, errors.NewNotFoundError("code not found")
+	}
+	return attempts, nil
+}
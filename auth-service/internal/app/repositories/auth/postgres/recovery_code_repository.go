@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/SamEkb/messenger-app/pkg/platform/postgres"
+)
+
+var _ ports.RecoveryCodeRepository = (*RecoveryCodeRepository)(nil)
+
+type RecoveryCodeRepository struct {
+	txManager *postgres.TxManager
+	logger    logger.Logger
+}
+
+func NewRecoveryCodeRepository(txManager *postgres.TxManager, logger logger.Logger) *RecoveryCodeRepository {
+	return &RecoveryCodeRepository{txManager: txManager, logger: logger.With("component", "recovery_code_repository")}
+}
+
+func (r *RecoveryCodeRepository) ReplaceAll(ctx context.Context, userID models.UserID, hashes []string) error {
+	r.logger.Debug("replacing recovery codes", "user_id", userID, "count", len(hashes))
+	q := r.txManager.GetQueryEngine(ctx)
+	_, err := q.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = $1`, userID.String())
+	if err != nil {
+		r.logger.Error("failed to delete recovery codes", "user_id", userID, "error", err)
+		return errors.NewInternalError(err, "failed to delete recovery codes").WithDetails("user_id", userID.String())
+	}
+
+	for _, hash := range hashes {
+		_, err = q.ExecContext(ctx, `
+			INSERT INTO recovery_codes (code_hash, user_id) VALUES ($1, $2)
+		`, hash, userID.String())
+		if err != nil {
+			r.logger.Error("failed to store recovery code", "user_id", userID, "error", err)
+			return errors.NewInternalError(err, "failed to store recovery code").WithDetails("user_id", userID.String())
+		}
+	}
+	return nil
+}
+
+func (r *RecoveryCodeRepository) Use(ctx context.Context, userID models.UserID, hash string, usedAt time.Time) error {
+	q := r.txManager.GetQueryEngine(ctx)
+	res, err := q.ExecContext(ctx, `
+		UPDATE recovery_codes SET used_at = $3
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`, userID.String(), hash, usedAt)
+	if err != nil {
+		r.logger.Error("failed to use recovery code", "user_id", userID, "error", err)
+		return errors.NewInternalError(err, "failed to use recovery code").WithDetails("user_id", userID.String())
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		r.logger.Warn("recovery code rejected", "user_id", userID)
+		return errors.NewTokenError(errors.ErrInvalidToken, "recovery code is invalid")
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/SamEkb/messenger-app/pkg/platform/postgres"
+)
+
+var _ ports.RefreshTokenRepository = (*RefreshTokenRepository)(nil)
+
+type RefreshTokenRepository struct {
+	txManager *postgres.TxManager
+	logger    logger.Logger
+}
+
+func NewRefreshTokenRepository(txManager *postgres.TxManager, logger logger.Logger) *RefreshTokenRepository {
+	return &RefreshTokenRepository{txManager: txManager, logger: logger.With("component", "refresh_token_repository")}
+}
+
+func (r *RefreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
+	r.logger.Debug("attempting to create refresh token", "user_id", token.UserID(), "family_id", token.FamilyID())
+	q := r.txManager.GetQueryEngine(ctx)
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO refresh_tokens (token_hash, user_id, family_id, expires_at)
+		VALUES ($1, $2, $3, $4)
+	`, token.Hash(), token.UserID().String(), token.FamilyID().String(), token.ExpiresAt())
+	if err != nil {
+		r.logger.Warn("refresh token already exists", "family_id", token.FamilyID())
+		return errors.NewAlreadyExistsError("refresh token already exists").WithDetails("family_id", token.FamilyID().String())
+	}
+	r.logger.Debug("refresh token created successfully", "user_id", token.UserID(), "family_id", token.FamilyID())
+	return nil
+}
+
+func (r *RefreshTokenRepository) GetByHash(ctx context.Context, hash string) (*models.RefreshToken, error) {
+	r.logger.Debug("getting refresh token")
+	q := r.txManager.GetQueryEngine(ctx)
+	var t struct {
+		Hash      string       `db:"token_hash"`
+		UserID    string       `db:"user_id"`
+		FamilyID  string       `db:"family_id"`
+		ExpiresAt time.Time    `db:"expires_at"`
+		UsedAt    sql.NullTime `db:"used_at"`
+		RevokedAt sql.NullTime `db:"revoked_at"`
+	}
+	err := q.GetContext(ctx, &t, `
+		SELECT token_hash, user_id, family_id, expires_at, used_at, revoked_at
+		FROM refresh_tokens WHERE token_hash = $1
+	`, hash)
+	if err != nil {
+		r.logger.Warn("refresh token not found")
+		return nil, errors.NewNotFoundError("refresh token not found")
+	}
+	userID, err := models.UserIDFromString(t.UserID)
+	if err != nil {
+		r.logger.Warn("failed to parse UUID", "UUID", t.UserID)
+		return nil, err
+	}
+	familyID, err := models.FamilyIDFromString(t.FamilyID)
+	if err != nil {
+		r.logger.Warn("failed to parse UUID", "UUID", t.FamilyID)
+		return nil, err
+	}
+	return models.RestoreRefreshToken(t.Hash, userID, familyID, t.ExpiresAt, t.UsedAt.Time, t.RevokedAt.Time)
+}
+
+func (r *RefreshTokenRepository) MarkUsed(ctx context.Context, hash string, usedAt time.Time) error {
+	r.logger.Debug("marking refresh token as used")
+	q := r.txManager.GetQueryEngine(ctx)
+	res, err := q.ExecContext(ctx, `
+		UPDATE refresh_tokens SET used_at = $2
+		WHERE token_hash = $1 AND used_at IS NULL AND revoked_at IS NULL
+	`, hash, usedAt)
+	if err != nil {
+		r.logger.Error("failed to mark refresh token as used", "error", err)
+		return errors.NewInternalError(err, "failed to mark refresh token as used")
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		r.logger.Warn("refresh token already used or revoked")
+		return errors.NewTokenError(errors.ErrInvalidToken, "refresh token already used")
+	}
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, familyID models.FamilyID, revokedAt time.Time) error {
+	r.logger.Debug("revoking refresh token family", "family_id", familyID)
+	q := r.txManager.GetQueryEngine(ctx)
+	_, err := q.ExecContext(ctx, `
+		UPDATE refresh_tokens SET revoked_at = $2
+		WHERE family_id = $1 AND revoked_at IS NULL
+	`, familyID.String(), revokedAt)
+	if err != nil {
+		r.logger.Error("failed to revoke refresh token family", "family_id", familyID, "error", err)
+		return errors.NewInternalError(err, "failed to revoke refresh tokens").WithDetails("family_id", familyID.String())
+	}
+	r.logger.Info("refresh token family revoked", "family_id", familyID)
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeByUser(ctx context.Context, userID models.UserID, revokedAt time.Time) error {
+	r.logger.Debug("revoking refresh tokens of user", "user_id", userID)
+	q := r.txManager.GetQueryEngine(ctx)
+	_, err := q.ExecContext(ctx, `
+		UPDATE refresh_tokens SET revoked_at = $2
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`, userID.String(), revokedAt)
+	if err != nil {
+		r.logger.Error("failed to revoke refresh tokens of user", "user_id", userID, "error", err)
+		return errors.NewInternalError(err, "failed to revoke refresh tokens").WithDetails("user_id", userID.String())
+	}
+	r.logger.Info("refresh tokens of user revoked", "user_id", userID)
+	return nil
+}
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/SamEkb/messenger-app/pkg/platform/postgres"
+)
+
+var _ ports.SecurityEventRepository = (*SecurityEventRepository)(nil)
+
+type SecurityEventRepository struct {
+	txManager *postgres.TxManager
+	logger    logger.Logger
+}
+
+func NewSecurityEventRepository(txManager *postgres.TxManager, logger logger.Logger) *SecurityEventRepository {
+	return &SecurityEventRepository{txManager: txManager, logger: logger.With("component", "security_event_repository")}
+}
+
+type securityEventRow struct {
+	ID         string         `db:"id"`
+	UserID     sql.NullString `db:"user_id"`
+	EventType  string         `db:"event_type"`
+	Email      string         `db:"email"`
+	IP         string         `db:"ip"`
+	UserAgent  string         `db:"user_agent"`
+	Reason     string         `db:"reason"`
+	ActorID    sql.NullString `db:"actor_id"`
+	OccurredAt time.Time      `db:"occurred_at"`
+}
+
+func (r securityEventRow) toModel() (*models.SecurityEvent, error) {
+	id, err := models.SecurityEventIDFromString(r.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var userID models.UserID
+	if r.UserID.Valid {
+		userID, err = models.UserIDFromString(r.UserID.String)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	client := models.SessionMetadata{UserAgent: r.UserAgent, IP: r.IP}
+	event := models.RestoreSecurityEvent(id, userID, models.SecurityEventType(r.EventType), r.Email, client,
+		r.Reason, r.OccurredAt)
+	if r.ActorID.Valid {
+		actorID, err := models.UserIDFromString(r.ActorID.String)
+		if err != nil {
+			return nil, err
+		}
+		event = event.WithActor(actorID)
+	}
+	return event, nil
+}
+
+func (r *SecurityEventRepository) Append(ctx context.Context, event *models.SecurityEvent) error {
+	var userID sql.NullString
+	if !event.UserID().IsEmpty() {
+		userID = sql.NullString{String: event.UserID().String(), Valid: true}
+	}
+	var actorID sql.NullString
+	if !event.ActorID().IsEmpty() {
+		actorID = sql.NullString{String: event.ActorID().String(), Valid: true}
+	}
+
+	q := r.txManager.GetQueryEngine(ctx)
+	client := event.Client()
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO security_events (id, user_id, event_type, email, ip, user_agent, reason, actor_id, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, event.ID().String(), userID, event.Type().String(), event.Email(), client.IP, client.UserAgent,
+		event.Reason(), actorID, event.OccurredAt())
+	if err != nil {
+		r.logger.Error("failed to append security event", "type", event.Type(), "error", err)
+		return errors.NewInternalError(err, "failed to append security event")
+	}
+	return nil
+}
+
+func (r *SecurityEventRepository) List(ctx context.Context, filter models.SecurityEventFilter) ([]*models.SecurityEvent, error) {
+	var (
+		conditions []string
+		args       []any
+	)
+	if !filter.UserID.IsEmpty() {
+		args = append(args, filter.UserID.String())
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", len(args)))
+	}
+	if !filter.Since.IsZero() {
+		args = append(args, filter.Since)
+		conditions = append(conditions, fmt.Sprintf("occurred_at >= $%d", len(args)))
+	}
+	if !filter.Until.IsZero() {
+		args = append(args, filter.Until)
+		conditions = append(conditions, fmt.Sprintf("occurred_at < $%d", len(args)))
+	}
+
+	query := `SELECT id, user_id, event_type, email, ip, user_agent, reason, actor_id, occurred_at FROM security_events`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY occurred_at DESC, id`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	q := r.txManager.GetQueryEngine(ctx)
+	var rows []securityEventRow
+	if err := q.SelectContext(ctx, &rows, query, args...); err != nil {
+		r.logger.Error("failed to list security events", "error", err)
+		return nil, errors.NewInternalError(err, "failed to list security events")
+	}
+
+	events := make([]*models.SecurityEvent, 0, len(rows))
+	for _, row := range rows {
+		event, err := row.toModel()
+		if err != nil {
+			r.logger.Warn("failed to parse security event", "id", row.ID, "error", err)
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
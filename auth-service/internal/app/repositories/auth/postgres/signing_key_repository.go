@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/SamEkb/messenger-app/pkg/platform/postgres"
+)
+
+var _ ports.SigningKeyRepository = (*SigningKeyRepository)(nil)
+
+type SigningKeyRepository struct {
+	txManager *postgres.TxManager
+	logger    logger.Logger
+}
+
+func NewSigningKeyRepository(txManager *postgres.TxManager, logger logger.Logger) *SigningKeyRepository {
+	return &SigningKeyRepository{txManager: txManager, logger: logger.With("component", "signing_key_repository")}
+}
+
+type signingKeyRow struct {
+	ID         string       `db:"id"`
+	Algorithm  string       `db:"algorithm"`
+	PrivateKey []byte       `db:"private_key"`
+	CreatedAt  time.Time    `db:"created_at"`
+	RetiredAt  sql.NullTime `db:"retired_at"`
+}
+
+func (row signingKeyRow) toModel() *models.SigningKey {
+	return models.RestoreSigningKey(row.ID, row.Algorithm, row.PrivateKey, row.CreatedAt, row.RetiredAt.Time)
+}
+
+// Lock takes a table lock that conflicts with itself but not with reads, so
+// that only one replica at a time decides whether to rotate.
+func (r *SigningKeyRepository) Lock(ctx context.Context) error {
+	q := r.txManager.GetQueryEngine(ctx)
+	if _, err := q.ExecContext(ctx, `LOCK TABLE signing_keys IN EXCLUSIVE MODE`); err != nil {
+		r.logger.Error("failed to lock signing keys", "error", err)
+		return errors.NewInternalError(err, "failed to lock signing keys")
+	}
+	return nil
+}
+
+func (r *SigningKeyRepository) GetCurrent(ctx context.Context) (*models.SigningKey, error) {
+	q := r.txManager.GetQueryEngine(ctx)
+	var row signingKeyRow
+	err := q.GetContext(ctx, &row, `
+		SELECT id, algorithm, private_key, created_at, retired_at
+		FROM signing_keys WHERE retired_at IS NULL
+	`)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.NewNotFoundError("signing key not found")
+		}
+		r.logger.Error("failed to get current signing key", "error", err)
+		return nil, errors.NewInternalError(err, "failed to get current signing key")
+	}
+	return row.toModel(), nil
+}
+
+func (r *SigningKeyRepository) Rotate(ctx context.Context, key *models.SigningKey) error {
+	q := r.txManager.GetQueryEngine(ctx)
+	_, err := q.ExecContext(ctx, `
+		UPDATE signing_keys SET retired_at = $1 WHERE retired_at IS NULL
+	`, key.CreatedAt())
+	if err != nil {
+		r.logger.Error("failed to retire signing key", "error", err)
+		return errors.NewInternalError(err, "failed to retire signing key")
+	}
+
+	_, err = q.ExecContext(ctx, `
+		INSERT INTO signing_keys (id, algorithm, private_key, created_at)
+		VALUES ($1, $2, $3, $4)
+	`, key.ID(), key.Algorithm(), key.PrivateKey(), key.CreatedAt())
+	if err != nil {
+		r.logger.Error("failed to save signing key", "kid", key.ID(), "error", err)
+		return errors.NewInternalError(err, "failed to save signing key").WithDetails("kid", key.ID())
+	}
+	return nil
+}
+
+func (r *SigningKeyRepository) ListPublished(ctx context.Context, retiredAfter time.Time) ([]*models.SigningKey, error) {
+	q := r.txManager.GetQueryEngine(ctx)
+	var rows []signingKeyRow
+	err := q.SelectContext(ctx, &rows, `
+		SELECT id, algorithm, private_key, created_at, retired_at
+		FROM signing_keys
+		WHERE retired_at IS NULL OR retired_at > $1
+		ORDER BY created_at DESC
+	`, retiredAfter)
+	if err != nil {
+		r.logger.Error("failed to list signing keys", "error", err)
+		return nil, errors.NewInternalError(err, "failed to list signing keys")
+	}
+
+	keys := make([]*models.SigningKey, 0, len(rows))
+	for _, row := range rows {
+		keys = append(keys, row.toModel())
+	}
+	return keys, nil
+}
+
+func (r *SigningKeyRepository) DeleteRetired(ctx context.Context, before time.Time) error {
+	q := r.txManager.GetQueryEngine(ctx)
+	_, err := q.ExecContext(ctx, `
+		DELETE FROM signing_keys WHERE retired_at < $1
+	`, before)
+	if err != nil {
+		r.logger.Error("failed to delete retired signing keys", "error", err)
+		return errors.NewInternalError(err, "failed to delete retired signing keys")
+	}
+	return nil
+}
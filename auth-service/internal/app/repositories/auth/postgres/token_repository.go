@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
@@ -18,73 +19,277 @@ type TokenRepository struct {
 	logger    logger.Logger
 }
 
+type tokenRow struct {
+	Token      string         `db:"token"`
+	UserID     string         `db:"user_id"`
+	FamilyID   string         `db:"family_id"`
+	ExpiresAt  time.Time      `db:"expires_at"`
+	DeviceName string         `db:"device_name"`
+	UserAgent  string         `db:"user_agent"`
+	IP         string         `db:"ip"`
+	ActorID    sql.NullString `db:"actor_id"`
+	CreatedAt  time.Time      `db:"created_at"`
+	LastUsedAt time.Time      `db:"last_used_at"`
+}
+
+const tokenColumns = `token, user_id, family_id, expires_at, device_name, user_agent, ip, actor_id, created_at, last_used_at`
+
+func (r tokenRow) toModel() (*models.AuthToken, error) {
+	userID, err := models.UserIDFromString(r.UserID)
+	if err != nil {
+		return nil, err
+	}
+	familyID, err := models.FamilyIDFromString(r.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	session := models.SessionMetadata{
+		DeviceName: r.DeviceName,
+		UserAgent:  r.UserAgent,
+		IP:         r.IP,
+	}
+	authToken, err := models.RestoreAuthToken(models.Token(r.Token), userID, familyID, r.ExpiresAt, session, r.CreatedAt, r.LastUsedAt)
+	if err != nil {
+		return nil, err
+	}
+	if r.ActorID.Valid {
+		actorID, err := models.UserIDFromString(r.ActorID.String)
+		if err != nil {
+			return nil, err
+		}
+		authToken = authToken.WithActor(actorID)
+	}
+	return authToken, nil
+}
+
 func NewTokenRepository(txManager *postgres.TxManager, logger logger.Logger) *TokenRepository {
 	return &TokenRepository{txManager: txManager, logger: logger.With("component", "token_repository")}
 }
 
 func (r *TokenRepository) Create(ctx context.Context, token *models.AuthToken) (*models.AuthToken, error) {
-	r.logger.Debug("attempting to create token", "token", token.Token(), "user_id", token.UserID())
+	r.logger.Debug("attempting to create token", "user_id", token.UserID())
 	q := r.txManager.GetQueryEngine(ctx)
+	session := token.Session()
+	var actorID sql.NullString
+	if token.IsImpersonation() {
+		actorID = sql.NullString{String: token.ActorID().String(), Valid: true}
+	}
 	_, err := q.ExecContext(ctx, `
-		INSERT INTO tokens (token, user_id, expires_at)
-		VALUES ($1, $2, $3)
-	`, token.Token(), token.UserID(), token.ExpiresAt())
+		INSERT INTO tokens (token, user_id, family_id, expires_at, device_name, user_agent, ip, actor_id, created_at, last_used_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`, token.Token(), token.UserID().String(), token.FamilyID().String(), token.ExpiresAt(),
+		session.DeviceName, session.UserAgent, session.IP, actorID, token.CreatedAt(), token.LastUsedAt())
 	if err != nil {
-		r.logger.Warn("token already exists", "token", token.Token())
-		return nil, errors.NewAlreadyExistsError("token already exists").WithDetails("token", token.Token())
+		r.logger.Warn("failed to create token", "user_id", token.UserID(), "error", err)
+		return nil, errors.NewAlreadyExistsError("token already exists").WithDetails("user_id", token.UserID().String())
 	}
-	r.logger.Debug("token created successfully", "token", token.Token(), "user_id", token.UserID())
+	r.logger.Debug("token created successfully", "user_id", token.UserID())
 	return token, nil
 }
 
 func (r *TokenRepository) FindToken(ctx context.Context, token string) (models.Token, error) {
-	r.logger.Debug("looking for token", "token", token)
+	r.logger.Debug("looking for token")
 	q := r.txManager.GetQueryEngine(ctx)
 	var t string
 	err := q.GetContext(ctx, &t, `
 		SELECT token FROM tokens WHERE token = $1
 	`, token)
 	if err != nil {
-		r.logger.Warn("token not found", "token", token)
-		return "", errors.NewNotFoundError("token not found").WithDetails("token", token)
+		return "", r.lookupError(err)
 	}
-	r.logger.Debug("token found", "token", token)
+	r.logger.Debug("token found")
 	return models.Token(t), nil
 }
 
+func (r *TokenRepository) GetToken(ctx context.Context, token models.Token) (*models.AuthToken, error) {
+	r.logger.Debug("getting token")
+	q := r.txManager.GetQueryEngine(ctx)
+	var t tokenRow
+	err := q.GetContext(ctx, &t, `
+		SELECT `+tokenColumns+` FROM tokens WHERE token = $1
+	`, token)
+	if err != nil {
+		return nil, r.lookupError(err)
+	}
+	authToken, err := t.toModel()
+	if err != nil {
+		r.logger.Warn("failed to map token", "error", err)
+		return nil, err
+	}
+	return authToken, nil
+}
+
 func (r *TokenRepository) ValidateToken(ctx context.Context, token string) (bool, error) {
-	r.logger.Debug("validating token", "token", token)
+	r.logger.Debug("validating token")
 	q := r.txManager.GetQueryEngine(ctx)
 	var expiresAt time.Time
 	err := q.GetContext(ctx, &expiresAt, `
 		SELECT expires_at FROM tokens WHERE token = $1
 	`, token)
 	if err != nil {
-		r.logger.Warn("token not found during validation", "token", token)
-		return false, errors.NewNotFoundError("token not found").WithDetails("token", token)
+		return false, r.lookupError(err)
 	}
 	if time.Now().After(expiresAt) {
-		r.logger.Debug("token is expired", "token", token, "expires_at", expiresAt)
-		return false, errors.NewTokenError(errors.ErrTokenExpired, "token is expired").WithDetails("token", token).WithDetails("expires_at", expiresAt)
+		r.logger.Debug("token is expired", "expires_at", expiresAt)
+		return false, errors.NewTokenError(errors.ErrTokenExpired, "token is expired").WithDetails("expires_at", expiresAt)
 	}
-	r.logger.Debug("token is valid", "token", token)
+	r.logger.Debug("token is valid")
 	return true, nil
 }
 
 func (r *TokenRepository) DeleteToken(ctx context.Context, token models.Token) error {
-	r.logger.Debug("attempting to delete token", "token", token)
+	r.logger.Debug("attempting to delete token")
 	q := r.txManager.GetQueryEngine(ctx)
 	res, err := q.ExecContext(ctx, `
 		DELETE FROM tokens WHERE token = $1
 	`, token)
 	if err != nil {
-		r.logger.Warn("token not found for deletion", "token", token)
-		return errors.NewNotFoundError("token not found").WithDetails("token", token)
+		r.logger.Error("failed to delete token", "error", err)
+		return errors.NewInternalError(err, "failed to delete token")
 	}
 	if rows, _ := res.RowsAffected(); rows == 0 {
-		r.logger.Warn("token not found for deletion", "token", token)
-		return errors.NewNotFoundError("token not found").WithDetails("token", token)
+		r.logger.Warn("token not found for deletion")
+		return errors.NewNotFoundError("token not found")
+	}
+	r.logger.Info("token deleted successfully")
+	return nil
+}
+
+// lookupError keeps a missing token apart from a failed query, so that a
+// database outage does not make every token look revoked. The token itself is
+// a credential and is never logged or returned in error details.
+func (r *TokenRepository) lookupError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		r.logger.Debug("token not found")
+		return errors.NewNotFoundError("token not found")
+	}
+	r.logger.Error("failed to look up token", "error", err)
+	return errors.NewInternalError(err, "failed to look up token")
+}
+
+func (r *TokenRepository) DeleteTokensByFamily(ctx context.Context, familyID models.FamilyID) error {
+	r.logger.Debug("deleting tokens by family", "family_id", familyID)
+	q := r.txManager.GetQueryEngine(ctx)
+	_, err := q.ExecContext(ctx, `
+		DELETE FROM tokens WHERE family_id = $1
+	`, familyID.String())
+	if err != nil {
+		r.logger.Error("failed to delete tokens by family", "family_id", familyID, "error", err)
+		return errors.NewInternalError(err, "failed to delete tokens").WithDetails("family_id", familyID.String())
+	}
+	r.logger.Debug("tokens deleted by family", "family_id", familyID)
+	return nil
+}
+
+func (r *TokenRepository) DeleteTokensByUser(ctx context.Context, userID models.UserID) error {
+	r.logger.Debug("deleting tokens by user", "user_id", userID)
+	q := r.txManager.GetQueryEngine(ctx)
+	_, err := q.ExecContext(ctx, `
+		DELETE FROM tokens WHERE user_id = $1
+	`, userID.String())
+	if err != nil {
+		r.logger.Error("failed to delete tokens by user", "user_id", userID, "error", err)
+		return errors.NewInternalError(err, "failed to delete tokens").WithDetails("user_id", userID.String())
 	}
-	r.logger.Info("token deleted successfully", "token", token)
+	r.logger.Info("tokens deleted by user", "user_id", userID)
 	return nil
 }
+
+func (r *TokenRepository) GetSession(ctx context.Context, familyID models.FamilyID) (*models.AuthToken, error) {
+	r.logger.Debug("getting session", "family_id", familyID)
+	q := r.txManager.GetQueryEngine(ctx)
+	var t tokenRow
+	err := q.GetContext(ctx, &t, `
+		SELECT `+tokenColumns+` FROM tokens WHERE family_id = $1
+	`, familyID.String())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			r.logger.Warn("session not found", "family_id", familyID)
+			return nil, errors.NewNotFoundError("session not found").WithDetails("session_id", familyID.String())
+		}
+		r.logger.Error("failed to get session", "family_id", familyID, "error", err)
+		return nil, errors.NewInternalError(err, "failed to get session").WithDetails("session_id", familyID.String())
+	}
+	authToken, err := t.toModel()
+	if err != nil {
+		r.logger.Warn("failed to map session", "error", err)
+		return nil, err
+	}
+	return authToken, nil
+}
+
+func (r *TokenRepository) ListSessions(ctx context.Context, userID models.UserID) ([]*models.AuthToken, error) {
+	r.logger.Debug("listing sessions", "user_id", userID)
+	q := r.txManager.GetQueryEngine(ctx)
+	var rows []tokenRow
+	err := q.SelectContext(ctx, &rows, `
+		SELECT `+tokenColumns+` FROM tokens
+		WHERE user_id = $1
+		ORDER BY last_used_at DESC
+	`, userID.String())
+	if err != nil {
+		r.logger.Error("failed to list sessions", "user_id", userID, "error", err)
+		return nil, errors.NewInternalError(err, "failed to list sessions").WithDetails("user_id", userID.String())
+	}
+	sessions := make([]*models.AuthToken, 0, len(rows))
+	for _, row := range rows {
+		session, err := row.toModel()
+		if err != nil {
+			r.logger.Warn("failed to map session", "error", err)
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+func (r *TokenRepository) RotateToken(ctx context.Context, familyID models.FamilyID, token models.Token, expiresAt time.Time) error {
+	r.logger.Debug("rotating session token", "family_id", familyID)
+	q := r.txManager.GetQueryEngine(ctx)
+	res, err := q.ExecContext(ctx, `
+		UPDATE tokens SET token = $2, expires_at = $3, last_used_at = now()
+		WHERE family_id = $1
+	`, familyID.String(), token, expiresAt)
+	if err != nil {
+		r.logger.Error("failed to rotate session token", "family_id", familyID, "error", err)
+		return errors.NewInternalError(err, "failed to rotate token").WithDetails("session_id", familyID.String())
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		r.logger.Warn("session not found for rotation", "family_id", familyID)
+		return errors.NewNotFoundError("session not found").WithDetails("session_id", familyID.String())
+	}
+	return nil
+}
+
+func (r *TokenRepository) TouchToken(ctx context.Context, token models.Token, usedAt time.Time) error {
+	q := r.txManager.GetQueryEngine(ctx)
+	_, err := q.ExecContext(ctx, `
+		UPDATE tokens SET last_used_at = $2 WHERE token = $1
+	`, token, usedAt)
+	if err != nil {
+		r.logger.Error("failed to update token last use", "error", err)
+		return errors.NewInternalError(err, "failed to update token")
+	}
+	return nil
+}
+
+func (r *TokenRepository) DeleteExpired(ctx context.Context, before time.Time, limit int) (int, error) {
+	q := r.txManager.GetQueryEngine(ctx)
+	res, err := q.ExecContext(ctx, `
+		DELETE FROM tokens WHERE token IN (
+			SELECT token FROM tokens WHERE expires_at < $1
+			ORDER BY expires_at
+			LIMIT $2
+		)
+	`, before, limit)
+	if err != nil {
+		r.logger.Error("failed to delete expired tokens", "error", err)
+		return 0, errors.NewInternalError(err, "failed to delete expired tokens")
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.NewInternalError(err, "failed to get rows affected")
+	}
+	return int(rows), nil
+}
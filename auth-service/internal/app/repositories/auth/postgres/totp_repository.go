@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/SamEkb/messenger-app/pkg/platform/postgres"
+)
+
+var _ ports.TOTPRepository = (*TOTPRepository)(nil)
+
+type TOTPRepository struct {
+	txManager *postgres.TxManager
+	logger    logger.Logger
+}
+
+func NewTOTPRepository(txManager *postgres.TxManager, logger logger.Logger) *TOTPRepository {
+	return &TOTPRepository{txManager: txManager, logger: logger.With("component", "totp_repository")}
+}
+
+func (r *TOTPRepository) Save(ctx context.Context, factor *models.TOTPFactor) error {
+	r.logger.Debug("attempting to save totp factor", "user_id", factor.UserID())
+	q := r.txManager.GetQueryEngine(ctx)
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO totp_factors (user_id, secret, confirmed_at, last_used_step)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret,
+		    confirmed_at = EXCLUDED.confirmed_at,
+		    last_used_step = EXCLUDED.last_used_step,
+		    created_at = now()
+	`, factor.UserID().String(), factor.Secret(), nullTime(factor.ConfirmedAt()), factor.LastUsedStep())
+	if err != nil {
+		r.logger.Error("failed to save totp factor", "user_id", factor.UserID(), "error", err)
+		return errors.NewInternalError(err, "failed to save totp factor").WithDetails("user_id", factor.UserID().String())
+	}
+	return nil
+}
+
+func (r *TOTPRepository) GetByUser(ctx context.Context, userID models.UserID) (*models.TOTPFactor, error) {
+	q := r.txManager.GetQueryEngine(ctx)
+	var f struct {
+		Secret       string       `db:"secret"`
+		ConfirmedAt  sql.NullTime `db:"confirmed_at"`
+		LastUsedStep int64        `db:"last_used_step"`
+	}
+	err := q.GetContext(ctx, &f, `
+		SELECT secret, confirmed_at, last_used_step
+		FROM totp_factors WHERE user_id = $1
+	`, userID.String())
+	if err != nil {
+		r.logger.Debug("totp factor not found", "user_id", userID)
+		return nil, errors.NewNotFoundError("totp factor not found")
+	}
+	return models.RestoreTOTPFactor(userID, f.Secret, f.ConfirmedAt.Time, f.LastUsedStep)
+}
+
+func (r *TOTPRepository) Update(ctx context.Context, factor *models.TOTPFactor) error {
+	q := r.txManager.GetQueryEngine(ctx)
+	res, err := q.ExecContext(ctx, `
+		UPDATE totp_factors SET confirmed_at = $2, last_used_step = $3
+		WHERE user_id = $1
+	`, factor.UserID().String(), nullTime(factor.ConfirmedAt()), factor.LastUsedStep())
+	if err != nil {
+		r.logger.Error("failed to update totp factor", "user_id", factor.UserID(), "error", err)
+		return errors.NewInternalError(err, "failed to update totp factor").WithDetails("user_id", factor.UserID().String())
+	}
+	if rows, _ := res.RowsAffected(); rows == 0 {
+		return errors.NewNotFoundError("totp factor not found")
+	}
+	return nil
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// CreateAPIKey creates a named key limited to the given scopes. The key is
+// returned once; only its hash is stored.
+func (a *UseCase) CreateAPIKey(ctx context.Context, dto *ports.CreateAPIKeyDto) (*ports.CreatedAPIKeyDto, error) {
+	userID, _, err := callerFromContext(ctx)
+	if err != nil {
+		a.logger.Warn("unauthenticated create API key attempt", "error", err)
+		return nil, err
+	}
+
+	a.logger.Debug("create API key attempt", "user_id", userID, "scopes", dto.Scopes)
+
+	scopes, err := models.ParseScopes(dto.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := models.GenerateAPIKey()
+	if err != nil {
+		a.logger.Error("failed to generate API key", "error", err)
+		return nil, err
+	}
+
+	apiKey, err := models.NewAPIKey(userID, dto.Name, key, scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.apiKeyRepo.Create(ctx, apiKey); err != nil {
+		a.logger.Error("failed to save API key", "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	a.logger.Info("API key created", "user_id", userID, "key_id", apiKey.ID())
+	return &ports.CreatedAPIKeyDto{
+		APIKey: apiKeyToDto(apiKey),
+		Key:    key,
+	}, nil
+}
+
+func (a *UseCase) ListAPIKeys(ctx context.Context) ([]*ports.APIKeyDto, error) {
+	userID, _, err := callerFromContext(ctx)
+	if err != nil {
+		a.logger.Warn("unauthenticated list API keys attempt", "error", err)
+		return nil, err
+	}
+
+	keys, err := a.apiKeyRepo.ListByUser(ctx, userID)
+	if err != nil {
+		a.logger.Error("failed to list API keys", "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	result := make([]*ports.APIKeyDto, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, apiKeyToDto(key))
+	}
+	return result, nil
+}
+
+// RevokeAPIKey revokes one of the caller's keys. Someone else's key is
+// reported as missing so its existence is not leaked.
+func (a *UseCase) RevokeAPIKey(ctx context.Context, keyID models.APIKeyID) error {
+	userID, _, err := callerFromContext(ctx)
+	if err != nil {
+		a.logger.Warn("unauthenticated revoke API key attempt", "error", err)
+		return err
+	}
+
+	if err := a.apiKeyRepo.Revoke(ctx, userID, keyID, time.Now()); err != nil {
+		a.logger.Warn("failed to revoke API key", "user_id", userID, "key_id", keyID, "error", err)
+		return err
+	}
+
+	a.logger.Info("API key revoked", "user_id", userID, "key_id", keyID)
+	return nil
+}
+
+// validateAPIKey introspects an API key. Keys act with the plain user role
+// whatever roles their owner holds, so a leaked key cannot be used for
+// administration.
+func (a *UseCase) validateAPIKey(ctx context.Context, token models.Token) (*ports.TokenInfoDto, error) {
+	apiKey, err := a.apiKeyRepo.GetByHash(ctx, models.HashToken(token))
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			a.logger.Debug("API key not found")
+			return &ports.TokenInfoDto{Active: false}, nil
+		}
+		a.logger.Error("failed to get API key", "error", err)
+		return nil, err
+	}
+
+	if apiKey.IsRevoked() {
+		a.logger.Debug("API key revoked", "key_id", apiKey.ID())
+		return &ports.TokenInfoDto{Active: false}, nil
+	}
+
+	access, err := a.authRepo.FindUserAccess(ctx, apiKey.UserID())
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			a.logger.Warn("API key of unknown user", "user_id", apiKey.UserID())
+			return &ports.TokenInfoDto{Active: false}, nil
+		}
+		a.logger.Error("failed to get API key owner", "user_id", apiKey.UserID(), "error", err)
+		return nil, err
+	}
+	if access.IsSuspended() {
+		a.logger.Warn("API key of suspended user", "user_id", apiKey.UserID())
+		return &ports.TokenInfoDto{Active: false}, nil
+	}
+
+	if time.Since(apiKey.LastUsedAt()) > sessionTouchInterval {
+		if err := a.apiKeyRepo.Touch(ctx, apiKey.ID(), time.Now()); err != nil {
+			a.logger.Warn("failed to update API key last use", "error", err)
+		}
+	}
+
+	a.logger.Debug("API key validated", "user_id", apiKey.UserID(), "key_id", apiKey.ID())
+	return &ports.TokenInfoDto{
+		UserID:   apiKey.UserID(),
+		APIKeyID: apiKey.ID(),
+		Roles:    []models.Role{models.RoleUser},
+		Scopes:   apiKey.Scopes(),
+		Active:   true,
+	}, nil
+}
+
+func apiKeyToDto(key *models.APIKey) *ports.APIKeyDto {
+	return &ports.APIKeyDto{
+		ID:         key.ID(),
+		Name:       key.Name(),
+		Hint:       key.Hint(),
+		Scopes:     key.Scopes(),
+		CreatedAt:  key.CreatedAt(),
+		LastUsedAt: key.LastUsedAt(),
+	}
+}
@@ -0,0 +1,182 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUseCase_CreateAPIKey(t *testing.T) {
+	userID := models.UserID(uuid.New())
+
+	ctx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: userID.String(),
+	})
+
+	type args struct {
+		ctx context.Context
+		dto *ports.CreateAPIKeyDto
+	}
+	tests := map[string]struct {
+		args        args
+		wantScopes  []platformauth.Scope
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"key created": {
+			args:       args{ctx: ctx, dto: &ports.CreateAPIKeyDto{Name: "ci bot", Scopes: []string{"friends:read", "chat:write", "chat:write"}}},
+			wantScopes: []platformauth.Scope{platformauth.ScopeChatWrite, platformauth.ScopeFriendsRead},
+			deps: func(t *testing.T) UseCase {
+				mockAPIKeyRepo := mocks.NewAPIKeyRepository(t)
+				mockAPIKeyRepo.EXPECT().
+					Create(ctx, mock.MatchedBy(func(key *models.APIKey) bool {
+						return key.UserID() == userID && key.Name() == "ci bot" && key.Hash() != ""
+					})).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					apiKeyRepo: mockAPIKeyRepo,
+					logger:     logger.NewMockLogger(),
+				}
+			},
+		},
+		"unknown scope": {
+			args:        args{ctx: ctx, dto: &ports.CreateAPIKeyDto{Name: "ci bot", Scopes: []string{"auth:admin"}}},
+			wantErr:     true,
+			expectedErr: customerrors.NewInvalidInputError("unknown scope"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					logger: logger.NewMockLogger(),
+				}
+			},
+		},
+		"no scopes": {
+			args:        args{ctx: ctx, dto: &ports.CreateAPIKeyDto{Name: "ci bot"}},
+			wantErr:     true,
+			expectedErr: customerrors.NewInvalidInputError("at least one scope is required"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					logger: logger.NewMockLogger(),
+				}
+			},
+		},
+		"empty name": {
+			args:        args{ctx: ctx, dto: &ports.CreateAPIKeyDto{Name: "  ", Scopes: []string{"chat:write"}}},
+			wantErr:     true,
+			expectedErr: customerrors.NewInvalidInputError("name cannot be empty"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					logger: logger.NewMockLogger(),
+				}
+			},
+		},
+		"unauthenticated": {
+			args:        args{ctx: context.Background(), dto: &ports.CreateAPIKeyDto{Name: "ci bot", Scopes: []string{"chat:write"}}},
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("request is not authenticated"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					logger: logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			created, err := useCase.CreateAPIKey(tc.args.ctx, tc.args.dto)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, models.IsAPIKey(created.Key))
+			assert.Equal(t, tc.wantScopes, created.APIKey.Scopes)
+			assert.Equal(t, string(created.Key[len(created.Key)-4:]), created.APIKey.Hint)
+		})
+	}
+}
+
+func TestUseCase_RevokeAPIKey(t *testing.T) {
+	userID := models.UserID(uuid.New())
+	keyID := models.APIKeyID(uuid.New())
+
+	ctx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: userID.String(),
+	})
+
+	tests := map[string]struct {
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"key revoked": {
+			deps: func(t *testing.T) UseCase {
+				mockAPIKeyRepo := mocks.NewAPIKeyRepository(t)
+				mockAPIKeyRepo.EXPECT().
+					Revoke(ctx, userID, keyID, mock.AnythingOfType("time.Time")).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					apiKeyRepo: mockAPIKeyRepo,
+					logger:     logger.NewMockLogger(),
+				}
+			},
+		},
+		"key not found": {
+			wantErr:     true,
+			expectedErr: customerrors.NewNotFoundError("API key not found"),
+			deps: func(t *testing.T) UseCase {
+				mockAPIKeyRepo := mocks.NewAPIKeyRepository(t)
+				mockAPIKeyRepo.EXPECT().
+					Revoke(ctx, userID, keyID, mock.AnythingOfType("time.Time")).
+					Return(customerrors.NewNotFoundError("API key not found")).
+					Once()
+
+				return UseCase{
+					apiKeyRepo: mockAPIKeyRepo,
+					logger:     logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			err := useCase.RevokeAPIKey(ctx, keyID)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
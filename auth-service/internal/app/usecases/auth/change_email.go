@@ -0,0 +1,175 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// ChangeEmail mails a confirmation code to the new address of the caller.
+// The account keeps its current address until the code is confirmed, and
+// only the most recently requested change can be confirmed.
+func (a *UseCase) ChangeEmail(ctx context.Context, dto *ports.ChangeEmailDto) (time.Time, error) {
+	userID, _, err := callerFromContext(ctx)
+	if err != nil {
+		a.logger.Warn("unauthenticated change email attempt", "error", err)
+		return time.Time{}, err
+	}
+
+	a.logger.Debug("change email attempt", "user_id", userID)
+
+	newEmail := strings.TrimSpace(dto.NewEmail)
+	if address, err := mail.ParseAddress(newEmail); err != nil || address.Address != newEmail {
+		return time.Time{}, errors.NewValidationError("new email is not a valid address").
+			WithDetails("field", "new_email")
+	}
+
+	user, err := a.authRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		a.logger.Warn("user not found during email change", "user_id", userID, "error", err)
+		return time.Time{}, err
+	}
+
+	if err := a.passwordHasher.Compare([]byte(user.Password()), dto.CurrentPassword); err != nil {
+		a.logger.Warn("invalid current password", "user_id", userID)
+		return time.Time{}, errors.NewUnauthorizedError("invalid credentials")
+	}
+
+	if newEmail == user.Email() {
+		return time.Time{}, errors.NewInvalidInputError("new email is the same as the current one").
+			WithDetails("field", "new_email")
+	}
+
+	if err := a.ensureEmailAvailable(ctx, userID, newEmail); err != nil {
+		return time.Time{}, err
+	}
+
+	code, err := models.GenerateSecretToken()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	expiresAt := time.Now().Add(a.emailVerificationTTL)
+	changeCode, err := models.NewOneTimeCode(models.HashToken(code), userID, models.CodePurposeEmailChange, expiresAt)
+	if err != nil {
+		return time.Time{}, errors.NewInternalError(err, "failed to create confirmation code")
+	}
+
+	err = a.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		if err := a.codeRepo.InvalidateByUser(txCtx, userID, models.CodePurposeEmailChange, time.Now()); err != nil {
+			return err
+		}
+		return a.codeRepo.Create(txCtx, changeCode.WithEmail(newEmail))
+	})
+	if err != nil {
+		a.logger.Error("failed to store email change code", "user_id", userID, "error", err)
+		return time.Time{}, err
+	}
+
+	message := &ports.MailMessage{
+		To:      newEmail,
+		Subject: "Confirm your new email address",
+		Body: fmt.Sprintf(
+			"Use this code to confirm your new email address: %s\nThe code expires at %s.\n"+
+				"If you did not ask to change your email, ignore this message.",
+			code, expiresAt.UTC().Format(time.RFC1123),
+		),
+	}
+	if err := a.mailer.Send(ctx, message); err != nil {
+		a.logger.Error("failed to send email change code", "user_id", userID, "error", err)
+		return time.Time{}, errors.NewServiceError(err, "failed to send confirmation code")
+	}
+
+	a.logger.Info("email change code sent", "user_id", userID)
+	return expiresAt, nil
+}
+
+// ConfirmEmailChange switches the account to the address the code was sent
+// to. The new address counts as verified.
+func (a *UseCase) ConfirmEmailChange(ctx context.Context, code models.Token) error {
+	a.logger.Debug("email change confirmation attempt")
+
+	hash := models.HashToken(code)
+
+	changeCode, err := a.codeRepo.GetByHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			a.logger.Warn("unknown email change code")
+			return errors.NewTokenError(errors.ErrInvalidToken, "confirmation code is invalid")
+		}
+		a.logger.Error("failed to get email change code", "error", err)
+		return err
+	}
+
+	if changeCode.Purpose() != models.CodePurposeEmailChange || changeCode.IsUsed() || changeCode.Email() == "" {
+		a.logger.Warn("email change code rejected", "user_id", changeCode.UserID(), "used", changeCode.IsUsed())
+		return errors.NewTokenError(errors.ErrInvalidToken, "confirmation code is invalid")
+	}
+
+	if changeCode.IsExpired() {
+		a.logger.Warn("email change code expired", "user_id", changeCode.UserID())
+		return errors.NewTokenError(errors.ErrTokenExpired, "confirmation code is expired")
+	}
+
+	var previousEmail string
+	var user *models.User
+	err = a.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		if err := a.codeRepo.MarkUsed(txCtx, hash, time.Now()); err != nil {
+			return err
+		}
+
+		user, err = a.authRepo.FindUserByID(txCtx, changeCode.UserID())
+		if err != nil {
+			return err
+		}
+		previousEmail = user.Email()
+
+		// The address may have been taken since the code was sent.
+		if err := a.ensureEmailAvailable(txCtx, user.ID(), changeCode.Email()); err != nil {
+			return err
+		}
+
+		user, err = user.WithEmail(changeCode.Email())
+		if err != nil {
+			return err
+		}
+		if err := a.authRepo.Update(txCtx, user); err != nil {
+			return err
+		}
+		return a.enqueueCredentialsChanged(txCtx, user)
+	})
+	if err != nil {
+		a.logger.Error("failed to change email", "user_id", changeCode.UserID(), "error", err)
+		return err
+	}
+
+	a.recordSecurityEvent(ctx, models.NewSecurityEvent(models.SecurityEventEmailChanged, user.ID(), user.Email(),
+		models.ClientFromContext(ctx)))
+	a.logger.Info("email changed", "user_id", user.ID(), "previous_email", previousEmail, "email", user.Email())
+	return nil
+}
+
+// ensureEmailAvailable fails unless the address is free or already belongs
+// to the given user.
+func (a *UseCase) ensureEmailAvailable(ctx context.Context, userID models.UserID, email string) error {
+	owner, err := a.authRepo.FindUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return nil
+		}
+		a.logger.Error("failed to look up email owner", "error", err)
+		return err
+	}
+	if owner.ID() != userID {
+		a.logger.Warn("email already in use", "user_id", userID)
+		return errors.NewAlreadyExistsError("email is already in use").
+			WithDetails("field", "new_email")
+	}
+	return nil
+}
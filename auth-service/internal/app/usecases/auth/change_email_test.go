@@ -0,0 +1,337 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
+	"github.com/SamEkb/messenger-app/pkg/api/events/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestUseCase_ChangeEmail(t *testing.T) {
+	userID := models.UserID(uuid.New())
+	ctx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: userID.String(),
+	})
+
+	password := "currentPassword"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	user, err := models.NewUser(userID, "testuser", "test@test.ru", hashedPassword)
+	assert.NoError(t, err)
+	otherUser := newUser(t, models.UserID(uuid.New()))
+
+	type args struct {
+		ctx context.Context
+		dto *ports.ChangeEmailDto
+	}
+	tests := map[string]struct {
+		args        args
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"confirmation code sent": {
+			args: args{ctx: ctx, dto: &ports.ChangeEmailDto{NewEmail: "new@test.ru", CurrentPassword: password}},
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByID(ctx, userID).Return(user, nil).Once()
+				mockAuthRepo.EXPECT().FindUserByEmail(ctx, "new@test.ru").
+					Return(nil, customerrors.NewNotFoundError("user not found")).
+					Once()
+
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().
+					InvalidateByUser(ctx, userID, models.CodePurposeEmailChange, mock.AnythingOfType("time.Time")).
+					Return(nil).
+					Once()
+				mockCodeRepo.EXPECT().
+					Create(ctx, mock.MatchedBy(func(code *models.OneTimeCode) bool {
+						return code.UserID() == userID &&
+							code.Purpose() == models.CodePurposeEmailChange &&
+							code.Email() == "new@test.ru"
+					})).
+					Return(nil).
+					Once()
+
+				mockMailer := mocks.NewMailer(t)
+				mockMailer.EXPECT().
+					Send(ctx, mock.MatchedBy(func(message *ports.MailMessage) bool {
+						return message.To == "new@test.ru"
+					})).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					txManager:            newTxManager(t),
+					authRepo:             mockAuthRepo,
+					codeRepo:             mockCodeRepo,
+					passwordHasher:       newPasswordHasher(t),
+					mailer:               mockMailer,
+					emailVerificationTTL: time.Hour,
+					logger:               logger.NewMockLogger(),
+				}
+			},
+		},
+		"invalid address": {
+			args:        args{ctx: ctx, dto: &ports.ChangeEmailDto{NewEmail: "not an email", CurrentPassword: password}},
+			wantErr:     true,
+			expectedErr: customerrors.NewValidationError("new email is not a valid address"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{logger: logger.NewMockLogger()}
+			},
+		},
+		"wrong current password": {
+			args:        args{ctx: ctx, dto: &ports.ChangeEmailDto{NewEmail: "new@test.ru", CurrentPassword: "wrong"}},
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("invalid credentials"),
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByID(ctx, userID).Return(user, nil).Once()
+
+				return UseCase{
+					authRepo:       mockAuthRepo,
+					passwordHasher: newPasswordHasher(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"address taken": {
+			args:        args{ctx: ctx, dto: &ports.ChangeEmailDto{NewEmail: "new@test.ru", CurrentPassword: password}},
+			wantErr:     true,
+			expectedErr: customerrors.NewAlreadyExistsError("email is already in use"),
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByID(ctx, userID).Return(user, nil).Once()
+				mockAuthRepo.EXPECT().FindUserByEmail(ctx, "new@test.ru").Return(otherUser, nil).Once()
+
+				return UseCase{
+					authRepo:       mockAuthRepo,
+					passwordHasher: newPasswordHasher(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"mailer failure": {
+			args:    args{ctx: ctx, dto: &ports.ChangeEmailDto{NewEmail: "new@test.ru", CurrentPassword: password}},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByID(ctx, userID).Return(user, nil).Once()
+				mockAuthRepo.EXPECT().FindUserByEmail(ctx, "new@test.ru").
+					Return(nil, customerrors.NewNotFoundError("user not found")).
+					Once()
+
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().
+					InvalidateByUser(ctx, userID, models.CodePurposeEmailChange, mock.AnythingOfType("time.Time")).
+					Return(nil).
+					Once()
+				mockCodeRepo.EXPECT().Create(ctx, mock.AnythingOfType("*models.OneTimeCode")).Return(nil).Once()
+
+				mockMailer := mocks.NewMailer(t)
+				mockMailer.EXPECT().Send(ctx, mock.Anything).Return(errors.New("smtp down")).Once()
+
+				return UseCase{
+					txManager:            newTxManager(t),
+					authRepo:             mockAuthRepo,
+					codeRepo:             mockCodeRepo,
+					passwordHasher:       newPasswordHasher(t),
+					mailer:               mockMailer,
+					emailVerificationTTL: time.Hour,
+					logger:               logger.NewMockLogger(),
+				}
+			},
+		},
+		"unauthenticated": {
+			args:    args{ctx: context.Background(), dto: &ports.ChangeEmailDto{NewEmail: "new@test.ru", CurrentPassword: password}},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				return UseCase{logger: logger.NewMockLogger()}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			expiresAt, err := useCase.ChangeEmail(tc.args.ctx, tc.args.dto)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, time.Minute)
+			}
+		})
+	}
+}
+
+func TestUseCase_ConfirmEmailChange(t *testing.T) {
+	ctx := context.Background()
+
+	userID := models.UserID(uuid.New())
+	user := newUser(t, userID)
+	otherUser := newUser(t, models.UserID(uuid.New()))
+
+	code := models.Token("email-change-code")
+	hash := models.HashToken(code)
+
+	newCode := func(purpose models.CodePurpose, expiresAt time.Time) *models.OneTimeCode {
+		oneTimeCode, err := models.NewOneTimeCode(hash, userID, purpose, expiresAt)
+		assert.NoError(t, err)
+		return oneTimeCode.WithEmail("new@test.ru")
+	}
+	validCode := newCode(models.CodePurposeEmailChange, time.Now().Add(time.Hour))
+	expiredCode := newCode(models.CodePurposeEmailChange, time.Now().Add(-time.Hour))
+	verificationCode := newCode(models.CodePurposeEmailVerification, time.Now().Add(time.Hour))
+
+	type args struct {
+		ctx  context.Context
+		code models.Token
+	}
+	tests := map[string]struct {
+		args        args
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"email changed": {
+			args: args{ctx: ctx, code: code},
+			deps: func(t *testing.T) UseCase {
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().GetByHash(ctx, hash).Return(validCode, nil).Once()
+				mockCodeRepo.EXPECT().MarkUsed(ctx, hash, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByID(ctx, userID).Return(user, nil).Once()
+				mockAuthRepo.EXPECT().FindUserByEmail(ctx, "new@test.ru").
+					Return(nil, customerrors.NewNotFoundError("user not found")).
+					Once()
+				mockAuthRepo.EXPECT().
+					Update(ctx, mock.MatchedBy(func(u *models.User) bool {
+						return u.ID() == userID && u.Email() == "new@test.ru" && u.EmailVerified()
+					})).
+					Return(nil).
+					Once()
+
+				mockOutbox := mocks.NewUserEventsOutbox(t)
+				mockOutbox.EXPECT().
+					EnqueueUserCredentialsChangedEvent(ctx, mock.MatchedBy(func(event *events.UserCredentialsChangedEvent) bool {
+						return event.GetUserId() == userID.String() &&
+							event.GetEmail() == "new@test.ru" &&
+							event.GetUsername() == user.Username()
+					})).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					txManager:         newTxManager(t),
+					authRepo:          mockAuthRepo,
+					codeRepo:          mockCodeRepo,
+					userEventOutbox:   mockOutbox,
+					securityEventRepo: newSecurityEventRepo(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"unknown code": {
+			args:        args{ctx: ctx, code: code},
+			wantErr:     true,
+			expectedErr: customerrors.NewTokenError(customerrors.ErrInvalidToken, "confirmation code is invalid"),
+			deps: func(t *testing.T) UseCase {
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().GetByHash(ctx, hash).
+					Return(nil, customerrors.NewNotFoundError("code not found")).
+					Once()
+
+				return UseCase{
+					codeRepo: mockCodeRepo,
+					logger:   logger.NewMockLogger(),
+				}
+			},
+		},
+		"code issued for another purpose": {
+			args:        args{ctx: ctx, code: code},
+			wantErr:     true,
+			expectedErr: customerrors.NewTokenError(customerrors.ErrInvalidToken, "confirmation code is invalid"),
+			deps: func(t *testing.T) UseCase {
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().GetByHash(ctx, hash).Return(verificationCode, nil).Once()
+
+				return UseCase{
+					codeRepo: mockCodeRepo,
+					logger:   logger.NewMockLogger(),
+				}
+			},
+		},
+		"code expired": {
+			args:        args{ctx: ctx, code: code},
+			wantErr:     true,
+			expectedErr: customerrors.NewTokenError(customerrors.ErrTokenExpired, "confirmation code is expired"),
+			deps: func(t *testing.T) UseCase {
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().GetByHash(ctx, hash).Return(expiredCode, nil).Once()
+
+				return UseCase{
+					codeRepo: mockCodeRepo,
+					logger:   logger.NewMockLogger(),
+				}
+			},
+		},
+		"address taken since the code was sent": {
+			args:        args{ctx: ctx, code: code},
+			wantErr:     true,
+			expectedErr: customerrors.NewAlreadyExistsError("email is already in use"),
+			deps: func(t *testing.T) UseCase {
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().GetByHash(ctx, hash).Return(validCode, nil).Once()
+				mockCodeRepo.EXPECT().MarkUsed(ctx, hash, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByID(ctx, userID).Return(user, nil).Once()
+				mockAuthRepo.EXPECT().FindUserByEmail(ctx, "new@test.ru").Return(otherUser, nil).Once()
+
+				return UseCase{
+					txManager: newTxManager(t),
+					authRepo:  mockAuthRepo,
+					codeRepo:  mockCodeRepo,
+					logger:    logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			err := useCase.ConfirmEmailChange(tc.args.ctx, tc.args.code)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (a *UseCase) ChangePassword(ctx context.Context, dto *ports.ChangePasswordDto) error {
+	userID, sessionID, err := callerFromContext(ctx)
+	if err != nil {
+		a.logger.Warn("unauthenticated change password attempt", "error", err)
+		return err
+	}
+
+	a.logger.Debug("change password attempt", "user_id", userID)
+
+	if dto.NewPassword == "" {
+		return errors.NewValidationError("new password cannot be empty").
+			WithDetails("field", "new_password")
+	}
+
+	if err := a.passwordPolicy.Validate("new_password", dto.NewPassword); err != nil {
+		a.logger.Warn("weak new password", "user_id", userID)
+		return err
+	}
+
+	user, err := a.authRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		a.logger.Warn("user not found during password change", "user_id", userID, "error", err)
+		return err
+	}
+
+	if err := a.passwordHasher.Compare([]byte(user.Password()), dto.CurrentPassword); err != nil {
+		a.logger.Warn("invalid current password", "user_id", userID)
+		return errors.NewUnauthorizedError("invalid credentials")
+	}
+
+	hashedPassword, err := a.passwordHasher.Hash(dto.NewPassword)
+	if err != nil {
+		a.logger.Error("failed to hash password", "error", err)
+		return err
+	}
+
+	updated, err := user.WithPassword(hashedPassword)
+	if err != nil {
+		return err
+	}
+
+	err = a.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		if err := a.authRepo.Update(txCtx, updated); err != nil {
+			return err
+		}
+		return a.revokeOtherSessions(txCtx, userID, sessionID)
+	})
+	if err != nil {
+		a.logger.Error("failed to change password", "user_id", userID, "error", err)
+		return err
+	}
+
+	a.recordSecurityEvent(ctx, models.NewSecurityEvent(models.SecurityEventPasswordChanged, userID, user.Email(),
+		models.ClientFromContext(ctx)))
+	a.logger.Info("password changed", "user_id", userID)
+	return nil
+}
@@ -0,0 +1,194 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestUseCase_ChangePassword(t *testing.T) {
+	userID := models.UserID(uuid.New())
+	currentSessionID := models.NewFamilyID()
+	otherSessionID := models.NewFamilyID()
+	ctx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID:    userID.String(),
+		SessionID: currentSessionID.String(),
+	})
+
+	password := "currentPassword"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	user, err := models.NewUser(userID, "testuser", "test@test.ru", hashedPassword)
+	assert.NoError(t, err)
+
+	currentSession, err := models.NewAuthToken("current-token", userID, currentSessionID, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	otherSession, err := models.NewAuthToken("other-token", userID, otherSessionID, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	type args struct {
+		ctx context.Context
+		dto *ports.ChangePasswordDto
+	}
+	tests := map[string]struct {
+		args        args
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"password changed and other sessions revoked": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.ChangePasswordDto{CurrentPassword: password, NewPassword: "newPassword"},
+			},
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserByID(ctx, userID).
+					Return(user, nil).
+					Once()
+				mockAuthRepo.EXPECT().
+					Update(ctx, mock.MatchedBy(func(u *models.User) bool {
+						return bcrypt.CompareHashAndPassword([]byte(u.Password()), []byte("newPassword")) == nil
+					})).
+					Return(nil).
+					Once()
+
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().
+					ListSessions(ctx, userID).
+					Return([]*models.AuthToken{currentSession, otherSession}, nil).
+					Once()
+				mockTokenRepo.EXPECT().
+					DeleteTokensByFamily(ctx, otherSessionID).
+					Return(nil).
+					Once()
+
+				mockRefreshTokenRepo := mocks.NewRefreshTokenRepository(t)
+				mockRefreshTokenRepo.EXPECT().
+					RevokeFamily(ctx, otherSessionID, mock.AnythingOfType("time.Time")).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					txManager:         newTxManager(t),
+					authRepo:          mockAuthRepo,
+					tokenRepo:         mockTokenRepo,
+					refreshTokenRepo:  mockRefreshTokenRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"wrong current password": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.ChangePasswordDto{CurrentPassword: "wrong", NewPassword: "newPassword"},
+			},
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("invalid credentials"),
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserByID(ctx, userID).
+					Return(user, nil).
+					Once()
+
+				return UseCase{
+					authRepo:          mockAuthRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"empty new password": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.ChangePasswordDto{CurrentPassword: password},
+			},
+			wantErr:     true,
+			expectedErr: customerrors.NewValidationError("new password cannot be empty"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					authRepo:          mocks.NewAuthRepository(t),
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"unauthenticated": {
+			args: args{
+				ctx: context.Background(),
+				dto: &ports.ChangePasswordDto{CurrentPassword: password, NewPassword: "newPassword"},
+			},
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("request is not authenticated"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					authRepo:          mocks.NewAuthRepository(t),
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"failed to update user": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.ChangePasswordDto{CurrentPassword: password, NewPassword: "newPassword"},
+			},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserByID(ctx, userID).
+					Return(user, nil).
+					Once()
+				mockAuthRepo.EXPECT().
+					Update(ctx, mock.AnythingOfType("*models.User")).
+					Return(errors.New("database error")).
+					Once()
+
+				return UseCase{
+					txManager:         newTxManager(t),
+					authRepo:          mockAuthRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			err := useCase.ChangePassword(tc.args.ctx, tc.args.dto)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/pkg/api/events/v1"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ChangeUsername renames the caller. Usernames are the nicknames of
+// users-service, which takes them from the events published here. The unique
+// index on users.username settles concurrent renames to the same name; the
+// lookup below only reports the common case with a clearer error.
+func (a *UseCase) ChangeUsername(ctx context.Context, username string) error {
+	userID, _, err := callerFromContext(ctx)
+	if err != nil {
+		a.logger.Warn("unauthenticated change username attempt", "error", err)
+		return err
+	}
+
+	a.logger.Debug("change username attempt", "user_id", userID, "username", username)
+
+	username = strings.TrimSpace(username)
+	if username == "" {
+		return errors.NewValidationError("new username cannot be empty").
+			WithDetails("field", "new_username")
+	}
+
+	user, err := a.authRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		a.logger.Warn("user not found during username change", "user_id", userID, "error", err)
+		return err
+	}
+
+	if username == user.Username() {
+		a.logger.Debug("username unchanged", "user_id", userID)
+		return nil
+	}
+
+	owner, err := a.authRepo.FindUserByUsername(ctx, username)
+	if err != nil && !errors.Is(err, errors.ErrNotFound) {
+		a.logger.Error("failed to look up username owner", "error", err)
+		return err
+	}
+	if owner != nil && owner.ID() != userID {
+		a.logger.Warn("username already taken", "user_id", userID, "username", username)
+		return errors.NewAlreadyExistsError("username %s is already taken", username).
+			WithDetails("field", "new_username")
+	}
+
+	updated, err := user.WithUsername(username)
+	if err != nil {
+		return err
+	}
+
+	err = a.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		if err := a.authRepo.Update(txCtx, updated); err != nil {
+			return err
+		}
+		return a.enqueueCredentialsChanged(txCtx, updated)
+	})
+	if err != nil {
+		a.logger.Error("failed to change username", "user_id", userID, "error", err)
+		return err
+	}
+
+	a.recordSecurityEvent(ctx, models.NewSecurityEvent(models.SecurityEventUsernameChanged, userID, user.Email(),
+		models.ClientFromContext(ctx)))
+	a.logger.Info("username changed", "user_id", userID, "previous_username", user.Username(), "username", username)
+	return nil
+}
+
+// enqueueCredentialsChanged records UserCredentialsChangedEvent in the
+// outbox, so that users-service updates its copy of the username and email.
+// It must be called in the transaction that updates the user.
+func (a *UseCase) enqueueCredentialsChanged(ctx context.Context, user *models.User) error {
+	event := &events.UserCredentialsChangedEvent{
+		UserId:    user.ID().String(),
+		Username:  user.Username(),
+		Email:     user.Email(),
+		ChangedAt: timestamppb.Now(),
+	}
+
+	if err := a.userEventOutbox.EnqueueUserCredentialsChangedEvent(ctx, event); err != nil {
+		a.logger.Error("failed to enqueue credentials changed event", "error", err, "user_id", user.ID())
+		return err
+	}
+	return nil
+}
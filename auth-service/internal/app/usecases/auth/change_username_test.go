@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
+	"github.com/SamEkb/messenger-app/pkg/api/events/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUseCase_ChangeUsername(t *testing.T) {
+	userID := models.UserID(uuid.New())
+	ctx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: userID.String(),
+	})
+
+	user := newUser(t, userID)
+	otherUser := newUser(t, models.UserID(uuid.New()))
+
+	type args struct {
+		ctx      context.Context
+		username string
+	}
+	tests := map[string]struct {
+		args        args
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"username changed": {
+			args: args{ctx: ctx, username: " newname "},
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByID(ctx, userID).Return(user, nil).Once()
+				mockAuthRepo.EXPECT().FindUserByUsername(ctx, "newname").
+					Return(nil, customerrors.NewNotFoundError("user not found")).
+					Once()
+				mockAuthRepo.EXPECT().
+					Update(ctx, mock.MatchedBy(func(u *models.User) bool {
+						return u.ID() == userID && u.Username() == "newname"
+					})).
+					Return(nil).
+					Once()
+
+				mockOutbox := mocks.NewUserEventsOutbox(t)
+				mockOutbox.EXPECT().
+					EnqueueUserCredentialsChangedEvent(ctx, mock.MatchedBy(func(event *events.UserCredentialsChangedEvent) bool {
+						return event.GetUserId() == userID.String() &&
+							event.GetUsername() == "newname" &&
+							event.GetEmail() == user.Email()
+					})).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					txManager:         newTxManager(t),
+					authRepo:          mockAuthRepo,
+					userEventOutbox:   mockOutbox,
+					securityEventRepo: newSecurityEventRepo(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"username unchanged": {
+			args: args{ctx: ctx, username: user.Username()},
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByID(ctx, userID).Return(user, nil).Once()
+
+				return UseCase{
+					authRepo: mockAuthRepo,
+					logger:   logger.NewMockLogger(),
+				}
+			},
+		},
+		"empty username": {
+			args:        args{ctx: ctx, username: "  "},
+			wantErr:     true,
+			expectedErr: customerrors.NewValidationError("new username cannot be empty"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{logger: logger.NewMockLogger()}
+			},
+		},
+		"username taken": {
+			args:        args{ctx: ctx, username: "newname"},
+			wantErr:     true,
+			expectedErr: customerrors.NewAlreadyExistsError("username newname is already taken"),
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByID(ctx, userID).Return(user, nil).Once()
+				mockAuthRepo.EXPECT().FindUserByUsername(ctx, "newname").Return(otherUser, nil).Once()
+
+				return UseCase{
+					authRepo: mockAuthRepo,
+					logger:   logger.NewMockLogger(),
+				}
+			},
+		},
+		"outbox failure": {
+			args:    args{ctx: ctx, username: "newname"},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByID(ctx, userID).Return(user, nil).Once()
+				mockAuthRepo.EXPECT().FindUserByUsername(ctx, "newname").
+					Return(nil, customerrors.NewNotFoundError("user not found")).
+					Once()
+				mockAuthRepo.EXPECT().Update(ctx, mock.AnythingOfType("*models.User")).Return(nil).Once()
+
+				mockOutbox := mocks.NewUserEventsOutbox(t)
+				mockOutbox.EXPECT().
+					EnqueueUserCredentialsChangedEvent(ctx, mock.Anything).
+					Return(assert.AnError).
+					Once()
+
+				return UseCase{
+					txManager:       newTxManager(t),
+					authRepo:        mockAuthRepo,
+					userEventOutbox: mockOutbox,
+					logger:          logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			err := useCase.ChangeUsername(tc.args.ctx, tc.args.username)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
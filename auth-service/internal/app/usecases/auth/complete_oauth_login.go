@@ -0,0 +1,200 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/google/uuid"
+)
+
+func (a *UseCase) CompleteOAuthLogin(ctx context.Context, dto *ports.CompleteOAuthLoginDto) (*ports.LoginResultDto, error) {
+	a.logger.Debug("oauth login callback", "provider", dto.Provider)
+
+	provider, err := a.identityProvider(dto.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.Code == "" {
+		return nil, errors.NewValidationError("authorization code cannot be empty").
+			WithDetails("field", "code")
+	}
+
+	pending, err := a.oauthStateRepo.Consume(ctx, models.HashToken(dto.State))
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			a.logger.Warn("unknown oauth login state", "provider", provider.Name())
+			return nil, errors.NewTokenError(errors.ErrInvalidToken, "login state is invalid")
+		}
+		a.logger.Error("failed to get oauth login state", "error", err)
+		return nil, err
+	}
+
+	if pending.Provider() != provider.Name() {
+		a.logger.Warn("oauth login state used with another provider", "provider", provider.Name(), "state_provider", pending.Provider())
+		return nil, errors.NewTokenError(errors.ErrInvalidToken, "login state is invalid")
+	}
+
+	if pending.IsExpired(time.Now()) {
+		a.logger.Warn("oauth login state expired", "provider", provider.Name())
+		return nil, errors.NewTokenError(errors.ErrTokenExpired, "login state is expired")
+	}
+
+	identity, err := provider.Exchange(ctx, dto.Code, string(pending.CodeVerifier()), string(pending.Nonce()))
+	if err != nil {
+		a.logger.Warn("authorization code exchange failed", "provider", provider.Name(), "error", err)
+		return nil, err
+	}
+
+	user, err := a.resolveOAuthUser(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.completeLogin(ctx, user, dto.Session)
+}
+
+// resolveOAuthUser finds the local user of an external identity. An
+// unknown identity is linked to the account with the same email when the
+// provider has verified that address, or registers a new account otherwise.
+func (a *UseCase) resolveOAuthUser(ctx context.Context, identity *ports.ExternalIdentityDto) (*models.User, error) {
+	link, err := a.oauthIdentityRepo.FindBySubject(ctx, identity.Provider, identity.Subject)
+	if err == nil {
+		if err := a.oauthIdentityRepo.TouchLogin(ctx, identity.Provider, identity.Subject, time.Now()); err != nil {
+			a.logger.Warn("failed to record external login", "provider", identity.Provider, "user_id", link.UserID(), "error", err)
+		}
+		return a.authRepo.FindUserByID(ctx, link.UserID())
+	}
+	if !errors.Is(err, errors.ErrNotFound) {
+		a.logger.Error("failed to find external identity", "provider", identity.Provider, "error", err)
+		return nil, err
+	}
+
+	if identity.Email == "" {
+		return nil, errors.NewValidationError("identity provider did not return an email address").
+			WithDetails("provider", identity.Provider)
+	}
+
+	existing, err := a.authRepo.FindUserByEmail(ctx, identity.Email)
+	switch {
+	case err == nil:
+		return a.linkOAuthIdentity(ctx, existing, identity)
+	case errors.Is(err, errors.ErrNotFound):
+		return a.registerOAuthUser(ctx, identity)
+	default:
+		a.logger.Error("failed to find user by email", "error", err)
+		return nil, err
+	}
+}
+
+func (a *UseCase) linkOAuthIdentity(ctx context.Context, user *models.User, identity *ports.ExternalIdentityDto) (*models.User, error) {
+	// Without a verified address anyone could register the victim's email at
+	// the provider and take over the local account.
+	if !identity.EmailVerified {
+		a.logger.Warn("external identity with unverified email matches an account", "provider", identity.Provider, "user_id", user.ID())
+		return nil, errors.NewAlreadyExistsError("an account with this email already exists").
+			WithDetails("email", identity.Email)
+	}
+
+	// An unverified local account may have been registered by someone else
+	// in advance; linking would let them keep its password and sessions. The
+	// owner of the address can take the account over with a password reset
+	// and link the identity afterwards.
+	if !user.EmailVerified() {
+		a.logger.Warn("external identity matches an account with an unverified email", "provider", identity.Provider, "user_id", user.ID())
+		return nil, errors.NewAlreadyExistsError("an account with this email already exists; verify the address or reset the password first").
+			WithDetails("email", identity.Email)
+	}
+
+	link, err := models.NewOAuthIdentity(identity.Provider, identity.Subject, user.ID(), identity.Email)
+	if err != nil {
+		return nil, errors.NewInternalError(err, "failed to create external identity")
+	}
+
+	if err := a.oauthIdentityRepo.Create(ctx, link); err != nil {
+		a.logger.Error("failed to link external identity", "provider", identity.Provider, "user_id", user.ID(), "error", err)
+		return nil, err
+	}
+
+	a.logger.Info("external identity linked", "provider", identity.Provider, "user_id", user.ID())
+	return user, nil
+}
+
+func (a *UseCase) registerOAuthUser(ctx context.Context, identity *ports.ExternalIdentityDto) (*models.User, error) {
+	// Accounts created from an external identity have no usable password
+	// until the user sets one through the password reset flow.
+	secret, err := models.GenerateSecretToken()
+	if err != nil {
+		return nil, err
+	}
+	hashedPassword, err := a.passwordHasher.Hash(string(secret))
+	if err != nil {
+		a.logger.Error("failed to hash password", "error", err)
+		return nil, err
+	}
+
+	userID := models.UserID(uuid.New())
+	username, err := a.oauthUsername(ctx, userID, identity)
+	if err != nil {
+		return nil, err
+	}
+	user, err := models.NewUser(userID, username, identity.Email, hashedPassword)
+	if err != nil {
+		a.logger.Error("failed to create user model", "error", err)
+		return nil, err
+	}
+	if identity.EmailVerified {
+		user = user.WithEmailVerified()
+	}
+
+	link, err := models.NewOAuthIdentity(identity.Provider, identity.Subject, userID, identity.Email)
+	if err != nil {
+		return nil, errors.NewInternalError(err, "failed to create external identity")
+	}
+
+	err = a.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		if err := a.authRepo.Create(txCtx, user); err != nil {
+			a.logger.Error("failed to save user", "error", err)
+			return err
+		}
+		if err := a.oauthIdentityRepo.Create(txCtx, link); err != nil {
+			a.logger.Error("failed to link external identity", "provider", identity.Provider, "user_id", userID, "error", err)
+			return err
+		}
+		return a.enqueueUserRegistered(txCtx, user)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.logger.Info("user registered with external identity", "provider", identity.Provider, "user_id", userID)
+	return user, nil
+}
+
+// oauthUsername picks the initial username of an account created from an
+// external identity. Usernames are unique, so a name that is already taken
+// gets the start of the new user's ID appended, as duplicates did when the
+// unique index was introduced.
+func (a *UseCase) oauthUsername(ctx context.Context, userID models.UserID, identity *ports.ExternalIdentityDto) (string, error) {
+	username := identity.PreferredUsername
+	if username == "" {
+		username = identity.Name
+	}
+	if username == "" {
+		username, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	_, err := a.authRepo.FindUserByUsername(ctx, username)
+	if errors.Is(err, errors.ErrNotFound) {
+		return username, nil
+	}
+	if err != nil {
+		a.logger.Error("failed to look up username owner", "error", err)
+		return "", err
+	}
+	return username + "-" + userID.String()[:8], nil
+}
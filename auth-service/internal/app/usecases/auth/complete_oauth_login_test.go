@@ -0,0 +1,455 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// sessionMocks expects a session to be opened for any user.
+type sessionMocks struct {
+	totpRepo         *mocks.TOTPRepository
+	tokenIssuer      *mocks.TokenIssuer
+	tokenRepo        *mocks.TokenRepository
+	refreshTokenRepo *mocks.RefreshTokenRepository
+}
+
+func newSessionMocks(t *testing.T, ctx context.Context) sessionMocks {
+	m := sessionMocks{
+		totpRepo:         mocks.NewTOTPRepository(t),
+		tokenIssuer:      mocks.NewTokenIssuer(t),
+		tokenRepo:        mocks.NewTokenRepository(t),
+		refreshTokenRepo: mocks.NewRefreshTokenRepository(t),
+	}
+	m.totpRepo.EXPECT().
+		GetByUser(ctx, mock.AnythingOfType("models.UserID")).
+		Return(nil, customerrors.NewNotFoundError("totp factor not found")).
+		Once()
+	m.tokenIssuer.EXPECT().
+		Issue(ctx, mock.AnythingOfType("*models.User"), mock.AnythingOfType("time.Time")).
+		Return(models.Token("access-token"), nil).
+		Once()
+	m.tokenRepo.EXPECT().
+		Create(ctx, mock.AnythingOfType("*models.AuthToken")).
+		RunAndReturn(func(_ context.Context, token *models.AuthToken) (*models.AuthToken, error) {
+			return token, nil
+		}).
+		Once()
+	m.refreshTokenRepo.EXPECT().
+		Create(ctx, mock.AnythingOfType("*models.RefreshToken")).
+		Return(nil).
+		Once()
+	return m
+}
+
+func TestUseCase_CompleteOAuthLogin(t *testing.T) {
+	ctx := context.Background()
+
+	userID := models.UserID(uuid.New())
+	email := "test@test.ru"
+	state := models.Token("state")
+	session := models.SessionMetadata{DeviceName: "laptop", IP: "127.0.0.1"}
+
+	pending, err := models.NewOAuthState(models.HashToken(state), "google", "verifier", "nonce", time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	expired, err := models.NewOAuthState(models.HashToken(state), "google", "verifier", "nonce", time.Now().Add(-time.Minute))
+	assert.NoError(t, err)
+	otherProvider, err := models.NewOAuthState(models.HashToken(state), "github", "verifier", "nonce", time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+
+	link, err := models.NewOAuthIdentity("google", "subject-1", userID, email)
+	assert.NoError(t, err)
+	user, err := models.NewUser(userID, "testuser", email, []byte("hashed"))
+	assert.NoError(t, err)
+	verifiedUser := user.WithEmailVerified()
+
+	verifiedIdentity := &ports.ExternalIdentityDto{
+		Provider:          "google",
+		Subject:           "subject-1",
+		Email:             email,
+		EmailVerified:     true,
+		PreferredUsername: "testuser",
+	}
+	unverifiedIdentity := &ports.ExternalIdentityDto{
+		Provider: "google",
+		Subject:  "subject-1",
+		Email:    email,
+	}
+
+	dto := &ports.CompleteOAuthLoginDto{
+		Provider: "google",
+		State:    state,
+		Code:     "code",
+		Session:  session,
+	}
+
+	newStateRepo := func(t *testing.T, state *models.OAuthState) *mocks.OAuthStateRepository {
+		repo := mocks.NewOAuthStateRepository(t)
+		repo.EXPECT().
+			Consume(ctx, models.HashToken("state")).
+			Return(state, nil).
+			Once()
+		return repo
+	}
+	newProvider := func(t *testing.T, identity *ports.ExternalIdentityDto, err error) *mocks.IdentityProvider {
+		provider := newIdentityProvider(t, "google")
+		provider.EXPECT().
+			Exchange(ctx, "code", "verifier", "nonce").
+			Return(identity, err).
+			Once()
+		return provider
+	}
+
+	type args struct {
+		ctx context.Context
+		dto *ports.CompleteOAuthLoginDto
+	}
+	tests := map[string]struct {
+		args        args
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"linked identity logs in": {
+			args: args{ctx: ctx, dto: dto},
+			deps: func(t *testing.T) UseCase {
+				mockIdentityRepo := mocks.NewOAuthIdentityRepository(t)
+				mockIdentityRepo.EXPECT().
+					FindBySubject(ctx, "google", "subject-1").
+					Return(link, nil).
+					Once()
+				mockIdentityRepo.EXPECT().
+					TouchLogin(ctx, "google", "subject-1", mock.AnythingOfType("time.Time")).
+					Return(nil).
+					Once()
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserByID(ctx, userID).
+					Return(user, nil).
+					Once()
+
+				s := newSessionMocks(t, ctx)
+				return UseCase{
+					txManager:         newTxManager(t),
+					authRepo:          mockAuthRepo,
+					oauthStateRepo:    newStateRepo(t, pending),
+					oauthIdentityRepo: mockIdentityRepo,
+					identityProviders: map[string]ports.IdentityProvider{"google": newProvider(t, verifiedIdentity, nil)},
+					totpRepo:          s.totpRepo,
+					tokenIssuer:       s.tokenIssuer,
+					tokenRepo:         s.tokenRepo,
+					refreshTokenRepo:  s.refreshTokenRepo,
+					tokenTTL:          time.Minute,
+					refreshTokenTTL:   time.Hour,
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"first login registers user": {
+			args: args{ctx: ctx, dto: dto},
+			deps: func(t *testing.T) UseCase {
+				mockIdentityRepo := mocks.NewOAuthIdentityRepository(t)
+				mockIdentityRepo.EXPECT().
+					FindBySubject(ctx, "google", "subject-1").
+					Return(nil, customerrors.NewNotFoundError("external identity not found")).
+					Once()
+				mockIdentityRepo.EXPECT().
+					Create(ctx, mock.MatchedBy(func(identity *models.OAuthIdentity) bool {
+						return identity.Provider() == "google" && identity.Subject() == "subject-1"
+					})).
+					Return(nil).
+					Once()
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserByEmail(ctx, email).
+					Return(nil, customerrors.NewNotFoundError("user not found")).
+					Once()
+				mockAuthRepo.EXPECT().
+					FindUserByUsername(ctx, "testuser").
+					Return(nil, customerrors.NewNotFoundError("user not found")).
+					Once()
+				mockAuthRepo.EXPECT().
+					Create(ctx, mock.MatchedBy(func(u *models.User) bool {
+						return u.Email() == email && u.Username() == "testuser" && u.EmailVerified()
+					})).
+					Return(nil).
+					Once()
+
+				mockOutbox := mocks.NewUserEventsOutbox(t)
+				mockOutbox.EXPECT().
+					EnqueueUserRegisteredEvent(ctx, mock.AnythingOfType("*events.UserRegisteredEvent")).
+					Return(nil).
+					Once()
+
+				s := newSessionMocks(t, ctx)
+				return UseCase{
+					txManager:         newTxManager(t),
+					authRepo:          mockAuthRepo,
+					oauthStateRepo:    newStateRepo(t, pending),
+					oauthIdentityRepo: mockIdentityRepo,
+					identityProviders: map[string]ports.IdentityProvider{"google": newProvider(t, verifiedIdentity, nil)},
+					userEventOutbox:   mockOutbox,
+					totpRepo:          s.totpRepo,
+					tokenIssuer:       s.tokenIssuer,
+					tokenRepo:         s.tokenRepo,
+					refreshTokenRepo:  s.refreshTokenRepo,
+					tokenTTL:          time.Minute,
+					refreshTokenTTL:   time.Hour,
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"taken username gets a suffix": {
+			args: args{ctx: ctx, dto: dto},
+			deps: func(t *testing.T) UseCase {
+				mockIdentityRepo := mocks.NewOAuthIdentityRepository(t)
+				mockIdentityRepo.EXPECT().
+					FindBySubject(ctx, "google", "subject-1").
+					Return(nil, customerrors.NewNotFoundError("external identity not found")).
+					Once()
+				mockIdentityRepo.EXPECT().
+					Create(ctx, mock.MatchedBy(func(identity *models.OAuthIdentity) bool {
+						return identity.Provider() == "google" && identity.Subject() == "subject-1"
+					})).
+					Return(nil).
+					Once()
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserByEmail(ctx, email).
+					Return(nil, customerrors.NewNotFoundError("user not found")).
+					Once()
+				mockAuthRepo.EXPECT().
+					FindUserByUsername(ctx, "testuser").
+					Return(verifiedUser, nil).
+					Once()
+				mockAuthRepo.EXPECT().
+					Create(ctx, mock.MatchedBy(func(u *models.User) bool {
+						return u.Username() == "testuser-"+u.ID().String()[:8]
+					})).
+					Return(nil).
+					Once()
+
+				mockOutbox := mocks.NewUserEventsOutbox(t)
+				mockOutbox.EXPECT().
+					EnqueueUserRegisteredEvent(ctx, mock.AnythingOfType("*events.UserRegisteredEvent")).
+					Return(nil).
+					Once()
+
+				s := newSessionMocks(t, ctx)
+				return UseCase{
+					txManager:         newTxManager(t),
+					authRepo:          mockAuthRepo,
+					oauthStateRepo:    newStateRepo(t, pending),
+					oauthIdentityRepo: mockIdentityRepo,
+					identityProviders: map[string]ports.IdentityProvider{"google": newProvider(t, verifiedIdentity, nil)},
+					userEventOutbox:   mockOutbox,
+					totpRepo:          s.totpRepo,
+					tokenIssuer:       s.tokenIssuer,
+					tokenRepo:         s.tokenRepo,
+					refreshTokenRepo:  s.refreshTokenRepo,
+					tokenTTL:          time.Minute,
+					refreshTokenTTL:   time.Hour,
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"verified email links existing account": {
+			args: args{ctx: ctx, dto: dto},
+			deps: func(t *testing.T) UseCase {
+				mockIdentityRepo := mocks.NewOAuthIdentityRepository(t)
+				mockIdentityRepo.EXPECT().
+					FindBySubject(ctx, "google", "subject-1").
+					Return(nil, customerrors.NewNotFoundError("external identity not found")).
+					Once()
+				mockIdentityRepo.EXPECT().
+					Create(ctx, mock.MatchedBy(func(identity *models.OAuthIdentity) bool {
+						return identity.UserID() == userID
+					})).
+					Return(nil).
+					Once()
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserByEmail(ctx, email).
+					Return(verifiedUser, nil).
+					Once()
+
+				s := newSessionMocks(t, ctx)
+				return UseCase{
+					txManager:         newTxManager(t),
+					authRepo:          mockAuthRepo,
+					oauthStateRepo:    newStateRepo(t, pending),
+					oauthIdentityRepo: mockIdentityRepo,
+					identityProviders: map[string]ports.IdentityProvider{"google": newProvider(t, verifiedIdentity, nil)},
+					totpRepo:          s.totpRepo,
+					tokenIssuer:       s.tokenIssuer,
+					tokenRepo:         s.tokenRepo,
+					refreshTokenRepo:  s.refreshTokenRepo,
+					tokenTTL:          time.Minute,
+					refreshTokenTTL:   time.Hour,
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"unverified account is not linked": {
+			args:        args{ctx: ctx, dto: dto},
+			wantErr:     true,
+			expectedErr: customerrors.NewAlreadyExistsError("an account with this email already exists"),
+			deps: func(t *testing.T) UseCase {
+				mockIdentityRepo := mocks.NewOAuthIdentityRepository(t)
+				mockIdentityRepo.EXPECT().
+					FindBySubject(ctx, "google", "subject-1").
+					Return(nil, customerrors.NewNotFoundError("external identity not found")).
+					Once()
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserByEmail(ctx, email).
+					Return(user, nil).
+					Once()
+
+				return UseCase{
+					authRepo:          mockAuthRepo,
+					oauthStateRepo:    newStateRepo(t, pending),
+					oauthIdentityRepo: mockIdentityRepo,
+					identityProviders: map[string]ports.IdentityProvider{"google": newProvider(t, verifiedIdentity, nil)},
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"unverified email does not link existing account": {
+			args:        args{ctx: ctx, dto: dto},
+			wantErr:     true,
+			expectedErr: customerrors.NewAlreadyExistsError("an account with this email already exists"),
+			deps: func(t *testing.T) UseCase {
+				mockIdentityRepo := mocks.NewOAuthIdentityRepository(t)
+				mockIdentityRepo.EXPECT().
+					FindBySubject(ctx, "google", "subject-1").
+					Return(nil, customerrors.NewNotFoundError("external identity not found")).
+					Once()
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserByEmail(ctx, email).
+					Return(user, nil).
+					Once()
+
+				return UseCase{
+					authRepo:          mockAuthRepo,
+					oauthStateRepo:    newStateRepo(t, pending),
+					oauthIdentityRepo: mockIdentityRepo,
+					identityProviders: map[string]ports.IdentityProvider{"google": newProvider(t, unverifiedIdentity, nil)},
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"unknown state": {
+			args:        args{ctx: ctx, dto: dto},
+			wantErr:     true,
+			expectedErr: customerrors.NewTokenError(customerrors.ErrInvalidToken, "login state is invalid"),
+			deps: func(t *testing.T) UseCase {
+				mockStateRepo := mocks.NewOAuthStateRepository(t)
+				mockStateRepo.EXPECT().
+					Consume(ctx, models.HashToken("state")).
+					Return(nil, customerrors.NewNotFoundError("oauth state not found")).
+					Once()
+
+				return UseCase{
+					oauthStateRepo:    mockStateRepo,
+					identityProviders: map[string]ports.IdentityProvider{"google": newIdentityProvider(t, "google")},
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"expired state": {
+			args:        args{ctx: ctx, dto: dto},
+			wantErr:     true,
+			expectedErr: customerrors.NewTokenError(customerrors.ErrTokenExpired, "login state is expired"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					oauthStateRepo:    newStateRepo(t, expired),
+					identityProviders: map[string]ports.IdentityProvider{"google": newIdentityProvider(t, "google")},
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"state started with another provider": {
+			args:        args{ctx: ctx, dto: dto},
+			wantErr:     true,
+			expectedErr: customerrors.NewTokenError(customerrors.ErrInvalidToken, "login state is invalid"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					oauthStateRepo:    newStateRepo(t, otherProvider),
+					identityProviders: map[string]ports.IdentityProvider{"google": newIdentityProvider(t, "google")},
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"authorization code rejected": {
+			args:        args{ctx: ctx, dto: dto},
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("authorization code was rejected"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					oauthStateRepo:    newStateRepo(t, pending),
+					identityProviders: map[string]ports.IdentityProvider{"google": newProvider(t, nil, customerrors.NewUnauthorizedError("authorization code was rejected"))},
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			result, err := useCase.CompleteOAuthLogin(tc.args.ctx, tc.args.dto)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Nil(t, result.Challenge)
+			assert.Equal(t, models.Token("access-token"), result.Tokens.AccessToken)
+			assert.NotEmpty(t, result.Tokens.RefreshToken)
+		})
+	}
+}
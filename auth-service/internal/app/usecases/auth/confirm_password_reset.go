@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (a *UseCase) ConfirmPasswordReset(ctx context.Context, dto *ports.ConfirmPasswordResetDto) error {
+	a.logger.Debug("password reset confirmation attempt")
+
+	if dto.NewPassword == "" {
+		return errors.NewValidationError("new password cannot be empty").
+			WithDetails("field", "new_password")
+	}
+
+	if err := a.passwordPolicy.Validate("new_password", dto.NewPassword); err != nil {
+		a.logger.Warn("weak password in reset")
+		return err
+	}
+
+	hash := models.HashToken(dto.Code)
+
+	code, err := a.codeRepo.GetByHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			a.logger.Warn("unknown password reset code")
+			return errors.NewTokenError(errors.ErrInvalidToken, "reset code is invalid")
+		}
+		a.logger.Error("failed to get reset code", "error", err)
+		return err
+	}
+
+	if code.Purpose() != models.CodePurposePasswordReset || code.IsUsed() {
+		a.logger.Warn("password reset code rejected", "user_id", code.UserID(), "used", code.IsUsed())
+		return errors.NewTokenError(errors.ErrInvalidToken, "reset code is invalid")
+	}
+
+	if code.IsExpired() {
+		a.logger.Warn("password reset code expired", "user_id", code.UserID())
+		return errors.NewTokenError(errors.ErrTokenExpired, "reset code is expired")
+	}
+
+	hashedPassword, err := a.passwordHasher.Hash(dto.NewPassword)
+	if err != nil {
+		a.logger.Error("failed to hash password", "error", err)
+		return err
+	}
+
+	err = a.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		if err := a.codeRepo.MarkUsed(txCtx, hash, time.Now()); err != nil {
+			return err
+		}
+
+		user, err := a.authRepo.FindUserByID(txCtx, code.UserID())
+		if err != nil {
+			return err
+		}
+
+		updated, err := user.WithPassword(hashedPassword)
+		if err != nil {
+			return err
+		}
+
+		if err := a.authRepo.Update(txCtx, updated); err != nil {
+			return err
+		}
+
+		return a.revokeAllSessions(txCtx, code.UserID())
+	})
+	if err != nil {
+		a.logger.Error("failed to reset password", "user_id", code.UserID(), "error", err)
+		return err
+	}
+
+	a.recordSecurityEvent(ctx, models.NewSecurityEvent(models.SecurityEventPasswordChanged, code.UserID(), "",
+		models.ClientFromContext(ctx)).WithReason("password_reset"))
+	a.logger.Info("password reset", "user_id", code.UserID())
+	return nil
+}
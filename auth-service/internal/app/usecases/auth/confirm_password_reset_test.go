@@ -0,0 +1,209 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUseCase_ConfirmPasswordReset(t *testing.T) {
+	ctx := context.Background()
+
+	userID := models.UserID(uuid.New())
+	user, err := models.NewUser(userID, "testuser", "test@test.ru", []byte("hashed"))
+	assert.NoError(t, err)
+
+	code := models.Token("reset-code")
+	hash := models.HashToken(code)
+
+	newCode := func(t *testing.T, purpose models.CodePurpose, expiresAt, usedAt time.Time) *models.OneTimeCode {
+		c, err := models.RestoreOneTimeCode(hash, userID, purpose, expiresAt, usedAt)
+		assert.NoError(t, err)
+		return c
+	}
+
+	type args struct {
+		ctx context.Context
+		dto *ports.ConfirmPasswordResetDto
+	}
+	tests := map[string]struct {
+		args        args
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"password reset": {
+			args: args{ctx: ctx, dto: &ports.ConfirmPasswordResetDto{Code: code, NewPassword: "newPassword"}},
+			deps: func(t *testing.T) UseCase {
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().
+					GetByHash(ctx, hash).
+					Return(newCode(t, models.CodePurposePasswordReset, time.Now().Add(time.Hour), time.Time{}), nil).
+					Once()
+				mockCodeRepo.EXPECT().
+					MarkUsed(ctx, hash, mock.AnythingOfType("time.Time")).
+					Return(nil).
+					Once()
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserByID(ctx, userID).
+					Return(user, nil).
+					Once()
+				mockAuthRepo.EXPECT().
+					Update(ctx, mock.AnythingOfType("*models.User")).
+					Return(nil).
+					Once()
+
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().
+					DeleteTokensByUser(ctx, userID).
+					Return(nil).
+					Once()
+
+				mockRefreshTokenRepo := mocks.NewRefreshTokenRepository(t)
+				mockRefreshTokenRepo.EXPECT().
+					RevokeByUser(ctx, userID, mock.AnythingOfType("time.Time")).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					txManager:         newTxManager(t),
+					authRepo:          mockAuthRepo,
+					tokenRepo:         mockTokenRepo,
+					refreshTokenRepo:  mockRefreshTokenRepo,
+					codeRepo:          mockCodeRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"unknown code": {
+			args:        args{ctx: ctx, dto: &ports.ConfirmPasswordResetDto{Code: code, NewPassword: "newPassword"}},
+			wantErr:     true,
+			expectedErr: customerrors.NewTokenError(nil, "reset code is invalid"),
+			deps: func(t *testing.T) UseCase {
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().
+					GetByHash(ctx, hash).
+					Return(nil, customerrors.NewNotFoundError("code not found")).
+					Once()
+
+				return UseCase{
+					codeRepo:          mockCodeRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"code already used": {
+			args:        args{ctx: ctx, dto: &ports.ConfirmPasswordResetDto{Code: code, NewPassword: "newPassword"}},
+			wantErr:     true,
+			expectedErr: customerrors.NewTokenError(nil, "reset code is invalid"),
+			deps: func(t *testing.T) UseCase {
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().
+					GetByHash(ctx, hash).
+					Return(newCode(t, models.CodePurposePasswordReset, time.Now().Add(time.Hour), time.Now()), nil).
+					Once()
+
+				return UseCase{
+					codeRepo:          mockCodeRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"code expired": {
+			args:        args{ctx: ctx, dto: &ports.ConfirmPasswordResetDto{Code: code, NewPassword: "newPassword"}},
+			wantErr:     true,
+			expectedErr: customerrors.NewTokenError(nil, "reset code is expired"),
+			deps: func(t *testing.T) UseCase {
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().
+					GetByHash(ctx, hash).
+					Return(newCode(t, models.CodePurposePasswordReset, time.Now().Add(-time.Minute), time.Time{}), nil).
+					Once()
+
+				return UseCase{
+					codeRepo:          mockCodeRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"code issued for another purpose": {
+			args:        args{ctx: ctx, dto: &ports.ConfirmPasswordResetDto{Code: code, NewPassword: "newPassword"}},
+			wantErr:     true,
+			expectedErr: customerrors.NewTokenError(nil, "reset code is invalid"),
+			deps: func(t *testing.T) UseCase {
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().
+					GetByHash(ctx, hash).
+					Return(newCode(t, models.CodePurpose("other"), time.Now().Add(time.Hour), time.Time{}), nil).
+					Once()
+
+				return UseCase{
+					codeRepo:          mockCodeRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"code redeemed concurrently": {
+			args:    args{ctx: ctx, dto: &ports.ConfirmPasswordResetDto{Code: code, NewPassword: "newPassword"}},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().
+					GetByHash(ctx, hash).
+					Return(newCode(t, models.CodePurposePasswordReset, time.Now().Add(time.Hour), time.Time{}), nil).
+					Once()
+				mockCodeRepo.EXPECT().
+					MarkUsed(ctx, hash, mock.AnythingOfType("time.Time")).
+					Return(customerrors.NewTokenError(customerrors.ErrInvalidToken, "code already used")).
+					Once()
+
+				return UseCase{
+					txManager:         newTxManager(t),
+					codeRepo:          mockCodeRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			err := useCase.ConfirmPasswordReset(tc.args.ctx, tc.args.dto)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// recoveryCodeCount is the number of recovery codes issued when TOTP is enabled.
+const recoveryCodeCount = 10
+
+// ConfirmTOTP activates the caller's pending TOTP factor and returns a fresh
+// set of recovery codes. The codes are shown only once; only their hashes
+// are kept.
+func (a *UseCase) ConfirmTOTP(ctx context.Context, code string) ([]models.Token, error) {
+	userID, _, err := callerFromContext(ctx)
+	if err != nil {
+		a.logger.Warn("unauthenticated totp confirmation attempt", "error", err)
+		return nil, err
+	}
+
+	a.logger.Debug("totp confirmation attempt", "user_id", userID)
+
+	factor, err := a.totpRepo.GetByUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return nil, errors.NewNotFoundError("two-factor enrollment has not been started")
+		}
+		a.logger.Error("failed to get totp factor", "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	if factor.IsConfirmed() {
+		a.logger.Warn("totp already enabled", "user_id", userID)
+		return nil, errors.NewAlreadyExistsError("two-factor authentication is already enabled")
+	}
+
+	now := time.Now()
+	step, ok := factor.Verify(code, now)
+	if !ok {
+		a.logger.Warn("invalid totp confirmation code", "user_id", userID)
+		return nil, errors.NewUnauthorizedError("invalid verification code")
+	}
+
+	recoveryCodes, err := models.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, 0, len(recoveryCodes))
+	for _, recoveryCode := range recoveryCodes {
+		hashes = append(hashes, models.HashToken(models.NormalizeRecoveryCode(recoveryCode)))
+	}
+
+	err = a.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		if err := a.totpRepo.Update(txCtx, factor.Confirm(now, step)); err != nil {
+			return err
+		}
+		return a.recoveryCodeRepo.ReplaceAll(txCtx, userID, hashes)
+	})
+	if err != nil {
+		a.logger.Error("failed to enable totp", "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	a.logger.Info("totp enabled", "user_id", userID)
+	return recoveryCodes, nil
+}
@@ -0,0 +1,168 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUseCase_ConfirmTOTP(t *testing.T) {
+	userID := models.UserID(uuid.New())
+	ctx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID:    userID.String(),
+		SessionID: models.NewFamilyID().String(),
+	})
+
+	secret, err := models.GenerateTOTPSecret()
+	assert.NoError(t, err)
+	pendingFactor, err := models.NewTOTPFactor(userID, secret)
+	assert.NoError(t, err)
+	activeFactor, err := models.RestoreTOTPFactor(userID, secret, time.Now(), 0)
+	assert.NoError(t, err)
+
+	validCode, err := models.TOTPCode(secret, time.Now())
+	assert.NoError(t, err)
+	invalidCode := "000000"
+	if invalidCode == validCode {
+		invalidCode = "111111"
+	}
+
+	type args struct {
+		ctx  context.Context
+		code string
+	}
+	tests := map[string]struct {
+		args        args
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"totp enabled": {
+			args: args{ctx: ctx, code: validCode},
+			deps: func(t *testing.T) UseCase {
+				mockTOTPRepo := mocks.NewTOTPRepository(t)
+				mockTOTPRepo.EXPECT().GetByUser(ctx, userID).Return(pendingFactor, nil).Once()
+				mockTOTPRepo.EXPECT().
+					Update(ctx, mock.MatchedBy(func(factor *models.TOTPFactor) bool {
+						return factor.IsConfirmed() && factor.LastUsedStep() > 0
+					})).
+					Return(nil).
+					Once()
+
+				mockRecoveryCodeRepo := mocks.NewRecoveryCodeRepository(t)
+				mockRecoveryCodeRepo.EXPECT().
+					ReplaceAll(ctx, userID, mock.MatchedBy(func(hashes []string) bool {
+						return len(hashes) == recoveryCodeCount
+					})).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					txManager:        newTxManager(t),
+					totpRepo:         mockTOTPRepo,
+					recoveryCodeRepo: mockRecoveryCodeRepo,
+					logger:           logger.NewMockLogger(),
+				}
+			},
+		},
+		"invalid code": {
+			args:        args{ctx: ctx, code: invalidCode},
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("invalid verification code"),
+			deps: func(t *testing.T) UseCase {
+				mockTOTPRepo := mocks.NewTOTPRepository(t)
+				mockTOTPRepo.EXPECT().GetByUser(ctx, userID).Return(pendingFactor, nil).Once()
+
+				return UseCase{
+					totpRepo: mockTOTPRepo,
+					logger:   logger.NewMockLogger(),
+				}
+			},
+		},
+		"enrollment not started": {
+			args:        args{ctx: ctx, code: validCode},
+			wantErr:     true,
+			expectedErr: customerrors.NewNotFoundError("two-factor enrollment has not been started"),
+			deps: func(t *testing.T) UseCase {
+				mockTOTPRepo := mocks.NewTOTPRepository(t)
+				mockTOTPRepo.EXPECT().
+					GetByUser(ctx, userID).
+					Return(nil, customerrors.NewNotFoundError("totp factor not found")).
+					Once()
+
+				return UseCase{
+					totpRepo: mockTOTPRepo,
+					logger:   logger.NewMockLogger(),
+				}
+			},
+		},
+		"already enabled": {
+			args:        args{ctx: ctx, code: validCode},
+			wantErr:     true,
+			expectedErr: customerrors.NewAlreadyExistsError("two-factor authentication is already enabled"),
+			deps: func(t *testing.T) UseCase {
+				mockTOTPRepo := mocks.NewTOTPRepository(t)
+				mockTOTPRepo.EXPECT().GetByUser(ctx, userID).Return(activeFactor, nil).Once()
+
+				return UseCase{
+					totpRepo: mockTOTPRepo,
+					logger:   logger.NewMockLogger(),
+				}
+			},
+		},
+		"failed to store recovery codes": {
+			args:    args{ctx: ctx, code: validCode},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				mockTOTPRepo := mocks.NewTOTPRepository(t)
+				mockTOTPRepo.EXPECT().GetByUser(ctx, userID).Return(pendingFactor, nil).Once()
+				mockTOTPRepo.EXPECT().
+					Update(ctx, mock.AnythingOfType("*models.TOTPFactor")).
+					Return(nil).
+					Once()
+
+				mockRecoveryCodeRepo := mocks.NewRecoveryCodeRepository(t)
+				mockRecoveryCodeRepo.EXPECT().
+					ReplaceAll(ctx, userID, mock.Anything).
+					Return(errors.New("database error")).
+					Once()
+
+				return UseCase{
+					txManager:        newTxManager(t),
+					totpRepo:         mockTOTPRepo,
+					recoveryCodeRepo: mockRecoveryCodeRepo,
+					logger:           logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			recoveryCodes, err := useCase.ConfirmTOTP(tc.args.ctx, tc.args.code)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, recoveryCodes, recoveryCodeCount)
+			}
+		})
+	}
+}
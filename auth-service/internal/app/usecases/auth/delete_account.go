@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/api/events/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DeleteAccount deletes the caller's own account, or any account when the
+// caller is an administrator. Sessions and credentials go with the user row;
+// the other services clean up after UserDeletedEvent.
+func (a *UseCase) DeleteAccount(ctx context.Context, dto *ports.DeleteAccountDto) error {
+	callerID, _, err := callerFromContext(ctx)
+	if err != nil {
+		a.logger.Warn("unauthenticated delete account attempt", "error", err)
+		return err
+	}
+
+	userID := dto.UserID
+	if userID.IsEmpty() {
+		userID = callerID
+	}
+
+	a.logger.Debug("delete account attempt", "caller_id", callerID, "user_id", userID)
+
+	if userID == callerID {
+		user, err := a.authRepo.FindUserByID(ctx, userID)
+		if err != nil {
+			a.logger.Warn("user not found during account deletion", "user_id", userID, "error", err)
+			return err
+		}
+
+		if err := a.passwordHasher.Compare([]byte(user.Password()), dto.Password); err != nil {
+			a.logger.Warn("invalid password for account deletion", "user_id", userID)
+			return errors.NewUnauthorizedError("invalid credentials")
+		}
+	} else if err := platformauth.RequireRole(ctx, platformauth.RoleAdmin); err != nil {
+		a.logger.Warn("delete foreign account denied", "caller_id", callerID, "user_id", userID)
+		return err
+	}
+
+	err = a.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		if err := a.authRepo.Delete(txCtx, userID); err != nil {
+			return err
+		}
+		return a.enqueueUserDeleted(txCtx, userID)
+	})
+	if err != nil {
+		a.logger.Error("failed to delete account", "user_id", userID, "error", err)
+		return err
+	}
+
+	a.logger.Info("account deleted", "caller_id", callerID, "user_id", userID)
+	return nil
+}
+
+// enqueueUserDeleted records UserDeletedEvent in the outbox. It must be
+// called in the transaction that deletes the user.
+func (a *UseCase) enqueueUserDeleted(ctx context.Context, userID models.UserID) error {
+	event := &events.UserDeletedEvent{
+		UserId:    userID.String(),
+		DeletedAt: timestamppb.New(time.Now()),
+	}
+
+	if err := a.userEventOutbox.EnqueueUserDeletedEvent(ctx, event); err != nil {
+		a.logger.Error("failed to enqueue user deletion event", "error", err, "user_id", userID)
+		return err
+	}
+	return nil
+}
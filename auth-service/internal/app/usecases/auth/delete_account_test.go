@@ -0,0 +1,171 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
+	"github.com/SamEkb/messenger-app/pkg/api/events/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestUseCase_DeleteAccount(t *testing.T) {
+	userID := models.UserID(uuid.New())
+	otherID := models.UserID(uuid.New())
+	password := "somestrongpassword"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+
+	user, err := models.RestoreUser(userID, "testuser", "test@test.ru", hashedPassword, true, nil, time.Time{})
+	assert.NoError(t, err)
+
+	userCtx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: userID.String(),
+		Roles:  []platformauth.Role{platformauth.RoleUser},
+	})
+	adminCtx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: userID.String(),
+		Roles:  []platformauth.Role{platformauth.RoleAdmin, platformauth.RoleUser},
+	})
+
+	deletedEvent := func(userID models.UserID) any {
+		return mock.MatchedBy(func(event *events.UserDeletedEvent) bool {
+			return event.GetUserId() == userID.String() && event.GetDeletedAt() != nil
+		})
+	}
+
+	type args struct {
+		ctx context.Context
+		dto *ports.DeleteAccountDto
+	}
+	tests := map[string]struct {
+		args        args
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"own account deleted": {
+			args: args{ctx: userCtx, dto: &ports.DeleteAccountDto{Password: password}},
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByID(userCtx, userID).Return(user, nil).Once()
+				mockAuthRepo.EXPECT().Delete(userCtx, userID).Return(nil).Once()
+
+				mockOutbox := mocks.NewUserEventsOutbox(t)
+				mockOutbox.EXPECT().EnqueueUserDeletedEvent(userCtx, deletedEvent(userID)).Return(nil).Once()
+
+				return UseCase{
+					txManager:       newTxManager(t),
+					authRepo:        mockAuthRepo,
+					userEventOutbox: mockOutbox,
+					passwordHasher:  newPasswordHasher(t),
+					logger:          logger.NewMockLogger(),
+				}
+			},
+		},
+		"wrong password": {
+			args:        args{ctx: userCtx, dto: &ports.DeleteAccountDto{Password: "wrong"}},
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("invalid credentials"),
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByID(userCtx, userID).Return(user, nil).Once()
+
+				return UseCase{
+					authRepo:       mockAuthRepo,
+					passwordHasher: newPasswordHasher(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"admin deletes another account": {
+			args: args{ctx: adminCtx, dto: &ports.DeleteAccountDto{UserID: otherID}},
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().Delete(adminCtx, otherID).Return(nil).Once()
+
+				mockOutbox := mocks.NewUserEventsOutbox(t)
+				mockOutbox.EXPECT().EnqueueUserDeletedEvent(adminCtx, deletedEvent(otherID)).Return(nil).Once()
+
+				return UseCase{
+					txManager:       newTxManager(t),
+					authRepo:        mockAuthRepo,
+					userEventOutbox: mockOutbox,
+					passwordHasher:  newPasswordHasher(t),
+					logger:          logger.NewMockLogger(),
+				}
+			},
+		},
+		"user cannot delete another account": {
+			args:        args{ctx: userCtx, dto: &ports.DeleteAccountDto{UserID: otherID}},
+			wantErr:     true,
+			expectedErr: customerrors.NewForbiddenError("caller lacks required role"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					passwordHasher: newPasswordHasher(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"failed to enqueue event": {
+			args:    args{ctx: adminCtx, dto: &ports.DeleteAccountDto{UserID: otherID}},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().Delete(adminCtx, otherID).Return(nil).Once()
+
+				mockOutbox := mocks.NewUserEventsOutbox(t)
+				mockOutbox.EXPECT().
+					EnqueueUserDeletedEvent(adminCtx, deletedEvent(otherID)).
+					Return(customerrors.NewInternalError(nil, "failed to enqueue UserDeletedEvent")).
+					Once()
+
+				return UseCase{
+					txManager:       newTxManager(t),
+					authRepo:        mockAuthRepo,
+					userEventOutbox: mockOutbox,
+					passwordHasher:  newPasswordHasher(t),
+					logger:          logger.NewMockLogger(),
+				}
+			},
+		},
+		"unauthenticated": {
+			args:        args{ctx: context.Background(), dto: &ports.DeleteAccountDto{Password: password}},
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("request is not authenticated"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					passwordHasher: newPasswordHasher(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			err := useCase.DeleteAccount(tc.args.ctx, tc.args.dto)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
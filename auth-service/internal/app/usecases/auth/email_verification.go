@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// issueVerificationCode replaces any pending verification code of the user
+// with a new one. It must run inside a transaction.
+func (a *UseCase) issueVerificationCode(ctx context.Context, userID models.UserID) (models.Token, time.Time, error) {
+	code, err := models.GenerateSecretToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	expiresAt := time.Now().Add(a.emailVerificationTTL)
+	verificationCode, err := models.NewOneTimeCode(models.HashToken(code), userID, models.CodePurposeEmailVerification, expiresAt)
+	if err != nil {
+		return "", time.Time{}, errors.NewInternalError(err, "failed to create verification code")
+	}
+
+	if err := a.codeRepo.InvalidateByUser(ctx, userID, models.CodePurposeEmailVerification, time.Now()); err != nil {
+		return "", time.Time{}, err
+	}
+	if err := a.codeRepo.Create(ctx, verificationCode); err != nil {
+		return "", time.Time{}, err
+	}
+
+	return code, expiresAt, nil
+}
+
+func (a *UseCase) sendVerificationCode(ctx context.Context, user *models.User, code models.Token, expiresAt time.Time) error {
+	message := &ports.MailMessage{
+		To:      user.Email(),
+		Subject: "Confirm your email address",
+		Body: fmt.Sprintf(
+			"Use this code to confirm your email address: %s\nThe code expires at %s.",
+			code, expiresAt.UTC().Format(time.RFC1123),
+		),
+	}
+	if err := a.mailer.Send(ctx, message); err != nil {
+		a.logger.Error("failed to send verification code", "user_id", user.ID(), "error", err)
+		return errors.NewServiceError(err, "failed to send verification code")
+	}
+	return nil
+}
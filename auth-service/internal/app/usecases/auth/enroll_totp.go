@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// EnrollTOTP generates a new TOTP secret for the caller. The factor stays
+// inactive until ConfirmTOTP proves the authenticator app produces valid
+// codes; enrolling again before that replaces the pending secret.
+func (a *UseCase) EnrollTOTP(ctx context.Context) (*ports.TOTPEnrollmentDto, error) {
+	userID, _, err := callerFromContext(ctx)
+	if err != nil {
+		a.logger.Warn("unauthenticated totp enrollment attempt", "error", err)
+		return nil, err
+	}
+
+	a.logger.Debug("totp enrollment attempt", "user_id", userID)
+
+	existing, err := a.totpRepo.GetByUser(ctx, userID)
+	if err != nil && !errors.Is(err, errors.ErrNotFound) {
+		a.logger.Error("failed to get totp factor", "user_id", userID, "error", err)
+		return nil, err
+	}
+	if existing != nil && existing.IsConfirmed() {
+		a.logger.Warn("totp already enabled", "user_id", userID)
+		return nil, errors.NewAlreadyExistsError("two-factor authentication is already enabled")
+	}
+
+	user, err := a.authRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		a.logger.Warn("user not found during totp enrollment", "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	secret, err := models.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	factor, err := models.NewTOTPFactor(userID, secret)
+	if err != nil {
+		return nil, errors.NewInternalError(err, "failed to create totp factor")
+	}
+
+	if err := a.totpRepo.Save(ctx, factor); err != nil {
+		a.logger.Error("failed to save totp factor", "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	a.logger.Info("totp enrollment started", "user_id", userID)
+	return &ports.TOTPEnrollmentDto{
+		Secret: secret,
+		URI:    factor.URI(a.totpIssuer, user.Email()),
+	}, nil
+}
@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUseCase_EnrollTOTP(t *testing.T) {
+	userID := models.UserID(uuid.New())
+	ctx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID:    userID.String(),
+		SessionID: models.NewFamilyID().String(),
+	})
+
+	user, err := models.NewUser(userID, "testuser", "test@test.ru", []byte("hashed"))
+	assert.NoError(t, err)
+
+	secret, err := models.GenerateTOTPSecret()
+	assert.NoError(t, err)
+	pendingFactor, err := models.NewTOTPFactor(userID, secret)
+	assert.NoError(t, err)
+	activeFactor, err := models.RestoreTOTPFactor(userID, secret, time.Now(), 0)
+	assert.NoError(t, err)
+
+	tests := map[string]struct {
+		ctx         context.Context
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"enrollment started": {
+			ctx: ctx,
+			deps: func(t *testing.T) UseCase {
+				mockTOTPRepo := mocks.NewTOTPRepository(t)
+				mockTOTPRepo.EXPECT().
+					GetByUser(ctx, userID).
+					Return(nil, customerrors.NewNotFoundError("totp factor not found")).
+					Once()
+				mockTOTPRepo.EXPECT().
+					Save(ctx, mock.MatchedBy(func(factor *models.TOTPFactor) bool {
+						return factor.UserID() == userID && !factor.IsConfirmed()
+					})).
+					Return(nil).
+					Once()
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByID(ctx, userID).Return(user, nil).Once()
+
+				return UseCase{
+					authRepo:   mockAuthRepo,
+					totpRepo:   mockTOTPRepo,
+					totpIssuer: "Messenger",
+					logger:     logger.NewMockLogger(),
+				}
+			},
+		},
+		"pending enrollment is replaced": {
+			ctx: ctx,
+			deps: func(t *testing.T) UseCase {
+				mockTOTPRepo := mocks.NewTOTPRepository(t)
+				mockTOTPRepo.EXPECT().GetByUser(ctx, userID).Return(pendingFactor, nil).Once()
+				mockTOTPRepo.EXPECT().
+					Save(ctx, mock.MatchedBy(func(factor *models.TOTPFactor) bool {
+						return factor.Secret() != secret
+					})).
+					Return(nil).
+					Once()
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByID(ctx, userID).Return(user, nil).Once()
+
+				return UseCase{
+					authRepo:   mockAuthRepo,
+					totpRepo:   mockTOTPRepo,
+					totpIssuer: "Messenger",
+					logger:     logger.NewMockLogger(),
+				}
+			},
+		},
+		"already enabled": {
+			ctx:         ctx,
+			wantErr:     true,
+			expectedErr: customerrors.NewAlreadyExistsError("two-factor authentication is already enabled"),
+			deps: func(t *testing.T) UseCase {
+				mockTOTPRepo := mocks.NewTOTPRepository(t)
+				mockTOTPRepo.EXPECT().GetByUser(ctx, userID).Return(activeFactor, nil).Once()
+
+				return UseCase{
+					totpRepo: mockTOTPRepo,
+					logger:   logger.NewMockLogger(),
+				}
+			},
+		},
+		"unauthenticated": {
+			ctx:         context.Background(),
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("unauthenticated"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{logger: logger.NewMockLogger()}
+			},
+		},
+		"failed to save factor": {
+			ctx:     ctx,
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				mockTOTPRepo := mocks.NewTOTPRepository(t)
+				mockTOTPRepo.EXPECT().
+					GetByUser(ctx, userID).
+					Return(nil, customerrors.NewNotFoundError("totp factor not found")).
+					Once()
+				mockTOTPRepo.EXPECT().
+					Save(ctx, mock.AnythingOfType("*models.TOTPFactor")).
+					Return(errors.New("database error")).
+					Once()
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByID(ctx, userID).Return(user, nil).Once()
+
+				return UseCase{
+					authRepo: mockAuthRepo,
+					totpRepo: mockTOTPRepo,
+					logger:   logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			enrollment, err := useCase.EnrollTOTP(tc.ctx)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, enrollment.Secret)
+				assert.True(t, strings.HasPrefix(enrollment.URI, "otpauth://totp/Messenger:test@test.ru?"))
+				assert.Contains(t, enrollment.URI, "secret="+enrollment.Secret)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// impersonationDeviceName labels impersonation tokens in the session list of
+// the impersonated user.
+const impersonationDeviceName = "impersonation"
+
+// Impersonate issues a short-lived access token of another user that names
+// the calling administrator as actor. No refresh token is issued. Only
+// administrators may call it, and only accounts holding nothing but the user
+// role can be impersonated, so an impersonation token never carries a
+// privileged role.
+func (a *UseCase) Impersonate(ctx context.Context, dto *ports.ImpersonateDto) (*ports.ImpersonationDto, error) {
+	callerID, _, err := callerFromContext(ctx)
+	if err != nil {
+		a.logger.Warn("unauthenticated impersonate attempt", "error", err)
+		return nil, err
+	}
+
+	if err := platformauth.RequireRole(ctx, platformauth.RoleAdmin); err != nil {
+		a.logger.Warn("impersonate denied", "caller_id", callerID, "user_id", dto.UserID)
+		return nil, err
+	}
+
+	a.logger.Debug("impersonate attempt", "caller_id", callerID, "user_id", dto.UserID)
+
+	reason := strings.TrimSpace(dto.Reason)
+	if reason == "" {
+		return nil, errors.NewValidationError("reason cannot be empty").
+			WithDetails("field", "reason")
+	}
+	if callerID == dto.UserID {
+		return nil, errors.NewInvalidInputError("administrators cannot impersonate themselves")
+	}
+
+	user, err := a.authRepo.FindUserByID(ctx, dto.UserID)
+	if err != nil {
+		a.logger.Warn("failed to find user to impersonate", "user_id", dto.UserID, "error", err)
+		return nil, err
+	}
+	if user.IsSuspended() {
+		return nil, errors.NewForbiddenError("suspended accounts cannot be impersonated")
+	}
+	if slices.ContainsFunc(user.Roles(), func(role models.Role) bool { return role != models.RoleUser }) {
+		a.logger.Warn("impersonation of privileged user denied", "caller_id", callerID, "user_id", dto.UserID)
+		return nil, errors.NewForbiddenError("only regular users can be impersonated")
+	}
+
+	expiresAt := time.Now().Add(a.impersonationTTL)
+	token, err := a.tokenIssuer.IssueImpersonation(ctx, user, callerID, expiresAt)
+	if err != nil {
+		a.logger.Error("failed to issue impersonation token", "error", err)
+		return nil, err
+	}
+
+	authToken, err := models.NewAuthToken(token, user.ID(), models.NewFamilyID(), expiresAt)
+	if err != nil {
+		return nil, errors.NewInternalError(err, "failed to create impersonation token")
+	}
+
+	client := models.ClientFromContext(ctx)
+	session := client
+	session.DeviceName = impersonationDeviceName
+	if _, err := a.tokenRepo.Create(ctx, authToken.WithSession(session).WithActor(callerID)); err != nil {
+		a.logger.Error("failed to store impersonation token", "error", err)
+		return nil, err
+	}
+
+	a.recordSecurityEvent(ctx, models.NewSecurityEvent(models.SecurityEventImpersonationStarted, user.ID(), user.Email(), client).
+		WithActor(callerID).
+		WithReason(reason))
+
+	a.logger.Info("impersonation started", "caller_id", callerID, "user_id", user.ID(), "reason", reason, "expires_at", expiresAt)
+	return &ports.ImpersonationDto{
+		UserID:      user.ID(),
+		ActorID:     callerID,
+		AccessToken: token,
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+// auditImpersonationUse records that an impersonation token was presented.
+// The auth service and every other service validate tokens here, so each
+// request made with the token leaves an entry. The entry is kept even when
+// the user's email cannot be read.
+func (a *UseCase) auditImpersonationUse(ctx context.Context, userID, actorID models.UserID) {
+	a.logger.Info("impersonation token used", "user_id", userID, "actor_id", actorID)
+
+	var email string
+	if user, err := a.authRepo.FindUserByID(ctx, userID); err == nil {
+		email = user.Email()
+	} else {
+		a.logger.Warn("failed to get impersonated user", "user_id", userID, "error", err)
+	}
+
+	a.recordSecurityEvent(ctx, models.NewSecurityEvent(models.SecurityEventImpersonationUsed, userID, email,
+		models.ClientFromContext(ctx)).WithActor(actorID))
+}
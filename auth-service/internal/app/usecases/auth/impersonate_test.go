@@ -0,0 +1,214 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUseCase_Impersonate(t *testing.T) {
+	adminID := models.UserID(uuid.New())
+	userID := models.UserID(uuid.New())
+	impersonationTTL := 15 * time.Minute
+
+	ctx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: adminID.String(),
+		Roles:  []platformauth.Role{platformauth.RoleAdmin},
+	})
+	userCtx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: userID.String(),
+		Roles:  []platformauth.Role{platformauth.RoleUser},
+	})
+
+	type args struct {
+		ctx context.Context
+		dto *ports.ImpersonateDto
+	}
+	tests := map[string]struct {
+		args        args
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"token issued": {
+			args: args{ctx: ctx, dto: &ports.ImpersonateDto{UserID: userID, Reason: "ticket 42"}},
+			deps: func(t *testing.T) UseCase {
+				user := newUser(t, userID)
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByID(ctx, userID).Return(user, nil).Once()
+
+				mockTokenIssuer := mocks.NewTokenIssuer(t)
+				mockTokenIssuer.EXPECT().
+					IssueImpersonation(ctx, user, adminID, mock.AnythingOfType("time.Time")).
+					Return(models.Token("impersonation-token"), nil).
+					Once()
+
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().
+					Create(ctx, mock.MatchedBy(func(token *models.AuthToken) bool {
+						return token.UserID() == userID &&
+							token.ActorID() == adminID &&
+							token.Session().DeviceName == impersonationDeviceName &&
+							token.ExpiresAt().Before(time.Now().Add(impersonationTTL+time.Second))
+					})).
+					RunAndReturn(func(_ context.Context, token *models.AuthToken) (*models.AuthToken, error) {
+						return token, nil
+					}).
+					Once()
+
+				mockSecurityEventRepo := mocks.NewSecurityEventRepository(t)
+				mockSecurityEventRepo.EXPECT().
+					Append(ctx, mock.MatchedBy(func(event *models.SecurityEvent) bool {
+						return event.Type() == models.SecurityEventImpersonationStarted &&
+							event.UserID() == userID &&
+							event.ActorID() == adminID &&
+							event.Reason() == "ticket 42"
+					})).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					authRepo:          mockAuthRepo,
+					tokenIssuer:       mockTokenIssuer,
+					tokenRepo:         mockTokenRepo,
+					securityEventRepo: mockSecurityEventRepo,
+					impersonationTTL:  impersonationTTL,
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"missing reason": {
+			args:        args{ctx: ctx, dto: &ports.ImpersonateDto{UserID: userID, Reason: " "}},
+			wantErr:     true,
+			expectedErr: customerrors.NewValidationError("reason cannot be empty"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{logger: logger.NewMockLogger()}
+			},
+		},
+		"self impersonation": {
+			args:        args{ctx: ctx, dto: &ports.ImpersonateDto{UserID: adminID, Reason: "ticket 42"}},
+			wantErr:     true,
+			expectedErr: customerrors.NewInvalidInputError("administrators cannot impersonate themselves"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{logger: logger.NewMockLogger()}
+			},
+		},
+		"administrator target": {
+			args:        args{ctx: ctx, dto: &ports.ImpersonateDto{UserID: userID, Reason: "ticket 42"}},
+			wantErr:     true,
+			expectedErr: customerrors.NewForbiddenError("only regular users can be impersonated"),
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserByID(ctx, userID).
+					Return(newUser(t, userID, string(models.RoleAdmin)), nil).
+					Once()
+
+				return UseCase{
+					authRepo: mockAuthRepo,
+					logger:   logger.NewMockLogger(),
+				}
+			},
+		},
+		"moderator target": {
+			args:        args{ctx: ctx, dto: &ports.ImpersonateDto{UserID: userID, Reason: "ticket 42"}},
+			wantErr:     true,
+			expectedErr: customerrors.NewForbiddenError("only regular users can be impersonated"),
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserByID(ctx, userID).
+					Return(newUser(t, userID, string(models.RoleModerator)), nil).
+					Once()
+
+				return UseCase{
+					authRepo: mockAuthRepo,
+					logger:   logger.NewMockLogger(),
+				}
+			},
+		},
+		"suspended target": {
+			args:        args{ctx: ctx, dto: &ports.ImpersonateDto{UserID: userID, Reason: "ticket 42"}},
+			wantErr:     true,
+			expectedErr: customerrors.NewForbiddenError("suspended accounts cannot be impersonated"),
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserByID(ctx, userID).
+					Return(newUser(t, userID).WithSuspended(time.Now()), nil).
+					Once()
+
+				return UseCase{
+					authRepo: mockAuthRepo,
+					logger:   logger.NewMockLogger(),
+				}
+			},
+		},
+		"unknown target": {
+			args:        args{ctx: ctx, dto: &ports.ImpersonateDto{UserID: userID, Reason: "ticket 42"}},
+			wantErr:     true,
+			expectedErr: customerrors.NewNotFoundError("user not found"),
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserByID(ctx, userID).
+					Return(nil, customerrors.NewNotFoundError("user not found")).
+					Once()
+
+				return UseCase{
+					authRepo: mockAuthRepo,
+					logger:   logger.NewMockLogger(),
+				}
+			},
+		},
+		"caller is not an administrator": {
+			args:        args{ctx: userCtx, dto: &ports.ImpersonateDto{UserID: adminID, Reason: "ticket 42"}},
+			wantErr:     true,
+			expectedErr: customerrors.NewForbiddenError("caller lacks required role"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{logger: logger.NewMockLogger()}
+			},
+		},
+		"unauthenticated": {
+			args:        args{ctx: context.Background(), dto: &ports.ImpersonateDto{UserID: userID, Reason: "ticket 42"}},
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("request is not authenticated"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{logger: logger.NewMockLogger()}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			result, err := useCase.Impersonate(tc.args.ctx, tc.args.dto)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, userID, result.UserID)
+				assert.Equal(t, adminID, result.ActorID)
+				assert.Equal(t, models.Token("impersonation-token"), result.AccessToken)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+)
+
+func (a *UseCase) ListSessions(ctx context.Context) ([]*ports.SessionDto, error) {
+	userID, currentSessionID, err := callerFromContext(ctx)
+	if err != nil {
+		a.logger.Warn("unauthenticated list sessions attempt", "error", err)
+		return nil, err
+	}
+
+	a.logger.Debug("list sessions attempt", "user_id", userID)
+
+	sessions, err := a.tokenRepo.ListSessions(ctx, userID)
+	if err != nil {
+		a.logger.Error("failed to list sessions", "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	result := make([]*ports.SessionDto, 0, len(sessions))
+	for _, session := range sessions {
+		result = append(result, &ports.SessionDto{
+			ID:         session.FamilyID(),
+			DeviceName: session.Session().DeviceName,
+			UserAgent:  session.Session().UserAgent,
+			IP:         session.Session().IP,
+			CreatedAt:  session.CreatedAt(),
+			LastUsedAt: session.LastUsedAt(),
+			ExpiresAt:  session.ExpiresAt(),
+			Current:    session.FamilyID() == currentSessionID,
+		})
+	}
+
+	a.logger.Debug("sessions listed", "user_id", userID, "count", len(result))
+	return result, nil
+}
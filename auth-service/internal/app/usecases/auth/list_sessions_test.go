@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUseCase_ListSessions(t *testing.T) {
+	userID := models.UserID(uuid.New())
+	currentSessionID := models.NewFamilyID()
+	otherSessionID := models.NewFamilyID()
+	ctx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID:    userID.String(),
+		SessionID: currentSessionID.String(),
+	})
+
+	current, err := models.RestoreAuthToken(
+		"current-token", userID, currentSessionID, time.Now().Add(time.Hour),
+		models.SessionMetadata{DeviceName: "laptop", UserAgent: "firefox", IP: "10.0.0.1"},
+		time.Now().Add(-time.Hour), time.Now(),
+	)
+	assert.NoError(t, err)
+	other, err := models.RestoreAuthToken(
+		"other-token", userID, otherSessionID, time.Now().Add(time.Hour),
+		models.SessionMetadata{DeviceName: "phone"},
+		time.Now().Add(-2*time.Hour), time.Now().Add(-time.Hour),
+	)
+	assert.NoError(t, err)
+
+	type args struct {
+		ctx context.Context
+	}
+	tests := map[string]struct {
+		args        args
+		wantIDs     []models.FamilyID
+		wantCurrent []bool
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"sessions listed": {
+			args:        args{ctx: ctx},
+			wantIDs:     []models.FamilyID{currentSessionID, otherSessionID},
+			wantCurrent: []bool{true, false},
+			deps: func(t *testing.T) UseCase {
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().
+					ListSessions(ctx, userID).
+					Return([]*models.AuthToken{current, other}, nil).
+					Once()
+
+				return UseCase{
+					tokenRepo: mockTokenRepo,
+					logger:    logger.NewMockLogger(),
+				}
+			},
+		},
+		"unauthenticated": {
+			args:        args{ctx: context.Background()},
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("request is not authenticated"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					tokenRepo: mocks.NewTokenRepository(t),
+					logger:    logger.NewMockLogger(),
+				}
+			},
+		},
+		"repository error": {
+			args:    args{ctx: ctx},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().
+					ListSessions(ctx, userID).
+					Return(nil, errors.New("database error")).
+					Once()
+
+				return UseCase{
+					tokenRepo: mockTokenRepo,
+					logger:    logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			sessions, err := useCase.ListSessions(tc.args.ctx)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, sessions, len(tc.wantIDs))
+			for i, session := range sessions {
+				assert.Equal(t, tc.wantIDs[i], session.ID)
+				assert.Equal(t, tc.wantCurrent[i], session.Current)
+			}
+		})
+	}
+}
@@ -7,43 +7,113 @@ import (
 	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
 	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
 	"github.com/SamEkb/messenger-app/pkg/platform/errors"
-	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
 )
 
-func (a *UseCase) Login(ctx context.Context, dto *ports.LoginDto) (models.Token, error) {
+func (a *UseCase) Login(ctx context.Context, dto *ports.LoginDto) (*ports.LoginResultDto, error) {
 	a.logger.Debug("login attempt", "email", dto.Email)
 
+	now := time.Now()
+	attemptKeys := loginAttemptKeys(dto.Email, dto.Session.IP)
+	if err := a.checkLoginLockout(ctx, attemptKeys, now); err != nil {
+		if errors.Is(err, errors.ErrResourceExhausted) {
+			a.auditLoginFailure(ctx, models.UserID{}, dto.Email, dto.Session, loginFailureThrottled)
+		}
+		return nil, err
+	}
+
 	user, err := a.authRepo.FindUserByEmail(ctx, dto.Email)
 	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			a.recordLoginFailure(ctx, attemptKeys, now)
+			a.auditLoginFailure(ctx, models.UserID{}, dto.Email, dto.Session, loginFailureUnknownAccount)
+		}
 		a.logger.Warn("user not found during login", "email", dto.Email, "error", err)
-		return "", err
+		return nil, err
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password()), []byte(dto.Password)); err != nil {
+	if err := a.passwordHasher.Compare([]byte(user.Password()), dto.Password); err != nil {
+		a.recordLoginFailure(ctx, attemptKeys, now)
+		a.auditLoginFailure(ctx, user.ID(), dto.Email, dto.Session, loginFailureInvalidPassword)
 		a.logger.Warn("invalid credentials", "email", dto.Email)
-		return "", errors.NewUnauthorizedError("invalid credentials").
+		return nil, errors.NewUnauthorizedError("invalid credentials").
 			WithDetails("email", dto.Email)
 	}
 
-	token, err := models.NewAuthToken(
-		models.Token(uuid.New().String()),
-		user.ID(),
-		time.Now().Add(a.tokenTTL),
-	)
+	a.resetLoginFailures(ctx, dto.Email)
+	user = a.upgradePasswordHash(ctx, user, dto.Password)
+
+	return a.completeLogin(ctx, user, dto.Session)
+}
+
+// upgradePasswordHash rehashes a just verified password when its stored hash
+// uses a weaker algorithm or cost than configured. Errors are logged only:
+// the old hash keeps working and the upgrade is retried on the next login.
+func (a *UseCase) upgradePasswordHash(ctx context.Context, user *models.User, password string) *models.User {
+	if !a.passwordHasher.NeedsRehash([]byte(user.Password())) {
+		return user
+	}
+
+	hashedPassword, err := a.passwordHasher.Hash(password)
+	if err != nil {
+		a.logger.Warn("failed to rehash password", "user_id", user.ID(), "error", err)
+		return user
+	}
+
+	updated, err := user.WithPassword(hashedPassword)
+	if err != nil {
+		return user
+	}
+
+	if err := a.authRepo.Update(ctx, updated); err != nil {
+		a.logger.Warn("failed to store upgraded password hash", "user_id", user.ID(), "error", err)
+		return user
+	}
+
+	a.logger.Info("password hash upgraded", "user_id", user.ID())
+	return updated
+}
+
+// completeLogin finishes a login whose first factor has been checked: it
+// enforces email verification, then either starts a second factor challenge
+// or opens a session.
+func (a *UseCase) completeLogin(ctx context.Context, user *models.User, session models.SessionMetadata) (*ports.LoginResultDto, error) {
+	if user.IsSuspended() {
+		a.auditLoginFailure(ctx, user.ID(), user.Email(), session, loginFailureSuspended)
+		a.logger.Warn("login to suspended account", "user_id", user.ID())
+		return nil, accountSuspendedError(user)
+	}
+
+	if a.requireVerifiedEmail && !user.EmailVerified() {
+		a.auditLoginFailure(ctx, user.ID(), user.Email(), session, loginFailureUnverifiedEmail)
+		a.logger.Warn("login with unverified email", "user_id", user.ID())
+		return nil, errors.NewForbiddenError("email address is not verified").
+			WithDetails("email", user.Email())
+	}
+
+	required, err := a.secondFactorRequired(ctx, user.ID())
 	if err != nil {
-		a.logger.Error("failed to create auth token", "error", err)
-		return "", errors.NewInternalError(err, "failed to create auth token").
-			WithDetails("user_id", user.ID().String())
+		a.logger.Error("failed to check second factor", "user_id", user.ID(), "error", err)
+		return nil, err
+	}
+	if required {
+		challenge, err := a.startSecondFactorChallenge(ctx, user.ID())
+		if err != nil {
+			return nil, err
+		}
+		a.logger.Info("login requires second factor", "user_id", user.ID())
+		return &ports.LoginResultDto{Challenge: challenge}, nil
 	}
 
-	token, err = a.tokenRepo.Create(ctx, token)
+	var tokens *ports.AuthTokensDto
+	err = a.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		tokens, err = a.issueTokens(txCtx, user, session)
+		return err
+	})
 	if err != nil {
-		a.logger.Error("failed to save token", "error", err)
-		return "", errors.NewInternalError(err, "failed to save token").
-			WithDetails("user_id", user.ID().String())
+		return nil, err
 	}
 
-	a.logger.Info("login successful", "user_id", user.ID(), "token", token.Token())
-	return token.Token(), nil
+	a.recordSecurityEvent(ctx, models.NewSecurityEvent(models.SecurityEventLoginSucceeded, user.ID(), user.Email(), session))
+	a.logger.Info("login successful", "user_id", user.ID())
+	return &ports.LoginResultDto{Tokens: tokens}, nil
 }
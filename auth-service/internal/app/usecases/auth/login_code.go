@@ -0,0 +1,199 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// RequestLoginCode mails a passwordless login code, or a link carrying one,
+// and invalidates the previous code. Unknown addresses are not reported, so
+// the endpoint cannot be used to probe which emails are registered.
+func (a *UseCase) RequestLoginCode(ctx context.Context, email string) error {
+	if !a.loginCodePolicy.Enabled() {
+		return errors.NewForbiddenError("passwordless login is disabled")
+	}
+
+	a.logger.Debug("login code requested", "email", email)
+
+	user, err := a.authRepo.FindUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			a.logger.Info("login code requested for unknown email", "email", email)
+			return nil
+		}
+		a.logger.Error("failed to find user for login code", "error", err)
+		return err
+	}
+
+	if user.IsSuspended() {
+		a.logger.Warn("login code requested for suspended account", "user_id", user.ID())
+		return nil
+	}
+
+	code, err := models.GenerateLoginCode(a.loginCodePolicy.Mode)
+	if err != nil {
+		return err
+	}
+	hash, err := models.HashLoginCode(code)
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Add(a.loginCodePolicy.TTL)
+	loginCode, err := models.NewOneTimeCode(hash, user.ID(), models.CodePurposeLogin, expiresAt)
+	if err != nil {
+		return errors.NewInternalError(err, "failed to create login code")
+	}
+
+	err = a.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		if err := a.codeRepo.InvalidateByUser(txCtx, user.ID(), models.CodePurposeLogin, time.Now()); err != nil {
+			return err
+		}
+		return a.codeRepo.Create(txCtx, loginCode)
+	})
+	if err != nil {
+		a.logger.Error("failed to store login code", "user_id", user.ID(), "error", err)
+		return err
+	}
+
+	if err := a.mailer.Send(ctx, a.loginCodeMessage(user, code, expiresAt)); err != nil {
+		a.logger.Error("failed to send login code", "user_id", user.ID(), "error", err)
+		return errors.NewServiceError(err, "failed to send login code")
+	}
+
+	a.logger.Info("login code sent", "user_id", user.ID())
+	return nil
+}
+
+func (a *UseCase) loginCodeMessage(user *models.User, code models.Token, expiresAt time.Time) *ports.MailMessage {
+	expires := expiresAt.UTC().Format(time.RFC1123)
+
+	if a.loginCodePolicy.Mode == models.LoginCodeLink {
+		query := url.Values{"email": {user.Email()}, "code": {string(code)}}
+		return &ports.MailMessage{
+			To:      user.Email(),
+			Subject: "Your login link",
+			Body: fmt.Sprintf(
+				"Open this link to log in: %s?%s\nThe link expires at %s.",
+				a.loginCodePolicy.LinkURL, query.Encode(), expires,
+			),
+		}
+	}
+
+	return &ports.MailMessage{
+		To:      user.Email(),
+		Subject: "Your login code",
+		Body:    fmt.Sprintf("Use this code to log in: %s\nThe code expires at %s.", code, expires),
+	}
+}
+
+// LoginWithCode logs in with a code sent by RequestLoginCode. It counts as
+// the first factor, like a password: failures are throttled the same way and
+// a second factor is still asked for when enrolled. Receiving the code proves
+// the address, so it is marked verified.
+func (a *UseCase) LoginWithCode(ctx context.Context, dto *ports.LoginWithCodeDto) (*ports.LoginResultDto, error) {
+	if !a.loginCodePolicy.Enabled() {
+		return nil, errors.NewForbiddenError("passwordless login is disabled")
+	}
+
+	a.logger.Debug("login with code attempt", "email", dto.Email)
+
+	now := time.Now()
+	attemptKeys := loginAttemptKeys(dto.Email, dto.Session.IP)
+	if err := a.checkLoginLockout(ctx, attemptKeys, now); err != nil {
+		if errors.Is(err, errors.ErrResourceExhausted) {
+			a.auditLoginFailure(ctx, models.UserID{}, dto.Email, dto.Session, loginFailureThrottled)
+		}
+		return nil, err
+	}
+
+	user, err := a.authRepo.FindUserByEmail(ctx, dto.Email)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			a.recordLoginFailure(ctx, attemptKeys, now)
+			a.auditLoginFailure(ctx, models.UserID{}, dto.Email, dto.Session, loginFailureUnknownAccount)
+			a.logger.Warn("login code for unknown email", "email", dto.Email)
+			return nil, errors.NewUnauthorizedError("invalid login code")
+		}
+		a.logger.Error("failed to find user for login code", "error", err)
+		return nil, err
+	}
+
+	loginCode, err := a.codeRepo.GetActiveByUser(ctx, user.ID(), models.CodePurposeLogin)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			a.recordLoginFailure(ctx, attemptKeys, now)
+			a.auditLoginFailure(ctx, user.ID(), dto.Email, dto.Session, loginFailureInvalidLoginCode)
+			a.logger.Warn("no active login code", "user_id", user.ID())
+			return nil, errors.NewUnauthorizedError("invalid login code")
+		}
+		a.logger.Error("failed to get login code", "user_id", user.ID(), "error", err)
+		return nil, err
+	}
+
+	if loginCode.IsExpired() {
+		a.logger.Warn("login code expired", "user_id", user.ID())
+		return nil, errors.NewTokenError(errors.ErrTokenExpired, "login code is expired")
+	}
+
+	if !models.LoginCodeMatches(loginCode.Hash(), dto.Code) {
+		a.recordLoginFailure(ctx, attemptKeys, now)
+		a.auditLoginFailure(ctx, user.ID(), dto.Email, dto.Session, loginFailureInvalidLoginCode)
+		return nil, a.rejectLoginCode(ctx, loginCode)
+	}
+
+	err = a.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		if err := a.codeRepo.MarkUsed(txCtx, loginCode.Hash(), now); err != nil {
+			return err
+		}
+		if user.EmailVerified() {
+			return nil
+		}
+		user = user.WithEmailVerified()
+		return a.authRepo.Update(txCtx, user)
+	})
+	if err != nil {
+		if errors.Is(err, errors.ErrInvalidToken) {
+			a.logger.Warn("login code already used", "user_id", user.ID())
+			return nil, errors.NewUnauthorizedError("invalid login code")
+		}
+		a.logger.Error("failed to redeem login code", "user_id", user.ID(), "error", err)
+		return nil, err
+	}
+
+	a.resetLoginFailures(ctx, dto.Email)
+
+	return a.completeLogin(ctx, user, dto.Session)
+}
+
+// rejectLoginCode counts a wrong guess and burns the code once the attempt
+// limit is reached.
+func (a *UseCase) rejectLoginCode(ctx context.Context, loginCode *models.OneTimeCode) error {
+	userID := loginCode.UserID()
+	a.logger.Warn("invalid login code", "user_id", userID)
+
+	attempts, err := a.codeRepo.RecordFailedAttempt(ctx, loginCode.Hash())
+	if err != nil {
+		a.logger.Error("failed to record login code attempt", "user_id", userID, "error", err)
+		return err
+	}
+
+	maxAttempts := max(a.loginCodePolicy.MaxAttempts, 1)
+	if attempts >= maxAttempts {
+		if err := a.codeRepo.MarkUsed(ctx, loginCode.Hash(), time.Now()); err != nil && !errors.Is(err, errors.ErrInvalidToken) {
+			a.logger.Error("failed to invalidate login code", "user_id", userID, "error", err)
+			return err
+		}
+		a.logger.Warn("login code exhausted", "user_id", userID)
+		return errors.NewUnauthorizedError("too many invalid codes, request a new one")
+	}
+
+	return errors.NewUnauthorizedError("invalid login code").
+		WithDetails("attempts_left", maxAttempts-attempts)
+}
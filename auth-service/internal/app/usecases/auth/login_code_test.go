@@ -0,0 +1,414 @@
+package auth
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUseCase_RequestLoginCode(t *testing.T) {
+	ctx := context.Background()
+	userID := models.UserID(uuid.New())
+	user := newUser(t, userID)
+
+	codePolicy := models.LoginCodePolicy{Mode: models.LoginCodeDigits, TTL: 10 * time.Minute, MaxAttempts: 3}
+	linkPolicy := models.LoginCodePolicy{
+		Mode:        models.LoginCodeLink,
+		TTL:         10 * time.Minute,
+		MaxAttempts: 3,
+		LinkURL:     "https://messenger.test/login",
+	}
+	sixDigits := regexp.MustCompile(`\b\d{6}\b`)
+
+	tests := map[string]struct {
+		email       string
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"code sent": {
+			email: user.Email(),
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByEmail(ctx, user.Email()).Return(user, nil).Once()
+
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().
+					InvalidateByUser(ctx, userID, models.CodePurposeLogin, mock.AnythingOfType("time.Time")).
+					Return(nil).
+					Once()
+				mockCodeRepo.EXPECT().
+					Create(ctx, mock.MatchedBy(func(code *models.OneTimeCode) bool {
+						return code.UserID() == userID &&
+							code.Purpose() == models.CodePurposeLogin &&
+							!code.IsExpired()
+					})).
+					Return(nil).
+					Once()
+
+				mockMailer := mocks.NewMailer(t)
+				mockMailer.EXPECT().
+					Send(ctx, mock.MatchedBy(func(message *ports.MailMessage) bool {
+						return message.To == user.Email() && sixDigits.MatchString(message.Body)
+					})).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					txManager:       newTxManager(t),
+					authRepo:        mockAuthRepo,
+					codeRepo:        mockCodeRepo,
+					mailer:          mockMailer,
+					loginCodePolicy: codePolicy,
+					logger:          logger.NewMockLogger(),
+				}
+			},
+		},
+		"link sent": {
+			email: user.Email(),
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByEmail(ctx, user.Email()).Return(user, nil).Once()
+
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().
+					InvalidateByUser(ctx, userID, models.CodePurposeLogin, mock.AnythingOfType("time.Time")).
+					Return(nil).
+					Once()
+				mockCodeRepo.EXPECT().Create(ctx, mock.AnythingOfType("*models.OneTimeCode")).Return(nil).Once()
+
+				mockMailer := mocks.NewMailer(t)
+				mockMailer.EXPECT().
+					Send(ctx, mock.MatchedBy(func(message *ports.MailMessage) bool {
+						return strings.Contains(message.Body, linkPolicy.LinkURL+"?code=")
+					})).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					txManager:       newTxManager(t),
+					authRepo:        mockAuthRepo,
+					codeRepo:        mockCodeRepo,
+					mailer:          mockMailer,
+					loginCodePolicy: linkPolicy,
+					logger:          logger.NewMockLogger(),
+				}
+			},
+		},
+		"unknown email": {
+			email: "nobody@test.ru",
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserByEmail(ctx, "nobody@test.ru").
+					Return(nil, customerrors.NewNotFoundError("user not found")).
+					Once()
+
+				return UseCase{
+					authRepo:        mockAuthRepo,
+					loginCodePolicy: codePolicy,
+					logger:          logger.NewMockLogger(),
+				}
+			},
+		},
+		"disabled": {
+			email:       user.Email(),
+			wantErr:     true,
+			expectedErr: customerrors.NewForbiddenError("passwordless login is disabled"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					loginCodePolicy: models.LoginCodePolicy{Mode: models.LoginCodeOff},
+					logger:          logger.NewMockLogger(),
+				}
+			},
+		},
+		"mailer failure": {
+			email:       user.Email(),
+			wantErr:     true,
+			expectedErr: customerrors.NewServiceError(assert.AnError, "failed to send login code"),
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByEmail(ctx, user.Email()).Return(user, nil).Once()
+
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().
+					InvalidateByUser(ctx, userID, models.CodePurposeLogin, mock.AnythingOfType("time.Time")).
+					Return(nil).
+					Once()
+				mockCodeRepo.EXPECT().Create(ctx, mock.AnythingOfType("*models.OneTimeCode")).Return(nil).Once()
+
+				mockMailer := mocks.NewMailer(t)
+				mockMailer.EXPECT().Send(ctx, mock.AnythingOfType("*ports.MailMessage")).Return(assert.AnError).Once()
+
+				return UseCase{
+					txManager:       newTxManager(t),
+					authRepo:        mockAuthRepo,
+					codeRepo:        mockCodeRepo,
+					mailer:          mockMailer,
+					loginCodePolicy: codePolicy,
+					logger:          logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			err := useCase.RequestLoginCode(ctx, tc.email)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUseCase_LoginWithCode(t *testing.T) {
+	ctx := context.Background()
+	userID := models.UserID(uuid.New())
+	user := newUser(t, userID)
+	email := user.Email()
+	session := models.SessionMetadata{DeviceName: "phone"}
+
+	policy := models.LoginCodePolicy{Mode: models.LoginCodeDigits, TTL: 10 * time.Minute, MaxAttempts: 3}
+	lockoutPolicy := models.LockoutPolicy{
+		FreeAttempts:     3,
+		BackoffBase:      time.Second,
+		BackoffMax:       time.Minute,
+		LockoutThreshold: 10,
+		LockoutDuration:  15 * time.Minute,
+		FailureWindow:    time.Hour,
+	}
+	failedOnce, err := models.RestoreLoginAttempt(models.LoginAttemptKeyForEmail(email), 1, time.Now(), time.Time{})
+	assert.NoError(t, err)
+
+	code := models.Token("123456")
+	hash, err := models.HashLoginCode(code)
+	assert.NoError(t, err)
+	activeCode, err := models.NewOneTimeCode(hash, userID, models.CodePurposeLogin, time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	expiredCode, err := models.NewOneTimeCode(hash, userID, models.CodePurposeLogin, time.Now().Add(-time.Minute))
+	assert.NoError(t, err)
+
+	// failedAttemptRepo expects the lockout check and one counted failure.
+	failedAttemptRepo := func(t *testing.T) *mocks.LoginAttemptRepository {
+		repo := newLoginAttemptRepo(t, ctx, loginAttemptKeys(email, ""))
+		repo.EXPECT().
+			RecordFailure(ctx, models.LoginAttemptKeyForEmail(email), mock.AnythingOfType("time.Time"), time.Hour).
+			Return(failedOnce, nil).
+			Once()
+		return repo
+	}
+
+	tests := map[string]struct {
+		code        models.Token
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"login successful": {
+			code: code,
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByEmail(ctx, email).Return(user, nil).Once()
+
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().GetActiveByUser(ctx, userID, models.CodePurposeLogin).Return(activeCode, nil).Once()
+				mockCodeRepo.EXPECT().MarkUsed(ctx, hash, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+				mockLoginAttemptRepo := newLoginAttemptRepo(t, ctx, loginAttemptKeys(email, ""))
+				mockLoginAttemptRepo.EXPECT().Reset(ctx, models.LoginAttemptKeyForEmail(email)).Return(nil).Once()
+
+				mockTOTPRepo := mocks.NewTOTPRepository(t)
+				mockTOTPRepo.EXPECT().
+					GetByUser(ctx, userID).
+					Return(nil, customerrors.NewNotFoundError("totp factor not found")).
+					Once()
+
+				mockTokenIssuer := mocks.NewTokenIssuer(t)
+				mockTokenIssuer.EXPECT().
+					Issue(ctx, userWithID(userID), mock.AnythingOfType("time.Time")).
+					Return(models.Token("access-token"), nil).
+					Once()
+
+				authToken, err := models.NewAuthToken("access-token", userID, models.NewFamilyID(), time.Now().Add(time.Minute))
+				assert.NoError(t, err)
+
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().
+					Create(ctx, mock.MatchedBy(func(token *models.AuthToken) bool {
+						return token.Session() == session
+					})).
+					Return(authToken, nil).
+					Once()
+
+				mockRefreshTokenRepo := mocks.NewRefreshTokenRepository(t)
+				mockRefreshTokenRepo.EXPECT().
+					Create(ctx, mock.AnythingOfType("*models.RefreshToken")).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					txManager:         newTxManager(t),
+					authRepo:          mockAuthRepo,
+					codeRepo:          mockCodeRepo,
+					loginAttemptRepo:  mockLoginAttemptRepo,
+					totpRepo:          mockTOTPRepo,
+					tokenIssuer:       mockTokenIssuer,
+					tokenRepo:         mockTokenRepo,
+					refreshTokenRepo:  mockRefreshTokenRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					lockoutPolicy:     lockoutPolicy,
+					loginCodePolicy:   policy,
+					tokenTTL:          15 * time.Minute,
+					refreshTokenTTL:   time.Hour,
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"wrong code": {
+			code:        "654321",
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("invalid login code"),
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByEmail(ctx, email).Return(user, nil).Once()
+
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().GetActiveByUser(ctx, userID, models.CodePurposeLogin).Return(activeCode, nil).Once()
+				mockCodeRepo.EXPECT().RecordFailedAttempt(ctx, hash).Return(1, nil).Once()
+
+				return UseCase{
+					authRepo:          mockAuthRepo,
+					codeRepo:          mockCodeRepo,
+					loginAttemptRepo:  failedAttemptRepo(t),
+					securityEventRepo: newSecurityEventRepo(t),
+					lockoutPolicy:     lockoutPolicy,
+					loginCodePolicy:   policy,
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"attempts exhausted": {
+			code:        "654321",
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("too many invalid codes, request a new one"),
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByEmail(ctx, email).Return(user, nil).Once()
+
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().GetActiveByUser(ctx, userID, models.CodePurposeLogin).Return(activeCode, nil).Once()
+				mockCodeRepo.EXPECT().RecordFailedAttempt(ctx, hash).Return(3, nil).Once()
+				mockCodeRepo.EXPECT().MarkUsed(ctx, hash, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+				return UseCase{
+					authRepo:          mockAuthRepo,
+					codeRepo:          mockCodeRepo,
+					loginAttemptRepo:  failedAttemptRepo(t),
+					securityEventRepo: newSecurityEventRepo(t),
+					lockoutPolicy:     lockoutPolicy,
+					loginCodePolicy:   policy,
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"code expired": {
+			code:        code,
+			wantErr:     true,
+			expectedErr: customerrors.NewTokenError(customerrors.ErrTokenExpired, "login code is expired"),
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByEmail(ctx, email).Return(user, nil).Once()
+
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().GetActiveByUser(ctx, userID, models.CodePurposeLogin).Return(expiredCode, nil).Once()
+
+				return UseCase{
+					authRepo:         mockAuthRepo,
+					codeRepo:         mockCodeRepo,
+					loginAttemptRepo: newLoginAttemptRepo(t, ctx, loginAttemptKeys(email, "")),
+					lockoutPolicy:    lockoutPolicy,
+					loginCodePolicy:  policy,
+					logger:           logger.NewMockLogger(),
+				}
+			},
+		},
+		"no code requested": {
+			code:        code,
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("invalid login code"),
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByEmail(ctx, email).Return(user, nil).Once()
+
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().
+					GetActiveByUser(ctx, userID, models.CodePurposeLogin).
+					Return(nil, customerrors.NewNotFoundError("code not found")).
+					Once()
+
+				return UseCase{
+					authRepo:          mockAuthRepo,
+					codeRepo:          mockCodeRepo,
+					loginAttemptRepo:  failedAttemptRepo(t),
+					securityEventRepo: newSecurityEventRepo(t),
+					lockoutPolicy:     lockoutPolicy,
+					loginCodePolicy:   policy,
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"disabled": {
+			code:        code,
+			wantErr:     true,
+			expectedErr: customerrors.NewForbiddenError("passwordless login is disabled"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{logger: logger.NewMockLogger()}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			result, err := useCase.LoginWithCode(ctx, &ports.LoginWithCodeDto{
+				Email:   email,
+				Code:    tc.code,
+				Session: session,
+			})
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, models.Token("access-token"), result.Tokens.AccessToken)
+			}
+		})
+	}
+}
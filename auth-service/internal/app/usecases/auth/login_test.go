@@ -17,26 +17,56 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// newLoginAttemptRepo expects a lockout check for every key, none of them locked.
+func newLoginAttemptRepo(t *testing.T, ctx context.Context, keys []string) *mocks.LoginAttemptRepository {
+	repo := mocks.NewLoginAttemptRepository(t)
+	for _, key := range keys {
+		repo.EXPECT().
+			Get(ctx, key).
+			Return(nil, customerrors.NewNotFoundError("no failed login attempts")).
+			Once()
+	}
+	return repo
+}
+
 func TestUseCase_Login(t *testing.T) {
-	ttlDuration := time.Hour
+	ttlDuration := 15 * time.Minute
+	refreshTTLDuration := 30 * 24 * time.Hour
 	ctx := context.Background()
 
 	userID := models.UserID(uuid.New())
 	email := "test@test.ru"
 	password := "somestrongpassword"
+	session := models.SessionMetadata{DeviceName: "laptop", UserAgent: "test-agent", IP: "127.0.0.1"}
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 
+	lockoutPolicy := models.LockoutPolicy{
+		FreeAttempts:     3,
+		BackoffBase:      time.Second,
+		BackoffMax:       time.Minute,
+		LockoutThreshold: 10,
+		LockoutDuration:  15 * time.Minute,
+		FailureWindow:    time.Hour,
+	}
+	failedOnce, err := models.RestoreLoginAttempt(models.LoginAttemptKeyForEmail(email), 1, time.Now(), time.Time{})
+	assert.NoError(t, err)
+	failedOften, err := models.RestoreLoginAttempt(models.LoginAttemptKeyForEmail(email), 5, time.Now(), time.Time{})
+	assert.NoError(t, err)
+	locked, err := models.RestoreLoginAttempt(models.LoginAttemptKeyForIP(session.IP), 10, time.Now(), time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+
 	type args struct {
 		ctx context.Context
 		dto *ports.LoginDto
 	}
 
 	tests := map[string]struct {
-		args        args
-		want        models.Token
-		wantErr     bool
-		expectedErr error
-		deps        func(t *testing.T) UseCase
+		args          args
+		want          models.Token
+		wantChallenge bool
+		wantErr       bool
+		expectedErr   error
+		deps          func(t *testing.T) UseCase
 	}{
 		"login successful": {
 			args: args{
@@ -44,6 +74,7 @@ func TestUseCase_Login(t *testing.T) {
 				dto: &ports.LoginDto{
 					Email:    email,
 					Password: password,
+					Session:  session,
 				},
 			},
 			want:    models.Token("very-strong-token"),
@@ -59,24 +90,61 @@ func TestUseCase_Login(t *testing.T) {
 					Once()
 
 				expectedToken := models.Token("very-strong-token")
+
+				mockTOTPRepo := mocks.NewTOTPRepository(t)
+				mockTOTPRepo.EXPECT().
+					GetByUser(ctx, userID).
+					Return(nil, customerrors.NewNotFoundError("totp factor not found")).
+					Once()
+
+				mockTokenIssuer := mocks.NewTokenIssuer(t)
+				mockTokenIssuer.EXPECT().
+					Issue(ctx, mockUser, mock.AnythingOfType("time.Time")).
+					Return(expectedToken, nil).
+					Once()
+
 				mockAuthToken, err := models.NewAuthToken(
 					expectedToken,
 					userID,
+					models.NewFamilyID(),
 					time.Now().Add(ttlDuration),
 				)
 				assert.NoError(t, err)
 
 				mockTokenRepo := mocks.NewTokenRepository(t)
 				mockTokenRepo.EXPECT().
-					Create(ctx, mock.AnythingOfType("*models.AuthToken")).
+					Create(ctx, mock.MatchedBy(func(token *models.AuthToken) bool {
+						return token.Session() == session
+					})).
 					Return(mockAuthToken, nil).
 					Once()
 
+				mockRefreshTokenRepo := mocks.NewRefreshTokenRepository(t)
+				mockRefreshTokenRepo.EXPECT().
+					Create(ctx, mock.AnythingOfType("*models.RefreshToken")).
+					Return(nil).
+					Once()
+
+				mockLoginAttemptRepo := newLoginAttemptRepo(t, ctx, loginAttemptKeys(email, session.IP))
+				mockLoginAttemptRepo.EXPECT().
+					Reset(ctx, models.LoginAttemptKeyForEmail(email)).
+					Return(nil).
+					Once()
+
 				return UseCase{
-					authRepo:  mockAuthRepo,
-					tokenRepo: mockTokenRepo,
-					logger:    logger.NewMockLogger(),
-					tokenTTL:  ttlDuration,
+					loginAttemptRepo:  mockLoginAttemptRepo,
+					lockoutPolicy:     lockoutPolicy,
+					txManager:         newTxManager(t),
+					authRepo:          mockAuthRepo,
+					tokenRepo:         mockTokenRepo,
+					refreshTokenRepo:  mockRefreshTokenRepo,
+					totpRepo:          mockTOTPRepo,
+					tokenIssuer:       mockTokenIssuer,
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+					tokenTTL:          ttlDuration,
+					refreshTokenTTL:   refreshTTLDuration,
 				}
 			},
 		},
@@ -99,11 +167,17 @@ func TestUseCase_Login(t *testing.T) {
 
 				mockTokenRepo := mocks.NewTokenRepository(t)
 
+				mockLoginAttemptRepo := newLoginAttemptRepo(t, ctx, loginAttemptKeys("test@test.ru", ""))
+
 				return UseCase{
-					authRepo:  mockAuthRepo,
-					tokenRepo: mockTokenRepo,
-					logger:    logger.NewMockLogger(),
-					tokenTTL:  ttlDuration,
+					loginAttemptRepo:  mockLoginAttemptRepo,
+					lockoutPolicy:     lockoutPolicy,
+					authRepo:          mockAuthRepo,
+					tokenRepo:         mockTokenRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+					tokenTTL:          ttlDuration,
 				}
 			},
 		},
@@ -129,11 +203,205 @@ func TestUseCase_Login(t *testing.T) {
 					Once()
 				mockTokenRepo := mocks.NewTokenRepository(t)
 
+				mockLoginAttemptRepo := newLoginAttemptRepo(t, ctx, loginAttemptKeys(email, ""))
+				mockLoginAttemptRepo.EXPECT().
+					RecordFailure(ctx, models.LoginAttemptKeyForEmail(email), mock.AnythingOfType("time.Time"), time.Hour).
+					Return(failedOnce, nil).
+					Once()
+
+				mockSecurityEventRepo := mocks.NewSecurityEventRepository(t)
+				mockSecurityEventRepo.EXPECT().
+					Append(ctx, mock.MatchedBy(func(event *models.SecurityEvent) bool {
+						return event.Type() == models.SecurityEventLoginFailed &&
+							event.UserID() == userID && event.Reason() == loginFailureInvalidPassword
+					})).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					loginAttemptRepo:  mockLoginAttemptRepo,
+					lockoutPolicy:     lockoutPolicy,
+					authRepo:          mockAuthRepo,
+					tokenRepo:         mockTokenRepo,
+					securityEventRepo: mockSecurityEventRepo,
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+					tokenTTL:          ttlDuration,
+				}
+			},
+		},
+		"email not verified": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.LoginDto{
+					Email:    email,
+					Password: password,
+				},
+			},
+			want:        "",
+			wantErr:     true,
+			expectedErr: customerrors.NewForbiddenError("email address is not verified"),
+			deps: func(t *testing.T) UseCase {
+				mockUser, err := models.NewUser(userID, "testuser", email, hashedPassword)
+				assert.NoError(t, err)
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserByEmail(ctx, email).
+					Return(mockUser, nil).
+					Once()
+
+				mockLoginAttemptRepo := newLoginAttemptRepo(t, ctx, loginAttemptKeys(email, ""))
+				mockLoginAttemptRepo.EXPECT().
+					Reset(ctx, models.LoginAttemptKeyForEmail(email)).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					loginAttemptRepo:     mockLoginAttemptRepo,
+					lockoutPolicy:        lockoutPolicy,
+					authRepo:             mockAuthRepo,
+					requireVerifiedEmail: true,
+					securityEventRepo:    newSecurityEventRepo(t),
+					passwordHasher:       newPasswordHasher(t),
+					logger:               logger.NewMockLogger(),
+					tokenTTL:             ttlDuration,
+				}
+			},
+		},
+		"second factor required": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.LoginDto{
+					Email:    email,
+					Password: password,
+					Session:  session,
+				},
+			},
+			wantChallenge: true,
+			deps: func(t *testing.T) UseCase {
+				mockUser, err := models.NewUser(userID, "testuser", email, hashedPassword)
+				assert.NoError(t, err)
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserByEmail(ctx, email).
+					Return(mockUser, nil).
+					Once()
+
+				secret, err := models.GenerateTOTPSecret()
+				assert.NoError(t, err)
+				factor, err := models.RestoreTOTPFactor(userID, secret, time.Now(), 0)
+				assert.NoError(t, err)
+
+				mockTOTPRepo := mocks.NewTOTPRepository(t)
+				mockTOTPRepo.EXPECT().
+					GetByUser(ctx, userID).
+					Return(factor, nil).
+					Once()
+
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().
+					Create(ctx, mock.MatchedBy(func(code *models.OneTimeCode) bool {
+						return code.UserID() == userID && code.Purpose() == models.CodePurposeSecondFactor
+					})).
+					Return(nil).
+					Once()
+
+				mockLoginAttemptRepo := newLoginAttemptRepo(t, ctx, loginAttemptKeys(email, session.IP))
+				mockLoginAttemptRepo.EXPECT().
+					Reset(ctx, models.LoginAttemptKeyForEmail(email)).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					loginAttemptRepo:  mockLoginAttemptRepo,
+					lockoutPolicy:     lockoutPolicy,
+					authRepo:          mockAuthRepo,
+					totpRepo:          mockTOTPRepo,
+					codeRepo:          mockCodeRepo,
+					tokenRepo:         mocks.NewTokenRepository(t),
+					tokenIssuer:       mocks.NewTokenIssuer(t),
+					secondFactorTTL:   5 * time.Minute,
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+					tokenTTL:          ttlDuration,
+				}
+			},
+		},
+		"locked out address": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.LoginDto{
+					Email:    email,
+					Password: password,
+					Session:  session,
+				},
+			},
+			wantErr:     true,
+			expectedErr: customerrors.NewResourceExhaustedError(time.Minute, "too many failed login attempts"),
+			deps: func(t *testing.T) UseCase {
+				mockLoginAttemptRepo := mocks.NewLoginAttemptRepository(t)
+				mockLoginAttemptRepo.EXPECT().
+					Get(ctx, models.LoginAttemptKeyForEmail(email)).
+					Return(nil, customerrors.NewNotFoundError("no failed login attempts")).
+					Once()
+				mockLoginAttemptRepo.EXPECT().
+					Get(ctx, models.LoginAttemptKeyForIP(session.IP)).
+					Return(locked, nil).
+					Once()
+
+				return UseCase{
+					authRepo:          mocks.NewAuthRepository(t),
+					loginAttemptRepo:  mockLoginAttemptRepo,
+					lockoutPolicy:     lockoutPolicy,
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"repeated failures lock the account": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.LoginDto{
+					Email:    email,
+					Password: "wrongPassword",
+				},
+			},
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("invalid credentials"),
+			deps: func(t *testing.T) UseCase {
+				mockUser, err := models.NewUser(userID, "testuser", email, hashedPassword)
+				assert.NoError(t, err)
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserByEmail(ctx, email).
+					Return(mockUser, nil).
+					Once()
+
+				mockLoginAttemptRepo := newLoginAttemptRepo(t, ctx, loginAttemptKeys(email, ""))
+				mockLoginAttemptRepo.EXPECT().
+					RecordFailure(ctx, models.LoginAttemptKeyForEmail(email), mock.AnythingOfType("time.Time"), time.Hour).
+					Return(failedOften, nil).
+					Once()
+				mockLoginAttemptRepo.EXPECT().
+					Lock(ctx, models.LoginAttemptKeyForEmail(email), mock.MatchedBy(func(until time.Time) bool {
+						// The fifth failure is the second delayed one: 2 * BackoffBase.
+						return until.Sub(time.Now()) > time.Second && until.Sub(time.Now()) <= 2*time.Second
+					})).
+					Return(nil).
+					Once()
+
 				return UseCase{
-					authRepo:  mockAuthRepo,
-					tokenRepo: mockTokenRepo,
-					logger:    logger.NewMockLogger(),
-					tokenTTL:  ttlDuration,
+					authRepo:          mockAuthRepo,
+					loginAttemptRepo:  mockLoginAttemptRepo,
+					lockoutPolicy:     lockoutPolicy,
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
 				}
 			},
 		},
@@ -158,17 +426,166 @@ func TestUseCase_Login(t *testing.T) {
 					Return(mockUser, nil).
 					Once()
 
+				mockTOTPRepo := mocks.NewTOTPRepository(t)
+				mockTOTPRepo.EXPECT().
+					GetByUser(ctx, userID).
+					Return(nil, customerrors.NewNotFoundError("totp factor not found")).
+					Once()
+
+				mockTokenIssuer := mocks.NewTokenIssuer(t)
+				mockTokenIssuer.EXPECT().
+					Issue(ctx, mockUser, mock.AnythingOfType("time.Time")).
+					Return(models.Token("very-strong-token"), nil).
+					Once()
+
 				mockTokenRepo := mocks.NewTokenRepository(t)
 				mockTokenRepo.EXPECT().
 					Create(ctx, mock.AnythingOfType("*models.AuthToken")).
 					Return(nil, customerrors.NewInternalError(nil, "database error")).
 					Once()
 
+				mockLoginAttemptRepo := newLoginAttemptRepo(t, ctx, loginAttemptKeys(email, ""))
+				mockLoginAttemptRepo.EXPECT().
+					Reset(ctx, models.LoginAttemptKeyForEmail(email)).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					loginAttemptRepo:  mockLoginAttemptRepo,
+					lockoutPolicy:     lockoutPolicy,
+					txManager:         newTxManager(t),
+					authRepo:          mockAuthRepo,
+					tokenRepo:         mockTokenRepo,
+					totpRepo:          mockTOTPRepo,
+					tokenIssuer:       mockTokenIssuer,
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+					tokenTTL:          ttlDuration,
+				}
+			},
+		},
+		"failed to issue token": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.LoginDto{
+					Email:    email,
+					Password: password,
+				},
+			},
+			want:        "",
+			wantErr:     true,
+			expectedErr: customerrors.NewInternalError(nil, "failed to sign access token"),
+			deps: func(t *testing.T) UseCase {
+				mockUser, err := models.NewUser(userID, "testuser", email, hashedPassword)
+				assert.NoError(t, err)
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserByEmail(ctx, email).
+					Return(mockUser, nil).
+					Once()
+
+				mockTOTPRepo := mocks.NewTOTPRepository(t)
+				mockTOTPRepo.EXPECT().
+					GetByUser(ctx, userID).
+					Return(nil, customerrors.NewNotFoundError("totp factor not found")).
+					Once()
+
+				mockTokenIssuer := mocks.NewTokenIssuer(t)
+				mockTokenIssuer.EXPECT().
+					Issue(ctx, mockUser, mock.AnythingOfType("time.Time")).
+					Return("", customerrors.NewInternalError(nil, "failed to sign access token")).
+					Once()
+
+				mockLoginAttemptRepo := newLoginAttemptRepo(t, ctx, loginAttemptKeys(email, ""))
+				mockLoginAttemptRepo.EXPECT().
+					Reset(ctx, models.LoginAttemptKeyForEmail(email)).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					loginAttemptRepo:  mockLoginAttemptRepo,
+					lockoutPolicy:     lockoutPolicy,
+					txManager:         newTxManager(t),
+					authRepo:          mockAuthRepo,
+					tokenRepo:         mocks.NewTokenRepository(t),
+					totpRepo:          mockTOTPRepo,
+					tokenIssuer:       mockTokenIssuer,
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+					tokenTTL:          ttlDuration,
+				}
+			},
+		},
+		"failed to save refresh token": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.LoginDto{
+					Email:    email,
+					Password: password,
+				},
+			},
+			want:        "",
+			wantErr:     true,
+			expectedErr: customerrors.NewInternalError(nil, "failed to save refresh token"),
+			deps: func(t *testing.T) UseCase {
+				mockUser, err := models.NewUser(userID, "testuser", email, hashedPassword)
+				assert.NoError(t, err)
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserByEmail(ctx, email).
+					Return(mockUser, nil).
+					Once()
+
+				mockTOTPRepo := mocks.NewTOTPRepository(t)
+				mockTOTPRepo.EXPECT().
+					GetByUser(ctx, userID).
+					Return(nil, customerrors.NewNotFoundError("totp factor not found")).
+					Once()
+
+				mockTokenIssuer := mocks.NewTokenIssuer(t)
+				mockTokenIssuer.EXPECT().
+					Issue(ctx, mockUser, mock.AnythingOfType("time.Time")).
+					Return(models.Token("very-strong-token"), nil).
+					Once()
+
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().
+					Create(ctx, mock.AnythingOfType("*models.AuthToken")).
+					RunAndReturn(func(_ context.Context, token *models.AuthToken) (*models.AuthToken, error) {
+						return token, nil
+					}).
+					Once()
+
+				mockRefreshTokenRepo := mocks.NewRefreshTokenRepository(t)
+				mockRefreshTokenRepo.EXPECT().
+					Create(ctx, mock.AnythingOfType("*models.RefreshToken")).
+					Return(errors.New("database error")).
+					Once()
+
+				mockLoginAttemptRepo := newLoginAttemptRepo(t, ctx, loginAttemptKeys(email, ""))
+				mockLoginAttemptRepo.EXPECT().
+					Reset(ctx, models.LoginAttemptKeyForEmail(email)).
+					Return(nil).
+					Once()
+
 				return UseCase{
-					authRepo:  mockAuthRepo,
-					tokenRepo: mockTokenRepo,
-					logger:    logger.NewMockLogger(),
-					tokenTTL:  ttlDuration,
+					loginAttemptRepo:  mockLoginAttemptRepo,
+					lockoutPolicy:     lockoutPolicy,
+					txManager:         newTxManager(t),
+					authRepo:          mockAuthRepo,
+					tokenRepo:         mockTokenRepo,
+					refreshTokenRepo:  mockRefreshTokenRepo,
+					totpRepo:          mockTOTPRepo,
+					tokenIssuer:       mockTokenIssuer,
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+					tokenTTL:          ttlDuration,
+					refreshTokenTTL:   refreshTTLDuration,
 				}
 			},
 		},
@@ -179,17 +596,104 @@ func TestUseCase_Login(t *testing.T) {
 			t.Parallel()
 
 			useCase := tc.deps(t)
-			token, err := useCase.Login(tc.args.ctx, tc.args.dto)
+			result, err := useCase.Login(tc.args.ctx, tc.args.dto)
 
 			if tc.wantErr {
 				assert.Error(t, err)
 				if tc.expectedErr != nil {
 					assert.IsType(t, tc.expectedErr, err)
 				}
+			} else if tc.wantChallenge {
+				assert.NoError(t, err)
+				assert.Nil(t, result.Tokens)
+				assert.NotEmpty(t, result.Challenge.Token)
+				assert.True(t, result.Challenge.ExpiresAt.After(time.Now()))
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tc.want, token)
+				assert.Nil(t, result.Challenge)
+				tokens := result.Tokens
+				assert.Equal(t, tc.want, tokens.AccessToken)
+				assert.Equal(t, userID, tokens.UserID)
+				assert.NotEmpty(t, tokens.RefreshToken)
+				assert.True(t, tokens.RefreshTokenExpiresAt.After(tokens.AccessTokenExpiresAt))
 			}
 		})
 	}
 }
+
+func TestUseCase_upgradePasswordHash(t *testing.T) {
+	ctx := context.Background()
+	userID := models.UserID(uuid.New())
+	password := "somestrongpassword"
+
+	tests := map[string]struct {
+		wantHash string
+		deps     func(t *testing.T) UseCase
+	}{
+		"outdated hash upgraded": {
+			wantHash: "$argon2id$new",
+			deps: func(t *testing.T) UseCase {
+				mockHasher := mocks.NewPasswordHasher(t)
+				mockHasher.EXPECT().NeedsRehash([]byte("hashed")).Return(true).Once()
+				mockHasher.EXPECT().Hash(password).Return([]byte("$argon2id$new"), nil).Once()
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					Update(ctx, mock.MatchedBy(func(user *models.User) bool {
+						return user.ID() == userID && user.Password() == "$argon2id$new"
+					})).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					authRepo:       mockAuthRepo,
+					passwordHasher: mockHasher,
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"current hash kept": {
+			wantHash: "hashed",
+			deps: func(t *testing.T) UseCase {
+				mockHasher := mocks.NewPasswordHasher(t)
+				mockHasher.EXPECT().NeedsRehash([]byte("hashed")).Return(false).Once()
+
+				return UseCase{
+					passwordHasher: mockHasher,
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"failed upgrade keeps old hash": {
+			wantHash: "hashed",
+			deps: func(t *testing.T) UseCase {
+				mockHasher := mocks.NewPasswordHasher(t)
+				mockHasher.EXPECT().NeedsRehash([]byte("hashed")).Return(true).Once()
+				mockHasher.EXPECT().Hash(password).Return([]byte("$argon2id$new"), nil).Once()
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					Update(ctx, mock.AnythingOfType("*models.User")).
+					Return(errors.New("database error")).
+					Once()
+
+				return UseCase{
+					authRepo:       mockAuthRepo,
+					passwordHasher: mockHasher,
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			user := useCase.upgradePasswordHash(ctx, newUser(t, userID), password)
+
+			assert.Equal(t, tc.wantHash, user.Password())
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// loginAttemptKeys returns the throttling keys of a login: the account and,
+// when known, the client address.
+func loginAttemptKeys(email, ip string) []string {
+	keys := []string{models.LoginAttemptKeyForEmail(email)}
+	if ip != "" {
+		keys = append(keys, models.LoginAttemptKeyForIP(ip))
+	}
+	return keys
+}
+
+// checkLoginLockout rejects the login while any of its keys is locked.
+func (a *UseCase) checkLoginLockout(ctx context.Context, keys []string, now time.Time) error {
+	for _, key := range keys {
+		attempt, err := a.loginAttemptRepo.Get(ctx, key)
+		if err != nil {
+			if errors.Is(err, errors.ErrNotFound) {
+				continue
+			}
+			a.logger.Error("failed to get login attempts", "error", err)
+			return err
+		}
+
+		if attempt.IsLocked(now) {
+			retryAfter := attempt.RetryAfter(now)
+			a.logger.Warn("login throttled", "key", key, "failures", attempt.Failures(), "retry_after", retryAfter)
+			return errors.NewResourceExhaustedError(retryAfter,
+				"too many failed login attempts, retry in %d seconds", int(math.Ceil(retryAfter.Seconds())))
+		}
+	}
+	return nil
+}
+
+// recordLoginFailure counts a failed login against every key and locks the
+// keys the policy says should wait. Errors are logged only, so a counter
+// failure does not change the response to the client.
+func (a *UseCase) recordLoginFailure(ctx context.Context, keys []string, now time.Time) {
+	for _, key := range keys {
+		attempt, err := a.loginAttemptRepo.RecordFailure(ctx, key, now, a.lockoutPolicy.FailureWindow)
+		if err != nil {
+			a.logger.Error("failed to record login failure", "error", err)
+			continue
+		}
+
+		lockFor := a.lockoutPolicy.LockFor(attempt.Failures())
+		if lockFor <= 0 {
+			continue
+		}
+
+		if err := a.loginAttemptRepo.Lock(ctx, key, now.Add(lockFor)); err != nil {
+			a.logger.Error("failed to lock login key", "error", err)
+			continue
+		}
+		a.logger.Warn("login key locked", "key", key, "failures", attempt.Failures(), "lock_for", lockFor)
+	}
+}
+
+// resetLoginFailures clears the account counter after a correct password.
+// The address counter is kept, so logging into one's own account cannot be
+// used to keep guessing passwords of others from the same address.
+func (a *UseCase) resetLoginFailures(ctx context.Context, email string) {
+	if err := a.loginAttemptRepo.Reset(ctx, models.LoginAttemptKeyForEmail(email)); err != nil {
+		a.logger.Warn("failed to reset login attempts", "error", err)
+	}
+}
@@ -2,32 +2,43 @@ package auth
 
 import (
 	"context"
+	"time"
 
 	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
 	"github.com/SamEkb/messenger-app/pkg/platform/errors"
 )
 
 func (a *UseCase) Logout(ctx context.Context, token models.Token) error {
-	a.logger.Debug("logout attempt", "token", token)
+	a.logger.Debug("logout attempt")
 
-	valid, err := a.tokenRepo.ValidateToken(ctx, string(token))
+	authToken, err := a.tokenRepo.GetToken(ctx, token)
 	if err != nil {
-		a.logger.Warn("token validation failed", "error", err)
+		a.logger.Warn("token lookup failed", "error", err)
 		return err
 	}
 
-	if !valid {
-		a.logger.Warn("invalid token", "token", token)
-		return errors.NewTokenError(errors.ErrInvalidToken, "token is invalid").
-			WithDetails("token", token)
+	if authToken.IsExpired() {
+		a.logger.Warn("invalid token", "user_id", authToken.UserID())
+		return errors.NewTokenError(errors.ErrInvalidToken, "token is invalid")
 	}
 
-	err = a.tokenRepo.DeleteToken(ctx, token)
+	err = a.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		if err := a.tokenRepo.DeleteToken(txCtx, token); err != nil {
+			a.logger.Error("failed to delete token", "error", err)
+			return err
+		}
+		if err := a.refreshTokenRepo.RevokeFamily(txCtx, authToken.FamilyID(), time.Now()); err != nil {
+			a.logger.Error("failed to revoke refresh tokens", "error", err)
+			return err
+		}
+		return nil
+	})
 	if err != nil {
-		a.logger.Error("failed to delete token", "error", err)
 		return err
 	}
 
-	a.logger.Info("logout successful", "token", token)
+	a.recordSecurityEvent(ctx, models.NewSecurityEvent(models.SecurityEventLoggedOut, authToken.UserID(), "",
+		models.ClientFromContext(ctx)))
+	a.logger.Info("logout successful", "user_id", authToken.UserID())
 	return nil
 }
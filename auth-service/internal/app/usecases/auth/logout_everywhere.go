@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+)
+
+func (a *UseCase) LogoutEverywhere(ctx context.Context) error {
+	userID, _, err := callerFromContext(ctx)
+	if err != nil {
+		a.logger.Warn("unauthenticated logout everywhere attempt", "error", err)
+		return err
+	}
+
+	a.logger.Debug("logout everywhere attempt", "user_id", userID)
+
+	err = a.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		return a.revokeAllSessions(txCtx, userID)
+	})
+	if err != nil {
+		a.logger.Error("failed to log out everywhere", "user_id", userID, "error", err)
+		return err
+	}
+
+	a.recordSecurityEvent(ctx, models.NewSecurityEvent(models.SecurityEventLoggedOut, userID, "",
+		models.ClientFromContext(ctx)).WithReason("all_sessions"))
+	a.logger.Info("logged out everywhere", "user_id", userID)
+	return nil
+}
@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUseCase_LogoutEverywhere(t *testing.T) {
+	userID := models.UserID(uuid.New())
+	ctx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: userID.String(),
+	})
+
+	type args struct {
+		ctx context.Context
+	}
+	tests := map[string]struct {
+		args        args
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"logged out everywhere": {
+			args: args{ctx: ctx},
+			deps: func(t *testing.T) UseCase {
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().
+					DeleteTokensByUser(ctx, userID).
+					Return(nil).
+					Once()
+
+				mockRefreshTokenRepo := mocks.NewRefreshTokenRepository(t)
+				mockRefreshTokenRepo.EXPECT().
+					RevokeByUser(ctx, userID, mock.AnythingOfType("time.Time")).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					txManager:         newTxManager(t),
+					tokenRepo:         mockTokenRepo,
+					refreshTokenRepo:  mockRefreshTokenRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"unauthenticated": {
+			args:        args{ctx: context.Background()},
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("request is not authenticated"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					securityEventRepo: newSecurityEventRepo(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"failed to revoke refresh tokens": {
+			args:    args{ctx: ctx},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().
+					DeleteTokensByUser(ctx, userID).
+					Return(nil).
+					Once()
+
+				mockRefreshTokenRepo := mocks.NewRefreshTokenRepository(t)
+				mockRefreshTokenRepo.EXPECT().
+					RevokeByUser(ctx, userID, mock.AnythingOfType("time.Time")).
+					Return(errors.New("database error")).
+					Once()
+
+				return UseCase{
+					txManager:         newTxManager(t),
+					tokenRepo:         mockTokenRepo,
+					refreshTokenRepo:  mockRefreshTokenRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			err := useCase.LogoutEverywhere(tc.args.ctx)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
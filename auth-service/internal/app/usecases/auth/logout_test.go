@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
 	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
 	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
 	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -16,6 +18,14 @@ import (
 func TestUseCase_Logout(t *testing.T) {
 	ctx := context.Background()
 
+	userID := models.UserID(uuid.New())
+	familyID := models.NewFamilyID()
+
+	activeToken, err := models.NewAuthToken("very-strong-token", userID, familyID, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	expiredToken, err := models.NewAuthToken("invalid-token", userID, familyID, time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+
 	type args struct {
 		ctx   context.Context
 		token models.Token
@@ -34,8 +44,8 @@ func TestUseCase_Logout(t *testing.T) {
 			wantErr: false,
 			deps: func(t *testing.T) UseCase {
 				mockTokenRepo := mocks.NewTokenRepository(t)
-				mockTokenRepo.EXPECT().ValidateToken(ctx, "very-strong-token").
-					Return(true, nil).
+				mockTokenRepo.EXPECT().GetToken(ctx, models.Token("very-strong-token")).
+					Return(activeToken, nil).
 					Once()
 
 				mockTokenRepo.EXPECT().
@@ -43,13 +53,22 @@ func TestUseCase_Logout(t *testing.T) {
 					Return(nil).
 					Once()
 
+				mockRefreshTokenRepo := mocks.NewRefreshTokenRepository(t)
+				mockRefreshTokenRepo.EXPECT().
+					RevokeFamily(ctx, familyID, mock.AnythingOfType("time.Time")).
+					Return(nil).
+					Once()
+
 				return UseCase{
-					tokenRepo: mockTokenRepo,
-					logger:    logger.NewMockLogger(),
+					txManager:         newTxManager(t),
+					tokenRepo:         mockTokenRepo,
+					refreshTokenRepo:  mockRefreshTokenRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					logger:            logger.NewMockLogger(),
 				}
 			},
 		},
-		"failed to validate token": {
+		"failed to get token": {
 			args: args{
 				ctx:   ctx,
 				token: models.Token("very-strong-token"),
@@ -57,13 +76,14 @@ func TestUseCase_Logout(t *testing.T) {
 			wantErr: true,
 			deps: func(t *testing.T) UseCase {
 				mockTokenRepo := mocks.NewTokenRepository(t)
-				mockTokenRepo.EXPECT().ValidateToken(ctx, "very-strong-token").
-					Return(false, errors.New("database error")).
+				mockTokenRepo.EXPECT().GetToken(ctx, models.Token("very-strong-token")).
+					Return(nil, errors.New("database error")).
 					Once()
 
 				return UseCase{
-					tokenRepo: mockTokenRepo,
-					logger:    logger.NewMockLogger(),
+					tokenRepo:         mockTokenRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					logger:            logger.NewMockLogger(),
 				}
 			},
 		},
@@ -76,13 +96,14 @@ func TestUseCase_Logout(t *testing.T) {
 			expectedErr: customerrors.NewTokenError(nil, "invalid token"),
 			deps: func(t *testing.T) UseCase {
 				mockTokenRepo := mocks.NewTokenRepository(t)
-				mockTokenRepo.EXPECT().ValidateToken(ctx, "invalid-token").
-					Return(false, nil).
+				mockTokenRepo.EXPECT().GetToken(ctx, models.Token("invalid-token")).
+					Return(expiredToken, nil).
 					Once()
 
 				return UseCase{
-					tokenRepo: mockTokenRepo,
-					logger:    logger.NewMockLogger(),
+					tokenRepo:         mockTokenRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					logger:            logger.NewMockLogger(),
 				}
 			},
 		},
@@ -94,8 +115,8 @@ func TestUseCase_Logout(t *testing.T) {
 			wantErr: true,
 			deps: func(t *testing.T) UseCase {
 				mockTokenRepo := mocks.NewTokenRepository(t)
-				mockTokenRepo.EXPECT().ValidateToken(ctx, "very-strong-token").
-					Return(true, nil).
+				mockTokenRepo.EXPECT().GetToken(ctx, models.Token("very-strong-token")).
+					Return(activeToken, nil).
 					Once()
 				mockTokenRepo.EXPECT().
 					DeleteToken(ctx, mock.AnythingOfType("models.Token")).
@@ -103,8 +124,41 @@ func TestUseCase_Logout(t *testing.T) {
 					Once()
 
 				return UseCase{
-					tokenRepo: mockTokenRepo,
-					logger:    logger.NewMockLogger(),
+					txManager:         newTxManager(t),
+					tokenRepo:         mockTokenRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"failed to revoke refresh tokens": {
+			args: args{
+				ctx:   ctx,
+				token: models.Token("very-strong-token"),
+			},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().GetToken(ctx, models.Token("very-strong-token")).
+					Return(activeToken, nil).
+					Once()
+				mockTokenRepo.EXPECT().
+					DeleteToken(ctx, mock.AnythingOfType("models.Token")).
+					Return(nil).
+					Once()
+
+				mockRefreshTokenRepo := mocks.NewRefreshTokenRepository(t)
+				mockRefreshTokenRepo.EXPECT().
+					RevokeFamily(ctx, familyID, mock.AnythingOfType("time.Time")).
+					Return(errors.New("database error")).
+					Once()
+
+				return UseCase{
+					txManager:         newTxManager(t),
+					tokenRepo:         mockTokenRepo,
+					refreshTokenRepo:  mockRefreshTokenRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					logger:            logger.NewMockLogger(),
 				}
 			},
 		},
@@ -1,5 +1,19 @@
 package auth
 
+//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name TxManager --output ./mocks --filename tx_manager_mock.go
 //go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name AuthRepository --output ./mocks --filename auth_repository_mock.go
 //go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name TokenRepository --output ./mocks --filename token_repository_mock.go
-//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name UserEventsKafkaProducer --output ./mocks --filename user_events_kafka_producer_mock.go
+//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name RefreshTokenRepository --output ./mocks --filename refresh_token_repository_mock.go
+//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name OneTimeCodeRepository --output ./mocks --filename one_time_code_repository_mock.go
+//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name TOTPRepository --output ./mocks --filename totp_repository_mock.go
+//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name RecoveryCodeRepository --output ./mocks --filename recovery_code_repository_mock.go
+//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name LoginAttemptRepository --output ./mocks --filename login_attempt_repository_mock.go
+//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name TokenIssuer --output ./mocks --filename token_issuer_mock.go
+//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name Mailer --output ./mocks --filename mailer_mock.go
+//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name OAuthStateRepository --output ./mocks --filename oauth_state_repository_mock.go
+//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name OAuthIdentityRepository --output ./mocks --filename oauth_identity_repository_mock.go
+//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name IdentityProvider --output ./mocks --filename identity_provider_mock.go
+//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name UserEventsOutbox --output ./mocks --filename user_events_outbox_mock.go
+//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name APIKeyRepository --output ./mocks --filename api_key_repository_mock.go
+//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name SecurityEventRepository --output ./mocks --filename security_event_repository_mock.go
+//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name PasswordHasher --output ./mocks --filename password_hasher_mock.go
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (a *UseCase) RefreshToken(ctx context.Context, refreshToken models.Token) (*ports.AuthTokensDto, error) {
+	a.logger.Debug("refresh token attempt")
+
+	hash := models.HashToken(refreshToken)
+
+	stored, err := a.refreshTokenRepo.GetByHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			a.logger.Warn("refresh token not found")
+			return nil, errors.NewUnauthorizedError("invalid refresh token")
+		}
+		a.logger.Error("failed to get refresh token", "error", err)
+		return nil, err
+	}
+
+	if stored.IsRevoked() {
+		a.logger.Warn("revoked refresh token presented", "family_id", stored.FamilyID())
+		return nil, errors.NewUnauthorizedError("refresh token has been revoked")
+	}
+
+	if stored.IsUsed() {
+		return nil, a.revokeFamily(ctx, stored)
+	}
+
+	if stored.IsExpired() {
+		a.logger.Debug("refresh token is expired", "family_id", stored.FamilyID())
+		return nil, errors.NewTokenError(errors.ErrTokenExpired, "refresh token is expired").
+			WithDetails("expires_at", stored.ExpiresAt())
+	}
+
+	var tokens *ports.AuthTokensDto
+	err = a.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		if err := a.refreshTokenRepo.MarkUsed(txCtx, hash, time.Now()); err != nil {
+			return err
+		}
+		tokens, err = a.rotateTokens(txCtx, stored.UserID(), stored.FamilyID())
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, errors.ErrInvalidToken) {
+			// Another request consumed the token between the read and the update.
+			return nil, a.revokeFamily(ctx, stored)
+		}
+		a.logger.Error("failed to rotate refresh token", "error", err)
+		return nil, err
+	}
+
+	a.logger.Info("refresh token rotated", "user_id", stored.UserID(), "family_id", stored.FamilyID())
+	return tokens, nil
+}
+
+// revokeFamily handles reuse of an already rotated refresh token: the whole
+// family is assumed compromised, so every token issued from it is revoked.
+func (a *UseCase) revokeFamily(ctx context.Context, stored *models.RefreshToken) error {
+	a.logger.Warn("refresh token reuse detected, revoking family",
+		"user_id", stored.UserID(), "family_id", stored.FamilyID())
+
+	err := a.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		if err := a.refreshTokenRepo.RevokeFamily(txCtx, stored.FamilyID(), time.Now()); err != nil {
+			return err
+		}
+		return a.tokenRepo.DeleteTokensByFamily(txCtx, stored.FamilyID())
+	})
+	if err != nil {
+		a.logger.Error("failed to revoke token family", "family_id", stored.FamilyID(), "error", err)
+		return err
+	}
+
+	return errors.NewUnauthorizedError("refresh token reuse detected").
+		WithDetails("family_id", stored.FamilyID().String())
+}
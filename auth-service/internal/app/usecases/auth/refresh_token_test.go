@@ -0,0 +1,304 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUseCase_RefreshToken(t *testing.T) {
+	ctx := context.Background()
+
+	userID := models.UserID(uuid.New())
+	familyID := models.NewFamilyID()
+	refreshToken := models.Token("refresh-token")
+	hash := models.HashToken(refreshToken)
+
+	newStored := func(t *testing.T, expiresAt, usedAt, revokedAt time.Time) *models.RefreshToken {
+		stored, err := models.RestoreRefreshToken(hash, userID, familyID, expiresAt, usedAt, revokedAt)
+		assert.NoError(t, err)
+		return stored
+	}
+
+	type args struct {
+		ctx          context.Context
+		refreshToken models.Token
+	}
+	tests := map[string]struct {
+		args        args
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"refresh successful": {
+			args: args{
+				ctx:          ctx,
+				refreshToken: refreshToken,
+			},
+			deps: func(t *testing.T) UseCase {
+				mockRefreshTokenRepo := mocks.NewRefreshTokenRepository(t)
+				mockRefreshTokenRepo.EXPECT().
+					GetByHash(ctx, hash).
+					Return(newStored(t, time.Now().Add(time.Hour), time.Time{}, time.Time{}), nil).
+					Once()
+				mockRefreshTokenRepo.EXPECT().
+					MarkUsed(ctx, hash, mock.AnythingOfType("time.Time")).
+					Return(nil).
+					Once()
+				mockRefreshTokenRepo.EXPECT().
+					Create(ctx, mock.MatchedBy(func(token *models.RefreshToken) bool {
+						return token.FamilyID() == familyID && token.Hash() != hash
+					})).
+					Return(nil).
+					Once()
+
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().
+					RotateToken(ctx, familyID, models.Token("new-access-token"), mock.AnythingOfType("time.Time")).
+					Return(nil).
+					Once()
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByID(ctx, userID).Return(newUser(t, userID), nil).Once()
+
+				mockTokenIssuer := mocks.NewTokenIssuer(t)
+				mockTokenIssuer.EXPECT().
+					Issue(ctx, userWithID(userID), mock.AnythingOfType("time.Time")).
+					Return(models.Token("new-access-token"), nil).
+					Once()
+
+				return UseCase{
+					txManager:        newTxManager(t),
+					authRepo:         mockAuthRepo,
+					tokenRepo:        mockTokenRepo,
+					refreshTokenRepo: mockRefreshTokenRepo,
+					tokenIssuer:      mockTokenIssuer,
+					logger:           logger.NewMockLogger(),
+					tokenTTL:         15 * time.Minute,
+					refreshTokenTTL:  30 * 24 * time.Hour,
+				}
+			},
+		},
+		"unknown refresh token": {
+			args: args{
+				ctx:          ctx,
+				refreshToken: refreshToken,
+			},
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("invalid refresh token"),
+			deps: func(t *testing.T) UseCase {
+				mockRefreshTokenRepo := mocks.NewRefreshTokenRepository(t)
+				mockRefreshTokenRepo.EXPECT().
+					GetByHash(ctx, hash).
+					Return(nil, customerrors.NewNotFoundError("refresh token not found")).
+					Once()
+
+				return UseCase{
+					refreshTokenRepo: mockRefreshTokenRepo,
+					logger:           logger.NewMockLogger(),
+				}
+			},
+		},
+		"revoked refresh token": {
+			args: args{
+				ctx:          ctx,
+				refreshToken: refreshToken,
+			},
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("refresh token has been revoked"),
+			deps: func(t *testing.T) UseCase {
+				mockRefreshTokenRepo := mocks.NewRefreshTokenRepository(t)
+				mockRefreshTokenRepo.EXPECT().
+					GetByHash(ctx, hash).
+					Return(newStored(t, time.Now().Add(time.Hour), time.Now(), time.Now()), nil).
+					Once()
+
+				return UseCase{
+					refreshTokenRepo: mockRefreshTokenRepo,
+					logger:           logger.NewMockLogger(),
+				}
+			},
+		},
+		"expired refresh token": {
+			args: args{
+				ctx:          ctx,
+				refreshToken: refreshToken,
+			},
+			wantErr:     true,
+			expectedErr: customerrors.NewTokenError(nil, "refresh token is expired"),
+			deps: func(t *testing.T) UseCase {
+				mockRefreshTokenRepo := mocks.NewRefreshTokenRepository(t)
+				mockRefreshTokenRepo.EXPECT().
+					GetByHash(ctx, hash).
+					Return(newStored(t, time.Now().Add(-time.Hour), time.Time{}, time.Time{}), nil).
+					Once()
+
+				return UseCase{
+					refreshTokenRepo: mockRefreshTokenRepo,
+					logger:           logger.NewMockLogger(),
+				}
+			},
+		},
+		"reused refresh token revokes family": {
+			args: args{
+				ctx:          ctx,
+				refreshToken: refreshToken,
+			},
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("refresh token reuse detected"),
+			deps: func(t *testing.T) UseCase {
+				mockRefreshTokenRepo := mocks.NewRefreshTokenRepository(t)
+				mockRefreshTokenRepo.EXPECT().
+					GetByHash(ctx, hash).
+					Return(newStored(t, time.Now().Add(time.Hour), time.Now().Add(-time.Minute), time.Time{}), nil).
+					Once()
+				mockRefreshTokenRepo.EXPECT().
+					RevokeFamily(ctx, familyID, mock.AnythingOfType("time.Time")).
+					Return(nil).
+					Once()
+
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().
+					DeleteTokensByFamily(ctx, familyID).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					txManager:        newTxManager(t),
+					tokenRepo:        mockTokenRepo,
+					refreshTokenRepo: mockRefreshTokenRepo,
+					logger:           logger.NewMockLogger(),
+				}
+			},
+		},
+		"concurrent rotation revokes family": {
+			args: args{
+				ctx:          ctx,
+				refreshToken: refreshToken,
+			},
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("refresh token reuse detected"),
+			deps: func(t *testing.T) UseCase {
+				mockRefreshTokenRepo := mocks.NewRefreshTokenRepository(t)
+				mockRefreshTokenRepo.EXPECT().
+					GetByHash(ctx, hash).
+					Return(newStored(t, time.Now().Add(time.Hour), time.Time{}, time.Time{}), nil).
+					Once()
+				mockRefreshTokenRepo.EXPECT().
+					MarkUsed(ctx, hash, mock.AnythingOfType("time.Time")).
+					Return(customerrors.NewTokenError(customerrors.ErrInvalidToken, "refresh token already used")).
+					Once()
+				mockRefreshTokenRepo.EXPECT().
+					RevokeFamily(ctx, familyID, mock.AnythingOfType("time.Time")).
+					Return(nil).
+					Once()
+
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().
+					DeleteTokensByFamily(ctx, familyID).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					txManager:        newTxManager(t),
+					tokenRepo:        mockTokenRepo,
+					refreshTokenRepo: mockRefreshTokenRepo,
+					logger:           logger.NewMockLogger(),
+				}
+			},
+		},
+		"session revoked during rotation": {
+			args: args{
+				ctx:          ctx,
+				refreshToken: refreshToken,
+			},
+			wantErr:     true,
+			expectedErr: customerrors.NewNotFoundError("session not found"),
+			deps: func(t *testing.T) UseCase {
+				mockRefreshTokenRepo := mocks.NewRefreshTokenRepository(t)
+				mockRefreshTokenRepo.EXPECT().
+					GetByHash(ctx, hash).
+					Return(newStored(t, time.Now().Add(time.Hour), time.Time{}, time.Time{}), nil).
+					Once()
+				mockRefreshTokenRepo.EXPECT().
+					MarkUsed(ctx, hash, mock.AnythingOfType("time.Time")).
+					Return(nil).
+					Once()
+
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().
+					RotateToken(ctx, familyID, models.Token("new-access-token"), mock.AnythingOfType("time.Time")).
+					Return(customerrors.NewNotFoundError("session not found")).
+					Once()
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByID(ctx, userID).Return(newUser(t, userID), nil).Once()
+
+				mockTokenIssuer := mocks.NewTokenIssuer(t)
+				mockTokenIssuer.EXPECT().
+					Issue(ctx, userWithID(userID), mock.AnythingOfType("time.Time")).
+					Return(models.Token("new-access-token"), nil).
+					Once()
+
+				return UseCase{
+					txManager:        newTxManager(t),
+					authRepo:         mockAuthRepo,
+					tokenRepo:        mockTokenRepo,
+					refreshTokenRepo: mockRefreshTokenRepo,
+					tokenIssuer:      mockTokenIssuer,
+					logger:           logger.NewMockLogger(),
+					tokenTTL:         15 * time.Minute,
+				}
+			},
+		},
+		"repository error": {
+			args: args{
+				ctx:          ctx,
+				refreshToken: refreshToken,
+			},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				mockRefreshTokenRepo := mocks.NewRefreshTokenRepository(t)
+				mockRefreshTokenRepo.EXPECT().
+					GetByHash(ctx, hash).
+					Return(nil, errors.New("database error")).
+					Once()
+
+				return UseCase{
+					refreshTokenRepo: mockRefreshTokenRepo,
+					logger:           logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			tokens, err := useCase.RefreshToken(tc.args.ctx, tc.args.refreshToken)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, tokens)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, models.Token("new-access-token"), tokens.AccessToken)
+				assert.NotEqual(t, refreshToken, tokens.RefreshToken)
+			}
+		})
+	}
+}
@@ -2,23 +2,27 @@ package auth
 
 import (
 	"context"
+	"time"
 
 	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
 	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
 	"github.com/SamEkb/messenger-app/pkg/api/events/v1"
-	"github.com/SamEkb/messenger-app/pkg/platform/errors"
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (a *UseCase) Register(ctx context.Context, dto *ports.RegisterDto) (models.UserID, error) {
 	a.logger.Debug("register attempt", "username", dto.Username, "email", dto.Email)
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(dto.Password), bcrypt.DefaultCost)
+	if err := a.passwordPolicy.Validate("password", dto.Password); err != nil {
+		a.logger.Warn("weak password on registration", "username", dto.Username)
+		return models.UserID{}, err
+	}
+
+	hashedPassword, err := a.passwordHasher.Hash(dto.Password)
 	if err != nil {
 		a.logger.Error("failed to hash password", "error", err)
-		return models.UserID{}, errors.NewInternalError(err, "failed to hash password")
+		return models.UserID{}, err
 	}
 
 	userID := models.UserID(uuid.New())
@@ -34,17 +38,43 @@ func (a *UseCase) Register(ctx context.Context, dto *ports.RegisterDto) (models.
 		return models.UserID{}, err
 	}
 
+	var (
+		verificationCode models.Token
+		expiresAt        time.Time
+	)
 	err = a.txManager.RunTx(ctx, func(txCtx context.Context) error {
 		if err := a.authRepo.Create(txCtx, user); err != nil {
 			a.logger.Error("failed to save user", "error", err)
 			return err
 		}
-		return nil
+
+		verificationCode, expiresAt, err = a.issueVerificationCode(txCtx, user.ID())
+		if err != nil {
+			a.logger.Error("failed to store verification code", "error", err)
+			return err
+		}
+
+		return a.enqueueUserRegistered(txCtx, user)
 	})
 	if err != nil {
 		return models.UserID{}, err
 	}
 
+	// The account exists at this point; a lost email can be recovered with
+	// ResendVerification, so a mailer failure must not fail registration.
+	if err := a.sendVerificationCode(ctx, user, verificationCode, expiresAt); err != nil {
+		a.logger.Warn("verification code not delivered", "user_id", user.ID(), "error", err)
+	}
+
+	a.recordSecurityEvent(ctx, models.NewSecurityEvent(models.SecurityEventRegistered, user.ID(), user.Email(),
+		models.ClientFromContext(ctx)))
+	a.logger.Info("user registered successfully", "user_id", user.ID(), "username", user.Username(), "email", user.Email())
+	return user.ID(), nil
+}
+
+// enqueueUserRegistered records UserRegisteredEvent in the outbox. It must
+// be called in the transaction that creates the user.
+func (a *UseCase) enqueueUserRegistered(ctx context.Context, user *models.User) error {
 	event := &events.UserRegisteredEvent{
 		UserId:       user.ID().String(),
 		Username:     user.Username(),
@@ -52,11 +82,9 @@ func (a *UseCase) Register(ctx context.Context, dto *ports.RegisterDto) (models.
 		RegisteredAt: timestamppb.Now(),
 	}
 
-	if err = a.userEventPublisher.ProduceUserRegisteredEvent(ctx, event); err != nil {
-		a.logger.Warn("failed to publish user registration event", "error", err, "user_id", user.ID())
-		return models.UserID{}, err
+	if err := a.userEventOutbox.EnqueueUserRegisteredEvent(ctx, event); err != nil {
+		a.logger.Error("failed to enqueue user registration event", "error", err, "user_id", user.ID())
+		return err
 	}
-
-	a.logger.Info("user registered successfully", "user_id", user.ID(), "username", user.Username(), "email", user.Email())
-	return user.ID(), nil
+	return nil
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
 	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
 	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
 	"github.com/SamEkb/messenger-app/pkg/platform/logger"
@@ -42,16 +43,37 @@ func TestUseCase_Register(t *testing.T) {
 					Return(nil).
 					Once()
 
-				mockKafkaProducer := mocks.NewUserEventsKafkaProducer(t)
-				mockKafkaProducer.EXPECT().
-					ProduceUserRegisteredEvent(ctx, mock.AnythingOfType("*events.UserRegisteredEvent")).
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().
+					InvalidateByUser(ctx, mock.AnythingOfType("models.UserID"), models.CodePurposeEmailVerification, mock.AnythingOfType("time.Time")).
+					Return(nil).
+					Once()
+				mockCodeRepo.EXPECT().
+					Create(ctx, mock.AnythingOfType("*models.OneTimeCode")).
+					Return(nil).
+					Once()
+
+				mockMailer := mocks.NewMailer(t)
+				mockMailer.EXPECT().
+					Send(ctx, mock.AnythingOfType("*ports.MailMessage")).
+					Return(nil).
+					Once()
+
+				mockOutbox := mocks.NewUserEventsOutbox(t)
+				mockOutbox.EXPECT().
+					EnqueueUserRegisteredEvent(ctx, mock.AnythingOfType("*events.UserRegisteredEvent")).
 					Return(nil).
 					Once()
 
 				return UseCase{
-					authRepo:           mockAuthRepo,
-					userEventPublisher: mockKafkaProducer,
-					logger:             logger.NewMockLogger(),
+					txManager:         newTxManager(t),
+					authRepo:          mockAuthRepo,
+					codeRepo:          mockCodeRepo,
+					mailer:            mockMailer,
+					userEventOutbox:   mockOutbox,
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
 				}
 			},
 		},
@@ -73,12 +95,15 @@ func TestUseCase_Register(t *testing.T) {
 					Once()
 
 				return UseCase{
-					authRepo: mockAuthRepo,
-					logger:   logger.NewMockLogger(),
+					txManager:         newTxManager(t),
+					authRepo:          mockAuthRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
 				}
 			},
 		},
-		"failed to publish event": {
+		"failed to enqueue event": {
 			args: args{
 				ctx: ctx,
 				dto: &ports.RegisterDto{
@@ -95,16 +120,101 @@ func TestUseCase_Register(t *testing.T) {
 					Return(nil).
 					Once()
 
-				mockKafkaProducer := mocks.NewUserEventsKafkaProducer(t)
-				mockKafkaProducer.EXPECT().
-					ProduceUserRegisteredEvent(ctx, mock.AnythingOfType("*events.UserRegisteredEvent")).
-					Return(errors.New("kafka error")).
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().
+					InvalidateByUser(ctx, mock.AnythingOfType("models.UserID"), models.CodePurposeEmailVerification, mock.AnythingOfType("time.Time")).
+					Return(nil).
+					Once()
+				mockCodeRepo.EXPECT().
+					Create(ctx, mock.AnythingOfType("*models.OneTimeCode")).
+					Return(nil).
 					Once()
 
+				// The event is part of the registration transaction, so the
+				// user is rolled back and no verification mail goes out.
+				mockOutbox := mocks.NewUserEventsOutbox(t)
+				mockOutbox.EXPECT().
+					EnqueueUserRegisteredEvent(ctx, mock.AnythingOfType("*events.UserRegisteredEvent")).
+					Return(errors.New("database error")).
+					Once()
+
+				return UseCase{
+					txManager:         newTxManager(t),
+					authRepo:          mockAuthRepo,
+					codeRepo:          mockCodeRepo,
+					mailer:            mocks.NewMailer(t),
+					userEventOutbox:   mockOutbox,
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"verification email not delivered": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.RegisterDto{
+					Username: "testuser",
+					Email:    "test@test.ru",
+					Password: "strongAndLongPassword",
+				},
+			},
+			wantErr: false,
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					Create(ctx, mock.AnythingOfType("*models.User")).
+					Return(nil).
+					Once()
+
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().
+					InvalidateByUser(ctx, mock.AnythingOfType("models.UserID"), models.CodePurposeEmailVerification, mock.AnythingOfType("time.Time")).
+					Return(nil).
+					Once()
+				mockCodeRepo.EXPECT().
+					Create(ctx, mock.AnythingOfType("*models.OneTimeCode")).
+					Return(nil).
+					Once()
+
+				mockMailer := mocks.NewMailer(t)
+				mockMailer.EXPECT().
+					Send(ctx, mock.AnythingOfType("*ports.MailMessage")).
+					Return(errors.New("smtp error")).
+					Once()
+
+				mockOutbox := mocks.NewUserEventsOutbox(t)
+				mockOutbox.EXPECT().
+					EnqueueUserRegisteredEvent(ctx, mock.AnythingOfType("*events.UserRegisteredEvent")).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					txManager:         newTxManager(t),
+					authRepo:          mockAuthRepo,
+					codeRepo:          mockCodeRepo,
+					mailer:            mockMailer,
+					userEventOutbox:   mockOutbox,
+					securityEventRepo: newSecurityEventRepo(t),
+					passwordHasher:    newPasswordHasher(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"weak password": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.RegisterDto{
+					Username: "testuser",
+					Email:    "test@test.ru",
+					Password: "short",
+				},
+			},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
 				return UseCase{
-					authRepo:           mockAuthRepo,
-					userEventPublisher: mockKafkaProducer,
-					logger:             logger.NewMockLogger(),
+					passwordPolicy: models.PasswordPolicy{MinLength: 8, RequireDigit: true},
+					logger:         logger.NewMockLogger(),
 				}
 			},
 		},
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// RequestPasswordReset mails a single-use reset code to the given address.
+// Unknown addresses are not reported, so the endpoint cannot be used to probe
+// which emails are registered.
+func (a *UseCase) RequestPasswordReset(ctx context.Context, email string) error {
+	a.logger.Debug("password reset requested", "email", email)
+
+	user, err := a.authRepo.FindUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			a.logger.Info("password reset requested for unknown email", "email", email)
+			return nil
+		}
+		a.logger.Error("failed to find user for password reset", "error", err)
+		return err
+	}
+
+	code, err := models.GenerateSecretToken()
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Add(a.passwordResetTTL)
+	resetCode, err := models.NewOneTimeCode(models.HashToken(code), user.ID(), models.CodePurposePasswordReset, expiresAt)
+	if err != nil {
+		return errors.NewInternalError(err, "failed to create reset code")
+	}
+
+	err = a.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		if err := a.codeRepo.InvalidateByUser(txCtx, user.ID(), models.CodePurposePasswordReset, time.Now()); err != nil {
+			return err
+		}
+		return a.codeRepo.Create(txCtx, resetCode)
+	})
+	if err != nil {
+		a.logger.Error("failed to store reset code", "user_id", user.ID(), "error", err)
+		return err
+	}
+
+	message := &ports.MailMessage{
+		To:      user.Email(),
+		Subject: "Password reset",
+		Body: fmt.Sprintf(
+			"Use this code to reset your password: %s\nThe code expires at %s.",
+			code, expiresAt.UTC().Format(time.RFC1123),
+		),
+	}
+	if err := a.mailer.Send(ctx, message); err != nil {
+		a.logger.Error("failed to send reset code", "user_id", user.ID(), "error", err)
+		return errors.NewServiceError(err, "failed to send reset code")
+	}
+
+	a.logger.Info("password reset code sent", "user_id", user.ID())
+	return nil
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// ResendVerification mails a fresh verification code and invalidates the
+// previous one. Unknown and already verified addresses are not reported, so
+// the endpoint cannot be used to probe which emails are registered.
+func (a *UseCase) ResendVerification(ctx context.Context, email string) error {
+	a.logger.Debug("verification code resend requested", "email", email)
+
+	user, err := a.authRepo.FindUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			a.logger.Info("verification requested for unknown email", "email", email)
+			return nil
+		}
+		a.logger.Error("failed to find user for verification", "error", err)
+		return err
+	}
+
+	if user.EmailVerified() {
+		a.logger.Info("verification requested for verified email", "user_id", user.ID())
+		return nil
+	}
+
+	var (
+		code      models.Token
+		expiresAt time.Time
+	)
+	err = a.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		code, expiresAt, err = a.issueVerificationCode(txCtx, user.ID())
+		return err
+	})
+	if err != nil {
+		a.logger.Error("failed to store verification code", "user_id", user.ID(), "error", err)
+		return err
+	}
+
+	if err := a.sendVerificationCode(ctx, user, code, expiresAt); err != nil {
+		return err
+	}
+
+	a.logger.Info("verification code sent", "user_id", user.ID())
+	return nil
+}
@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUseCase_ResendVerification(t *testing.T) {
+	ctx := context.Background()
+
+	userID := models.UserID(uuid.New())
+	email := "test@test.ru"
+	user, err := models.NewUser(userID, "testuser", email, []byte("hashed"))
+	assert.NoError(t, err)
+	verifiedUser := user.WithEmailVerified()
+
+	type args struct {
+		ctx   context.Context
+		email string
+	}
+	tests := map[string]struct {
+		args    args
+		wantErr bool
+		deps    func(t *testing.T) UseCase
+	}{
+		"verification code sent": {
+			args: args{ctx: ctx, email: email},
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByEmail(ctx, email).Return(user, nil).Once()
+
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().
+					InvalidateByUser(ctx, userID, models.CodePurposeEmailVerification, mock.AnythingOfType("time.Time")).
+					Return(nil).
+					Once()
+				mockCodeRepo.EXPECT().
+					Create(ctx, mock.MatchedBy(func(code *models.OneTimeCode) bool {
+						return code.UserID() == userID && code.Purpose() == models.CodePurposeEmailVerification
+					})).
+					Return(nil).
+					Once()
+
+				mockMailer := mocks.NewMailer(t)
+				mockMailer.EXPECT().
+					Send(ctx, mock.MatchedBy(func(message *ports.MailMessage) bool {
+						return message.To == email
+					})).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					txManager:            newTxManager(t),
+					authRepo:             mockAuthRepo,
+					codeRepo:             mockCodeRepo,
+					mailer:               mockMailer,
+					emailVerificationTTL: time.Hour,
+					logger:               logger.NewMockLogger(),
+				}
+			},
+		},
+		"unknown email is not reported": {
+			args: args{ctx: ctx, email: "unknown@test.ru"},
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserByEmail(ctx, "unknown@test.ru").
+					Return(nil, customerrors.NewNotFoundError("user not found")).
+					Once()
+
+				return UseCase{
+					authRepo: mockAuthRepo,
+					mailer:   mocks.NewMailer(t),
+					logger:   logger.NewMockLogger(),
+				}
+			},
+		},
+		"already verified": {
+			args: args{ctx: ctx, email: email},
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByEmail(ctx, email).Return(verifiedUser, nil).Once()
+
+				return UseCase{
+					authRepo: mockAuthRepo,
+					codeRepo: mocks.NewOneTimeCodeRepository(t),
+					mailer:   mocks.NewMailer(t),
+					logger:   logger.NewMockLogger(),
+				}
+			},
+		},
+		"failed to send mail": {
+			args:    args{ctx: ctx, email: email},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByEmail(ctx, email).Return(user, nil).Once()
+
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().
+					InvalidateByUser(ctx, userID, models.CodePurposeEmailVerification, mock.AnythingOfType("time.Time")).
+					Return(nil).
+					Once()
+				mockCodeRepo.EXPECT().
+					Create(ctx, mock.AnythingOfType("*models.OneTimeCode")).
+					Return(nil).
+					Once()
+
+				mockMailer := mocks.NewMailer(t)
+				mockMailer.EXPECT().
+					Send(ctx, mock.AnythingOfType("*ports.MailMessage")).
+					Return(errors.New("smtp error")).
+					Once()
+
+				return UseCase{
+					txManager:            newTxManager(t),
+					authRepo:             mockAuthRepo,
+					codeRepo:             mockCodeRepo,
+					mailer:               mockMailer,
+					emailVerificationTTL: time.Hour,
+					logger:               logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			err := useCase.ResendVerification(tc.args.ctx, tc.args.email)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
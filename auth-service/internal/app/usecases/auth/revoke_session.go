@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (a *UseCase) RevokeSession(ctx context.Context, sessionID models.FamilyID) error {
+	userID, _, err := callerFromContext(ctx)
+	if err != nil {
+		a.logger.Warn("unauthenticated revoke session attempt", "error", err)
+		return err
+	}
+
+	a.logger.Debug("revoke session attempt", "user_id", userID, "session_id", sessionID)
+
+	session, err := a.tokenRepo.GetSession(ctx, sessionID)
+	if err != nil {
+		a.logger.Warn("session not found", "session_id", sessionID, "error", err)
+		return err
+	}
+
+	// Someone else's session is reported as missing so its existence is not leaked.
+	if session.UserID() != userID {
+		a.logger.Warn("attempt to revoke foreign session", "user_id", userID, "session_id", sessionID)
+		return errors.NewNotFoundError("session not found").
+			WithDetails("session_id", sessionID.String())
+	}
+
+	err = a.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		if err := a.tokenRepo.DeleteTokensByFamily(txCtx, sessionID); err != nil {
+			return err
+		}
+		return a.refreshTokenRepo.RevokeFamily(txCtx, sessionID, time.Now())
+	})
+	if err != nil {
+		a.logger.Error("failed to revoke session", "session_id", sessionID, "error", err)
+		return err
+	}
+
+	a.recordSecurityEvent(ctx, models.NewSecurityEvent(models.SecurityEventSessionRevoked, userID, "",
+		models.ClientFromContext(ctx)))
+	a.logger.Info("session revoked", "user_id", userID, "session_id", sessionID)
+	return nil
+}
@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUseCase_RevokeSession(t *testing.T) {
+	userID := models.UserID(uuid.New())
+	sessionID := models.NewFamilyID()
+	ctx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: userID.String(),
+	})
+
+	ownSession, err := models.NewAuthToken("own-token", userID, sessionID, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	foreignSession, err := models.NewAuthToken("foreign-token", models.UserID(uuid.New()), sessionID, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	type args struct {
+		ctx       context.Context
+		sessionID models.FamilyID
+	}
+	tests := map[string]struct {
+		args        args
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"session revoked": {
+			args: args{ctx: ctx, sessionID: sessionID},
+			deps: func(t *testing.T) UseCase {
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().
+					GetSession(ctx, sessionID).
+					Return(ownSession, nil).
+					Once()
+				mockTokenRepo.EXPECT().
+					DeleteTokensByFamily(ctx, sessionID).
+					Return(nil).
+					Once()
+
+				mockRefreshTokenRepo := mocks.NewRefreshTokenRepository(t)
+				mockRefreshTokenRepo.EXPECT().
+					RevokeFamily(ctx, sessionID, mock.AnythingOfType("time.Time")).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					txManager:         newTxManager(t),
+					tokenRepo:         mockTokenRepo,
+					refreshTokenRepo:  mockRefreshTokenRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"session of another user": {
+			args:        args{ctx: ctx, sessionID: sessionID},
+			wantErr:     true,
+			expectedErr: customerrors.NewNotFoundError("session not found"),
+			deps: func(t *testing.T) UseCase {
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().
+					GetSession(ctx, sessionID).
+					Return(foreignSession, nil).
+					Once()
+
+				return UseCase{
+					tokenRepo:         mockTokenRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"session not found": {
+			args:        args{ctx: ctx, sessionID: sessionID},
+			wantErr:     true,
+			expectedErr: customerrors.NewNotFoundError("session not found"),
+			deps: func(t *testing.T) UseCase {
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().
+					GetSession(ctx, sessionID).
+					Return(nil, customerrors.NewNotFoundError("session not found")).
+					Once()
+
+				return UseCase{
+					tokenRepo:         mockTokenRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"unauthenticated": {
+			args:        args{ctx: context.Background(), sessionID: sessionID},
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("request is not authenticated"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					tokenRepo:         mocks.NewTokenRepository(t),
+					securityEventRepo: newSecurityEventRepo(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"failed to delete tokens": {
+			args:    args{ctx: ctx, sessionID: sessionID},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().
+					GetSession(ctx, sessionID).
+					Return(ownSession, nil).
+					Once()
+				mockTokenRepo.EXPECT().
+					DeleteTokensByFamily(ctx, sessionID).
+					Return(errors.New("database error")).
+					Once()
+
+				return UseCase{
+					txManager:         newTxManager(t),
+					tokenRepo:         mockTokenRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			err := useCase.RevokeSession(tc.args.ctx, tc.args.sessionID)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// maxSecondFactorAttempts caps wrong codes per login challenge, so the six
+// digit space cannot be searched within the challenge lifetime.
+const maxSecondFactorAttempts = 5
+
+// secondFactorRequired reports whether the user has an active TOTP factor.
+func (a *UseCase) secondFactorRequired(ctx context.Context, userID models.UserID) (bool, error) {
+	factor, err := a.totpRepo.GetByUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return factor.IsConfirmed(), nil
+}
+
+// startSecondFactorChallenge stores a short-lived challenge that proves the
+// password step succeeded.
+func (a *UseCase) startSecondFactorChallenge(ctx context.Context, userID models.UserID) (*ports.SecondFactorChallengeDto, error) {
+	token, err := models.GenerateSecretToken()
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(a.secondFactorTTL)
+	challenge, err := models.NewOneTimeCode(models.HashToken(token), userID, models.CodePurposeSecondFactor, expiresAt)
+	if err != nil {
+		return nil, errors.NewInternalError(err, "failed to create login challenge")
+	}
+
+	if err := a.codeRepo.Create(ctx, challenge); err != nil {
+		a.logger.Error("failed to store login challenge", "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	return &ports.SecondFactorChallengeDto{
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+const (
+	defaultSecurityEventsLimit = 50
+	maxSecurityEventsLimit     = 200
+)
+
+// Reasons recorded with failed logins.
+const (
+	loginFailureUnknownAccount      = "unknown_account"
+	loginFailureInvalidPassword     = "invalid_password"
+	loginFailureThrottled           = "throttled"
+	loginFailureSuspended           = "account_suspended"
+	loginFailureUnverifiedEmail     = "email_not_verified"
+	loginFailureInvalidSecondFactor = "invalid_second_factor"
+	loginFailureInvalidLoginCode    = "invalid_login_code"
+)
+
+// ListSecurityEvents returns entries of the audit log, newest first. Users
+// see only their own events; administrators may name any user, or none to
+// see every user.
+func (a *UseCase) ListSecurityEvents(ctx context.Context, dto *ports.ListSecurityEventsDto) ([]*ports.SecurityEventDto, error) {
+	callerID, _, err := callerFromContext(ctx)
+	if err != nil {
+		a.logger.Warn("unauthenticated list security events attempt", "error", err)
+		return nil, err
+	}
+
+	a.logger.Debug("list security events attempt", "caller_id", callerID, "user_id", dto.UserID)
+
+	filter := models.SecurityEventFilter{
+		UserID: dto.UserID,
+		Since:  dto.Since,
+		Until:  dto.Until,
+		Limit:  dto.Limit,
+	}
+
+	if filter.UserID != callerID {
+		if err := platformauth.RequireRole(ctx, platformauth.RoleAdmin); err != nil {
+			if !filter.UserID.IsEmpty() {
+				a.logger.Warn("list foreign security events denied", "caller_id", callerID, "user_id", filter.UserID)
+				return nil, err
+			}
+			filter.UserID = callerID
+		}
+	}
+
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && !filter.Since.Before(filter.Until) {
+		return nil, errors.NewInvalidInputError("since must be before until").
+			WithDetails("field", "since")
+	}
+
+	switch {
+	case filter.Limit < 0:
+		return nil, errors.NewInvalidInputError("limit cannot be negative").
+			WithDetails("field", "limit")
+	case filter.Limit == 0:
+		filter.Limit = defaultSecurityEventsLimit
+	case filter.Limit > maxSecurityEventsLimit:
+		filter.Limit = maxSecurityEventsLimit
+	}
+
+	events, err := a.securityEventRepo.List(ctx, filter)
+	if err != nil {
+		a.logger.Error("failed to list security events", "caller_id", callerID, "error", err)
+		return nil, err
+	}
+
+	result := make([]*ports.SecurityEventDto, 0, len(events))
+	for _, event := range events {
+		result = append(result, &ports.SecurityEventDto{
+			ID:         event.ID(),
+			UserID:     event.UserID(),
+			Type:       event.Type(),
+			Email:      event.Email(),
+			IP:         event.Client().IP,
+			UserAgent:  event.Client().UserAgent,
+			Reason:     event.Reason(),
+			ActorID:    event.ActorID(),
+			OccurredAt: event.OccurredAt(),
+		})
+	}
+
+	a.logger.Debug("security events listed", "caller_id", callerID, "count", len(result))
+	return result, nil
+}
+
+// recordSecurityEvent appends an event to the audit log. It is called once
+// the audited operation has finished; errors are logged only, so a failing
+// audit store does not change the response to the client.
+func (a *UseCase) recordSecurityEvent(ctx context.Context, event *models.SecurityEvent) {
+	if err := a.securityEventRepo.Append(ctx, event); err != nil {
+		a.logger.Error("failed to record security event", "type", event.Type(), "user_id", event.UserID(), "error", err)
+	}
+}
+
+// auditLoginFailure records a failed login. userID is empty when the email
+// does not belong to an account.
+func (a *UseCase) auditLoginFailure(ctx context.Context, userID models.UserID, email string, client models.SessionMetadata, reason string) {
+	a.recordSecurityEvent(ctx, models.NewSecurityEvent(models.SecurityEventLoginFailed, userID, email, client).WithReason(reason))
+}
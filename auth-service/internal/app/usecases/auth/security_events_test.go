@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUseCase_ListSecurityEvents(t *testing.T) {
+	adminID := models.UserID(uuid.New())
+	userID := models.UserID(uuid.New())
+	otherID := models.UserID(uuid.New())
+
+	userCtx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: userID.String(),
+		Roles:  []platformauth.Role{platformauth.RoleUser},
+	})
+	adminCtx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: adminID.String(),
+		Roles:  []platformauth.Role{platformauth.RoleAdmin, platformauth.RoleUser},
+	})
+
+	client := models.SessionMetadata{UserAgent: "test-agent", IP: "127.0.0.1"}
+	event := models.NewSecurityEvent(models.SecurityEventLoginSucceeded, userID, "test@test.ru", client)
+	since := time.Now().Add(-time.Hour).Truncate(time.Second)
+	until := time.Now().Truncate(time.Second)
+
+	type args struct {
+		ctx context.Context
+		dto *ports.ListSecurityEventsDto
+	}
+	tests := map[string]struct {
+		args        args
+		want        []*ports.SecurityEventDto
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"user sees own events": {
+			args: args{ctx: userCtx, dto: &ports.ListSecurityEventsDto{}},
+			want: []*ports.SecurityEventDto{{
+				ID:         event.ID(),
+				UserID:     userID,
+				Type:       models.SecurityEventLoginSucceeded,
+				Email:      "test@test.ru",
+				IP:         "127.0.0.1",
+				UserAgent:  "test-agent",
+				OccurredAt: event.OccurredAt(),
+			}},
+			deps: func(t *testing.T) UseCase {
+				mockRepo := mocks.NewSecurityEventRepository(t)
+				mockRepo.EXPECT().
+					List(userCtx, models.SecurityEventFilter{UserID: userID, Limit: defaultSecurityEventsLimit}).
+					Return([]*models.SecurityEvent{event}, nil).
+					Once()
+
+				return UseCase{
+					securityEventRepo: mockRepo,
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"user cannot see other users' events": {
+			args:        args{ctx: userCtx, dto: &ports.ListSecurityEventsDto{UserID: otherID}},
+			wantErr:     true,
+			expectedErr: customerrors.NewForbiddenError("caller lacks required role"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					logger: logger.NewMockLogger(),
+				}
+			},
+		},
+		"admin queries any user by time range": {
+			args: args{ctx: adminCtx, dto: &ports.ListSecurityEventsDto{UserID: otherID, Since: since, Until: until, Limit: 1000}},
+			want: []*ports.SecurityEventDto{},
+			deps: func(t *testing.T) UseCase {
+				mockRepo := mocks.NewSecurityEventRepository(t)
+				mockRepo.EXPECT().
+					List(adminCtx, models.SecurityEventFilter{UserID: otherID, Since: since, Until: until, Limit: maxSecurityEventsLimit}).
+					Return(nil, nil).
+					Once()
+
+				return UseCase{
+					securityEventRepo: mockRepo,
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"admin queries every user": {
+			args: args{ctx: adminCtx, dto: &ports.ListSecurityEventsDto{Limit: 10}},
+			want: []*ports.SecurityEventDto{},
+			deps: func(t *testing.T) UseCase {
+				mockRepo := mocks.NewSecurityEventRepository(t)
+				mockRepo.EXPECT().
+					List(adminCtx, models.SecurityEventFilter{Limit: 10}).
+					Return(nil, nil).
+					Once()
+
+				return UseCase{
+					securityEventRepo: mockRepo,
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"empty time range": {
+			args:        args{ctx: userCtx, dto: &ports.ListSecurityEventsDto{Since: until, Until: since}},
+			wantErr:     true,
+			expectedErr: customerrors.NewInvalidInputError("since must be before until"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					logger: logger.NewMockLogger(),
+				}
+			},
+		},
+		"unauthenticated": {
+			args:        args{ctx: context.Background(), dto: &ports.ListSecurityEventsDto{}},
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("request is not authenticated"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					logger: logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			events, err := useCase.ListSecurityEvents(tc.args.ctx, tc.args.dto)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, events)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+)
+
+// callerFromContext returns the authenticated user and the session their
+// token belongs to.
+func callerFromContext(ctx context.Context) (models.UserID, models.FamilyID, error) {
+	callerID, err := platformauth.UserIDFromContext(ctx)
+	if err != nil {
+		return models.UserID{}, models.FamilyID{}, err
+	}
+
+	userID, err := models.UserIDFromString(callerID)
+	if err != nil {
+		return models.UserID{}, models.FamilyID{}, err
+	}
+
+	var sessionID models.FamilyID
+	if principal, ok := platformauth.PrincipalFromContext(ctx); ok && principal.SessionID != "" {
+		sessionID, _ = models.FamilyIDFromString(principal.SessionID)
+	}
+
+	return userID, sessionID, nil
+}
+
+// revokeAllSessions logs the user out of every session. It must be called
+// inside a transaction.
+func (a *UseCase) revokeAllSessions(ctx context.Context, userID models.UserID) error {
+	if err := a.tokenRepo.DeleteTokensByUser(ctx, userID); err != nil {
+		return err
+	}
+	return a.refreshTokenRepo.RevokeByUser(ctx, userID, time.Now())
+}
+
+// revokeOtherSessions logs the user out of every session except keep. It must
+// be called inside a transaction.
+func (a *UseCase) revokeOtherSessions(ctx context.Context, userID models.UserID, keep models.FamilyID) error {
+	if keep.IsEmpty() {
+		return a.revokeAllSessions(ctx, userID)
+	}
+
+	sessions, err := a.tokenRepo.ListSessions(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, session := range sessions {
+		if session.FamilyID() == keep {
+			continue
+		}
+		if err := a.tokenRepo.DeleteTokensByFamily(ctx, session.FamilyID()); err != nil {
+			return err
+		}
+		if err := a.refreshTokenRepo.RevokeFamily(ctx, session.FamilyID(), now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// SetUserRoles replaces the roles of a user. Only administrators may call it.
+func (a *UseCase) SetUserRoles(ctx context.Context, dto *ports.SetUserRolesDto) ([]models.Role, error) {
+	callerID, _, err := callerFromContext(ctx)
+	if err != nil {
+		a.logger.Warn("unauthenticated set user roles attempt", "error", err)
+		return nil, err
+	}
+
+	if err := platformauth.RequireRole(ctx, platformauth.RoleAdmin); err != nil {
+		a.logger.Warn("set user roles denied", "caller_id", callerID, "user_id", dto.UserID)
+		return nil, err
+	}
+
+	a.logger.Debug("set user roles attempt", "caller_id", callerID, "user_id", dto.UserID, "roles", dto.Roles)
+
+	var updated *models.User
+	err = a.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		user, err := a.authRepo.FindUserByID(txCtx, dto.UserID)
+		if err != nil {
+			return err
+		}
+
+		updated, err = user.WithRoles(dto.Roles)
+		if err != nil {
+			return err
+		}
+
+		// An administrator demoting themselves could leave nobody able to
+		// grant the role back.
+		if callerID == dto.UserID && user.HasRole(models.RoleAdmin) && !updated.HasRole(models.RoleAdmin) {
+			return errors.NewForbiddenError("administrators cannot revoke their own admin role")
+		}
+
+		return a.authRepo.Update(txCtx, updated)
+	})
+	if err != nil {
+		a.logger.Warn("failed to set user roles", "user_id", dto.UserID, "error", err)
+		return nil, err
+	}
+
+	a.logger.Info("user roles changed", "caller_id", callerID, "user_id", dto.UserID, "roles", updated.Roles())
+	return updated.Roles(), nil
+}
@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUseCase_SetUserRoles(t *testing.T) {
+	adminID := models.UserID(uuid.New())
+	userID := models.UserID(uuid.New())
+
+	ctx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: adminID.String(),
+		Roles:  []platformauth.Role{platformauth.RoleAdmin},
+	})
+	userCtx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: userID.String(),
+		Roles:  []platformauth.Role{platformauth.RoleUser},
+	})
+
+	type args struct {
+		ctx context.Context
+		dto *ports.SetUserRolesDto
+	}
+	tests := map[string]struct {
+		args        args
+		want        []models.Role
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"moderator granted": {
+			args: args{ctx: ctx, dto: &ports.SetUserRolesDto{UserID: userID, Roles: []string{"moderator"}}},
+			want: []models.Role{models.RoleModerator, models.RoleUser},
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByID(ctx, userID).Return(newUser(t, userID), nil).Once()
+				mockAuthRepo.EXPECT().
+					Update(ctx, mock.MatchedBy(func(user *models.User) bool {
+						return user.ID() == userID && user.HasRole(models.RoleModerator)
+					})).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					txManager: newTxManager(t),
+					authRepo:  mockAuthRepo,
+					logger:    logger.NewMockLogger(),
+				}
+			},
+		},
+		"unknown role": {
+			args:        args{ctx: ctx, dto: &ports.SetUserRolesDto{UserID: userID, Roles: []string{"owner"}}},
+			wantErr:     true,
+			expectedErr: customerrors.NewInvalidInputError("unknown role"),
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByID(ctx, userID).Return(newUser(t, userID), nil).Once()
+
+				return UseCase{
+					txManager: newTxManager(t),
+					authRepo:  mockAuthRepo,
+					logger:    logger.NewMockLogger(),
+				}
+			},
+		},
+		"admin cannot demote themselves": {
+			args:        args{ctx: ctx, dto: &ports.SetUserRolesDto{UserID: adminID, Roles: []string{"user"}}},
+			wantErr:     true,
+			expectedErr: customerrors.NewForbiddenError("administrators cannot revoke their own admin role"),
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByID(ctx, adminID).Return(newUser(t, adminID, "admin"), nil).Once()
+
+				return UseCase{
+					txManager: newTxManager(t),
+					authRepo:  mockAuthRepo,
+					logger:    logger.NewMockLogger(),
+				}
+			},
+		},
+		"user not found": {
+			args:        args{ctx: ctx, dto: &ports.SetUserRolesDto{UserID: userID, Roles: []string{"admin"}}},
+			wantErr:     true,
+			expectedErr: customerrors.NewNotFoundError("user not found"),
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserByID(ctx, userID).
+					Return(nil, customerrors.NewNotFoundError("user not found")).
+					Once()
+
+				return UseCase{
+					txManager: newTxManager(t),
+					authRepo:  mockAuthRepo,
+					logger:    logger.NewMockLogger(),
+				}
+			},
+		},
+		"caller is not an administrator": {
+			args:        args{ctx: userCtx, dto: &ports.SetUserRolesDto{UserID: userID, Roles: []string{"admin"}}},
+			wantErr:     true,
+			expectedErr: customerrors.NewForbiddenError("caller lacks required role"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{logger: logger.NewMockLogger()}
+			},
+		},
+		"unauthenticated": {
+			args:        args{ctx: context.Background(), dto: &ports.SetUserRolesDto{UserID: userID, Roles: []string{"admin"}}},
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("request is not authenticated"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					logger: logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			roles, err := useCase.SetUserRoles(tc.args.ctx, tc.args.dto)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, roles)
+		})
+	}
+}
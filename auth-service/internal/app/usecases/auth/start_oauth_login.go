@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+func (a *UseCase) StartOAuthLogin(ctx context.Context, providerName string) (*ports.OAuthLoginStartDto, error) {
+	a.logger.Debug("oauth login start", "provider", providerName)
+
+	provider, err := a.identityProvider(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := models.GenerateSecretToken()
+	if err != nil {
+		return nil, err
+	}
+	codeVerifier, err := models.GenerateSecretToken()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := models.GenerateSecretToken()
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(a.oauthStateTTL)
+	pending, err := models.NewOAuthState(models.HashToken(state), provider.Name(), codeVerifier, nonce, expiresAt)
+	if err != nil {
+		return nil, errors.NewInternalError(err, "failed to create login state")
+	}
+
+	authorizationURL, err := provider.AuthCodeURL(ctx, string(state), string(nonce), models.PKCEChallenge(codeVerifier))
+	if err != nil {
+		a.logger.Error("failed to build authorization url", "provider", provider.Name(), "error", err)
+		return nil, err
+	}
+
+	if err := a.oauthStateRepo.Create(ctx, pending); err != nil {
+		a.logger.Error("failed to store login state", "provider", provider.Name(), "error", err)
+		return nil, err
+	}
+
+	return &ports.OAuthLoginStartDto{
+		AuthorizationURL: authorizationURL,
+		State:            state,
+		ExpiresAt:        expiresAt,
+	}, nil
+}
+
+func (a *UseCase) identityProvider(name string) (ports.IdentityProvider, error) {
+	provider, ok := a.identityProviders[name]
+	if !ok {
+		return nil, errors.NewNotFoundError("identity provider %q is not configured", name).
+			WithDetails("provider", name)
+	}
+	return provider, nil
+}
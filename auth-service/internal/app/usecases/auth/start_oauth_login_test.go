@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newIdentityProvider(t *testing.T, name string) *mocks.IdentityProvider {
+	provider := mocks.NewIdentityProvider(t)
+	provider.EXPECT().Name().Return(name).Maybe()
+	return provider
+}
+
+func TestUseCase_StartOAuthLogin(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx      context.Context
+		provider string
+	}
+	tests := map[string]struct {
+		args        args
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"authorization url returned": {
+			args: args{ctx: ctx, provider: "google"},
+			deps: func(t *testing.T) UseCase {
+				var sentNonce, sentChallenge string
+
+				provider := newIdentityProvider(t, "google")
+				provider.EXPECT().
+					AuthCodeURL(ctx, mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).
+					RunAndReturn(func(_ context.Context, _, nonce, codeChallenge string) (string, error) {
+						sentNonce, sentChallenge = nonce, codeChallenge
+						return "https://accounts.example.com/authorize", nil
+					}).
+					Once()
+
+				mockStateRepo := mocks.NewOAuthStateRepository(t)
+				mockStateRepo.EXPECT().
+					Create(ctx, mock.MatchedBy(func(state *models.OAuthState) bool {
+						// The stored verifier must answer the challenge sent to the provider.
+						return state.Provider() == "google" &&
+							string(state.Nonce()) == sentNonce &&
+							models.PKCEChallenge(state.CodeVerifier()) == sentChallenge
+					})).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					oauthStateRepo:    mockStateRepo,
+					identityProviders: map[string]ports.IdentityProvider{"google": provider},
+					oauthStateTTL:     10 * time.Minute,
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"unknown provider": {
+			args:        args{ctx: ctx, provider: "unknown"},
+			wantErr:     true,
+			expectedErr: customerrors.NewNotFoundError("identity provider is not configured"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					identityProviders: map[string]ports.IdentityProvider{},
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"provider discovery failed": {
+			args:    args{ctx: ctx, provider: "google"},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				provider := newIdentityProvider(t, "google")
+				provider.EXPECT().
+					AuthCodeURL(ctx, mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).
+					Return("", errors.New("connection refused")).
+					Once()
+
+				return UseCase{
+					oauthStateRepo:    mocks.NewOAuthStateRepository(t),
+					identityProviders: map[string]ports.IdentityProvider{"google": provider},
+					oauthStateTTL:     10 * time.Minute,
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			start, err := useCase.StartOAuthLogin(tc.args.ctx, tc.args.provider)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotEmpty(t, start.AuthorizationURL)
+			assert.NotEmpty(t, start.State)
+			assert.True(t, start.ExpiresAt.After(time.Now()))
+		})
+	}
+}
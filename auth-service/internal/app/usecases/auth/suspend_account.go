@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/api/events/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SuspendAccount blocks a user from logging in and revokes their sessions.
+// Only administrators may call it. Suspending an already suspended account is
+// a no-op.
+func (a *UseCase) SuspendAccount(ctx context.Context, dto *ports.SuspendAccountDto) error {
+	callerID, _, err := callerFromContext(ctx)
+	if err != nil {
+		a.logger.Warn("unauthenticated suspend account attempt", "error", err)
+		return err
+	}
+
+	if err := platformauth.RequireRole(ctx, platformauth.RoleAdmin); err != nil {
+		a.logger.Warn("suspend account denied", "caller_id", callerID, "user_id", dto.UserID)
+		return err
+	}
+
+	a.logger.Debug("suspend account attempt", "caller_id", callerID, "user_id", dto.UserID)
+
+	if callerID == dto.UserID {
+		return errors.NewForbiddenError("administrators cannot suspend their own account")
+	}
+
+	err = a.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		user, err := a.authRepo.FindUserByID(txCtx, dto.UserID)
+		if err != nil {
+			return err
+		}
+
+		if user.IsSuspended() {
+			a.logger.Debug("account already suspended", "user_id", dto.UserID)
+			return nil
+		}
+
+		suspended := user.WithSuspended(time.Now())
+		if err := a.authRepo.Update(txCtx, suspended); err != nil {
+			return err
+		}
+		if err := a.revokeAllSessions(txCtx, dto.UserID); err != nil {
+			return err
+		}
+		return a.enqueueUserSuspended(txCtx, suspended, dto.Reason)
+	})
+	if err != nil {
+		a.logger.Error("failed to suspend account", "user_id", dto.UserID, "error", err)
+		return err
+	}
+
+	a.logger.Info("account suspended", "caller_id", callerID, "user_id", dto.UserID, "reason", dto.Reason)
+	return nil
+}
+
+// enqueueUserSuspended records UserSuspendedEvent in the outbox. It must be
+// called in the transaction that suspends the user.
+func (a *UseCase) enqueueUserSuspended(ctx context.Context, user *models.User, reason string) error {
+	event := &events.UserSuspendedEvent{
+		UserId:      user.ID().String(),
+		Reason:      reason,
+		SuspendedAt: timestamppb.New(user.SuspendedAt()),
+	}
+
+	if err := a.userEventOutbox.EnqueueUserSuspendedEvent(ctx, event); err != nil {
+		a.logger.Error("failed to enqueue user suspension event", "error", err, "user_id", user.ID())
+		return err
+	}
+	return nil
+}
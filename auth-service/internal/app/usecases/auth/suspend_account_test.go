@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
+	"github.com/SamEkb/messenger-app/pkg/api/events/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUseCase_SuspendAccount(t *testing.T) {
+	adminID := models.UserID(uuid.New())
+	userID := models.UserID(uuid.New())
+
+	ctx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: adminID.String(),
+		Roles:  []platformauth.Role{platformauth.RoleAdmin},
+	})
+	userCtx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: userID.String(),
+		Roles:  []platformauth.Role{platformauth.RoleUser},
+	})
+
+	type args struct {
+		ctx context.Context
+		dto *ports.SuspendAccountDto
+	}
+	tests := map[string]struct {
+		args        args
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"account suspended": {
+			args: args{ctx: ctx, dto: &ports.SuspendAccountDto{UserID: userID, Reason: "spam"}},
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByID(ctx, userID).Return(newUser(t, userID), nil).Once()
+				mockAuthRepo.EXPECT().
+					Update(ctx, mock.MatchedBy(func(user *models.User) bool {
+						return user.ID() == userID && user.IsSuspended()
+					})).
+					Return(nil).
+					Once()
+
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().DeleteTokensByUser(ctx, userID).Return(nil).Once()
+
+				mockRefreshTokenRepo := mocks.NewRefreshTokenRepository(t)
+				mockRefreshTokenRepo.EXPECT().RevokeByUser(ctx, userID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+				mockOutbox := mocks.NewUserEventsOutbox(t)
+				mockOutbox.EXPECT().
+					EnqueueUserSuspendedEvent(ctx, mock.MatchedBy(func(event *events.UserSuspendedEvent) bool {
+						return event.GetUserId() == userID.String() && event.GetReason() == "spam"
+					})).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					txManager:        newTxManager(t),
+					authRepo:         mockAuthRepo,
+					tokenRepo:        mockTokenRepo,
+					refreshTokenRepo: mockRefreshTokenRepo,
+					userEventOutbox:  mockOutbox,
+					logger:           logger.NewMockLogger(),
+				}
+			},
+		},
+		"already suspended": {
+			args: args{ctx: ctx, dto: &ports.SuspendAccountDto{UserID: userID, Reason: "spam"}},
+			deps: func(t *testing.T) UseCase {
+				suspended := newUser(t, userID).WithSuspended(time.Now().Add(-time.Hour))
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByID(ctx, userID).Return(suspended, nil).Once()
+
+				return UseCase{
+					txManager: newTxManager(t),
+					authRepo:  mockAuthRepo,
+					logger:    logger.NewMockLogger(),
+				}
+			},
+		},
+		"cannot suspend themselves": {
+			args:        args{ctx: ctx, dto: &ports.SuspendAccountDto{UserID: adminID}},
+			wantErr:     true,
+			expectedErr: customerrors.NewForbiddenError("administrators cannot suspend their own account"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					logger: logger.NewMockLogger(),
+				}
+			},
+		},
+		"caller is not an administrator": {
+			args:        args{ctx: userCtx, dto: &ports.SuspendAccountDto{UserID: adminID, Reason: "spam"}},
+			wantErr:     true,
+			expectedErr: customerrors.NewForbiddenError("caller lacks required role"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{logger: logger.NewMockLogger()}
+			},
+		},
+		"user not found": {
+			args:        args{ctx: ctx, dto: &ports.SuspendAccountDto{UserID: userID}},
+			wantErr:     true,
+			expectedErr: customerrors.NewNotFoundError("user not found"),
+			deps: func(t *testing.T) UseCase {
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserByID(ctx, userID).
+					Return(nil, customerrors.NewNotFoundError("user not found")).
+					Once()
+
+				return UseCase{
+					txManager: newTxManager(t),
+					authRepo:  mockAuthRepo,
+					logger:    logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			err := useCase.SuspendAccount(tc.args.ctx, tc.args.dto)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+)
+
+const (
+	DefaultTokenSweepInterval  = 10 * time.Minute
+	DefaultTokenSweepBatchSize = 1000
+)
+
+type TokenSweeperConfig struct {
+	Interval  time.Duration
+	BatchSize int
+	// Retention is how long a token is kept after it expires. A session is
+	// refreshed by rotating its token row in place, so rows must outlive
+	// their refresh token.
+	Retention time.Duration
+}
+
+// TokenSweeper periodically deletes expired access tokens. Tokens are removed
+// in small batches so that a large backlog does not hold long locks.
+type TokenSweeper struct {
+	tokenRepo ports.TokenRepository
+	cfg       TokenSweeperConfig
+	logger    logger.Logger
+}
+
+func NewTokenSweeper(tokenRepo ports.TokenRepository, cfg TokenSweeperConfig, logger logger.Logger) *TokenSweeper {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultTokenSweepInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultTokenSweepBatchSize
+	}
+	if cfg.Retention < 0 {
+		cfg.Retention = 0
+	}
+
+	return &TokenSweeper{
+		tokenRepo: tokenRepo,
+		cfg:       cfg,
+		logger:    logger.With("component", "token_sweeper"),
+	}
+}
+
+// Run sweeps expired tokens until ctx is cancelled.
+func (s *TokenSweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Sweep(ctx)
+		}
+	}
+}
+
+// Sweep deletes every token that expired before the retention period and
+// returns how many were deleted.
+func (s *TokenSweeper) Sweep(ctx context.Context) int {
+	before := time.Now().Add(-s.cfg.Retention)
+
+	total := 0
+	for {
+		deleted, err := s.tokenRepo.DeleteExpired(ctx, before, s.cfg.BatchSize)
+		if err != nil {
+			s.logger.Error("failed to delete expired tokens", "error", err)
+			break
+		}
+		total += deleted
+		// Keep going while full batches come back.
+		if deleted < s.cfg.BatchSize || ctx.Err() != nil {
+			break
+		}
+	}
+
+	if total > 0 {
+		s.logger.Info("expired tokens deleted", "count", total)
+	}
+	return total
+}
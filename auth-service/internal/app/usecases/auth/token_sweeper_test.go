@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTokenSweeper_Sweep(t *testing.T) {
+	ctx := context.Background()
+	retention := 24 * time.Hour
+
+	// beforeRetention matches a cutoff one retention period in the past.
+	beforeRetention := mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) >= retention && time.Since(before) < retention+time.Minute
+	})
+
+	tests := map[string]struct {
+		want int
+		deps func(t *testing.T) *mocks.TokenRepository
+	}{
+		"deletes batches until a partial one": {
+			want: 25,
+			deps: func(t *testing.T) *mocks.TokenRepository {
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().DeleteExpired(ctx, beforeRetention, 10).Return(10, nil).Twice()
+				mockTokenRepo.EXPECT().DeleteExpired(ctx, beforeRetention, 10).Return(5, nil).Once()
+				return mockTokenRepo
+			},
+		},
+		"nothing to delete": {
+			want: 0,
+			deps: func(t *testing.T) *mocks.TokenRepository {
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().DeleteExpired(ctx, beforeRetention, 10).Return(0, nil).Once()
+				return mockTokenRepo
+			},
+		},
+		"stops on repository error": {
+			want: 10,
+			deps: func(t *testing.T) *mocks.TokenRepository {
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().DeleteExpired(ctx, beforeRetention, 10).Return(10, nil).Once()
+				mockTokenRepo.EXPECT().DeleteExpired(ctx, beforeRetention, 10).Return(0, errors.New("db error")).Once()
+				return mockTokenRepo
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			sweeper := NewTokenSweeper(tt.deps(t), TokenSweeperConfig{
+				Interval:  time.Minute,
+				BatchSize: 10,
+				Retention: retention,
+			}, logger.NewMockLogger())
+
+			assert.Equal(t, tt.want, sweeper.Sweep(ctx))
+		})
+	}
+}
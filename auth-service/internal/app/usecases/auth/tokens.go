@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// issueTokens opens a new session and stores its access/refresh token pair.
+// It must be called inside a transaction.
+func (a *UseCase) issueTokens(ctx context.Context, user *models.User, session models.SessionMetadata) (*ports.AuthTokensDto, error) {
+	if user.IsSuspended() {
+		return nil, accountSuspendedError(user)
+	}
+
+	userID := user.ID()
+	familyID := models.NewFamilyID()
+	accessExpiresAt := time.Now().Add(a.tokenTTL)
+
+	accessToken, err := a.tokenIssuer.Issue(ctx, user, accessExpiresAt)
+	if err != nil {
+		a.logger.Error("failed to issue access token", "error", err)
+		return nil, err
+	}
+
+	authToken, err := models.NewAuthToken(accessToken, userID, familyID, accessExpiresAt)
+	if err != nil {
+		a.logger.Error("failed to create auth token", "error", err)
+		return nil, errors.NewInternalError(err, "failed to create auth token").
+			WithDetails("user_id", userID.String())
+	}
+
+	if _, err = a.tokenRepo.Create(ctx, authToken.WithSession(session)); err != nil {
+		a.logger.Error("failed to save token", "error", err)
+		return nil, errors.NewInternalError(err, "failed to save token").
+			WithDetails("user_id", userID.String())
+	}
+
+	return a.withRefreshToken(ctx, &ports.AuthTokensDto{
+		UserID:               userID,
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: accessExpiresAt,
+	}, familyID)
+}
+
+// rotateTokens replaces the access token of an existing session and issues a
+// new refresh token in the same family. The user is reloaded so that role
+// changes reach the new access token. It must be called inside a transaction.
+func (a *UseCase) rotateTokens(ctx context.Context, userID models.UserID, familyID models.FamilyID) (*ports.AuthTokensDto, error) {
+	accessExpiresAt := time.Now().Add(a.tokenTTL)
+
+	user, err := a.authRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		a.logger.Error("failed to get user for token rotation", "user_id", userID, "error", err)
+		return nil, err
+	}
+	if user.IsSuspended() {
+		return nil, accountSuspendedError(user)
+	}
+
+	accessToken, err := a.tokenIssuer.Issue(ctx, user, accessExpiresAt)
+	if err != nil {
+		a.logger.Error("failed to issue access token", "error", err)
+		return nil, err
+	}
+
+	if err = a.tokenRepo.RotateToken(ctx, familyID, accessToken, accessExpiresAt); err != nil {
+		a.logger.Error("failed to rotate access token", "error", err)
+		return nil, err
+	}
+
+	return a.withRefreshToken(ctx, &ports.AuthTokensDto{
+		UserID:               userID,
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: accessExpiresAt,
+	}, familyID)
+}
+
+func (a *UseCase) withRefreshToken(ctx context.Context, tokens *ports.AuthTokensDto, familyID models.FamilyID) (*ports.AuthTokensDto, error) {
+	refreshExpiresAt := time.Now().Add(a.refreshTokenTTL)
+
+	refreshToken, err := models.GenerateSecretToken()
+	if err != nil {
+		a.logger.Error("failed to generate refresh token", "error", err)
+		return nil, err
+	}
+
+	refresh, err := models.NewRefreshToken(models.HashToken(refreshToken), tokens.UserID, familyID, refreshExpiresAt)
+	if err != nil {
+		a.logger.Error("failed to create refresh token", "error", err)
+		return nil, errors.NewInternalError(err, "failed to create refresh token").
+			WithDetails("user_id", tokens.UserID.String())
+	}
+
+	if err = a.refreshTokenRepo.Create(ctx, refresh); err != nil {
+		a.logger.Error("failed to save refresh token", "error", err)
+		return nil, errors.NewInternalError(err, "failed to save refresh token").
+			WithDetails("user_id", tokens.UserID.String())
+	}
+
+	tokens.RefreshToken = refreshToken
+	tokens.RefreshTokenExpiresAt = refreshExpiresAt
+	return tokens, nil
+}
+
+func accountSuspendedError(user *models.User) error {
+	return errors.NewForbiddenError("account is suspended").
+		WithDetails("suspended_at", user.SuspendedAt())
+}
@@ -3,34 +3,131 @@ package auth
 import (
 	"time"
 
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
 	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
 	"github.com/SamEkb/messenger-app/pkg/platform/logger"
-	"github.com/SamEkb/messenger-app/pkg/platform/postgres"
 )
 
 type UseCase struct {
-	txManager          *postgres.TxManager
-	authRepo           ports.AuthRepository
-	tokenRepo          ports.TokenRepository
-	userEventPublisher ports.UserEventsKafkaProducer
-	tokenTTL           time.Duration
-	logger             logger.Logger
+	txManager         ports.TxManager
+	authRepo          ports.AuthRepository
+	tokenRepo         ports.TokenRepository
+	refreshTokenRepo  ports.RefreshTokenRepository
+	codeRepo          ports.OneTimeCodeRepository
+	totpRepo          ports.TOTPRepository
+	recoveryCodeRepo  ports.RecoveryCodeRepository
+	loginAttemptRepo  ports.LoginAttemptRepository
+	oauthStateRepo    ports.OAuthStateRepository
+	oauthIdentityRepo ports.OAuthIdentityRepository
+	apiKeyRepo        ports.APIKeyRepository
+	securityEventRepo ports.SecurityEventRepository
+	// identityProviders holds the configured OpenID Connect providers by name.
+	identityProviders map[string]ports.IdentityProvider
+	tokenIssuer       ports.TokenIssuer
+	passwordHasher    ports.PasswordHasher
+	mailer            ports.Mailer
+	userEventOutbox   ports.UserEventsOutbox
+	tokenTTL          time.Duration
+	refreshTokenTTL   time.Duration
+	passwordResetTTL  time.Duration
+	// emailVerificationTTL is how long a mailed verification code stays valid.
+	emailVerificationTTL time.Duration
+	// requireVerifiedEmail makes Login reject users who have not confirmed
+	// their email address yet.
+	requireVerifiedEmail bool
+	// totpIssuer names the service in authenticator apps.
+	totpIssuer string
+	// secondFactorTTL is how long a login challenge can be completed.
+	secondFactorTTL time.Duration
+	// lockoutPolicy throttles repeated failed logins per account and address.
+	lockoutPolicy models.LockoutPolicy
+	// passwordPolicy is enforced whenever a user chooses a new password.
+	passwordPolicy models.PasswordPolicy
+	// loginCodePolicy configures passwordless login with emailed codes.
+	loginCodePolicy models.LoginCodePolicy
+	// oauthStateTTL is how long an external login can be completed.
+	oauthStateTTL time.Duration
+	// impersonationTTL is how long a token issued by Impersonate stays valid.
+	impersonationTTL time.Duration
+	logger           logger.Logger
 }
 
-func NewAuthUseCase(
-	txManager *postgres.TxManager,
-	authRepo ports.AuthRepository,
-	tokenRepo ports.TokenRepository,
-	userEventPublisher ports.UserEventsKafkaProducer,
-	tokenTTL time.Duration,
-	logger logger.Logger,
-) *UseCase {
+// Dependencies are the ports the use case works through.
+type Dependencies struct {
+	TxManager         ports.TxManager
+	AuthRepo          ports.AuthRepository
+	TokenRepo         ports.TokenRepository
+	RefreshTokenRepo  ports.RefreshTokenRepository
+	CodeRepo          ports.OneTimeCodeRepository
+	TOTPRepo          ports.TOTPRepository
+	RecoveryCodeRepo  ports.RecoveryCodeRepository
+	LoginAttemptRepo  ports.LoginAttemptRepository
+	OAuthStateRepo    ports.OAuthStateRepository
+	OAuthIdentityRepo ports.OAuthIdentityRepository
+	APIKeyRepo        ports.APIKeyRepository
+	SecurityEventRepo ports.SecurityEventRepository
+	// IdentityProviders are the configured OpenID Connect providers; they
+	// are looked up by their Name.
+	IdentityProviders []ports.IdentityProvider
+	TokenIssuer       ports.TokenIssuer
+	PasswordHasher    ports.PasswordHasher
+	Mailer            ports.Mailer
+	UserEventOutbox   ports.UserEventsOutbox
+	Logger            logger.Logger
+}
+
+// Config holds the lifetimes and policies the use case enforces.
+type Config struct {
+	TokenTTL             time.Duration
+	RefreshTokenTTL      time.Duration
+	PasswordResetTTL     time.Duration
+	EmailVerificationTTL time.Duration
+	RequireVerifiedEmail bool
+	TOTPIssuer           string
+	SecondFactorTTL      time.Duration
+	LockoutPolicy        models.LockoutPolicy
+	PasswordPolicy       models.PasswordPolicy
+	LoginCodePolicy      models.LoginCodePolicy
+	OAuthStateTTL        time.Duration
+	ImpersonationTTL     time.Duration
+}
+
+func NewAuthUseCase(deps Dependencies, cfg Config) *UseCase {
+	providers := make(map[string]ports.IdentityProvider, len(deps.IdentityProviders))
+	for _, provider := range deps.IdentityProviders {
+		providers[provider.Name()] = provider
+	}
+
 	return &UseCase{
-		txManager:          txManager,
-		authRepo:           authRepo,
-		tokenRepo:          tokenRepo,
-		userEventPublisher: userEventPublisher,
-		tokenTTL:           tokenTTL,
-		logger:             logger.With("component", "auth_usecase"),
+		txManager:            deps.TxManager,
+		authRepo:             deps.AuthRepo,
+		tokenRepo:            deps.TokenRepo,
+		refreshTokenRepo:     deps.RefreshTokenRepo,
+		codeRepo:             deps.CodeRepo,
+		totpRepo:             deps.TOTPRepo,
+		recoveryCodeRepo:     deps.RecoveryCodeRepo,
+		loginAttemptRepo:     deps.LoginAttemptRepo,
+		oauthStateRepo:       deps.OAuthStateRepo,
+		oauthIdentityRepo:    deps.OAuthIdentityRepo,
+		apiKeyRepo:           deps.APIKeyRepo,
+		securityEventRepo:    deps.SecurityEventRepo,
+		identityProviders:    providers,
+		tokenIssuer:          deps.TokenIssuer,
+		passwordHasher:       deps.PasswordHasher,
+		mailer:               deps.Mailer,
+		userEventOutbox:      deps.UserEventOutbox,
+		tokenTTL:             cfg.TokenTTL,
+		refreshTokenTTL:      cfg.RefreshTokenTTL,
+		passwordResetTTL:     cfg.PasswordResetTTL,
+		emailVerificationTTL: cfg.EmailVerificationTTL,
+		requireVerifiedEmail: cfg.RequireVerifiedEmail,
+		totpIssuer:           cfg.TOTPIssuer,
+		secondFactorTTL:      cfg.SecondFactorTTL,
+		lockoutPolicy:        cfg.LockoutPolicy,
+		passwordPolicy:       cfg.PasswordPolicy,
+		loginCodePolicy:      cfg.LoginCodePolicy,
+		oauthStateTTL:        cfg.OAuthStateTTL,
+		impersonationTTL:     cfg.ImpersonationTTL,
+		logger:               deps.Logger.With("component", "auth_usecase"),
 	}
 }
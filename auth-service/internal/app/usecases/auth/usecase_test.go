@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// newTxManager returns a TxManager mock that runs the transaction body inline.
+func newTxManager(t *testing.T) *mocks.TxManager {
+	txManager := mocks.NewTxManager(t)
+	txManager.EXPECT().
+		RunTx(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+	return txManager
+}
+
+// newSecurityEventRepo returns a SecurityEventRepository mock that accepts
+// any audit events the operation records.
+func newSecurityEventRepo(t *testing.T) *mocks.SecurityEventRepository {
+	repo := mocks.NewSecurityEventRepository(t)
+	repo.EXPECT().Append(mock.Anything, mock.Anything).Return(nil).Maybe()
+	return repo
+}
+
+// newPasswordHasher returns a PasswordHasher mock backed by bcrypt at its
+// minimum cost, which never asks for a rehash.
+func newPasswordHasher(t *testing.T) *mocks.PasswordHasher {
+	hasher := mocks.NewPasswordHasher(t)
+	hasher.EXPECT().
+		Hash(mock.Anything).
+		RunAndReturn(func(password string) ([]byte, error) {
+			return bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+		}).
+		Maybe()
+	hasher.EXPECT().
+		Compare(mock.Anything, mock.Anything).
+		RunAndReturn(func(hash []byte, password string) error {
+			return bcrypt.CompareHashAndPassword(hash, []byte(password))
+		}).
+		Maybe()
+	hasher.EXPECT().NeedsRehash(mock.Anything).Return(false).Maybe()
+	return hasher
+}
+
+// newUser returns a user with the given ID and roles, e.g. for token
+// issuance that reloads the account.
+func newUser(t *testing.T, userID models.UserID, roles ...string) *models.User {
+	user, err := models.RestoreUser(userID, "testuser", "test@test.ru", []byte("hashed"), true, roles, time.Time{})
+	assert.NoError(t, err)
+	return user
+}
+
+// userWithID matches the user passed to TokenIssuer.Issue.
+func userWithID(userID models.UserID) any {
+	return mock.MatchedBy(func(user *models.User) bool {
+		return user.ID() == userID
+	})
+}
+
+func TestNewAuthUseCase(t *testing.T) {
+	google := mocks.NewIdentityProvider(t)
+	google.EXPECT().Name().Return("google").Once()
+	hasher := newPasswordHasher(t)
+
+	useCase := NewAuthUseCase(
+		Dependencies{
+			IdentityProviders: []ports.IdentityProvider{google},
+			PasswordHasher:    hasher,
+			Logger:            logger.NewMockLogger(),
+		},
+		Config{
+			TokenTTL:         time.Hour,
+			ImpersonationTTL: 15 * time.Minute,
+		},
+	)
+
+	assert.Equal(t, map[string]ports.IdentityProvider{"google": google}, useCase.identityProviders)
+	assert.Equal(t, hasher, useCase.passwordHasher)
+	assert.Equal(t, time.Hour, useCase.tokenTTL)
+	assert.Equal(t, 15*time.Minute, useCase.impersonationTTL)
+}
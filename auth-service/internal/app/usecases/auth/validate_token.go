@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// sessionTouchInterval limits how often validation writes last_used_at, so a
+// busy session does not turn every authenticated request into a write.
+const sessionTouchInterval = time.Minute
+
+func (a *UseCase) ValidateToken(ctx context.Context, token models.Token) (*ports.TokenInfoDto, error) {
+	a.logger.Debug("token validation attempt")
+
+	if models.IsAPIKey(token) {
+		return a.validateAPIKey(ctx, token)
+	}
+
+	authToken, err := a.tokenRepo.GetToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			a.logger.Debug("token not found")
+			return &ports.TokenInfoDto{Active: false}, nil
+		}
+		a.logger.Error("failed to get token", "error", err)
+		return nil, err
+	}
+
+	info := &ports.TokenInfoDto{
+		UserID:    authToken.UserID(),
+		SessionID: authToken.FamilyID(),
+		ExpiresAt: authToken.ExpiresAt(),
+		Active:    !authToken.IsExpired(),
+	}
+
+	if !info.Active {
+		a.logger.Debug("token validated", "user_id", info.UserID, "active", info.Active)
+		return info, nil
+	}
+
+	// Roles are looked up on every validation rather than stored with the
+	// token, so a revoked role stops working once the lookup cache, if any,
+	// drops the user.
+	access, err := a.authRepo.FindUserAccess(ctx, info.UserID)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			a.logger.Warn("token of unknown user", "user_id", info.UserID)
+			return &ports.TokenInfoDto{Active: false}, nil
+		}
+		a.logger.Error("failed to get token owner", "user_id", info.UserID, "error", err)
+		return nil, err
+	}
+	if access.IsSuspended() {
+		a.logger.Warn("token of suspended user", "user_id", info.UserID)
+		return &ports.TokenInfoDto{Active: false}, nil
+	}
+	info.Roles = access.Roles()
+
+	if authToken.IsImpersonation() {
+		info.ActorID = authToken.ActorID()
+		a.auditImpersonationUse(ctx, info.UserID, info.ActorID)
+	}
+
+	if time.Since(authToken.LastUsedAt()) > sessionTouchInterval {
+		if err := a.tokenRepo.TouchToken(ctx, token, time.Now()); err != nil {
+			a.logger.Warn("failed to update session last use", "error", err)
+		}
+	}
+
+	a.logger.Debug("token validated", "user_id", info.UserID, "active", info.Active)
+	return info, nil
+}
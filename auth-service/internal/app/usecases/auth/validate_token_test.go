@@ -0,0 +1,388 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUseCase_ValidateToken(t *testing.T) {
+	ctx := context.Background()
+
+	userID := models.UserID(uuid.New())
+	actorID := models.UserID(uuid.New())
+	familyID := models.NewFamilyID()
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	expiredAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	apiKeyToken := models.Token(models.APIKeyPrefix + "integration-secret")
+	apiKey, err := models.NewAPIKey(userID, "ci bot", apiKeyToken, []platformauth.Scope{platformauth.ScopeChatWrite})
+	assert.NoError(t, err)
+
+	type args struct {
+		ctx   context.Context
+		token models.Token
+	}
+	tests := map[string]struct {
+		args    args
+		want    *ports.TokenInfoDto
+		wantErr bool
+		deps    func(t *testing.T) UseCase
+	}{
+		"active token": {
+			args: args{
+				ctx:   ctx,
+				token: models.Token("very-strong-token"),
+			},
+			want: &ports.TokenInfoDto{
+				UserID:    userID,
+				SessionID: familyID,
+				Roles:     []models.Role{models.RoleAdmin, models.RoleUser},
+				ExpiresAt: expiresAt,
+				Active:    true,
+			},
+			deps: func(t *testing.T) UseCase {
+				authToken, err := models.NewAuthToken("very-strong-token", userID, familyID, expiresAt)
+				assert.NoError(t, err)
+
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().
+					GetToken(ctx, models.Token("very-strong-token")).
+					Return(authToken, nil).
+					Once()
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserAccess(ctx, userID).
+					Return(newUser(t, userID, "admin").Access(), nil).
+					Once()
+
+				return UseCase{
+					authRepo:  mockAuthRepo,
+					tokenRepo: mockTokenRepo,
+					logger:    logger.NewMockLogger(),
+				}
+			},
+		},
+		"expired token": {
+			args: args{
+				ctx:   ctx,
+				token: models.Token("expired-token"),
+			},
+			want: &ports.TokenInfoDto{
+				UserID:    userID,
+				SessionID: familyID,
+				ExpiresAt: expiredAt,
+				Active:    false,
+			},
+			deps: func(t *testing.T) UseCase {
+				authToken, err := models.NewAuthToken("expired-token", userID, familyID, expiredAt)
+				assert.NoError(t, err)
+
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().
+					GetToken(ctx, models.Token("expired-token")).
+					Return(authToken, nil).
+					Once()
+
+				return UseCase{
+					tokenRepo: mockTokenRepo,
+					logger:    logger.NewMockLogger(),
+				}
+			},
+		},
+		"stale session is touched": {
+			args: args{
+				ctx:   ctx,
+				token: models.Token("very-strong-token"),
+			},
+			want: &ports.TokenInfoDto{
+				UserID:    userID,
+				SessionID: familyID,
+				Roles:     []models.Role{models.RoleUser},
+				ExpiresAt: expiresAt,
+				Active:    true,
+			},
+			deps: func(t *testing.T) UseCase {
+				lastUsedAt := time.Now().Add(-time.Hour)
+				authToken, err := models.RestoreAuthToken(
+					"very-strong-token", userID, familyID, expiresAt,
+					models.SessionMetadata{}, lastUsedAt, lastUsedAt,
+				)
+				assert.NoError(t, err)
+
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().
+					GetToken(ctx, models.Token("very-strong-token")).
+					Return(authToken, nil).
+					Once()
+				mockTokenRepo.EXPECT().
+					TouchToken(ctx, models.Token("very-strong-token"), mock.AnythingOfType("time.Time")).
+					Return(nil).
+					Once()
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserAccess(ctx, userID).
+					Return(newUser(t, userID).Access(), nil).
+					Once()
+
+				return UseCase{
+					authRepo:  mockAuthRepo,
+					tokenRepo: mockTokenRepo,
+					logger:    logger.NewMockLogger(),
+				}
+			},
+		},
+		"impersonation token is audited": {
+			args: args{
+				ctx:   ctx,
+				token: models.Token("impersonation-token"),
+			},
+			want: &ports.TokenInfoDto{
+				UserID:    userID,
+				SessionID: familyID,
+				Roles:     []models.Role{models.RoleUser},
+				ActorID:   actorID,
+				ExpiresAt: expiresAt,
+				Active:    true,
+			},
+			deps: func(t *testing.T) UseCase {
+				authToken, err := models.NewAuthToken("impersonation-token", userID, familyID, expiresAt)
+				assert.NoError(t, err)
+
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().
+					GetToken(ctx, models.Token("impersonation-token")).
+					Return(authToken.WithActor(actorID), nil).
+					Once()
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserAccess(ctx, userID).
+					Return(newUser(t, userID).Access(), nil).
+					Once()
+				mockAuthRepo.EXPECT().
+					FindUserByID(ctx, userID).
+					Return(newUser(t, userID), nil).
+					Once()
+
+				mockSecurityEventRepo := mocks.NewSecurityEventRepository(t)
+				mockSecurityEventRepo.EXPECT().
+					Append(ctx, mock.MatchedBy(func(event *models.SecurityEvent) bool {
+						return event.Type() == models.SecurityEventImpersonationUsed &&
+							event.UserID() == userID &&
+							event.Email() != "" &&
+							event.ActorID() == actorID
+					})).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					authRepo:          mockAuthRepo,
+					tokenRepo:         mockTokenRepo,
+					securityEventRepo: mockSecurityEventRepo,
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"deleted owner": {
+			args: args{
+				ctx:   ctx,
+				token: models.Token("very-strong-token"),
+			},
+			want: &ports.TokenInfoDto{Active: false},
+			deps: func(t *testing.T) UseCase {
+				authToken, err := models.NewAuthToken("very-strong-token", userID, familyID, expiresAt)
+				assert.NoError(t, err)
+
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().
+					GetToken(ctx, models.Token("very-strong-token")).
+					Return(authToken, nil).
+					Once()
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserAccess(ctx, userID).
+					Return(nil, customerrors.NewNotFoundError("user not found")).
+					Once()
+
+				return UseCase{
+					authRepo:  mockAuthRepo,
+					tokenRepo: mockTokenRepo,
+					logger:    logger.NewMockLogger(),
+				}
+			},
+		},
+		"unknown token": {
+			args: args{
+				ctx:   ctx,
+				token: models.Token("unknown-token"),
+			},
+			want: &ports.TokenInfoDto{Active: false},
+			deps: func(t *testing.T) UseCase {
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().
+					GetToken(ctx, models.Token("unknown-token")).
+					Return(nil, customerrors.NewNotFoundError("token not found")).
+					Once()
+
+				return UseCase{
+					tokenRepo: mockTokenRepo,
+					logger:    logger.NewMockLogger(),
+				}
+			},
+		},
+		"repository error": {
+			args: args{
+				ctx:   ctx,
+				token: models.Token("very-strong-token"),
+			},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				mockTokenRepo := mocks.NewTokenRepository(t)
+				mockTokenRepo.EXPECT().
+					GetToken(ctx, models.Token("very-strong-token")).
+					Return(nil, errors.New("database error")).
+					Once()
+
+				return UseCase{
+					tokenRepo: mockTokenRepo,
+					logger:    logger.NewMockLogger(),
+				}
+			},
+		},
+		"active API key": {
+			args: args{
+				ctx:   ctx,
+				token: apiKeyToken,
+			},
+			want: &ports.TokenInfoDto{
+				UserID:   userID,
+				APIKeyID: apiKey.ID(),
+				Roles:    []models.Role{models.RoleUser},
+				Scopes:   []platformauth.Scope{platformauth.ScopeChatWrite},
+				Active:   true,
+			},
+			deps: func(t *testing.T) UseCase {
+				mockAPIKeyRepo := mocks.NewAPIKeyRepository(t)
+				mockAPIKeyRepo.EXPECT().
+					GetByHash(ctx, models.HashToken(apiKeyToken)).
+					Return(apiKey, nil).
+					Once()
+				mockAPIKeyRepo.EXPECT().
+					Touch(ctx, apiKey.ID(), mock.AnythingOfType("time.Time")).
+					Return(nil).
+					Once()
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserAccess(ctx, userID).
+					Return(newUser(t, userID, "admin").Access(), nil).
+					Once()
+
+				return UseCase{
+					authRepo:   mockAuthRepo,
+					apiKeyRepo: mockAPIKeyRepo,
+					logger:     logger.NewMockLogger(),
+				}
+			},
+		},
+		"revoked API key": {
+			args: args{
+				ctx:   ctx,
+				token: apiKeyToken,
+			},
+			want: &ports.TokenInfoDto{Active: false},
+			deps: func(t *testing.T) UseCase {
+				revoked, err := models.RestoreAPIKey(apiKey.ID(), userID, apiKey.Name(), apiKey.Hash(), apiKey.Hint(),
+					models.ScopeNames(apiKey.Scopes()), apiKey.CreatedAt(), time.Time{}, time.Now())
+				assert.NoError(t, err)
+
+				mockAPIKeyRepo := mocks.NewAPIKeyRepository(t)
+				mockAPIKeyRepo.EXPECT().
+					GetByHash(ctx, models.HashToken(apiKeyToken)).
+					Return(revoked, nil).
+					Once()
+
+				return UseCase{
+					apiKeyRepo: mockAPIKeyRepo,
+					logger:     logger.NewMockLogger(),
+				}
+			},
+		},
+		"unknown API key": {
+			args: args{
+				ctx:   ctx,
+				token: apiKeyToken,
+			},
+			want: &ports.TokenInfoDto{Active: false},
+			deps: func(t *testing.T) UseCase {
+				mockAPIKeyRepo := mocks.NewAPIKeyRepository(t)
+				mockAPIKeyRepo.EXPECT().
+					GetByHash(ctx, models.HashToken(apiKeyToken)).
+					Return(nil, customerrors.NewNotFoundError("API key not found")).
+					Once()
+
+				return UseCase{
+					apiKeyRepo: mockAPIKeyRepo,
+					logger:     logger.NewMockLogger(),
+				}
+			},
+		},
+		"API key of suspended owner": {
+			args: args{
+				ctx:   ctx,
+				token: apiKeyToken,
+			},
+			want: &ports.TokenInfoDto{Active: false},
+			deps: func(t *testing.T) UseCase {
+				mockAPIKeyRepo := mocks.NewAPIKeyRepository(t)
+				mockAPIKeyRepo.EXPECT().
+					GetByHash(ctx, models.HashToken(apiKeyToken)).
+					Return(apiKey, nil).
+					Once()
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().
+					FindUserAccess(ctx, userID).
+					Return(newUser(t, userID).WithSuspended(time.Now()).Access(), nil).
+					Once()
+
+				return UseCase{
+					authRepo:   mockAuthRepo,
+					apiKeyRepo: mockAPIKeyRepo,
+					logger:     logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			info, err := useCase.ValidateToken(tc.args.ctx, tc.args.token)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.want, info)
+			}
+		})
+	}
+}
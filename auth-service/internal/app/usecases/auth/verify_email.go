@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/pkg/api/events/v1"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (a *UseCase) VerifyEmail(ctx context.Context, code models.Token) error {
+	a.logger.Debug("email verification attempt")
+
+	hash := models.HashToken(code)
+
+	verificationCode, err := a.codeRepo.GetByHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			a.logger.Warn("unknown verification code")
+			return errors.NewTokenError(errors.ErrInvalidToken, "verification code is invalid")
+		}
+		a.logger.Error("failed to get verification code", "error", err)
+		return err
+	}
+
+	if verificationCode.Purpose() != models.CodePurposeEmailVerification || verificationCode.IsUsed() {
+		a.logger.Warn("verification code rejected", "user_id", verificationCode.UserID(), "used", verificationCode.IsUsed())
+		return errors.NewTokenError(errors.ErrInvalidToken, "verification code is invalid")
+	}
+
+	if verificationCode.IsExpired() {
+		a.logger.Warn("verification code expired", "user_id", verificationCode.UserID())
+		return errors.NewTokenError(errors.ErrTokenExpired, "verification code is expired")
+	}
+
+	verifiedAt := time.Now()
+
+	var user *models.User
+	err = a.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		if err := a.codeRepo.MarkUsed(txCtx, hash, verifiedAt); err != nil {
+			return err
+		}
+
+		user, err = a.authRepo.FindUserByID(txCtx, verificationCode.UserID())
+		if err != nil {
+			return err
+		}
+
+		user = user.WithEmailVerified()
+		if err := a.authRepo.Update(txCtx, user); err != nil {
+			return err
+		}
+
+		return a.userEventOutbox.EnqueueUserEmailVerifiedEvent(txCtx, &events.UserEmailVerifiedEvent{
+			UserId:     user.ID().String(),
+			Email:      user.Email(),
+			VerifiedAt: timestamppb.New(verifiedAt),
+		})
+	})
+	if err != nil {
+		a.logger.Error("failed to verify email", "user_id", verificationCode.UserID(), "error", err)
+		return err
+	}
+
+	a.logger.Info("email verified", "user_id", user.ID())
+	return nil
+}
@@ -0,0 +1,208 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
+	"github.com/SamEkb/messenger-app/pkg/api/events/v1"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUseCase_VerifyEmail(t *testing.T) {
+	ctx := context.Background()
+
+	userID := models.UserID(uuid.New())
+	user, err := models.NewUser(userID, "testuser", "test@test.ru", []byte("hashed"))
+	assert.NoError(t, err)
+
+	code := models.Token("verification-code")
+	hash := models.HashToken(code)
+
+	validCode, err := models.NewOneTimeCode(hash, userID, models.CodePurposeEmailVerification, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	expiredCode, err := models.NewOneTimeCode(hash, userID, models.CodePurposeEmailVerification, time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	usedCode, err := models.RestoreOneTimeCode(hash, userID, models.CodePurposeEmailVerification, time.Now().Add(time.Hour), time.Now())
+	assert.NoError(t, err)
+	resetCode, err := models.NewOneTimeCode(hash, userID, models.CodePurposePasswordReset, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	type args struct {
+		ctx  context.Context
+		code models.Token
+	}
+	tests := map[string]struct {
+		args        args
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"email verified": {
+			args: args{ctx: ctx, code: code},
+			deps: func(t *testing.T) UseCase {
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().GetByHash(ctx, hash).Return(validCode, nil).Once()
+				mockCodeRepo.EXPECT().MarkUsed(ctx, hash, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByID(ctx, userID).Return(user, nil).Once()
+				mockAuthRepo.EXPECT().
+					Update(ctx, mock.MatchedBy(func(u *models.User) bool {
+						return u.ID() == userID && u.EmailVerified()
+					})).
+					Return(nil).
+					Once()
+
+				mockOutbox := mocks.NewUserEventsOutbox(t)
+				mockOutbox.EXPECT().
+					EnqueueUserEmailVerifiedEvent(ctx, mock.MatchedBy(func(event *events.UserEmailVerifiedEvent) bool {
+						return event.GetUserId() == userID.String() && event.GetEmail() == user.Email()
+					})).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					txManager:       newTxManager(t),
+					authRepo:        mockAuthRepo,
+					codeRepo:        mockCodeRepo,
+					userEventOutbox: mockOutbox,
+					logger:          logger.NewMockLogger(),
+				}
+			},
+		},
+		"unknown code": {
+			args:        args{ctx: ctx, code: code},
+			wantErr:     true,
+			expectedErr: customerrors.NewTokenError(customerrors.ErrInvalidToken, "verification code is invalid"),
+			deps: func(t *testing.T) UseCase {
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().GetByHash(ctx, hash).
+					Return(nil, customerrors.NewNotFoundError("code not found")).
+					Once()
+
+				return UseCase{
+					codeRepo: mockCodeRepo,
+					logger:   logger.NewMockLogger(),
+				}
+			},
+		},
+		"code issued for another purpose": {
+			args:        args{ctx: ctx, code: code},
+			wantErr:     true,
+			expectedErr: customerrors.NewTokenError(customerrors.ErrInvalidToken, "verification code is invalid"),
+			deps: func(t *testing.T) UseCase {
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().GetByHash(ctx, hash).Return(resetCode, nil).Once()
+
+				return UseCase{
+					codeRepo: mockCodeRepo,
+					logger:   logger.NewMockLogger(),
+				}
+			},
+		},
+		"code already used": {
+			args:        args{ctx: ctx, code: code},
+			wantErr:     true,
+			expectedErr: customerrors.NewTokenError(customerrors.ErrInvalidToken, "verification code is invalid"),
+			deps: func(t *testing.T) UseCase {
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().GetByHash(ctx, hash).Return(usedCode, nil).Once()
+
+				return UseCase{
+					codeRepo: mockCodeRepo,
+					logger:   logger.NewMockLogger(),
+				}
+			},
+		},
+		"code expired": {
+			args:        args{ctx: ctx, code: code},
+			wantErr:     true,
+			expectedErr: customerrors.NewTokenError(customerrors.ErrTokenExpired, "verification code is expired"),
+			deps: func(t *testing.T) UseCase {
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().GetByHash(ctx, hash).Return(expiredCode, nil).Once()
+
+				return UseCase{
+					codeRepo: mockCodeRepo,
+					logger:   logger.NewMockLogger(),
+				}
+			},
+		},
+		"failed to update user": {
+			args:    args{ctx: ctx, code: code},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().GetByHash(ctx, hash).Return(validCode, nil).Once()
+				mockCodeRepo.EXPECT().MarkUsed(ctx, hash, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByID(ctx, userID).Return(user, nil).Once()
+				mockAuthRepo.EXPECT().
+					Update(ctx, mock.AnythingOfType("*models.User")).
+					Return(errors.New("database error")).
+					Once()
+
+				return UseCase{
+					txManager: newTxManager(t),
+					authRepo:  mockAuthRepo,
+					codeRepo:  mockCodeRepo,
+					logger:    logger.NewMockLogger(),
+				}
+			},
+		},
+		"failed to enqueue event": {
+			args:    args{ctx: ctx, code: code},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().GetByHash(ctx, hash).Return(validCode, nil).Once()
+				mockCodeRepo.EXPECT().MarkUsed(ctx, hash, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+				mockAuthRepo := mocks.NewAuthRepository(t)
+				mockAuthRepo.EXPECT().FindUserByID(ctx, userID).Return(user, nil).Once()
+				mockAuthRepo.EXPECT().Update(ctx, mock.AnythingOfType("*models.User")).Return(nil).Once()
+
+				mockOutbox := mocks.NewUserEventsOutbox(t)
+				mockOutbox.EXPECT().
+					EnqueueUserEmailVerifiedEvent(ctx, mock.AnythingOfType("*events.UserEmailVerifiedEvent")).
+					Return(errors.New("database error")).
+					Once()
+
+				return UseCase{
+					txManager:       newTxManager(t),
+					authRepo:        mockAuthRepo,
+					codeRepo:        mockCodeRepo,
+					userEventOutbox: mockOutbox,
+					logger:          logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			err := useCase.VerifyEmail(tc.args.ctx, tc.args.code)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
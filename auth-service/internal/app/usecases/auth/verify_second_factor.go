@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// VerifySecondFactor completes a login challenge with a TOTP code or a
+// recovery code and opens the session.
+func (a *UseCase) VerifySecondFactor(ctx context.Context, dto *ports.VerifySecondFactorDto) (*ports.AuthTokensDto, error) {
+	a.logger.Debug("second factor verification attempt")
+
+	hash := models.HashToken(dto.ChallengeToken)
+
+	challenge, err := a.codeRepo.GetByHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			a.logger.Warn("unknown login challenge")
+			return nil, errors.NewTokenError(errors.ErrInvalidToken, "login challenge is invalid")
+		}
+		a.logger.Error("failed to get login challenge", "error", err)
+		return nil, err
+	}
+
+	if challenge.Purpose() != models.CodePurposeSecondFactor || challenge.IsUsed() {
+		a.logger.Warn("login challenge rejected", "user_id", challenge.UserID(), "used", challenge.IsUsed())
+		return nil, errors.NewTokenError(errors.ErrInvalidToken, "login challenge is invalid")
+	}
+
+	if challenge.IsExpired() {
+		a.logger.Warn("login challenge expired", "user_id", challenge.UserID())
+		return nil, errors.NewTokenError(errors.ErrTokenExpired, "login challenge is expired")
+	}
+
+	userID := challenge.UserID()
+
+	factor, err := a.totpRepo.GetByUser(ctx, userID)
+	if err != nil {
+		a.logger.Error("failed to get totp factor", "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	step, totpOK := factor.Verify(dto.Code, now)
+
+	var tokens *ports.AuthTokensDto
+	err = a.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		if totpOK {
+			if err := a.totpRepo.Update(txCtx, factor.WithLastUsedStep(step)); err != nil {
+				return err
+			}
+		} else {
+			recoveryHash := models.HashToken(models.NormalizeRecoveryCode(models.Token(dto.Code)))
+			if err := a.recoveryCodeRepo.Use(txCtx, userID, recoveryHash, now); err != nil {
+				return err
+			}
+			a.logger.Info("recovery code used", "user_id", userID)
+		}
+
+		if err := a.codeRepo.MarkUsed(txCtx, hash, now); err != nil {
+			return err
+		}
+
+		user, err := a.authRepo.FindUserByID(txCtx, userID)
+		if err != nil {
+			return err
+		}
+
+		tokens, err = a.issueTokens(txCtx, user, dto.Session)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, errors.ErrInvalidToken) {
+			a.auditLoginFailure(ctx, userID, "", dto.Session, loginFailureInvalidSecondFactor)
+			return nil, a.rejectSecondFactor(ctx, hash, userID)
+		}
+		a.logger.Error("failed to complete login challenge", "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	a.recordSecurityEvent(ctx, models.NewSecurityEvent(models.SecurityEventLoginSucceeded, userID, "", dto.Session))
+	a.logger.Info("second factor verified", "user_id", userID)
+	return tokens, nil
+}
+
+// rejectSecondFactor counts a wrong code and burns the challenge once the
+// attempt limit is reached.
+func (a *UseCase) rejectSecondFactor(ctx context.Context, hash string, userID models.UserID) error {
+	a.logger.Warn("invalid second factor code", "user_id", userID)
+
+	attempts, err := a.codeRepo.RecordFailedAttempt(ctx, hash)
+	if err != nil {
+		a.logger.Error("failed to record second factor attempt", "user_id", userID, "error", err)
+		return err
+	}
+
+	if attempts >= maxSecondFactorAttempts {
+		if err := a.codeRepo.MarkUsed(ctx, hash, time.Now()); err != nil && !errors.Is(err, errors.ErrInvalidToken) {
+			a.logger.Error("failed to invalidate login challenge", "user_id", userID, "error", err)
+			return err
+		}
+		a.logger.Warn("login challenge exhausted", "user_id", userID)
+		return errors.NewUnauthorizedError("too many invalid codes, log in again")
+	}
+
+	return errors.NewUnauthorizedError("invalid verification code").
+		WithDetails("attempts_left", maxSecondFactorAttempts-attempts)
+}
@@ -0,0 +1,257 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/auth-service/internal/app/usecases/auth/mocks"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUseCase_VerifySecondFactor(t *testing.T) {
+	ctx := context.Background()
+
+	userID := models.UserID(uuid.New())
+	session := models.SessionMetadata{DeviceName: "phone", UserAgent: "test-agent", IP: "127.0.0.1"}
+
+	secret, err := models.GenerateTOTPSecret()
+	assert.NoError(t, err)
+	factor, err := models.RestoreTOTPFactor(userID, secret, time.Now().Add(-time.Hour), 0)
+	assert.NoError(t, err)
+
+	validCode, err := models.TOTPCode(secret, time.Now())
+	assert.NoError(t, err)
+	recoveryCode := models.Token("abcde-fghij")
+	recoveryHash := models.HashToken(models.NormalizeRecoveryCode(recoveryCode))
+
+	challengeToken := models.Token("challenge-token")
+	challengeHash := models.HashToken(challengeToken)
+	challenge, err := models.NewOneTimeCode(challengeHash, userID, models.CodePurposeSecondFactor, time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	expiredChallenge, err := models.NewOneTimeCode(challengeHash, userID, models.CodePurposeSecondFactor, time.Now().Add(-time.Minute))
+	assert.NoError(t, err)
+
+	issuedTokens := func(t *testing.T) (*mocks.AuthRepository, *mocks.TokenIssuer, *mocks.TokenRepository, *mocks.RefreshTokenRepository) {
+		mockAuthRepo := mocks.NewAuthRepository(t)
+		mockAuthRepo.EXPECT().FindUserByID(ctx, userID).Return(newUser(t, userID), nil).Once()
+
+		mockTokenIssuer := mocks.NewTokenIssuer(t)
+		mockTokenIssuer.EXPECT().
+			Issue(ctx, userWithID(userID), mock.AnythingOfType("time.Time")).
+			Return(models.Token("access-token"), nil).
+			Once()
+
+		mockTokenRepo := mocks.NewTokenRepository(t)
+		mockTokenRepo.EXPECT().
+			Create(ctx, mock.MatchedBy(func(token *models.AuthToken) bool {
+				return token.Session() == session
+			})).
+			Return(nil, nil).
+			Once()
+
+		mockRefreshTokenRepo := mocks.NewRefreshTokenRepository(t)
+		mockRefreshTokenRepo.EXPECT().
+			Create(ctx, mock.AnythingOfType("*models.RefreshToken")).
+			Return(nil).
+			Once()
+
+		return mockAuthRepo, mockTokenIssuer, mockTokenRepo, mockRefreshTokenRepo
+	}
+
+	type args struct {
+		ctx context.Context
+		dto *ports.VerifySecondFactorDto
+	}
+	tests := map[string]struct {
+		args        args
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"totp code accepted": {
+			args: args{ctx: ctx, dto: &ports.VerifySecondFactorDto{ChallengeToken: challengeToken, Code: validCode, Session: session}},
+			deps: func(t *testing.T) UseCase {
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().GetByHash(ctx, challengeHash).Return(challenge, nil).Once()
+				mockCodeRepo.EXPECT().MarkUsed(ctx, challengeHash, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+				mockTOTPRepo := mocks.NewTOTPRepository(t)
+				mockTOTPRepo.EXPECT().GetByUser(ctx, userID).Return(factor, nil).Once()
+				mockTOTPRepo.EXPECT().
+					Update(ctx, mock.MatchedBy(func(updated *models.TOTPFactor) bool {
+						return updated.LastUsedStep() > factor.LastUsedStep()
+					})).
+					Return(nil).
+					Once()
+
+				mockAuthRepo, mockTokenIssuer, mockTokenRepo, mockRefreshTokenRepo := issuedTokens(t)
+
+				return UseCase{
+					txManager:         newTxManager(t),
+					authRepo:          mockAuthRepo,
+					codeRepo:          mockCodeRepo,
+					totpRepo:          mockTOTPRepo,
+					tokenIssuer:       mockTokenIssuer,
+					tokenRepo:         mockTokenRepo,
+					refreshTokenRepo:  mockRefreshTokenRepo,
+					tokenTTL:          time.Minute,
+					refreshTokenTTL:   time.Hour,
+					securityEventRepo: newSecurityEventRepo(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"recovery code accepted": {
+			args: args{ctx: ctx, dto: &ports.VerifySecondFactorDto{ChallengeToken: challengeToken, Code: "ABCDE-FGHIJ", Session: session}},
+			deps: func(t *testing.T) UseCase {
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().GetByHash(ctx, challengeHash).Return(challenge, nil).Once()
+				mockCodeRepo.EXPECT().MarkUsed(ctx, challengeHash, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+				mockTOTPRepo := mocks.NewTOTPRepository(t)
+				mockTOTPRepo.EXPECT().GetByUser(ctx, userID).Return(factor, nil).Once()
+
+				mockRecoveryCodeRepo := mocks.NewRecoveryCodeRepository(t)
+				mockRecoveryCodeRepo.EXPECT().
+					Use(ctx, userID, recoveryHash, mock.AnythingOfType("time.Time")).
+					Return(nil).
+					Once()
+
+				mockAuthRepo, mockTokenIssuer, mockTokenRepo, mockRefreshTokenRepo := issuedTokens(t)
+
+				return UseCase{
+					txManager:         newTxManager(t),
+					authRepo:          mockAuthRepo,
+					codeRepo:          mockCodeRepo,
+					totpRepo:          mockTOTPRepo,
+					recoveryCodeRepo:  mockRecoveryCodeRepo,
+					tokenIssuer:       mockTokenIssuer,
+					tokenRepo:         mockTokenRepo,
+					refreshTokenRepo:  mockRefreshTokenRepo,
+					tokenTTL:          time.Minute,
+					refreshTokenTTL:   time.Hour,
+					securityEventRepo: newSecurityEventRepo(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"wrong code counts an attempt": {
+			args:        args{ctx: ctx, dto: &ports.VerifySecondFactorDto{ChallengeToken: challengeToken, Code: "not-a-code"}},
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("invalid verification code"),
+			deps: func(t *testing.T) UseCase {
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().GetByHash(ctx, challengeHash).Return(challenge, nil).Once()
+				mockCodeRepo.EXPECT().RecordFailedAttempt(ctx, challengeHash).Return(1, nil).Once()
+
+				mockTOTPRepo := mocks.NewTOTPRepository(t)
+				mockTOTPRepo.EXPECT().GetByUser(ctx, userID).Return(factor, nil).Once()
+
+				mockRecoveryCodeRepo := mocks.NewRecoveryCodeRepository(t)
+				mockRecoveryCodeRepo.EXPECT().
+					Use(ctx, userID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+					Return(customerrors.NewTokenError(customerrors.ErrInvalidToken, "recovery code is invalid")).
+					Once()
+
+				return UseCase{
+					txManager:         newTxManager(t),
+					codeRepo:          mockCodeRepo,
+					totpRepo:          mockTOTPRepo,
+					recoveryCodeRepo:  mockRecoveryCodeRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"challenge burned after too many attempts": {
+			args:        args{ctx: ctx, dto: &ports.VerifySecondFactorDto{ChallengeToken: challengeToken, Code: "not-a-code"}},
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("too many invalid codes, log in again"),
+			deps: func(t *testing.T) UseCase {
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().GetByHash(ctx, challengeHash).Return(challenge, nil).Once()
+				mockCodeRepo.EXPECT().RecordFailedAttempt(ctx, challengeHash).Return(maxSecondFactorAttempts, nil).Once()
+				mockCodeRepo.EXPECT().MarkUsed(ctx, challengeHash, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+				mockTOTPRepo := mocks.NewTOTPRepository(t)
+				mockTOTPRepo.EXPECT().GetByUser(ctx, userID).Return(factor, nil).Once()
+
+				mockRecoveryCodeRepo := mocks.NewRecoveryCodeRepository(t)
+				mockRecoveryCodeRepo.EXPECT().
+					Use(ctx, userID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+					Return(customerrors.NewTokenError(customerrors.ErrInvalidToken, "recovery code is invalid")).
+					Once()
+
+				return UseCase{
+					txManager:         newTxManager(t),
+					codeRepo:          mockCodeRepo,
+					totpRepo:          mockTOTPRepo,
+					recoveryCodeRepo:  mockRecoveryCodeRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"unknown challenge": {
+			args:        args{ctx: ctx, dto: &ports.VerifySecondFactorDto{ChallengeToken: challengeToken, Code: validCode}},
+			wantErr:     true,
+			expectedErr: customerrors.NewTokenError(customerrors.ErrInvalidToken, "login challenge is invalid"),
+			deps: func(t *testing.T) UseCase {
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().
+					GetByHash(ctx, challengeHash).
+					Return(nil, customerrors.NewNotFoundError("code not found")).
+					Once()
+
+				return UseCase{
+					codeRepo:          mockCodeRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+		"expired challenge": {
+			args:        args{ctx: ctx, dto: &ports.VerifySecondFactorDto{ChallengeToken: challengeToken, Code: validCode}},
+			wantErr:     true,
+			expectedErr: customerrors.NewTokenError(customerrors.ErrTokenExpired, "login challenge is expired"),
+			deps: func(t *testing.T) UseCase {
+				mockCodeRepo := mocks.NewOneTimeCodeRepository(t)
+				mockCodeRepo.EXPECT().GetByHash(ctx, challengeHash).Return(expiredChallenge, nil).Once()
+
+				return UseCase{
+					codeRepo:          mockCodeRepo,
+					securityEventRepo: newSecurityEventRepo(t),
+					logger:            logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			tokens, err := useCase.VerifySecondFactor(tc.args.ctx, tc.args.dto)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, models.Token("access-token"), tokens.AccessToken)
+				assert.Equal(t, userID, tokens.UserID)
+				assert.NotEmpty(t, tokens.RefreshToken)
+			}
+		})
+	}
+}
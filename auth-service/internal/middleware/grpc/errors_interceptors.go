@@ -2,11 +2,14 @@ package middleware_grpc
 
 import (
 	"context"
+	"time"
 
 	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 func ErrorsUnaryServerInterceptor() grpc.UnaryServerInterceptor {
@@ -40,13 +43,63 @@ func ErrorsUnaryServerInterceptor() grpc.UnaryServerInterceptor {
 				code = codes.Unavailable
 			case errors.Is(err, errors.ErrTimeout):
 				code = codes.DeadlineExceeded
+			case errors.Is(err, errors.ErrResourceExhausted):
+				code = codes.ResourceExhausted
 			default:
 				code = codes.Internal
 			}
 
-			return nil, status.Error(code, err.Error())
+			return nil, withFieldViolations(withRetryInfo(status.New(code, err.Error()), err), err).Err()
 		}
 
 		return resp, nil
 	}
 }
+
+// withRetryInfo attaches a RetryInfo detail when the error carries a retry
+// delay, so that gRPC and REST clients know when to try again.
+func withRetryInfo(st *status.Status, err error) *status.Status {
+	var appErr *errors.AppError
+	if !errors.As(err, &appErr) {
+		return st
+	}
+
+	retryAfter, ok := appErr.Details[errors.DetailRetryAfter].(time.Duration)
+	if !ok {
+		return st
+	}
+
+	withDetails, detailsErr := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)})
+	if detailsErr != nil {
+		return st
+	}
+	return withDetails
+}
+
+// withFieldViolations attaches a BadRequest detail when the error lists the
+// invalid fields, so that clients can point the user at each of them.
+func withFieldViolations(st *status.Status, err error) *status.Status {
+	var appErr *errors.AppError
+	if !errors.As(err, &appErr) {
+		return st
+	}
+
+	violations, ok := appErr.Details[errors.DetailFieldViolations].([]errors.FieldViolation)
+	if !ok || len(violations) == 0 {
+		return st
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, violation := range violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       violation.Field,
+			Description: violation.Description,
+		})
+	}
+
+	withDetails, detailsErr := st.WithDetails(badRequest)
+	if detailsErr != nil {
+		return st
+	}
+	return withDetails
+}
@@ -0,0 +1,31 @@
+-- +goose Up
+ALTER TABLE tokens
+    ADD COLUMN IF NOT EXISTS family_id UUID;
+
+UPDATE tokens
+SET family_id = gen_random_uuid()
+WHERE family_id IS NULL;
+
+ALTER TABLE tokens
+    ALTER COLUMN family_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS tokens_family_id_idx ON tokens (family_id);
+
+CREATE TABLE IF NOT EXISTS refresh_tokens
+(
+    token_hash TEXT PRIMARY KEY,
+    user_id    UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    family_id  UUID        NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    used_at    TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS refresh_tokens_family_id_idx ON refresh_tokens (family_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS refresh_tokens;
+DROP INDEX IF EXISTS tokens_family_id_idx;
+ALTER TABLE tokens
+    DROP COLUMN IF EXISTS family_id;
@@ -0,0 +1,22 @@
+-- +goose Up
+ALTER TABLE tokens
+    ADD COLUMN IF NOT EXISTS device_name  TEXT        NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS user_agent   TEXT        NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS ip           TEXT        NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN IF NOT EXISTS last_used_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE UNIQUE INDEX IF NOT EXISTS tokens_family_id_uniq ON tokens (family_id);
+DROP INDEX IF EXISTS tokens_family_id_idx;
+CREATE INDEX IF NOT EXISTS tokens_user_id_idx ON tokens (user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS tokens_user_id_idx;
+DROP INDEX IF EXISTS tokens_family_id_uniq;
+CREATE INDEX IF NOT EXISTS tokens_family_id_idx ON tokens (family_id);
+ALTER TABLE tokens
+    DROP COLUMN IF EXISTS last_used_at,
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS ip,
+    DROP COLUMN IF EXISTS user_agent,
+    DROP COLUMN IF EXISTS device_name;
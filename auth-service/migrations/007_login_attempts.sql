@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS login_attempts
+(
+    key             TEXT PRIMARY KEY,
+    failures        INT         NOT NULL,
+    last_failure_at TIMESTAMPTZ NOT NULL,
+    locked_until    TIMESTAMPTZ
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS login_attempts;
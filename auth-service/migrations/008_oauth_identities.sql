@@ -0,0 +1,29 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS oauth_states
+(
+    state_hash    TEXT PRIMARY KEY,
+    provider      TEXT        NOT NULL,
+    code_verifier TEXT        NOT NULL,
+    nonce         TEXT        NOT NULL,
+    expires_at    TIMESTAMPTZ NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_oauth_states_expires_at ON oauth_states (expires_at);
+
+CREATE TABLE IF NOT EXISTS oauth_identities
+(
+    provider      TEXT        NOT NULL,
+    subject       TEXT        NOT NULL,
+    user_id       UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    email         TEXT        NOT NULL DEFAULT '',
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_login_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (provider, subject)
+);
+
+CREATE INDEX IF NOT EXISTS idx_oauth_identities_user_id ON oauth_identities (user_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS oauth_identities;
+DROP TABLE IF EXISTS oauth_states;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Every account holds the user role; moderator and admin are granted through
+-- SetUserRoles. The first administrator has to be granted directly:
+-- UPDATE users SET roles = '{admin,user}' WHERE email = '...';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS roles TEXT[] NOT NULL DEFAULT '{user}';
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN IF EXISTS roles;
@@ -0,0 +1,35 @@
+-- +goose Up
+-- user_id has no foreign key so that the audit trail outlives deleted accounts.
+CREATE TABLE IF NOT EXISTS security_events
+(
+    id          UUID PRIMARY KEY,
+    user_id     UUID,
+    event_type  TEXT        NOT NULL,
+    email       TEXT        NOT NULL DEFAULT '',
+    ip          TEXT        NOT NULL DEFAULT '',
+    user_agent  TEXT        NOT NULL DEFAULT '',
+    reason      TEXT        NOT NULL DEFAULT '',
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_security_events_user_occurred ON security_events (user_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_security_events_occurred ON security_events (occurred_at DESC);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION security_events_append_only() RETURNS trigger AS
+$$
+BEGIN
+    RAISE EXCEPTION 'security_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER security_events_append_only
+    BEFORE UPDATE OR DELETE OR TRUNCATE
+    ON security_events
+    FOR EACH STATEMENT
+EXECUTE FUNCTION security_events_append_only();
+
+-- +goose Down
+DROP TABLE IF EXISTS security_events;
+DROP FUNCTION IF EXISTS security_events_append_only();
@@ -0,0 +1,5 @@
+-- +goose Up
+CREATE INDEX IF NOT EXISTS tokens_expires_at_idx ON tokens (expires_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS tokens_expires_at_idx;
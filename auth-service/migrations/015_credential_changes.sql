@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE one_time_codes ADD COLUMN IF NOT EXISTS email TEXT;
+
+CREATE INDEX IF NOT EXISTS users_username_idx ON users (username);
+
+-- +goose Down
+DROP INDEX IF EXISTS users_username_idx;
+
+ALTER TABLE one_time_codes DROP COLUMN IF EXISTS email;
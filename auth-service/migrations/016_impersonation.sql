@@ -0,0 +1,9 @@
+-- +goose Up
+-- actor_id is the administrator using an impersonation token. It is NULL for
+-- the user's own sessions and events.
+ALTER TABLE tokens ADD COLUMN IF NOT EXISTS actor_id UUID;
+ALTER TABLE security_events ADD COLUMN IF NOT EXISTS actor_id UUID;
+
+-- +goose Down
+ALTER TABLE security_events DROP COLUMN IF EXISTS actor_id;
+ALTER TABLE tokens DROP COLUMN IF EXISTS actor_id;
@@ -0,0 +1,17 @@
+-- +goose Up
+-- JWT signing keys shared by all replicas. private_key is PKCS #8 encoded.
+-- The key with retired_at NULL signs new tokens; retired keys stay published
+-- until the tokens they signed have expired.
+CREATE TABLE IF NOT EXISTS signing_keys
+(
+    id          TEXT PRIMARY KEY,
+    algorithm   TEXT        NOT NULL,
+    private_key BYTEA       NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    retired_at  TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS signing_keys_current_idx ON signing_keys ((retired_at IS NULL)) WHERE retired_at IS NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS signing_keys;
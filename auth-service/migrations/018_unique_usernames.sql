@@ -0,0 +1,29 @@
+-- +goose Up
+-- Usernames are the nicknames of users-service, which must be unique. Of each
+-- set of duplicates the user with the lowest id keeps the name; the others get
+-- a suffix from their id, and users-service learns of the rename through the
+-- outbox like any other credentials change.
+CREATE TEMPORARY TABLE renamed_users ON COMMIT DROP AS
+SELECT id, username || '-' || left(id::text, 8) AS username, email
+FROM (SELECT id, username, email, row_number() OVER (PARTITION BY username ORDER BY id) AS n
+      FROM users) ranked
+WHERE n > 1;
+
+UPDATE users
+SET username = renamed_users.username
+FROM renamed_users
+WHERE users.id = renamed_users.id;
+
+INSERT INTO outbox (topic, key, headers, payload)
+SELECT 'user-events',
+       id::text,
+       '{"event-type": "UserCredentialsChangedEvent"}',
+       convert_to(json_build_object('user_id', id::text, 'username', username, 'email', email)::text, 'UTF8')
+FROM renamed_users;
+
+DROP INDEX IF EXISTS users_username_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS users_username_key ON users (username);
+
+-- +goose Down
+DROP INDEX IF EXISTS users_username_key;
+CREATE INDEX IF NOT EXISTS users_username_idx ON users (username);
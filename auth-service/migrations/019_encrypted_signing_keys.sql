@@ -0,0 +1,10 @@
+-- +goose Up
+-- private_key is now encrypted with AUTH_JWT_KEY_ENCRYPTION_KEY. Keys stored
+-- in the clear cannot be read any more and are dropped; the next replica to
+-- sync generates an encrypted one. Access tokens signed with the dropped keys
+-- stop verifying, and clients have to refresh them.
+DELETE FROM signing_keys;
+
+-- +goose Down
+-- Encrypted keys cannot be used by the previous version either.
+DELETE FROM signing_keys;
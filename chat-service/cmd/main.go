@@ -5,9 +5,11 @@ import (
 
 	"github.com/SamEkb/messenger-app/chat-service/config/env"
 	grpcserver "github.com/SamEkb/messenger-app/chat-service/internal/app/adapters/in/grpc"
+	"github.com/SamEkb/messenger-app/chat-service/internal/app/adapters/in/kafka"
 	grpcclient "github.com/SamEkb/messenger-app/chat-service/internal/app/adapters/out/grpc"
 	"github.com/SamEkb/messenger-app/chat-service/internal/app/repositories/mongodb"
 	"github.com/SamEkb/messenger-app/chat-service/internal/app/usecases/chat"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
 	"github.com/SamEkb/messenger-app/pkg/platform/logger"
 	mongolib "github.com/SamEkb/messenger-app/pkg/platform/mongodb"
 )
@@ -45,9 +47,36 @@ func main() {
 		log.Fatal("failed to create Friends Service client", "error", err)
 	}
 
+	authConn, err := client.NewAuthServiceConn(ctx)
+	if err != nil {
+		log.Fatal("failed to connect to Auth Service", "error", err)
+	}
+	defer authConn.Close()
+	verifier, err := platformauth.NewTokenVerifier(platformauth.VerifierConfig{
+		Type:                config.TokenVerifier.Type,
+		JWKSURL:             config.TokenVerifier.JWKSURL,
+		JWKSRefreshInterval: config.TokenVerifier.JWKSRefreshInterval,
+		ServiceSecret:       config.TokenVerifier.ServiceSecret,
+	}, authConn)
+	if err != nil {
+		log.Fatal("failed to create token verifier", "error", err)
+	}
+
 	chatUseCase := chat.NewChatUseCase(chatRepository, usersClient, friendsClient, txManager, log)
 
-	server, err := grpcserver.NewChatServer(chatUseCase, config.Server, log)
+	kafkaServer := kafka.NewChatServiceServer(chatUseCase, log)
+
+	consumer, err := kafka.NewUserEventConsumer(kafkaServer, config.Kafka)
+	if err != nil {
+		log.Fatal("failed to create Kafka consumer", "error", err)
+	}
+
+	if err := consumer.Start(ctx); err != nil {
+		log.Fatal("failed to start Kafka consumer", "error", err)
+	}
+	defer consumer.Close()
+
+	server, err := grpcserver.NewChatServer(chatUseCase, verifier, config.Server, log)
 	if err != nil {
 		log.Fatal("failed to create grpc server", "error", err)
 	}
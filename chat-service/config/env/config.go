@@ -5,6 +5,8 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -12,14 +14,23 @@ import (
 const (
 	DefaultGRPCPort = 9002
 	DefaultHTTPPort = 8002
+
+	DefaultKafkaBroker = "localhost:9092"
+	DefaultKafkaTopic  = "user-events"
+
+	DefaultTokenVerifier = "introspection"
+	DefaultJWKSURL       = "http://localhost:8001/.well-known/jwks.json"
+	DefaultJWKSRefresh   = 5 * time.Minute
 )
 
 type Config struct {
-	AppName string
-	Debug   string
-	Server  *ServerConfig
-	Clients *ClientsConfig
-	MongoDB *MongoDBConfig
+	AppName       string
+	Debug         string
+	Server        *ServerConfig
+	Clients       *ClientsConfig
+	Kafka         *KafkaConfig
+	MongoDB       *MongoDBConfig
+	TokenVerifier *TokenVerifierConfig
 }
 
 type ServerConfig struct {
@@ -30,10 +41,17 @@ type ServerConfig struct {
 }
 
 type ClientsConfig struct {
+	Auth    *ServiceClientConfig
 	Users   *ServiceClientConfig
 	Friends *ServiceClientConfig
 }
 
+type KafkaConfig struct {
+	Brokers       []string
+	Topic         string
+	ConsumerGroup string
+}
+
 type MongoDBConfig struct {
 	URI      string
 	Database string
@@ -43,6 +61,17 @@ func (m *MongoDBConfig) ConnectionString() string {
 	return fmt.Sprintf("%s/%s", m.URI, m.Database)
 }
 
+// TokenVerifierConfig selects how incoming tokens are verified, see
+// platformauth.NewTokenVerifier.
+type TokenVerifierConfig struct {
+	// Type is "introspection" or "jwks".
+	Type                string
+	JWKSURL             string
+	JWKSRefreshInterval time.Duration
+	// ServiceSecret authenticates introspection calls to the auth service.
+	ServiceSecret string
+}
+
 type ServiceClientConfig struct {
 	Host string
 	Port int
@@ -70,10 +99,13 @@ func LoadConfig() (*Config, error) {
 		Debug:   getEnv("DEBUG", "dev"),
 		Server:  &ServerConfig{},
 		Clients: &ClientsConfig{
+			Auth:    &ServiceClientConfig{},
 			Users:   &ServiceClientConfig{},
 			Friends: &ServiceClientConfig{},
 		},
-		MongoDB: &MongoDBConfig{},
+		Kafka:         &KafkaConfig{},
+		TokenVerifier: &TokenVerifierConfig{},
+		MongoDB:       &MongoDBConfig{},
 	}
 
 	c.Server.GRPCHost = getEnv("GRPC_HOST", "0.0.0.0")
@@ -81,12 +113,24 @@ func LoadConfig() (*Config, error) {
 	c.Server.HTTPHost = getEnv("HTTP_HOST", "0.0.0.0")
 	c.Server.HTTPPort = getEnvAsInt("HTTP_PORT", DefaultHTTPPort)
 
+	c.Clients.Auth.Host = getEnv("AUTH_SERVICE_HOST", "localhost")
+	c.Clients.Auth.Port = getEnvAsInt("AUTH_SERVICE_PORT", 9001)
+
+	c.TokenVerifier.Type = getEnv("AUTH_TOKEN_VERIFIER", DefaultTokenVerifier)
+	c.TokenVerifier.JWKSURL = getEnv("AUTH_JWKS_URL", DefaultJWKSURL)
+	c.TokenVerifier.JWKSRefreshInterval = getEnvAsDuration("AUTH_JWKS_REFRESH_INTERVAL", DefaultJWKSRefresh)
+	c.TokenVerifier.ServiceSecret = getEnv("AUTH_SERVICE_SECRET", "")
+
 	c.Clients.Users.Host = getEnv("USERS_SERVICE_HOST", "localhost")
 	c.Clients.Users.Port = getEnvAsInt("USERS_SERVICE_PORT", 9004)
 
 	c.Clients.Friends.Host = getEnv("FRIENDS_SERVICE_HOST", "localhost")
 	c.Clients.Friends.Port = getEnvAsInt("FRIENDS_SERVICE_PORT", 9003)
 
+	c.Kafka.Brokers = getEnvAsSlice("KAFKA_BROKERS", []string{DefaultKafkaBroker})
+	c.Kafka.Topic = getEnv("KAFKA_TOPIC", DefaultKafkaTopic)
+	c.Kafka.ConsumerGroup = getEnv("KAFKA_CONSUMER_GROUP", "chat-service-group")
+
 	c.MongoDB.URI = getEnv("MONGODB_URI", "mongodb://localhost:27017")
 	c.MongoDB.Database = getEnv("MONGODB_DATABASE", "chat_db")
 
@@ -109,3 +153,20 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvAsSlice(key string, defaultValue []string) []string {
+	if v := os.Getenv(key); v != "" {
+		return strings.Split(v, ",")
+	}
+	return defaultValue
+}
+
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		val, err := time.ParseDuration(v)
+		if err == nil {
+			return val
+		}
+	}
+	return defaultValue
+}
@@ -4,14 +4,18 @@ go 1.24
 
 require (
 	github.com/SamEkb/messenger-app/pkg/api v0.0.0-00010101000000-000000000000
+	github.com/SamEkb/messenger-app/pkg/platform/auth v0.0.0-00010101000000-000000000000
 	github.com/SamEkb/messenger-app/pkg/platform/errors v0.0.0-00010101000000-000000000000
+	github.com/SamEkb/messenger-app/pkg/platform/kafka v0.0.0-00010101000000-000000000000
 	github.com/SamEkb/messenger-app/pkg/platform/logger v0.0.0-00010101000000-000000000000
 	github.com/SamEkb/messenger-app/pkg/platform/mongodb v0.0.0-00010101000000-000000000000
+	github.com/Shopify/sarama v1.38.1
 	github.com/bufbuild/protovalidate-go v0.10.0
 	github.com/google/uuid v1.6.0
 	github.com/grpc-ecosystem/go-grpc-middleware/v2 v2.3.1
 	github.com/grpc-ecosystem/grpc-gateway/v2 v2.26.3
 	github.com/joho/godotenv v1.5.1
+	github.com/stretchr/testify v1.10.0
 	go.mongodb.org/mongo-driver v1.17.3
 	google.golang.org/grpc v1.72.0
 	google.golang.org/protobuf v1.36.6
@@ -21,11 +25,28 @@ require (
 	buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go v1.36.6-20250423154025-7712fb530c57.1 // indirect
 	cel.dev/expr v0.23.1 // indirect
 	github.com/antlr4-go/antlr/v4 v4.13.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/eapache/go-resiliency v1.3.0 // indirect
+	github.com/eapache/go-xerial-snappy v0.0.0-20230111030713-bf00bc1b83b6 // indirect
+	github.com/eapache/queue v1.1.0 // indirect
+	github.com/golang-jwt/jwt/v5 v5.2.2 // indirect
 	github.com/golang/snappy v0.0.4 // indirect
 	github.com/google/cel-go v0.25.0 // indirect
+	github.com/hashicorp/errwrap v1.0.0 // indirect
+	github.com/hashicorp/go-multierror v1.1.1 // indirect
+	github.com/hashicorp/go-uuid v1.0.3 // indirect
+	github.com/jcmturner/aescts/v2 v2.0.0 // indirect
+	github.com/jcmturner/dnsutils/v2 v2.0.0 // indirect
+	github.com/jcmturner/gofork v1.7.6 // indirect
+	github.com/jcmturner/gokrb5/v8 v8.4.3 // indirect
+	github.com/jcmturner/rpc/v2 v2.0.3 // indirect
 	github.com/klauspost/compress v1.16.7 // indirect
 	github.com/montanaflynn/stats v0.7.1 // indirect
+	github.com/pierrec/lz4/v4 v4.1.17 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/rcrowley/go-metrics v0.0.0-20201227073835-cf1acfcdf475 // indirect
 	github.com/stoewer/go-strcase v1.3.0 // indirect
+	github.com/stretchr/objx v0.5.2 // indirect
 	github.com/xdg-go/pbkdf2 v1.0.0 // indirect
 	github.com/xdg-go/scram v1.1.2 // indirect
 	github.com/xdg-go/stringprep v1.0.4 // indirect
@@ -38,12 +59,17 @@ require (
 	golang.org/x/text v0.24.0 // indirect
 	google.golang.org/genproto/googleapis/api v0.0.0-20250425173222-7b384671a197 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250425173222-7b384671a197 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
 replace github.com/SamEkb/messenger-app/pkg/api => ../pkg/api
 
+replace github.com/SamEkb/messenger-app/pkg/platform/auth => ../pkg/platform/auth
+
 replace github.com/SamEkb/messenger-app/pkg/platform/logger => ../pkg/platform/logger
 
 replace github.com/SamEkb/messenger-app/pkg/platform/errors => ../pkg/platform/errors
 
 replace github.com/SamEkb/messenger-app/pkg/platform/mongodb => ../pkg/platform/mongodb
+
+replace github.com/SamEkb/messenger-app/pkg/platform/kafka => ../pkg/platform/kafka
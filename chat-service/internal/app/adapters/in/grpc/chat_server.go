@@ -11,6 +11,7 @@ import (
 	"github.com/SamEkb/messenger-app/chat-service/internal/app/ports"
 	middlewaregrpc "github.com/SamEkb/messenger-app/chat-service/internal/middleware/grpc"
 	chat "github.com/SamEkb/messenger-app/pkg/api/chat_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
 	"github.com/SamEkb/messenger-app/pkg/platform/errors"
 	"github.com/SamEkb/messenger-app/pkg/platform/logger"
 	"github.com/bufbuild/protovalidate-go"
@@ -24,11 +25,12 @@ type ChatServer struct {
 	chat.UnimplementedChatServiceServer
 	validator protovalidate.Validator
 	useCase   ports.ChatUseCase
+	verifier  platformauth.TokenVerifier
 	cfg       *env.ServerConfig
 	logger    logger.Logger
 }
 
-func NewChatServer(useCase ports.ChatUseCase, cfg *env.ServerConfig, logger logger.Logger) (*ChatServer, error) {
+func NewChatServer(useCase ports.ChatUseCase, verifier platformauth.TokenVerifier, cfg *env.ServerConfig, logger logger.Logger) (*ChatServer, error) {
 	validator, err := protovalidate.New()
 	if err != nil {
 		return nil, errors.NewInternalError(err, "failed to initialize validator")
@@ -37,6 +39,7 @@ func NewChatServer(useCase ports.ChatUseCase, cfg *env.ServerConfig, logger logg
 	return &ChatServer{
 		validator: validator,
 		useCase:   useCase,
+		verifier:  verifier,
 		cfg:       cfg,
 		logger:    logger,
 	}, nil
@@ -62,6 +65,10 @@ func (s *ChatServer) RunServers(ctx context.Context) error {
 			grpc.ChainUnaryInterceptor(
 				protovalidatemw.UnaryServerInterceptor(s.validator),
 				middlewaregrpc.ErrorsUnaryServerInterceptor(),
+				platformauth.UnaryServerInterceptor(s.verifier),
+			),
+			grpc.ChainStreamInterceptor(
+				platformauth.StreamServerInterceptor(s.verifier),
 			),
 		)
 		chat.RegisterChatServiceServer(grpcServer, s)
@@ -81,7 +88,9 @@ func (s *ChatServer) RunServers(ctx context.Context) error {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		mux := runtime.NewServeMux()
+		mux := runtime.NewServeMux(runtime.WithMiddlewares(
+			platformauth.HTTPMiddleware(s.verifier, chat.File_chat_service_v1_service_proto.Services().ByName("ChatService")),
+		))
 		if err := chat.RegisterChatServiceHandlerServer(ctx, mux, s); err != nil {
 			log.Fatalf("gateway registration error: %v", err)
 		}
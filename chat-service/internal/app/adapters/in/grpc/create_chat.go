@@ -4,9 +4,19 @@ import (
 	"context"
 
 	chat "github.com/SamEkb/messenger-app/pkg/api/chat_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
 )
 
 func (s *ChatServer) CreateChat(ctx context.Context, req *chat.CreateChatRequest) (*chat.CreateChatResponse, error) {
+	if err := platformauth.RequireScope(ctx, platformauth.ScopeChatWrite); err != nil {
+		s.logger.Warn("create chat denied", "error", err)
+		return nil, err
+	}
+	if err := platformauth.RejectImpersonation(ctx); err != nil {
+		s.logger.Warn("create chat denied", "error", err)
+		return nil, err
+	}
+
 	s.logger.Info("creating chat")
 
 	chatDto, err := s.useCase.CreateChat(ctx, req.Participants)
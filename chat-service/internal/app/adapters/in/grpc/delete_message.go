@@ -0,0 +1,33 @@
+package grpc
+
+import (
+	"context"
+
+	chat "github.com/SamEkb/messenger-app/pkg/api/chat_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+)
+
+func (s *ChatServer) DeleteMessage(ctx context.Context, req *chat.DeleteMessageRequest) (*chat.DeleteMessageResponse, error) {
+	if err := platformauth.RequireScope(ctx, platformauth.ScopeChatWrite); err != nil {
+		s.logger.Warn("delete message denied", "error", err)
+		return nil, err
+	}
+	if err := platformauth.RejectImpersonation(ctx); err != nil {
+		s.logger.Warn("delete message denied", "error", err)
+		return nil, err
+	}
+
+	s.logger.Info("deleting message")
+
+	if err := s.useCase.DeleteMessage(ctx, req.ChatId, req.MessageId); err != nil {
+		s.logger.Error("failed to delete message", "error", err)
+		return nil, err
+	}
+
+	s.logger.Info("message deleted successfully")
+
+	return &chat.DeleteMessageResponse{
+		Success: true,
+		Message: "message deleted successfully",
+	}, nil
+}
@@ -5,10 +5,16 @@ import (
 
 	"github.com/SamEkb/messenger-app/chat-service/internal/app/ports"
 	chat "github.com/SamEkb/messenger-app/pkg/api/chat_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (s *ChatServer) GetChatHistory(ctx context.Context, req *chat.GetChatHistoryRequest) (*chat.GetChatHistoryResponse, error) {
+	if err := platformauth.RequireScope(ctx, platformauth.ScopeChatRead); err != nil {
+		s.logger.Warn("get chat history denied", "error", err)
+		return nil, err
+	}
+
 	s.logger.Info("getting chat history")
 
 	history, err := s.useCase.GetChatHistory(ctx, req.ChatId)
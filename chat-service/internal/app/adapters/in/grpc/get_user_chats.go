@@ -5,13 +5,19 @@ import (
 
 	"github.com/SamEkb/messenger-app/chat-service/internal/app/ports"
 	chat "github.com/SamEkb/messenger-app/pkg/api/chat_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (s *ChatServer) GetUserChats(ctx context.Context, req *chat.GetUserChatsRequest) (*chat.GetUserChatsResponse, error) {
+	if err := platformauth.RequireScope(ctx, platformauth.ScopeChatRead); err != nil {
+		s.logger.Warn("get user chats denied", "error", err)
+		return nil, err
+	}
+
 	s.logger.Info("getting user chats")
 
-	chats, err := s.useCase.GetUserChats(ctx, req.UserId)
+	chats, err := s.useCase.GetUserChats(ctx)
 	if err != nil {
 		s.logger.Error("failed to get user chats", "error", err)
 		return nil, err
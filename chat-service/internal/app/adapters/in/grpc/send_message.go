@@ -4,13 +4,23 @@ import (
 	"context"
 
 	chat "github.com/SamEkb/messenger-app/pkg/api/chat_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (s *ChatServer) SendMessage(ctx context.Context, req *chat.SendMessageRequest) (*chat.SendMessageResponse, error) {
+	if err := platformauth.RequireScope(ctx, platformauth.ScopeChatWrite); err != nil {
+		s.logger.Warn("send message denied", "error", err)
+		return nil, err
+	}
+	if err := platformauth.RejectImpersonation(ctx); err != nil {
+		s.logger.Warn("send message denied", "error", err)
+		return nil, err
+	}
+
 	s.logger.Info("sending message")
 
-	msg, err := s.useCase.SendMessage(ctx, req.ChatId, req.AuthorId, req.Content)
+	msg, err := s.useCase.SendMessage(ctx, req.ChatId, req.Content)
 	if err != nil {
 		s.logger.Error("failed to send message", "error", err)
 		return nil, err
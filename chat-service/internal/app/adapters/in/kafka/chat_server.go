@@ -0,0 +1,18 @@
+package kafka
+
+import (
+	"github.com/SamEkb/messenger-app/chat-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+)
+
+type ChatServiceServer struct {
+	chatUseCase ports.ChatUseCase
+	logger      logger.Logger
+}
+
+func NewChatServiceServer(chatUseCase ports.ChatUseCase, logger logger.Logger) *ChatServiceServer {
+	return &ChatServiceServer{
+		chatUseCase: chatUseCase,
+		logger:      logger,
+	}
+}
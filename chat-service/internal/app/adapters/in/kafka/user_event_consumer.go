@@ -0,0 +1,40 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/SamEkb/messenger-app/chat-service/config/env"
+	"github.com/SamEkb/messenger-app/pkg/api/events/v1"
+	platformkafka "github.com/SamEkb/messenger-app/pkg/platform/kafka"
+	"github.com/Shopify/sarama"
+)
+
+const userDeletedEventType = "UserDeletedEvent"
+
+// NewUserEventConsumer consumes the user events of auth-service. The group
+// starts from the oldest retained event so that no deletion is missed.
+func NewUserEventConsumer(server *ChatServiceServer, kafkaConfig *env.KafkaConfig) (*platformkafka.Consumer, error) {
+	return platformkafka.NewConsumer(platformkafka.ConsumerConfig{
+		Brokers:       kafkaConfig.Brokers,
+		Group:         kafkaConfig.ConsumerGroup,
+		Topics:        []string{kafkaConfig.Topic},
+		InitialOffset: sarama.OffsetOldest,
+	}, server.handleUserEvent, server.logger)
+}
+
+func (s *ChatServiceServer) handleUserEvent(ctx context.Context, msg *sarama.ConsumerMessage) error {
+	switch platformkafka.EventType(msg) {
+	case userDeletedEventType:
+		var event events.UserDeletedEvent
+		if err := json.Unmarshal(msg.Value, &event); err != nil {
+			// Retrying cannot fix a malformed record.
+			s.logger.Error("Skipping malformed user deleted event", "error", err, "offset", msg.Offset)
+			return nil
+		}
+		return s.HandleUserDeleted(ctx, &event)
+	default:
+		// Registrations and suspensions leave chats untouched.
+		return nil
+	}
+}
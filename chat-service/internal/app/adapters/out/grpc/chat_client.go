@@ -7,6 +7,7 @@ import (
 	"github.com/SamEkb/messenger-app/chat-service/internal/app/ports"
 	friends "github.com/SamEkb/messenger-app/pkg/api/friends_service/v1"
 	users "github.com/SamEkb/messenger-app/pkg/api/users_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
 	"github.com/SamEkb/messenger-app/pkg/platform/errors"
 	"github.com/SamEkb/messenger-app/pkg/platform/logger"
 	"google.golang.org/grpc"
@@ -30,6 +31,7 @@ func (f *Client) NewUsersServiceClient(ctx context.Context) (ports.UserServiceCl
 		ctx,
 		f.config.Users.Addr(),
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(platformauth.UnaryClientInterceptor()),
 	)
 	if err != nil {
 		return nil, errors.NewServiceError(err, "failed to connect to Users Service")
@@ -47,6 +49,7 @@ func (f *Client) NewFriendsServiceClient(ctx context.Context) (ports.FriendServi
 		ctx,
 		f.config.Friends.Addr(),
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(platformauth.UnaryClientInterceptor()),
 	)
 	if err != nil {
 		return nil, errors.NewServiceError(err, "failed to connect to Friends Service")
@@ -58,3 +61,17 @@ func (f *Client) NewFriendsServiceClient(ctx context.Context) (ports.FriendServi
 		conn:   conn,
 	}, nil
 }
+
+// NewAuthServiceConn connects to the auth service, which verifies the
+// tokens of incoming requests through platformauth.IntrospectionVerifier.
+func (f *Client) NewAuthServiceConn(ctx context.Context) (*grpc.ClientConn, error) {
+	conn, err := grpc.DialContext(
+		ctx,
+		f.config.Auth.Addr(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return nil, errors.NewServiceError(err, "failed to connect to Auth Service")
+	}
+	return conn, nil
+}
@@ -25,8 +25,8 @@ func (c *FriendsServiceClientAdapter) Close() error {
 	return nil
 }
 
-func (c *FriendsServiceClientAdapter) CheckFriendsStatus(userID1, userID2 string) (friends.FriendshipStatus, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (c *FriendsServiceClientAdapter) CheckFriendsStatus(ctx context.Context, userID1, userID2 string) (friends.FriendshipStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	req := &friends.CheckFriendshipStatusRequest{
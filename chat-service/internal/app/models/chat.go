@@ -7,6 +7,10 @@ import (
 	"github.com/google/uuid"
 )
 
+// DeletedUserID replaces the ID of a deleted account in chat participants
+// and message authors.
+const DeletedUserID = "deleted-user"
+
 type MessageID uuid.UUID
 type ChatID uuid.UUID
 
@@ -57,6 +61,25 @@ func NewMessage(authorID, content string) (*Message, error) {
 	}, nil
 }
 
+func NewChatFromDB(id ChatID, participants []string, messages []Message, createdAt, updatedAt time.Time) *Chat {
+	return &Chat{
+		id:           id,
+		participants: participants,
+		messages:     messages,
+		createdAt:    createdAt,
+		updatedAt:    updatedAt,
+	}
+}
+
+func NewMessageFromDB(id MessageID, authorID, content string, timestamp time.Time) *Message {
+	return &Message{
+		id:        id,
+		authorID:  authorID,
+		content:   content,
+		timestamp: timestamp,
+	}
+}
+
 func (u MessageID) IsEmpty() bool {
 	return u == MessageID(uuid.Nil)
 }
@@ -113,6 +136,16 @@ func (c *Chat) Participants() []string {
 	return c.participants
 }
 
+// HasParticipant reports whether userID takes part in the chat.
+func (c *Chat) HasParticipant(userID string) bool {
+	for _, p := range c.participants {
+		if p == userID {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Chat) Messages() []Message {
 	return c.messages
 }
@@ -133,3 +166,38 @@ func (c *Chat) AddMessage(msg *Message) error {
 	c.updatedAt = time.Now()
 	return nil
 }
+
+// RemoveMessage drops the message with messageID and reports whether it was
+// part of the chat.
+func (c *Chat) RemoveMessage(messageID MessageID) bool {
+	for i := range c.messages {
+		if c.messages[i].id == messageID {
+			c.messages = append(c.messages[:i], c.messages[i+1:]...)
+			c.updatedAt = time.Now()
+			return true
+		}
+	}
+	return false
+}
+
+// ReplaceUser swaps userID for replacementID among the participants and
+// message authors.
+func (c *Chat) ReplaceUser(userID, replacementID string) {
+	for i, p := range c.participants {
+		if p == userID {
+			c.participants[i] = replacementID
+		}
+	}
+	for i := range c.messages {
+		if c.messages[i].authorID == userID {
+			c.messages[i].authorID = replacementID
+		}
+	}
+}
+
+// WithAuthor returns a copy of the message attributed to authorID.
+func (m *Message) WithAuthor(authorID string) *Message {
+	msg := *m
+	msg.authorID = authorID
+	return &msg
+}
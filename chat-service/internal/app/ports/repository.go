@@ -4,11 +4,19 @@ import (
 	"context"
 
 	"github.com/SamEkb/messenger-app/chat-service/internal/app/models"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
+type TxManager interface {
+	RunTx(ctx context.Context, fn func(sessionContext mongo.SessionContext) error) error
+}
+
 type ChatRepository interface {
 	Create(ctx context.Context, participants []string) (*models.Chat, error)
 	Get(ctx context.Context, userID string) ([]*models.Chat, error)
+	GetByID(ctx context.Context, chatID models.ChatID) (*models.Chat, error)
 	SendMessage(ctx context.Context, chatID models.ChatID, authorID, content string) (*models.Message, error)
 	GetMessages(ctx context.Context, chatID models.ChatID) ([]*models.Message, error)
+	DeleteMessage(ctx context.Context, chatID models.ChatID, messageID models.MessageID) error
+	ReplaceUser(ctx context.Context, userID, replacementID string) error
 }
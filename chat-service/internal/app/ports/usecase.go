@@ -7,9 +7,11 @@ import (
 
 type ChatUseCase interface {
 	CreateChat(ctx context.Context, participants []string) (*ChatDto, error)
-	GetUserChats(ctx context.Context, userID string) ([]*ChatDto, error)
-	SendMessage(ctx context.Context, chatID string, authorID, content string) (*MessageDto, error)
+	GetUserChats(ctx context.Context) ([]*ChatDto, error)
+	SendMessage(ctx context.Context, chatID string, content string) (*MessageDto, error)
 	GetChatHistory(ctx context.Context, chatID string) ([]*MessageDto, error)
+	DeleteMessage(ctx context.Context, chatID, messageID string) error
+	RemoveUser(ctx context.Context, userID string) error
 }
 
 type ChatDto struct {
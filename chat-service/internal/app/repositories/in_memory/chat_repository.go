@@ -62,6 +62,20 @@ func (r *ChatRepository) Get(ctx context.Context, userID string) ([]*models.Chat
 	return result, nil
 }
 
+func (r *ChatRepository) GetByID(ctx context.Context, chatID models.ChatID) (*models.Chat, error) {
+	r.logger.Info("getting chat", "chatID", chatID)
+
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	chat, ok := r.storage[chatID]
+	if !ok {
+		r.logger.Error("chat not found", "chatID", chatID)
+		return nil, errors.NewNotFoundError("chat not found").WithDetails("chatID", chatID.String())
+	}
+	return chat, nil
+}
+
 func (r *ChatRepository) SendMessage(ctx context.Context, chatID models.ChatID, authorID, content string) (*models.Message, error) {
 	r.logger.Info("sending message", "chatID", chatID, "authorID", authorID, "content", content)
 
@@ -71,7 +85,7 @@ func (r *ChatRepository) SendMessage(ctx context.Context, chatID models.ChatID,
 	chat, ok := r.storage[chatID]
 	if !ok {
 		r.logger.Error("chat not found", "chatID", chatID)
-		return nil, errors.NewNotFoundError("chat not found", "chatID", chatID)
+		return nil, errors.NewNotFoundError("chat not found").WithDetails("chatID", chatID.String())
 	}
 	msg, err := models.NewMessage(authorID, content)
 	if err != nil {
@@ -104,3 +118,46 @@ func (r *ChatRepository) GetMessages(ctx context.Context, chatID models.ChatID)
 	r.logger.Info("chat history retrieved", "chatID", chatID, "messages", msgs)
 	return msgs, nil
 }
+
+func (r *ChatRepository) DeleteMessage(ctx context.Context, chatID models.ChatID, messageID models.MessageID) error {
+	r.logger.Info("deleting message", "chatID", chatID, "messageID", messageID)
+
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	chat, ok := r.storage[chatID]
+	if !ok || !chat.RemoveMessage(messageID) {
+		r.logger.Error("message not found", "chatID", chatID, "messageID", messageID)
+		return errors.NewNotFoundError("message not found").WithDetails("messageID", messageID.String())
+	}
+
+	msgs := r.messages[chatID]
+	for i, msg := range msgs {
+		if msg.ID() == messageID {
+			r.messages[chatID] = append(msgs[:i:i], msgs[i+1:]...)
+			break
+		}
+	}
+
+	r.logger.Info("message deleted", "chatID", chatID, "messageID", messageID)
+	return nil
+}
+
+func (r *ChatRepository) ReplaceUser(ctx context.Context, userID, replacementID string) error {
+	r.logger.Info("replacing user in chats", "userID", userID)
+
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	for chatID, chat := range r.storage {
+		chat.ReplaceUser(userID, replacementID)
+		for i, msg := range r.messages[chatID] {
+			if msg.AuthorID() == userID {
+				r.messages[chatID][i] = msg.WithAuthor(replacementID)
+			}
+		}
+	}
+
+	r.logger.Info("user replaced in chats", "userID", userID)
+	return nil
+}
@@ -8,7 +8,6 @@ import (
 	"github.com/SamEkb/messenger-app/chat-service/internal/app/ports"
 	"github.com/SamEkb/messenger-app/pkg/platform/errors"
 	"github.com/SamEkb/messenger-app/pkg/platform/logger"
-	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -115,6 +114,29 @@ func (r *ChatRepository) Get(ctx context.Context, userID string) ([]*models.Chat
 	return chats, nil
 }
 
+func (r *ChatRepository) GetByID(ctx context.Context, chatID models.ChatID) (*models.Chat, error) {
+	r.logger.Debug("getting chat", "chat_id", chatID)
+
+	filter := bson.M{"_id": chatID.String()}
+	var doc chatDocument
+	err := r.db.Collection("chats").FindOne(ctx, filter).Decode(&doc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			r.logger.Debug("chat not found", "chat_id", chatID)
+			return nil, errors.NewNotFoundError("chat not found")
+		}
+		r.logger.Error("failed to find chat", "error", err)
+		return nil, errors.NewInternalError(err, "failed to get chat")
+	}
+
+	chat, err := r.documentToModel(doc)
+	if err != nil {
+		r.logger.Error("failed to convert document to model", "error", err)
+		return nil, errors.NewInternalError(err, "failed to decode chat")
+	}
+	return chat, nil
+}
+
 func (r *ChatRepository) SendMessage(ctx context.Context, chatID models.ChatID, authorID, content string) (*models.Message, error) {
 	r.logger.Debug("sending message", "chat_id", chatID, "author_id", authorID)
 
@@ -169,49 +191,90 @@ func (r *ChatRepository) GetMessages(ctx context.Context, chatID models.ChatID)
 
 	messages := make([]*models.Message, 0, len(doc.Messages))
 	for _, msgDoc := range doc.Messages {
-		_, err := models.ParseMessageID(msgDoc.ID)
+		msgID, err := models.ParseMessageID(msgDoc.ID)
 		if err != nil {
 			r.logger.Error("failed to parse message ID", "error", err)
 			continue
 		}
 
-		msg, err := models.NewMessage(msgDoc.AuthorID, msgDoc.Content)
-		if err != nil {
-			r.logger.Error("failed to create message model", "error", err)
-			continue
-		}
-
-		messages = append(messages, msg)
+		messages = append(messages, models.NewMessageFromDB(msgID, msgDoc.AuthorID, msgDoc.Content, msgDoc.Timestamp))
 	}
 
 	r.logger.Debug("got messages", "chat_id", chatID, "count", len(messages))
 	return messages, nil
 }
 
-func (r *ChatRepository) documentToModel(doc chatDocument) (*models.Chat, error) {
-	_, err := models.ParseChatID(doc.ID)
+func (r *ChatRepository) DeleteMessage(ctx context.Context, chatID models.ChatID, messageID models.MessageID) error {
+	r.logger.Debug("deleting message", "chat_id", chatID, "message_id", messageID)
+
+	filter := bson.M{"_id": chatID.String(), "messages._id": messageID.String()}
+	update := bson.M{
+		"$pull": bson.M{"messages": bson.M{"_id": messageID.String()}},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := r.db.Collection("chats").UpdateOne(ctx, filter, update)
 	if err != nil {
-		return nil, err
+		r.logger.Error("failed to delete message", "error", err)
+		return errors.NewInternalError(err, "failed to delete message")
 	}
 
-	chat, err := models.NewChat(doc.Participants)
+	if result.MatchedCount == 0 {
+		r.logger.Error("message not found", "chat_id", chatID, "message_id", messageID)
+		return errors.NewNotFoundError("message not found")
+	}
+
+	r.logger.Info("message deleted", "chat_id", chatID, "message_id", messageID)
+	return nil
+}
+
+// ReplaceUser rewrites userID to replacementID in participant lists and
+// message authors of every chat the user appears in.
+func (r *ChatRepository) ReplaceUser(ctx context.Context, userID, replacementID string) error {
+	r.logger.Debug("replacing user in chats", "user_id", userID)
+
+	filter := bson.M{"$or": bson.A{
+		bson.M{"participants": userID},
+		bson.M{"messages.author_id": userID},
+	}}
+	update := bson.M{
+		"$set": bson.M{
+			"participants.$[participant]":   replacementID,
+			"messages.$[message].author_id": replacementID,
+		},
+	}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []any{
+			bson.M{"participant": userID},
+			bson.M{"message.author_id": userID},
+		},
+	})
+
+	result, err := r.db.Collection("chats").UpdateMany(ctx, filter, update, opts)
+	if err != nil {
+		r.logger.Error("failed to replace user in chats", "error", err)
+		return errors.NewInternalError(err, "failed to replace user in chats")
+	}
+
+	r.logger.Info("user replaced in chats", "user_id", userID, "count", result.ModifiedCount)
+	return nil
+}
+
+func (r *ChatRepository) documentToModel(doc chatDocument) (*models.Chat, error) {
+	chatID, err := models.ParseChatID(doc.ID)
 	if err != nil {
 		return nil, err
 	}
 
+	messages := make([]models.Message, 0, len(doc.Messages))
 	for _, msgDoc := range doc.Messages {
-		_, err := uuid.Parse(msgDoc.ID)
-		if err != nil {
-			continue
-		}
-
-		msg, err := models.NewMessage(msgDoc.AuthorID, msgDoc.Content)
+		msgID, err := models.ParseMessageID(msgDoc.ID)
 		if err != nil {
 			continue
 		}
 
-		chat.AddMessage(msg)
+		messages = append(messages, *models.NewMessageFromDB(msgID, msgDoc.AuthorID, msgDoc.Content, msgDoc.Timestamp))
 	}
 
-	return chat, nil
+	return models.NewChatFromDB(chatID, doc.Participants, messages, doc.CreatedAt, doc.UpdatedAt), nil
 }
@@ -3,12 +3,14 @@ package chat
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/SamEkb/messenger-app/chat-service/internal/app/models"
 	"github.com/SamEkb/messenger-app/chat-service/internal/app/ports"
 	friends "github.com/SamEkb/messenger-app/pkg/api/friends_service/v1"
 	users "github.com/SamEkb/messenger-app/pkg/api/users_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
 	"github.com/SamEkb/messenger-app/pkg/platform/errors"
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -16,6 +18,16 @@ import (
 func (u *UseCase) CreateChat(ctx context.Context, participants []string) (*ports.ChatDto, error) {
 	u.logger.Info("creating chat")
 
+	callerID, err := platformauth.UserIDFromContext(ctx)
+	if err != nil {
+		u.logger.Error("unauthenticated request", "error", err)
+		return nil, err
+	}
+
+	if !slices.Contains(participants, callerID) {
+		participants = append(participants, callerID)
+	}
+
 	if len(participants) < 2 {
 		return nil, errors.NewInvalidInputError("chat requires at least 2 participants")
 	}
@@ -31,7 +43,7 @@ func (u *UseCase) CreateChat(ctx context.Context, participants []string) (*ports
 	if len(profilesResp.NotFoundIds) > 0 {
 		notFoundUsers := strings.Join(profilesResp.NotFoundIds, ", ")
 		u.logger.Info("some users not found", "missing_users", notFoundUsers)
-		return nil, errors.NewNotFoundError(fmt.Sprintf("users not found: %s", notFoundUsers))
+		return nil, errors.NewNotFoundError("users not found: %s", notFoundUsers)
 	}
 
 	friendshipResp, err := u.friendClient.CheckFriendshipsStatus(ctx, &friends.CheckFriendshipsStatusRequest{
@@ -45,8 +57,7 @@ func (u *UseCase) CreateChat(ctx context.Context, participants []string) (*ports
 	if !friendshipResp.AllAreFriends {
 		if len(friendshipResp.NonFriendPairs) > 0 {
 			pair := friendshipResp.NonFriendPairs[0]
-			return nil, errors.NewForbiddenError(
-				fmt.Sprintf("users %s and %s are not friends", pair.UserID1, pair.UserID2))
+			return nil, errors.NewForbiddenError("users %s and %s are not friends", pair.UserID1, pair.UserID2)
 		}
 		return nil, errors.NewForbiddenError("some participants are not friends")
 	}
@@ -0,0 +1,53 @@
+package chat
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/chat-service/internal/app/models"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// DeleteMessage removes any message from any chat. Only moderators and
+// administrators may call it.
+func (u *UseCase) DeleteMessage(ctx context.Context, chatID, messageID string) error {
+	callerID, err := platformauth.UserIDFromContext(ctx)
+	if err != nil {
+		u.logger.Error("unauthenticated request", "error", err)
+		return err
+	}
+
+	if err := platformauth.RequireRole(ctx, platformauth.RoleModerator, platformauth.RoleAdmin); err != nil {
+		u.logger.Warn("delete message denied", "callerID", callerID, "chatID", chatID, "messageID", messageID)
+		return err
+	}
+
+	u.logger.Info("deleting message", "callerID", callerID, "chatID", chatID, "messageID", messageID)
+
+	cID, err := models.ParseChatID(chatID)
+	if err != nil {
+		u.logger.Error("failed to parse chat ID", "chatID", chatID, "error", err)
+		return errors.NewInvalidInputError("invalid chat ID").WithDetails("chatID", chatID)
+	}
+	mID, err := models.ParseMessageID(messageID)
+	if err != nil {
+		u.logger.Error("failed to parse message ID", "messageID", messageID, "error", err)
+		return errors.NewInvalidInputError("invalid message ID").WithDetails("messageID", messageID)
+	}
+
+	err = u.txManager.RunTx(ctx, func(sessionCtx mongo.SessionContext) error {
+		if err := u.chatRepository.DeleteMessage(sessionCtx, cID, mID); err != nil {
+			u.logger.Error("failed to delete message", "chatID", chatID, "messageID", messageID, "error", err)
+			return err
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	u.logger.Info("message deleted", "callerID", callerID, "chatID", chatID, "messageID", messageID)
+	return nil
+}
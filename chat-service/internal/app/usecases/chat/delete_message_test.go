@@ -0,0 +1,127 @@
+package chat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SamEkb/messenger-app/chat-service/internal/app/repositories/in_memory"
+	"github.com/SamEkb/messenger-app/chat-service/internal/app/usecases/chat/mocks"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseCase_DeleteMessage(t *testing.T) {
+	moderatorCtx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: "moderator-1",
+		Roles:  []platformauth.Role{platformauth.RoleModerator},
+	})
+	userCtx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: "user-1",
+		Roles:  []platformauth.Role{platformauth.RoleUser},
+	})
+
+	repo := in_memory.NewChatRepository(logger.NewMockLogger())
+	chat, err := repo.Create(context.Background(), []string{"user-1", "user-2"})
+	require.NoError(t, err)
+	message, err := repo.SendMessage(context.Background(), chat.ID(), "user-2", "spam")
+	require.NoError(t, err)
+
+	chatID := chat.ID().String()
+	messageID := message.ID().String()
+
+	type args struct {
+		ctx       context.Context
+		chatID    string
+		messageID string
+	}
+
+	tests := map[string]struct {
+		args        args
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"moderator deletes message": {
+			args:    args{ctx: moderatorCtx, chatID: chatID, messageID: messageID},
+			wantErr: false,
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					chatRepository: repo,
+					txManager:      newTxManager(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"message not found": {
+			args:        args{ctx: moderatorCtx, chatID: chatID, messageID: messageID},
+			wantErr:     true,
+			expectedErr: customerrors.NewNotFoundError("message not found"),
+			deps: func(t *testing.T) UseCase {
+				mockChatRepository := mocks.NewChatRepository(t)
+				mockChatRepository.EXPECT().
+					DeleteMessage(mock.Anything, chat.ID(), message.ID()).
+					Return(customerrors.NewNotFoundError("message not found")).
+					Once()
+
+				return UseCase{
+					chatRepository: mockChatRepository,
+					txManager:      newTxManager(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"caller is not a moderator": {
+			args:        args{ctx: userCtx, chatID: chatID, messageID: messageID},
+			wantErr:     true,
+			expectedErr: customerrors.NewForbiddenError("caller lacks required role"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{logger: logger.NewMockLogger()}
+			},
+		},
+		"invalid message id": {
+			args:        args{ctx: moderatorCtx, chatID: chatID, messageID: "not-a-uuid"},
+			wantErr:     true,
+			expectedErr: customerrors.NewInvalidInputError("invalid message ID"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{logger: logger.NewMockLogger()}
+			},
+		},
+		"unauthenticated": {
+			args:        args{ctx: context.Background(), chatID: chatID, messageID: uuid.NewString()},
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("request is not authenticated"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{logger: logger.NewMockLogger()}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			err := useCase.DeleteMessage(tc.args.ctx, tc.args.chatID, tc.args.messageID)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+				return
+			}
+			assert.NoError(t, err)
+
+			messages, err := repo.GetMessages(context.Background(), chat.ID())
+			require.NoError(t, err)
+			for _, m := range messages {
+				assert.NotEqual(t, message.ID(), m.ID())
+			}
+		})
+	}
+}
@@ -5,16 +5,28 @@ import (
 
 	"github.com/SamEkb/messenger-app/chat-service/internal/app/models"
 	"github.com/SamEkb/messenger-app/chat-service/internal/app/ports"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
 )
 
+// GetChatHistory returns the messages of a chat the caller takes part in.
 func (u *UseCase) GetChatHistory(ctx context.Context, chatID string) ([]*ports.MessageDto, error) {
-	u.logger.Info("getting chat history", "chatID", chatID)
+	userID, err := platformauth.UserIDFromContext(ctx)
+	if err != nil {
+		u.logger.Error("unauthenticated request", "error", err)
+		return nil, err
+	}
+
+	u.logger.Info("getting chat history", "chatID", chatID, "userID", userID)
 
 	id, err := models.ParseChatID(chatID)
 	if err != nil {
 		u.logger.Error("failed to parse chat ID", "chatID", chatID, "error", err)
 		return nil, err
 	}
+	if err := u.requireParticipant(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
 	messages, err := u.chatRepository.GetMessages(ctx, id)
 	if err != nil {
 		u.logger.Error("failed to get chat history", "chatID", chatID, "error", err)
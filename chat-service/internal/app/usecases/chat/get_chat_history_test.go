@@ -0,0 +1,117 @@
+package chat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SamEkb/messenger-app/chat-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/chat-service/internal/app/repositories/in_memory"
+	"github.com/SamEkb/messenger-app/chat-service/internal/app/usecases/chat/mocks"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseCase_GetChatHistory(t *testing.T) {
+	participantCtx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: "user-1",
+		Roles:  []platformauth.Role{platformauth.RoleUser},
+	})
+	outsiderCtx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: "user-3",
+		Roles:  []platformauth.Role{platformauth.RoleUser},
+	})
+
+	repo := in_memory.NewChatRepository(logger.NewMockLogger())
+	chat, err := repo.Create(context.Background(), []string{"user-1", "user-2"})
+	require.NoError(t, err)
+	_, err = repo.SendMessage(context.Background(), chat.ID(), "user-2", "hello")
+	require.NoError(t, err)
+
+	chatID := chat.ID().String()
+	missingID := uuid.NewString()
+
+	type args struct {
+		ctx    context.Context
+		chatID string
+	}
+
+	tests := map[string]struct {
+		args        args
+		want        int
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"participant reads history": {
+			args:    args{ctx: participantCtx, chatID: chatID},
+			want:    1,
+			wantErr: false,
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					chatRepository: repo,
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"caller is not a participant": {
+			args:        args{ctx: outsiderCtx, chatID: chatID},
+			wantErr:     true,
+			expectedErr: customerrors.NewForbiddenError("caller is not a participant of the chat"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					chatRepository: repo,
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"chat not found": {
+			args:        args{ctx: participantCtx, chatID: missingID},
+			wantErr:     true,
+			expectedErr: customerrors.NewNotFoundError("chat not found"),
+			deps: func(t *testing.T) UseCase {
+				mockChatRepository := mocks.NewChatRepository(t)
+				mockChatRepository.EXPECT().
+					GetByID(mock.Anything, models.ChatID(uuid.MustParse(missingID))).
+					Return(nil, customerrors.NewNotFoundError("chat not found")).
+					Once()
+
+				return UseCase{
+					chatRepository: mockChatRepository,
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"unauthenticated": {
+			args:        args{ctx: context.Background(), chatID: chatID},
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("request is not authenticated"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{logger: logger.NewMockLogger()}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			got, err := useCase.GetChatHistory(tc.args.ctx, tc.args.chatID)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, got, tc.want)
+		})
+	}
+}
@@ -4,9 +4,16 @@ import (
 	"context"
 
 	"github.com/SamEkb/messenger-app/chat-service/internal/app/ports"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
 )
 
-func (u *UseCase) GetUserChats(ctx context.Context, userID string) ([]*ports.ChatDto, error) {
+func (u *UseCase) GetUserChats(ctx context.Context) ([]*ports.ChatDto, error) {
+	userID, err := platformauth.UserIDFromContext(ctx)
+	if err != nil {
+		u.logger.Error("unauthenticated request", "error", err)
+		return nil, err
+	}
+
 	u.logger.Info("getting user chats", "userID", userID)
 
 	chats, err := u.chatRepository.Get(ctx, userID)
@@ -0,0 +1,5 @@
+package chat
+
+//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name TxManager --output ./mocks --filename tx_manager_mock.go
+//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name ChatRepository --output ./mocks --filename chat_repository_mock.go
+//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name UserServiceClient --output ./mocks --filename user_service_client_mock.go
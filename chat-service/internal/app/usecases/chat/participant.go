@@ -0,0 +1,24 @@
+package chat
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/chat-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// requireParticipant fails unless userID takes part in the chat.
+func (u *UseCase) requireParticipant(ctx context.Context, chatID models.ChatID, userID string) error {
+	chat, err := u.chatRepository.GetByID(ctx, chatID)
+	if err != nil {
+		u.logger.Error("failed to get chat", "chatID", chatID, "error", err)
+		return err
+	}
+
+	if !chat.HasParticipant(userID) {
+		u.logger.Warn("caller is not a chat participant", "chatID", chatID, "userID", userID)
+		return errors.NewForbiddenError("caller is not a participant of the chat").
+			WithDetails("chatID", chatID.String())
+	}
+	return nil
+}
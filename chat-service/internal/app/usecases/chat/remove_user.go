@@ -0,0 +1,30 @@
+package chat
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/chat-service/internal/app/models"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// RemoveUser replaces a deleted account with models.DeletedUserID in every
+// chat it took part in, keeping the history readable for the others. It is
+// safe to call again for the same user.
+func (u *UseCase) RemoveUser(ctx context.Context, userID string) error {
+	u.logger.Info("removing deleted user from chats", "userID", userID)
+
+	err := u.txManager.RunTx(ctx, func(sessionCtx mongo.SessionContext) error {
+		if err := u.chatRepository.ReplaceUser(sessionCtx, userID, models.DeletedUserID); err != nil {
+			u.logger.Error("failed to remove user from chats", "userID", userID, "error", err)
+			return err
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	u.logger.Info("deleted user removed from chats", "userID", userID)
+	return nil
+}
@@ -0,0 +1,152 @@
+package chat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SamEkb/messenger-app/chat-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/chat-service/internal/app/repositories/in_memory"
+	"github.com/SamEkb/messenger-app/chat-service/internal/app/usecases/chat/mocks"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func newTxManager(t *testing.T) *mocks.TxManager {
+	txManager := mocks.NewTxManager(t)
+	txManager.EXPECT().
+		RunTx(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(mongo.SessionContext) error) error {
+			return fn(mongo.NewSessionContext(ctx, nil))
+		})
+	return txManager
+}
+
+func TestUseCase_RemoveUser(t *testing.T) {
+	ctx := context.Background()
+
+	const (
+		deletedUserID = "user-1"
+		otherUserID   = "user-2"
+	)
+
+	// seedChat stores a chat between both users with one message from each.
+	seedChat := func(t *testing.T, repo *in_memory.ChatRepository) {
+		chat, err := repo.Create(ctx, []string{deletedUserID, otherUserID})
+		require.NoError(t, err)
+		_, err = repo.SendMessage(ctx, chat.ID(), deletedUserID, "hello")
+		require.NoError(t, err)
+		_, err = repo.SendMessage(ctx, chat.ID(), otherUserID, "hi")
+		require.NoError(t, err)
+	}
+
+	type args struct {
+		ctx    context.Context
+		userID string
+		runs   int
+	}
+
+	tests := map[string]struct {
+		args             args
+		wantParticipants []string
+		wantAuthors      []string
+		wantErr          bool
+		deps             func(t *testing.T) UseCase
+	}{
+		"author replaced": {
+			args: args{
+				ctx:    ctx,
+				userID: deletedUserID,
+				runs:   1,
+			},
+			wantParticipants: []string{models.DeletedUserID, otherUserID},
+			wantAuthors:      []string{models.DeletedUserID, otherUserID},
+			wantErr:          false,
+			deps: func(t *testing.T) UseCase {
+				repo := in_memory.NewChatRepository(logger.NewMockLogger())
+				seedChat(t, repo)
+
+				return UseCase{
+					chatRepository: repo,
+					txManager:      newTxManager(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"second run is a no-op": {
+			args: args{
+				ctx:    ctx,
+				userID: deletedUserID,
+				runs:   2,
+			},
+			wantParticipants: []string{models.DeletedUserID, otherUserID},
+			wantAuthors:      []string{models.DeletedUserID, otherUserID},
+			wantErr:          false,
+			deps: func(t *testing.T) UseCase {
+				repo := in_memory.NewChatRepository(logger.NewMockLogger())
+				seedChat(t, repo)
+
+				return UseCase{
+					chatRepository: repo,
+					txManager:      newTxManager(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"failed to replace user": {
+			args: args{
+				ctx:    ctx,
+				userID: deletedUserID,
+				runs:   1,
+			},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				mockChatRepository := mocks.NewChatRepository(t)
+				mockChatRepository.EXPECT().
+					ReplaceUser(mock.Anything, deletedUserID, models.DeletedUserID).
+					Return(assert.AnError).
+					Once()
+
+				return UseCase{
+					chatRepository: mockChatRepository,
+					txManager:      newTxManager(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+
+			var err error
+			for i := 0; i < tc.args.runs; i++ {
+				err = useCase.RemoveUser(tc.args.ctx, tc.args.userID)
+			}
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, assert.AnError)
+				return
+			}
+			require.NoError(t, err)
+
+			chats, err := useCase.chatRepository.Get(ctx, otherUserID)
+			require.NoError(t, err)
+			require.Len(t, chats, 1)
+			assert.ElementsMatch(t, tc.wantParticipants, chats[0].Participants())
+
+			messages, err := useCase.chatRepository.GetMessages(ctx, chats[0].ID())
+			require.NoError(t, err)
+			authors := make([]string, 0, len(messages))
+			for _, message := range messages {
+				authors = append(authors, message.AuthorID())
+			}
+			assert.Equal(t, tc.wantAuthors, authors)
+		})
+	}
+}
@@ -5,11 +5,19 @@ import (
 
 	"github.com/SamEkb/messenger-app/chat-service/internal/app/models"
 	"github.com/SamEkb/messenger-app/chat-service/internal/app/ports"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
 	"github.com/SamEkb/messenger-app/pkg/platform/errors"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func (u *UseCase) SendMessage(ctx context.Context, chatID string, authorID, content string) (*ports.MessageDto, error) {
+// SendMessage appends a message from the caller to a chat they take part in.
+func (u *UseCase) SendMessage(ctx context.Context, chatID string, content string) (*ports.MessageDto, error) {
+	authorID, err := platformauth.UserIDFromContext(ctx)
+	if err != nil {
+		u.logger.Error("unauthenticated request", "error", err)
+		return nil, err
+	}
+
 	u.logger.Info("sending message", "chatID", chatID, "authorID", authorID)
 
 	if chatID == "" {
@@ -17,18 +25,13 @@ func (u *UseCase) SendMessage(ctx context.Context, chatID string, authorID, cont
 		u.logger.Error("invalid input", "error", err)
 		return nil, err
 	}
-	if authorID == "" {
-		err := errors.NewInvalidInputError("author ID is required")
-		u.logger.Error("invalid input", "error", err)
-		return nil, err
-	}
 	if content == "" {
 		err := errors.NewInvalidInputError("message content is required")
 		u.logger.Error("invalid input", "error", err)
 		return nil, err
 	}
 
-	_, err := u.userClient.GetUserProfile(authorID)
+	_, err = u.userClient.GetUserProfile(ctx, authorID)
 	if err != nil {
 		u.logger.Error("failed to get user profile", "authorID", authorID, "error", err)
 		return nil, err
@@ -42,6 +45,10 @@ func (u *UseCase) SendMessage(ctx context.Context, chatID string, authorID, cont
 
 	var msg *models.Message
 	err = u.txManager.RunTx(ctx, func(sessionCtx mongo.SessionContext) error {
+		if err := u.requireParticipant(sessionCtx, id, authorID); err != nil {
+			return err
+		}
+
 		var err error
 		msg, err = u.chatRepository.SendMessage(sessionCtx, id, authorID, content)
 		if err != nil {
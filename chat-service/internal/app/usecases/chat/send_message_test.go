@@ -0,0 +1,117 @@
+package chat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SamEkb/messenger-app/chat-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/chat-service/internal/app/repositories/in_memory"
+	"github.com/SamEkb/messenger-app/chat-service/internal/app/usecases/chat/mocks"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	customerrors "github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseCase_SendMessage(t *testing.T) {
+	participantCtx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: "user-1",
+		Roles:  []platformauth.Role{platformauth.RoleUser},
+	})
+	outsiderCtx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: "user-3",
+		Roles:  []platformauth.Role{platformauth.RoleUser},
+	})
+
+	repo := in_memory.NewChatRepository(logger.NewMockLogger())
+	chat, err := repo.Create(context.Background(), []string{"user-1", "user-2"})
+	require.NoError(t, err)
+
+	chatID := chat.ID().String()
+
+	newUserClient := func(t *testing.T, userID string) *mocks.UserServiceClient {
+		client := mocks.NewUserServiceClient(t)
+		client.EXPECT().
+			GetUserProfile(mock.Anything, userID).
+			Return(&ports.UserProfile{UserID: userID}, nil).
+			Once()
+		return client
+	}
+
+	type args struct {
+		ctx     context.Context
+		chatID  string
+		content string
+	}
+
+	tests := map[string]struct {
+		args        args
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"participant sends message": {
+			args:    args{ctx: participantCtx, chatID: chatID, content: "hello"},
+			wantErr: false,
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					chatRepository: repo,
+					userClient:     newUserClient(t, "user-1"),
+					txManager:      newTxManager(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"caller is not a participant": {
+			args:        args{ctx: outsiderCtx, chatID: chatID, content: "hello"},
+			wantErr:     true,
+			expectedErr: customerrors.NewForbiddenError("caller is not a participant of the chat"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					chatRepository: repo,
+					userClient:     newUserClient(t, "user-3"),
+					txManager:      newTxManager(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"empty content": {
+			args:        args{ctx: participantCtx, chatID: chatID},
+			wantErr:     true,
+			expectedErr: customerrors.NewInvalidInputError("message content is required"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{logger: logger.NewMockLogger()}
+			},
+		},
+		"unauthenticated": {
+			args:        args{ctx: context.Background(), chatID: chatID, content: "hello"},
+			wantErr:     true,
+			expectedErr: customerrors.NewUnauthorizedError("request is not authenticated"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{logger: logger.NewMockLogger()}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			got, err := useCase.SendMessage(tc.args.ctx, tc.args.chatID, tc.args.content)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "user-1", got.AuthorID())
+			assert.Equal(t, tc.args.content, got.Content())
+		})
+	}
+}
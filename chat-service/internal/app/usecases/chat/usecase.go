@@ -2,7 +2,6 @@ package chat
 
 import (
 	"github.com/SamEkb/messenger-app/chat-service/internal/app/ports"
-	"github.com/SamEkb/messenger-app/pkg/platform/mongodb"
 
 	"github.com/SamEkb/messenger-app/pkg/platform/logger"
 )
@@ -13,14 +12,14 @@ type UseCase struct {
 	chatRepository ports.ChatRepository
 	userClient     ports.UserServiceClient
 	friendClient   ports.FriendServiceClient
-	txManager      *mongodb.TxManager
+	txManager      ports.TxManager
 	logger         logger.Logger
 }
 
 func NewChatUseCase(chatRepository ports.ChatRepository,
 	userClient ports.UserServiceClient,
 	friendClient ports.FriendServiceClient,
-	txManager *mongodb.TxManager,
+	txManager ports.TxManager,
 	logger logger.Logger,
 ) *UseCase {
 	return &UseCase{
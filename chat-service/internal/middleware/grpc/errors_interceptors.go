@@ -40,6 +40,8 @@ func ErrorsUnaryServerInterceptor() grpc.UnaryServerInterceptor {
 				code = codes.Unavailable
 			case errors.Is(err, errors.ErrTimeout):
 				code = codes.DeadlineExceeded
+			case errors.Is(err, errors.ErrResourceExhausted):
+				code = codes.ResourceExhausted
 			default:
 				code = codes.Internal
 			}
@@ -5,9 +5,11 @@ import (
 
 	"github.com/SamEkb/messenger-app/friends-service/config/env"
 	grpcserver "github.com/SamEkb/messenger-app/friends-service/internal/app/adapters/in/grpc"
+	"github.com/SamEkb/messenger-app/friends-service/internal/app/adapters/in/kafka"
 	grpcclient "github.com/SamEkb/messenger-app/friends-service/internal/app/adapters/out/grpc"
 	"github.com/SamEkb/messenger-app/friends-service/internal/app/repositories/postgres"
 	"github.com/SamEkb/messenger-app/friends-service/internal/app/usecases/friendship"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
 	"github.com/SamEkb/messenger-app/pkg/platform/logger"
 	postgreslib "github.com/SamEkb/messenger-app/pkg/platform/postgres"
 	_ "github.com/lib/pq"
@@ -39,9 +41,36 @@ func main() {
 		log.Fatal("failed to create Users Service client", "error", err)
 	}
 
+	authConn, err := client.NewAuthServiceConn(ctx)
+	if err != nil {
+		log.Fatal("failed to connect to Auth Service", "error", err)
+	}
+	defer authConn.Close()
+	verifier, err := platformauth.NewTokenVerifier(platformauth.VerifierConfig{
+		Type:                cfg.TokenVerifier.Type,
+		JWKSURL:             cfg.TokenVerifier.JWKSURL,
+		JWKSRefreshInterval: cfg.TokenVerifier.JWKSRefreshInterval,
+		ServiceSecret:       cfg.TokenVerifier.ServiceSecret,
+	}, authConn)
+	if err != nil {
+		log.Fatal("failed to create token verifier", "error", err)
+	}
+
 	useCase := friendship.NewUseCase(repository, usersClient, txManager, log)
 
-	server, err := grpcserver.NewServer(cfg.Server, useCase, log)
+	kafkaServer := kafka.NewFriendsServiceServer(useCase, log)
+
+	consumer, err := kafka.NewUserEventConsumer(kafkaServer, cfg.Kafka)
+	if err != nil {
+		log.Fatal("failed to create Kafka consumer", "error", err)
+	}
+
+	if err := consumer.Start(ctx); err != nil {
+		log.Fatal("failed to start Kafka consumer", "error", err)
+	}
+	defer consumer.Close()
+
+	server, err := grpcserver.NewServer(cfg.Server, useCase, verifier, log)
 	if err != nil {
 		log.Fatal("failed to create grpc server", "error", err)
 	}
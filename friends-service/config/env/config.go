@@ -5,6 +5,8 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -12,14 +14,23 @@ import (
 const (
 	DefaultGRPCPort = 9003
 	DefaultHTTPPort = 8003
+
+	DefaultKafkaBroker = "localhost:9092"
+	DefaultKafkaTopic  = "user-events"
+
+	DefaultTokenVerifier = "introspection"
+	DefaultJWKSURL       = "http://localhost:8001/.well-known/jwks.json"
+	DefaultJWKSRefresh   = 5 * time.Minute
 )
 
 type Config struct {
-	AppName string
-	Debug   string
-	Server  *ServerConfig
-	Clients *ClientsConfig
-	DB      *DBConfig
+	AppName       string
+	Debug         string
+	Server        *ServerConfig
+	Clients       *ClientsConfig
+	Kafka         *KafkaConfig
+	DB            *DBConfig
+	TokenVerifier *TokenVerifierConfig
 }
 
 type ServerConfig struct {
@@ -30,10 +41,17 @@ type ServerConfig struct {
 }
 
 type ClientsConfig struct {
+	Auth    *ServiceClientConfig
 	Users   *ServiceClientConfig
 	Friends *ServiceClientConfig
 }
 
+type KafkaConfig struct {
+	Brokers       []string
+	Topic         string
+	ConsumerGroup string
+}
+
 type DBConfig struct {
 	Host     string
 	Port     int
@@ -47,6 +65,17 @@ func (db *DBConfig) DSN() string {
 		db.User, db.Password, db.Host, db.Port, db.Name)
 }
 
+// TokenVerifierConfig selects how incoming tokens are verified, see
+// platformauth.NewTokenVerifier.
+type TokenVerifierConfig struct {
+	// Type is "introspection" or "jwks".
+	Type                string
+	JWKSURL             string
+	JWKSRefreshInterval time.Duration
+	// ServiceSecret authenticates introspection calls to the auth service.
+	ServiceSecret string
+}
+
 type ServiceClientConfig struct {
 	Host string
 	Port int
@@ -74,10 +103,13 @@ func LoadConfig() (*Config, error) {
 		Debug:   getEnv("DEBUG", "dev"),
 		Server:  &ServerConfig{},
 		Clients: &ClientsConfig{
+			Auth:    &ServiceClientConfig{},
 			Users:   &ServiceClientConfig{},
 			Friends: &ServiceClientConfig{},
 		},
-		DB: &DBConfig{},
+		Kafka:         &KafkaConfig{},
+		TokenVerifier: &TokenVerifierConfig{},
+		DB:            &DBConfig{},
 	}
 
 	c.Server.GRPCHost = getEnv("GRPC_HOST", "0.0.0.0")
@@ -85,9 +117,21 @@ func LoadConfig() (*Config, error) {
 	c.Server.HTTPHost = getEnv("HTTP_HOST", "0.0.0.0")
 	c.Server.HTTPPort = getEnvAsInt("HTTP_PORT", DefaultHTTPPort)
 
+	c.Clients.Auth.Host = getEnv("AUTH_SERVICE_HOST", "localhost")
+	c.Clients.Auth.Port = getEnvAsInt("AUTH_SERVICE_PORT", 9001)
+
+	c.TokenVerifier.Type = getEnv("AUTH_TOKEN_VERIFIER", DefaultTokenVerifier)
+	c.TokenVerifier.JWKSURL = getEnv("AUTH_JWKS_URL", DefaultJWKSURL)
+	c.TokenVerifier.JWKSRefreshInterval = getEnvAsDuration("AUTH_JWKS_REFRESH_INTERVAL", DefaultJWKSRefresh)
+	c.TokenVerifier.ServiceSecret = getEnv("AUTH_SERVICE_SECRET", "")
+
 	c.Clients.Users.Host = getEnv("USERS_SERVICE_HOST", "localhost")
 	c.Clients.Users.Port = getEnvAsInt("USERS_SERVICE_PORT", 9004)
 
+	c.Kafka.Brokers = getEnvAsSlice("KAFKA_BROKERS", []string{DefaultKafkaBroker})
+	c.Kafka.Topic = getEnv("KAFKA_TOPIC", DefaultKafkaTopic)
+	c.Kafka.ConsumerGroup = getEnv("KAFKA_CONSUMER_GROUP", "friends-service-group")
+
 	c.DB = &DBConfig{
 		Host:     getEnv("POSTGRES_HOST", "localhost"),
 		Port:     getEnvAsInt("POSTGRES_PORT", 5432),
@@ -115,3 +159,20 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvAsSlice(key string, defaultValue []string) []string {
+	if v := os.Getenv(key); v != "" {
+		return strings.Split(v, ",")
+	}
+	return defaultValue
+}
+
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		val, err := time.ParseDuration(v)
+		if err == nil {
+			return val
+		}
+	}
+	return defaultValue
+}
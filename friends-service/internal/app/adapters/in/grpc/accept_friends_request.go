@@ -4,12 +4,22 @@ import (
 	"context"
 
 	friends "github.com/SamEkb/messenger-app/pkg/api/friends_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
 )
 
 func (s *FriendshipServiceServer) AcceptFriendRequest(ctx context.Context, req *friends.AcceptFriendRequestRequest) (*friends.AcceptFriendRequestResponse, error) {
+	if err := platformauth.RequireScope(ctx, platformauth.ScopeFriendsWrite); err != nil {
+		s.logger.Warn("accept friend request denied", "error", err)
+		return nil, err
+	}
+	if err := platformauth.RejectImpersonation(ctx); err != nil {
+		s.logger.Warn("accept friend request denied", "error", err)
+		return nil, err
+	}
+
 	s.logger.Info("accepting friend request")
 
-	if err := s.friendshipUseCase.AcceptFriendRequest(ctx, req.GetUserId(), req.GetFriendId()); err != nil {
+	if err := s.friendshipUseCase.AcceptFriendRequest(ctx, req.GetFriendId()); err != nil {
 		s.logger.Error("failed to accept friend request", "error", err)
 		return nil, err
 	}
@@ -11,7 +11,9 @@ import (
 
 	"github.com/SamEkb/messenger-app/friends-service/config/env"
 	"github.com/SamEkb/messenger-app/friends-service/internal/app/ports"
+	middlewaregrpc "github.com/SamEkb/messenger-app/friends-service/internal/middleware/grpc"
 	friends "github.com/SamEkb/messenger-app/pkg/api/friends_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
 	"github.com/SamEkb/messenger-app/pkg/platform/logger"
 	"github.com/bufbuild/protovalidate-go"
 	protovalidatemw "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/protovalidate"
@@ -26,11 +28,12 @@ type FriendshipServiceServer struct {
 	friends.UnimplementedFriendsServiceServer
 	friendshipUseCase ports.FriendshipUseCase
 	validator         protovalidate.Validator
+	verifier          platformauth.TokenVerifier
 	cfg               *env.ServerConfig
 	logger            logger.Logger
 }
 
-func NewServer(cfg *env.ServerConfig, friendshipUseCase ports.FriendshipUseCase, logger logger.Logger) (*FriendshipServiceServer, error) {
+func NewServer(cfg *env.ServerConfig, friendshipUseCase ports.FriendshipUseCase, verifier platformauth.TokenVerifier, logger logger.Logger) (*FriendshipServiceServer, error) {
 	validator, err := protovalidate.New()
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize validator: %w", err)
@@ -38,6 +41,7 @@ func NewServer(cfg *env.ServerConfig, friendshipUseCase ports.FriendshipUseCase,
 
 	return &FriendshipServiceServer{
 		validator:         validator,
+		verifier:          verifier,
 		cfg:               cfg,
 		logger:            logger,
 		friendshipUseCase: friendshipUseCase,
@@ -63,6 +67,11 @@ func (s *FriendshipServiceServer) RunServers(ctx context.Context) error {
 		grpcServer := grpclib.NewServer(
 			grpclib.ChainUnaryInterceptor(
 				protovalidatemw.UnaryServerInterceptor(s.validator),
+				middlewaregrpc.ErrorsUnaryServerInterceptor(),
+				platformauth.UnaryServerInterceptor(s.verifier),
+			),
+			grpclib.ChainStreamInterceptor(
+				platformauth.StreamServerInterceptor(s.verifier),
 			),
 		)
 		friends.RegisterFriendsServiceServer(grpcServer, s)
@@ -82,7 +91,9 @@ func (s *FriendshipServiceServer) RunServers(ctx context.Context) error {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		mux := runtime.NewServeMux()
+		mux := runtime.NewServeMux(runtime.WithMiddlewares(
+			platformauth.HTTPMiddleware(s.verifier, friends.File_friends_service_v1_service_proto.Services().ByName("FriendsService")),
+		))
 		if err := friends.RegisterFriendsServiceHandlerServer(ctx, mux, s); err != nil {
 			log.Fatalf("gateway registration error: %v", err)
 		}
@@ -4,10 +4,16 @@ import (
 	"context"
 
 	friends "github.com/SamEkb/messenger-app/pkg/api/friends_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (s *FriendshipServiceServer) GetFriendsList(ctx context.Context, req *friends.GetFriendsListRequest) (*friends.GetFriendsListResponse, error) {
+	if err := platformauth.RequireScope(ctx, platformauth.ScopeFriendsRead); err != nil {
+		s.logger.Warn("get friends list denied", "error", err)
+		return nil, err
+	}
+
 	s.logger.Info("getting friends list")
 
 	friendsList, err := s.friendshipUseCase.GetFriends(ctx, req.GetUserId())
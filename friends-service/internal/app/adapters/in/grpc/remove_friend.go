@@ -4,12 +4,22 @@ import (
 	"context"
 
 	friends "github.com/SamEkb/messenger-app/pkg/api/friends_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
 )
 
 func (s *FriendshipServiceServer) RemoveFriend(ctx context.Context, req *friends.RemoveFriendRequest) (*friends.RemoveFriendResponse, error) {
+	if err := platformauth.RequireScope(ctx, platformauth.ScopeFriendsWrite); err != nil {
+		s.logger.Warn("remove friend denied", "error", err)
+		return nil, err
+	}
+	if err := platformauth.RejectImpersonation(ctx); err != nil {
+		s.logger.Warn("remove friend denied", "error", err)
+		return nil, err
+	}
+
 	s.logger.Info("removing friend")
 
-	if err := s.friendshipUseCase.DeleteFriend(ctx, req.UserId, req.GetFriendId()); err != nil {
+	if err := s.friendshipUseCase.DeleteFriend(ctx, req.GetFriendId()); err != nil {
 		s.logger.Error("failed to remove friend", "error", err)
 		return nil, err
 	}
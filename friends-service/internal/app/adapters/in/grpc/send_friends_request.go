@@ -4,12 +4,22 @@ import (
 	"context"
 
 	friends "github.com/SamEkb/messenger-app/pkg/api/friends_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
 )
 
 func (s *FriendshipServiceServer) SendFriendRequest(ctx context.Context, req *friends.SendFriendRequestRequest) (*friends.SendFriendRequestResponse, error) {
+	if err := platformauth.RequireScope(ctx, platformauth.ScopeFriendsWrite); err != nil {
+		s.logger.Warn("send friend request denied", "error", err)
+		return nil, err
+	}
+	if err := platformauth.RejectImpersonation(ctx); err != nil {
+		s.logger.Warn("send friend request denied", "error", err)
+		return nil, err
+	}
+
 	s.logger.Info("sending friend request")
 
-	if err := s.friendshipUseCase.SendFriendRequest(ctx, req.GetUserId(), req.GetFriendId()); err != nil {
+	if err := s.friendshipUseCase.SendFriendRequest(ctx, req.GetFriendId()); err != nil {
 		s.logger.Error("failed to send friend request", "error", err)
 		return nil, err
 	}
@@ -0,0 +1,23 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/pkg/api/events/v1"
+)
+
+func (s *FriendsServiceServer) HandleUserDeleted(ctx context.Context, event *events.UserDeletedEvent) error {
+	s.logger.Info("Handling user deleted event",
+		"user_id", event.UserId)
+
+	if err := s.friendshipUseCase.RemoveUser(ctx, event.UserId); err != nil {
+		s.logger.Error("Failed to remove friendships from event",
+			"error", err,
+			"user_id", event.UserId)
+		return err
+	}
+
+	s.logger.Info("Friendships successfully removed from Kafka event",
+		"user_id", event.UserId)
+	return nil
+}
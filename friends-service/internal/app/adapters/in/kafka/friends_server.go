@@ -0,0 +1,18 @@
+package kafka
+
+import (
+	"github.com/SamEkb/messenger-app/friends-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+)
+
+type FriendsServiceServer struct {
+	friendshipUseCase ports.FriendshipUseCase
+	logger            logger.Logger
+}
+
+func NewFriendsServiceServer(friendshipUseCase ports.FriendshipUseCase, logger logger.Logger) *FriendsServiceServer {
+	return &FriendsServiceServer{
+		friendshipUseCase: friendshipUseCase,
+		logger:            logger,
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/SamEkb/messenger-app/friends-service/config/env"
 	"github.com/SamEkb/messenger-app/friends-service/internal/app/ports"
 	users "github.com/SamEkb/messenger-app/pkg/api/users_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
 	"github.com/SamEkb/messenger-app/pkg/platform/errors"
 	"github.com/SamEkb/messenger-app/pkg/platform/logger"
 	"google.golang.org/grpc"
@@ -29,6 +30,7 @@ func (f *Client) NewUsersServiceClient(ctx context.Context) (ports.UserServiceCl
 		ctx,
 		f.config.Users.Addr(),
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(platformauth.UnaryClientInterceptor()),
 	)
 	if err != nil {
 		return nil, errors.NewServiceError(err, "failed to connect to Users Service")
@@ -40,3 +42,17 @@ func (f *Client) NewUsersServiceClient(ctx context.Context) (ports.UserServiceCl
 		conn:   conn,
 	}, nil
 }
+
+// NewAuthServiceConn connects to the auth service, which verifies the
+// tokens of incoming requests through platformauth.IntrospectionVerifier.
+func (f *Client) NewAuthServiceConn(ctx context.Context) (*grpc.ClientConn, error) {
+	conn, err := grpc.DialContext(
+		ctx,
+		f.config.Auth.Addr(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return nil, errors.NewServiceError(err, "failed to connect to Auth Service")
+	}
+	return conn, nil
+}
@@ -25,8 +25,8 @@ func (c *UsersServiceClientAdapter) Close() error {
 	return nil
 }
 
-func (c *UsersServiceClientAdapter) GetUserProfile(userID string) (*ports.UserProfile, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (c *UsersServiceClientAdapter) GetUserProfile(ctx context.Context, userID string) (*ports.UserProfile, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	req := &users.GetUserProfileRequest{
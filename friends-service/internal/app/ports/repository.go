@@ -6,10 +6,15 @@ import (
 	"github.com/SamEkb/messenger-app/friends-service/internal/app/models"
 )
 
+type TxManager interface {
+	RunTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type FriendshipRepository interface {
 	GetFriends(ctx context.Context, userID string) ([]*models.Friendship, error)
 	SendFriendRequest(ctx context.Context, requestorID, recipientID string) error
 	AcceptFriendRequest(ctx context.Context, recipientID, requestorID string) error
 	RejectFriendRequest(ctx context.Context, recipientID, requestorID string) error
 	Delete(ctx context.Context, userID string, friendID string) error
+	DeleteAllForUser(ctx context.Context, userID string) error
 }
@@ -7,7 +7,7 @@ import (
 )
 
 type UserServiceClient interface {
-	GetUserProfile(userID string) (*UserProfile, error)
+	GetUserProfile(ctx context.Context, userID string) (*UserProfile, error)
 	GetProfiles(ctx context.Context, request *users.GetProfilesRequest) (*GetProfilesResponse, error)
 }
 
@@ -9,11 +9,12 @@ import (
 
 type FriendshipUseCase interface {
 	GetFriends(ctx context.Context, userID string) ([]*FriendshipDto, error)
-	SendFriendRequest(ctx context.Context, requestorID, recipientID string) error
-	AcceptFriendRequest(ctx context.Context, recipientID, requestorID string) error
-	RejectFriendRequest(ctx context.Context, recipientID, requestorID string) error
-	DeleteFriend(ctx context.Context, userID string, friendID string) error
+	SendFriendRequest(ctx context.Context, recipientID string) error
+	AcceptFriendRequest(ctx context.Context, requestorID string) error
+	RejectFriendRequest(ctx context.Context, requestorID string) error
+	DeleteFriend(ctx context.Context, friendID string) error
 	CheckMultipleFriendships(ctx context.Context, userIDs []string) ([]UserPair, error)
+	RemoveUser(ctx context.Context, userID string) error
 }
 
 type UserPair struct {
@@ -2,7 +2,6 @@ package in_memory
 
 import (
 	"context"
-	"fmt"
 	"sync"
 
 	"github.com/SamEkb/messenger-app/friends-service/internal/app/models"
@@ -74,7 +73,7 @@ func (r *FriendshipRepository) AcceptFriendRequest(ctx context.Context, recipien
 	}
 
 	if !friendship.IsRequested() {
-		return errors.NewInvalidInputError(fmt.Sprintf("friendship is not in REQUESTED state, current state: %s", friendship.Status()))
+		return errors.NewInvalidInputError("friendship is not in REQUESTED state, current state: %s", friendship.Status())
 	}
 
 	friendship.Accept()
@@ -91,7 +90,7 @@ func (r *FriendshipRepository) RejectFriendRequest(ctx context.Context, recipien
 	}
 
 	if !friendship.IsRequested() {
-		return errors.NewInvalidInputError(fmt.Sprintf("friendship is not in REQUESTED state, current state: %s", friendship.Status()))
+		return errors.NewInvalidInputError("friendship is not in REQUESTED state, current state: %s", friendship.Status())
 	}
 
 	friendship.Reject()
@@ -108,6 +107,22 @@ func (r *FriendshipRepository) Delete(ctx context.Context, userID string, friend
 	return nil
 }
 
+func (r *FriendshipRepository) DeleteAllForUser(ctx context.Context, userID string) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	for _, friendship := range r.friendships[userID] {
+		otherUserID := friendship.RequestorID()
+		if otherUserID == userID {
+			otherUserID = friendship.RecipientID()
+		}
+		r.removeFriendship(otherUserID, userID)
+	}
+	delete(r.friendships, userID)
+
+	return nil
+}
+
 func (r *FriendshipRepository) friendshipExists(user1ID, user2ID string) bool {
 	friendships, exists := r.friendships[user1ID]
 	if !exists {
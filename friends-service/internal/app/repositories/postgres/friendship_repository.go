@@ -231,6 +231,31 @@ func (r *FriendshipRepository) Delete(ctx context.Context, userID string, friend
 	return nil
 }
 
+// DeleteAllForUser removes every friendship and pending request the user takes
+// part in. Deleting nothing is not an error.
+func (r *FriendshipRepository) DeleteAllForUser(ctx context.Context, userID string) error {
+	r.logger.Debug("deleting all friendships of user", "user_id", userID)
+
+	q := r.txManager.GetQueryEngine(ctx)
+	result, err := q.ExecContext(ctx, `
+		DELETE FROM friendships
+		WHERE requestor_id = $1 OR recipient_id = $1
+	`, userID)
+	if err != nil {
+		r.logger.Error("failed to delete friendships of user", "error", err)
+		return errors.NewInternalError(err, "failed to delete friendships of user")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("failed to get rows affected", "error", err)
+		return errors.NewInternalError(err, "failed to get rows affected")
+	}
+
+	r.logger.Info("friendships of user deleted", "user_id", userID, "count", rowsAffected)
+	return nil
+}
+
 func (r *FriendshipRepository) mapToModel(id, requestorID, recipientID, status string, createdAt, updatedAt time.Time) (*models.Friendship, error) {
 	friendshipID, err := uuid.Parse(id)
 	if err != nil {
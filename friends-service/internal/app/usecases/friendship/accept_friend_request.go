@@ -1,11 +1,21 @@
 package friendship
 
-import "context"
+import (
+	"context"
 
-func (u *UseCase) AcceptFriendRequest(ctx context.Context, recipientID, requestorID string) error {
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+)
+
+func (u *UseCase) AcceptFriendRequest(ctx context.Context, requestorID string) error {
 	u.logger.Info("accepting friend request")
 
-	err := u.txManager.RunTx(ctx, func(txCtx context.Context) error {
+	recipientID, err := platformauth.UserIDFromContext(ctx)
+	if err != nil {
+		u.logger.Error("unauthenticated request", "error", err)
+		return err
+	}
+
+	err = u.txManager.RunTx(ctx, func(txCtx context.Context) error {
 		if err := u.friendRepository.AcceptFriendRequest(txCtx, recipientID, requestorID); err != nil {
 			u.logger.Error("failed to accept friend request", "error", err)
 			return err
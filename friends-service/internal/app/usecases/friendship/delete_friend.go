@@ -1,11 +1,21 @@
 package friendship
 
-import "context"
+import (
+	"context"
 
-func (u *UseCase) DeleteFriend(ctx context.Context, userID, friendID string) error {
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+)
+
+func (u *UseCase) DeleteFriend(ctx context.Context, friendID string) error {
 	u.logger.Info("deleting friend")
 
-	err := u.txManager.RunTx(ctx, func(txCtx context.Context) error {
+	userID, err := platformauth.UserIDFromContext(ctx)
+	if err != nil {
+		u.logger.Error("unauthenticated request", "error", err)
+		return err
+	}
+
+	err = u.txManager.RunTx(ctx, func(txCtx context.Context) error {
 		if err := u.friendRepository.Delete(txCtx, userID, friendID); err != nil {
 			u.logger.Error("failed to delete friend", "error", err)
 			return err
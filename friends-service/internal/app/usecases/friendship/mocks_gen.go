@@ -0,0 +1,4 @@
+package friendship
+
+//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name TxManager --output ./mocks --filename tx_manager_mock.go
+//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name FriendshipRepository --output ./mocks --filename friendship_repository_mock.go
@@ -0,0 +1,27 @@
+package friendship
+
+import (
+	"context"
+)
+
+// RemoveUser drops every friendship and pending request of a deleted
+// account. It is safe to call again for the same user.
+func (u *UseCase) RemoveUser(ctx context.Context, userID string) error {
+	u.logger.Info("removing friendships of deleted user", "user_id", userID)
+
+	err := u.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		if err := u.friendRepository.DeleteAllForUser(txCtx, userID); err != nil {
+			u.logger.Error("failed to remove friendships of user", "error", err, "user_id", userID)
+			return err
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	u.logger.Info("friendships of deleted user removed", "user_id", userID)
+
+	return nil
+}
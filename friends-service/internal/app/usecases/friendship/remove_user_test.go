@@ -0,0 +1,140 @@
+package friendship
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SamEkb/messenger-app/friends-service/internal/app/repositories/in_memory"
+	"github.com/SamEkb/messenger-app/friends-service/internal/app/usecases/friendship/mocks"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTxManager(t *testing.T) *mocks.TxManager {
+	txManager := mocks.NewTxManager(t)
+	txManager.EXPECT().
+		RunTx(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+	return txManager
+}
+
+func TestUseCase_RemoveUser(t *testing.T) {
+	ctx := context.Background()
+
+	const (
+		deletedUserID = "user-1"
+		friendID      = "user-2"
+		requestorID   = "user-3"
+	)
+
+	// seedFriendships makes friendID a friend of the deleted user and leaves
+	// a pending request from requestorID.
+	seedFriendships := func(t *testing.T, repo *in_memory.FriendshipRepository) {
+		require.NoError(t, repo.SendFriendRequest(ctx, deletedUserID, friendID))
+		require.NoError(t, repo.AcceptFriendRequest(ctx, friendID, deletedUserID))
+		require.NoError(t, repo.SendFriendRequest(ctx, requestorID, deletedUserID))
+	}
+
+	type args struct {
+		ctx    context.Context
+		userID string
+		runs   int
+	}
+
+	tests := map[string]struct {
+		args    args
+		wantErr bool
+		deps    func(t *testing.T) UseCase
+	}{
+		"friendships removed": {
+			args: args{
+				ctx:    ctx,
+				userID: deletedUserID,
+				runs:   1,
+			},
+			wantErr: false,
+			deps: func(t *testing.T) UseCase {
+				repo := in_memory.NewFriendshipRepository(logger.NewMockLogger())
+				seedFriendships(t, repo)
+
+				return UseCase{
+					friendRepository: repo,
+					txManager:        newTxManager(t),
+					logger:           logger.NewMockLogger(),
+				}
+			},
+		},
+		"second run is a no-op": {
+			args: args{
+				ctx:    ctx,
+				userID: deletedUserID,
+				runs:   2,
+			},
+			wantErr: false,
+			deps: func(t *testing.T) UseCase {
+				repo := in_memory.NewFriendshipRepository(logger.NewMockLogger())
+				seedFriendships(t, repo)
+
+				return UseCase{
+					friendRepository: repo,
+					txManager:        newTxManager(t),
+					logger:           logger.NewMockLogger(),
+				}
+			},
+		},
+		"failed to delete friendships": {
+			args: args{
+				ctx:    ctx,
+				userID: deletedUserID,
+				runs:   1,
+			},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				mockFriendshipRepository := mocks.NewFriendshipRepository(t)
+				mockFriendshipRepository.EXPECT().
+					DeleteAllForUser(mock.Anything, deletedUserID).
+					Return(assert.AnError).
+					Once()
+
+				return UseCase{
+					friendRepository: mockFriendshipRepository,
+					txManager:        newTxManager(t),
+					logger:           logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+
+			var err error
+			for i := 0; i < tc.args.runs; i++ {
+				err = useCase.RemoveUser(tc.args.ctx, tc.args.userID)
+			}
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, assert.AnError)
+				return
+			}
+			require.NoError(t, err)
+
+			for _, userID := range []string{deletedUserID, friendID} {
+				friends, err := useCase.friendRepository.GetFriends(ctx, userID)
+				require.NoError(t, err)
+				assert.Empty(t, friends, "friends of %s", userID)
+			}
+
+			// A new request from the former requestor must not collide with
+			// the one that was removed.
+			assert.NoError(t, useCase.friendRepository.SendFriendRequest(ctx, requestorID, deletedUserID))
+		})
+	}
+}
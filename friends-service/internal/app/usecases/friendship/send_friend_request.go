@@ -1,11 +1,21 @@
 package friendship
 
-import "context"
+import (
+	"context"
 
-func (u *UseCase) SendFriendRequest(ctx context.Context, requestorID, recipientID string) error {
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+)
+
+func (u *UseCase) SendFriendRequest(ctx context.Context, recipientID string) error {
 	u.logger.Info("sending friend request")
 
-	err := u.txManager.RunTx(ctx, func(txCtx context.Context) error {
+	requestorID, err := platformauth.UserIDFromContext(ctx)
+	if err != nil {
+		u.logger.Error("unauthenticated request", "error", err)
+		return err
+	}
+
+	err = u.txManager.RunTx(ctx, func(txCtx context.Context) error {
 		if err := u.friendRepository.SendFriendRequest(txCtx, requestorID, recipientID); err != nil {
 			u.logger.Error("failed to send friend request", "error", err)
 			return err
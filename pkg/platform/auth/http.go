@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// captureWithoutPattern matches a path variable written without an explicit
+// segment pattern, such as {user_id}.
+var captureWithoutPattern = regexp.MustCompile(`\{([^=}]+)\}`)
+
+// HTTPMiddleware authenticates grpc-gateway requests that are served
+// in-process and therefore bypass the gRPC interceptors. Install it with
+// runtime.WithMiddlewares so that it also covers routes added via HandlePath.
+//
+// Routes are mapped back to the full method names of service through their
+// google.api.http annotations, so the WithPublicMethods and WithServiceMethods
+// options given to the gRPC interceptors apply here as well. Every other
+// route, including custom ones, requires a valid bearer token and is answered
+// with 401 otherwise.
+func HTTPMiddleware(verifier TokenVerifier, service protoreflect.ServiceDescriptor, opts ...Option) runtime.Middleware {
+	o := newOptions(opts)
+	routes := httpRoutes(service)
+	errorMux := runtime.NewServeMux()
+
+	return func(next runtime.HandlerFunc) runtime.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+			ctx := r.Context()
+			pattern, _ := runtime.HTTPPattern(ctx)
+			method, ok := routes[routeKey(r.Method, pattern.String())]
+			if ok && o.isPublic(method) {
+				next(w, r, pathParams)
+				return
+			}
+
+			unauthenticated := func(err error) {
+				_, marshaler := runtime.MarshalerForRequest(errorMux, r)
+				runtime.HTTPError(ctx, errorMux, marshaler, w, r, status.Error(codes.Unauthenticated, err.Error()))
+			}
+
+			if ok && o.isServiceMethod(method) {
+				if err := o.authenticateService(r.Header.Get(serviceSecretHeader)); err != nil {
+					unauthenticated(err)
+					return
+				}
+				next(w, r, pathParams)
+				return
+			}
+
+			authCtx, err := authenticateRequest(r, verifier)
+			if err != nil {
+				unauthenticated(err)
+				return
+			}
+
+			next(w, r.WithContext(authCtx), pathParams)
+		}
+	}
+}
+
+func authenticateRequest(r *http.Request, verifier TokenVerifier) (context.Context, error) {
+	token, err := parseBearer(r.Header.Get("Authorization"))
+	if err != nil {
+		return nil, err
+	}
+
+	principal, err := verifier.Verify(r.Context(), token)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := ContextWithPrincipal(r.Context(), principal)
+	return contextWithToken(ctx, token), nil
+}
+
+// httpRoutes maps "METHOD /path" keys, with paths in the form reported by
+// runtime.Pattern.String, to the full gRPC method names they are bound to.
+func httpRoutes(service protoreflect.ServiceDescriptor) map[string]string {
+	routes := make(map[string]string)
+	methods := service.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		method := methods.Get(i)
+		rule, ok := proto.GetExtension(method.Options(), annotations.E_Http).(*annotations.HttpRule)
+		if !ok || rule == nil {
+			continue
+		}
+
+		fullMethod := fmt.Sprintf("/%s/%s", service.FullName(), method.Name())
+		for _, binding := range append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...) {
+			verb, path := httpBinding(binding)
+			if path != "" {
+				routes[routeKey(verb, path)] = fullMethod
+			}
+		}
+	}
+	return routes
+}
+
+func httpBinding(rule *annotations.HttpRule) (string, string) {
+	switch pattern := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return http.MethodGet, pattern.Get
+	case *annotations.HttpRule_Post:
+		return http.MethodPost, pattern.Post
+	case *annotations.HttpRule_Put:
+		return http.MethodPut, pattern.Put
+	case *annotations.HttpRule_Patch:
+		return http.MethodPatch, pattern.Patch
+	case *annotations.HttpRule_Delete:
+		return http.MethodDelete, pattern.Delete
+	case *annotations.HttpRule_Custom:
+		return pattern.Custom.GetKind(), pattern.Custom.GetPath()
+	default:
+		return "", ""
+	}
+}
+
+func routeKey(method, path string) string {
+	return method + " " + captureWithoutPattern.ReplaceAllString(path, "{$1=*}")
+}
@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	authv1 "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPMiddleware(t *testing.T) {
+	service := authv1.File_auth_service_v1_service_proto.Services().ByName("AuthService")
+	mux := runtime.NewServeMux(runtime.WithMiddlewares(HTTPMiddleware(fakeVerifier{}, service,
+		WithPublicMethods(authv1.AuthService_Login_FullMethodName, authv1.AuthService_StartOAuthLogin_FullMethodName),
+		WithServiceMethods([]string{"secret"}, authv1.AuthService_ValidateToken_FullMethodName),
+	)))
+
+	// The handlers report the caller the middleware resolved, if any.
+	echoCaller := func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		userID, err := UserIDFromContext(r.Context())
+		if err != nil {
+			userID = "anonymous"
+		}
+		_, _ = w.Write([]byte(userID))
+	}
+	for _, route := range []struct{ method, path string }{
+		{http.MethodPost, "/api/v1/auth/login"},
+		{http.MethodGet, "/api/v1/auth/oauth/{provider}/start"},
+		{http.MethodPost, "/api/v1/auth/validate"},
+		{http.MethodDelete, "/api/v1/auth/sessions/{session_id}"},
+		{http.MethodGet, "/custom"},
+	} {
+		require.NoError(t, mux.HandlePath(route.method, route.path, echoCaller))
+	}
+
+	tests := map[string]struct {
+		method   string
+		path     string
+		header   http.Header
+		wantCode int
+		wantBody string
+	}{
+		"public route without token": {
+			method:   http.MethodPost,
+			path:     "/api/v1/auth/login",
+			wantCode: http.StatusOK,
+			wantBody: "anonymous",
+		},
+		"public route with path variable": {
+			method:   http.MethodGet,
+			path:     "/api/v1/auth/oauth/google/start",
+			wantCode: http.StatusOK,
+			wantBody: "anonymous",
+		},
+		"protected route with valid token": {
+			method:   http.MethodDelete,
+			path:     "/api/v1/auth/sessions/123",
+			header:   http.Header{"Authorization": {"Bearer valid"}},
+			wantCode: http.StatusOK,
+			wantBody: "user-1",
+		},
+		"protected route without token": {
+			method:   http.MethodDelete,
+			path:     "/api/v1/auth/sessions/123",
+			wantCode: http.StatusUnauthorized,
+		},
+		"protected route with rejected token": {
+			method:   http.MethodDelete,
+			path:     "/api/v1/auth/sessions/123",
+			header:   http.Header{"Authorization": {"Bearer expired"}},
+			wantCode: http.StatusUnauthorized,
+		},
+		"service route with secret": {
+			method:   http.MethodPost,
+			path:     "/api/v1/auth/validate",
+			header:   http.Header{"X-Service-Secret": {"secret"}},
+			wantCode: http.StatusOK,
+			wantBody: "anonymous",
+		},
+		"service route with a user token only": {
+			method:   http.MethodPost,
+			path:     "/api/v1/auth/validate",
+			header:   http.Header{"Authorization": {"Bearer valid"}},
+			wantCode: http.StatusUnauthorized,
+		},
+		"custom route without token": {
+			method:   http.MethodGet,
+			path:     "/custom",
+			wantCode: http.StatusUnauthorized,
+		},
+		"custom route with valid token": {
+			method:   http.MethodGet,
+			path:     "/custom",
+			header:   http.Header{"Authorization": {"Bearer valid"}},
+			wantCode: http.StatusOK,
+			wantBody: "user-1",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			for key, values := range tc.header {
+				req.Header[key] = values
+			}
+			rec := httptest.NewRecorder()
+
+			mux.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.wantCode, rec.Code)
+			if tc.wantBody != "" {
+				assert.Equal(t, tc.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHTTPRoutes(t *testing.T) {
+	routes := httpRoutes(authv1.File_auth_service_v1_service_proto.Services().ByName("AuthService"))
+
+	assert.Equal(t, authv1.AuthService_Login_FullMethodName, routes["POST /api/v1/auth/login"])
+	assert.Equal(t, authv1.AuthService_RevokeSession_FullMethodName, routes["DELETE /api/v1/auth/sessions/{session_id=*}"])
+	assert.Equal(t, authv1.AuthService_ListSessions_FullMethodName, routes["GET /api/v1/auth/sessions"])
+}
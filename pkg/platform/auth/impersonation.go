@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// IsImpersonated reports whether an administrator is acting as the user
+// through an impersonation token.
+func (p *Principal) IsImpersonated() bool {
+	return p.ActorID != ""
+}
+
+// RejectImpersonation returns nil unless the caller uses an impersonation
+// token. Impersonation is meant for seeing what a user sees, so handlers of
+// operations that destroy or change the user's data, or act for the user
+// towards others, call it first thing, like RequireRole.
+func RejectImpersonation(ctx context.Context) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || principal.UserID == "" {
+		return errors.NewUnauthorizedError("request is not authenticated")
+	}
+
+	if principal.IsImpersonated() {
+		return errors.NewForbiddenError("operation is not allowed with an impersonation token").
+			WithDetails("actor_id", principal.ActorID)
+	}
+	return nil
+}
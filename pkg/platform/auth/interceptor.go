@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type options struct {
+	publicMethods  map[string]struct{}
+	serviceMethods map[string]struct{}
+	serviceSecrets [][]byte
+}
+
+type Option func(*options)
+
+// WithPublicMethods lets the listed full method names (for example
+// "/auth_service.v1.AuthService/Login") through without a token.
+func WithPublicMethods(methods ...string) Option {
+	return func(o *options) {
+		for _, method := range methods {
+			o.publicMethods[method] = struct{}{}
+		}
+	}
+}
+
+// WithServiceMethods lets other services call the listed full method names
+// by presenting one of secrets in the x-service-secret header instead of a
+// user's bearer token. Several secrets may be given to rotate them. The
+// handlers see no principal.
+func WithServiceMethods(secrets []string, methods ...string) Option {
+	return func(o *options) {
+		for _, secret := range secrets {
+			if secret != "" {
+				o.serviceSecrets = append(o.serviceSecrets, []byte(secret))
+			}
+		}
+		for _, method := range methods {
+			o.serviceMethods[method] = struct{}{}
+		}
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		publicMethods:  make(map[string]struct{}),
+		serviceMethods: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+func (o *options) isPublic(method string) bool {
+	_, ok := o.publicMethods[method]
+	return ok
+}
+
+func (o *options) isServiceMethod(method string) bool {
+	_, ok := o.serviceMethods[method]
+	return ok
+}
+
+// authenticateService checks the secret presented by a calling service.
+func (o *options) authenticateService(secret string) error {
+	if secret == "" {
+		return errors.NewUnauthorizedError("missing service secret")
+	}
+	for _, known := range o.serviceSecrets {
+		if subtle.ConstantTimeCompare([]byte(secret), known) == 1 {
+			return nil
+		}
+	}
+	return errors.NewUnauthorizedError("invalid service secret")
+}
+
+func authenticate(ctx context.Context, verifier TokenVerifier) (context.Context, error) {
+	token, err := TokenFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	principal, err := verifier.Verify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = ContextWithPrincipal(ctx, principal)
+	return contextWithToken(ctx, token), nil
+}
+
+// UnaryServerInterceptor rejects calls without a valid bearer token and
+// stores the resolved principal in the handler context.
+func UnaryServerInterceptor(verifier TokenVerifier, opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(opts)
+
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if o.isPublic(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		if o.isServiceMethod(info.FullMethod) {
+			if err := o.authenticateService(ServiceSecretFromMetadata(ctx)); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}
+
+		authCtx, err := authenticate(ctx, verifier)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(authCtx, req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor.
+func StreamServerInterceptor(verifier TokenVerifier, opts ...Option) grpc.StreamServerInterceptor {
+	o := newOptions(opts)
+
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if o.isPublic(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		if o.isServiceMethod(info.FullMethod) {
+			if err := o.authenticateService(ServiceSecretFromMetadata(ss.Context())); err != nil {
+				return err
+			}
+			return handler(srv, ss)
+		}
+
+		authCtx, err := authenticate(ss.Context(), verifier)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: authCtx})
+	}
+}
+
+// UnaryClientInterceptor forwards the caller's bearer token to downstream
+// services so that service-to-service calls act on behalf of the same user.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if token, ok := tokenFromContext(ctx); ok {
+			ctx = metadata.AppendToOutgoingContext(ctx, authorizationHeader, "Bearer "+token)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	publicMethod    = "/test.v1.TestService/Public"
+	serviceMethod   = "/test.v1.TestService/Introspect"
+	protectedMethod = "/test.v1.TestService/Protected"
+)
+
+// fakeVerifier accepts "valid" as the token of user-1.
+type fakeVerifier struct{}
+
+func (fakeVerifier) Verify(_ context.Context, token string) (*Principal, error) {
+	if token != "valid" {
+		return nil, errors.NewUnauthorizedError("token is invalid or expired")
+	}
+	return &Principal{UserID: "user-1", Roles: []Role{RoleUser}}, nil
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor(fakeVerifier{},
+		WithPublicMethods(publicMethod),
+		WithServiceMethods([]string{"old-secret", "new-secret"}, serviceMethod),
+	)
+
+	incoming := func(kv ...string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(kv...))
+	}
+
+	tests := map[string]struct {
+		ctx       context.Context
+		method    string
+		wantErr   bool
+		wantUser  string
+		wantToken string
+	}{
+		"public method without token": {
+			ctx:    context.Background(),
+			method: publicMethod,
+		},
+		"protected method with valid token": {
+			ctx:       incoming("authorization", "Bearer valid"),
+			method:    protectedMethod,
+			wantUser:  "user-1",
+			wantToken: "valid",
+		},
+		"protected method without token": {
+			ctx:     context.Background(),
+			method:  protectedMethod,
+			wantErr: true,
+		},
+		"protected method with rejected token": {
+			ctx:     incoming("authorization", "Bearer expired"),
+			method:  protectedMethod,
+			wantErr: true,
+		},
+		"service method with current secret": {
+			ctx:    incoming("x-service-secret", "new-secret"),
+			method: serviceMethod,
+		},
+		"service method with secret being rotated out": {
+			ctx:    incoming("x-service-secret", "old-secret"),
+			method: serviceMethod,
+		},
+		"service method with wrong secret": {
+			ctx:     incoming("x-service-secret", "guess"),
+			method:  serviceMethod,
+			wantErr: true,
+		},
+		"service method with a user token only": {
+			ctx:     incoming("authorization", "Bearer valid"),
+			method:  serviceMethod,
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var handlerCtx context.Context
+			handler := func(ctx context.Context, req any) (any, error) {
+				handlerCtx = ctx
+				return "ok", nil
+			}
+
+			resp, err := interceptor(tc.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tc.method}, handler)
+			if tc.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, handlerCtx)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "ok", resp)
+
+			userID, err := UserIDFromContext(handlerCtx)
+			if tc.wantUser == "" {
+				assert.Error(t, err)
+				return
+			}
+			assert.Equal(t, tc.wantUser, userID)
+			token, _ := tokenFromContext(handlerCtx)
+			assert.Equal(t, tc.wantToken, token)
+		})
+	}
+}
+
+func TestWithServiceMethods_IgnoresEmptySecrets(t *testing.T) {
+	interceptor := UnaryServerInterceptor(fakeVerifier{}, WithServiceMethods([]string{""}, serviceMethod))
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-service-secret", ""))
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: serviceMethod}, handler)
+	assert.Error(t, err)
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	interceptor := UnaryClientInterceptor()
+
+	tests := map[string]struct {
+		ctx  context.Context
+		want []string
+	}{
+		"forwards the caller's token": {
+			ctx:  contextWithToken(context.Background(), "valid"),
+			want: []string{"Bearer valid"},
+		},
+		"anonymous call": {
+			ctx: context.Background(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+			invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				md, _ := metadata.FromOutgoingContext(ctx)
+				got = md.Get("authorization")
+				return nil
+			}
+
+			err := interceptor(tc.ctx, protectedMethod, nil, nil, nil, invoker)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	authv1 "github.com/SamEkb/messenger-app/pkg/api/auth_service/v1"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var _ TokenVerifier = (*IntrospectionVerifier)(nil)
+
+const introspectionTimeout = 5 * time.Second
+
+// IntrospectionVerifier verifies tokens by asking the auth service through
+// ValidateToken. Unlike JWKSVerifier it sees revoked tokens and ended
+// sessions, resolves API keys and their scopes, and reports the actor behind
+// impersonation tokens.
+type IntrospectionVerifier struct {
+	client authv1.AuthServiceClient
+	secret string
+}
+
+// NewIntrospectionVerifier returns a verifier calling the auth service over
+// conn. secret authenticates the calling service, since the auth service
+// answers ValidateToken for known services only. The caller owns conn and
+// closes it.
+func NewIntrospectionVerifier(conn grpc.ClientConnInterface, secret string) *IntrospectionVerifier {
+	return &IntrospectionVerifier{client: authv1.NewAuthServiceClient(conn), secret: secret}
+}
+
+func (v *IntrospectionVerifier) Verify(ctx context.Context, token string) (*Principal, error) {
+	ctx, cancel := context.WithTimeout(ctx, introspectionTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, serviceSecretHeader, v.secret)
+
+	resp, err := v.client.ValidateToken(ctx, &authv1.ValidateTokenRequest{Token: token})
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			return nil, errors.NewServiceError(err, "failed to validate token: %s", st.Message())
+		}
+		return nil, errors.NewServiceError(err, "failed to validate token")
+	}
+
+	if !resp.GetActive() {
+		return nil, errors.NewUnauthorizedError("token is invalid or expired")
+	}
+
+	// API keys do not expire and report no expiry.
+	var expiresAt time.Time
+	if resp.GetExpiresAt() != 0 {
+		expiresAt = time.Unix(resp.GetExpiresAt(), 0)
+	}
+
+	return &Principal{
+		UserID:    resp.GetUserId(),
+		SessionID: resp.GetSessionId(),
+		Roles:     RolesFromStrings(resp.GetRoles()),
+		Scopes:    ScopesFromStrings(resp.GetScopes()),
+		ActorID:   resp.GetActorId(),
+		ExpiresAt: expiresAt,
+	}, nil
+}
@@ -0,0 +1,190 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jwksServer publishes the public halves of its signing keys the way the
+// auth service does. It answers 503 while unavailable is set.
+type jwksServer struct {
+	t           *testing.T
+	mx          sync.Mutex
+	keys        map[string]ed25519.PrivateKey
+	requests    int
+	unavailable bool
+	server      *httptest.Server
+}
+
+func newJWKSServer(t *testing.T) *jwksServer {
+	t.Helper()
+
+	s := &jwksServer{t: t, keys: make(map[string]ed25519.PrivateKey)}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		s.mx.Lock()
+		defer s.mx.Unlock()
+
+		s.requests++
+		if s.unavailable {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		set := JWKSet{Keys: []JWK{}}
+		for keyID, key := range s.keys {
+			jwk, err := NewJWK(keyID, key.Public())
+			require.NoError(t, err)
+			set.Keys = append(set.Keys, jwk)
+		}
+		_ = json.NewEncoder(w).Encode(set)
+	}))
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+func (s *jwksServer) addKey(keyID string) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(s.t, err)
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.keys[keyID] = key
+}
+
+// removeKey stops publishing a key, as the auth service does once a retired
+// key has outlived the tokens it signed.
+func (s *jwksServer) removeKey(keyID string) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	delete(s.keys, keyID)
+}
+
+func (s *jwksServer) setUnavailable(unavailable bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.unavailable = unavailable
+}
+
+func (s *jwksServer) requestCount() int {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.requests
+}
+
+// sign signs claims with the key published under keyID, or with a key that
+// was never published if there is none.
+func (s *jwksServer) sign(keyID string, claims jwt.Claims) string {
+	s.mx.Lock()
+	key, ok := s.keys[keyID]
+	s.mx.Unlock()
+	if !ok {
+		var err error
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+		require.NoError(s.t, err)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	token.Header["kid"] = keyID
+	signed, err := token.SignedString(key)
+	require.NoError(s.t, err)
+	return signed
+}
+
+func userClaims(expiresAt time.Time) *accessTokenClaims {
+	return &accessTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "user-1",
+			IssuedAt:  jwt.NewNumericDate(time.Now().Add(-time.Minute)),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		Roles: []Role{RoleUser},
+	}
+}
+
+func TestJWKSVerifier_Verify(t *testing.T) {
+	keys := newJWKSServer(t)
+	keys.addKey("key-1")
+	keys.addKey("retired-key")
+	retired := keys.sign("retired-key", userClaims(time.Now().Add(time.Hour)))
+	keys.removeKey("retired-key")
+
+	impersonation := userClaims(time.Now().Add(time.Hour))
+	impersonation.Act = &actorClaim{Subject: "admin-1"}
+
+	noKeyID := jwt.NewWithClaims(jwt.SigningMethodEdDSA, userClaims(time.Now().Add(time.Hour)))
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	withoutKeyID, err := noKeyID.SignedString(key)
+	require.NoError(t, err)
+
+	// A token whose claims were swapped for those of another one.
+	admin := userClaims(time.Now().Add(time.Hour))
+	admin.Roles = []Role{RoleAdmin}
+	valid := strings.Split(keys.sign("key-1", userClaims(time.Now().Add(time.Hour))), ".")
+	valid[1] = strings.Split(keys.sign("key-1", admin), ".")[1]
+	tampered := strings.Join(valid, ".")
+
+	tests := map[string]struct {
+		token    string
+		wantUser string
+		wantErr  bool
+	}{
+		"valid token": {
+			token:    keys.sign("key-1", userClaims(time.Now().Add(time.Hour))),
+			wantUser: "user-1",
+		},
+		"impersonation token": {
+			token:   keys.sign("key-1", impersonation),
+			wantErr: true,
+		},
+		"expired token": {
+			token:   keys.sign("key-1", userClaims(time.Now().Add(-time.Minute))),
+			wantErr: true,
+		},
+		"unknown key ID": {
+			token:   keys.sign("key-2", userClaims(time.Now().Add(time.Hour))),
+			wantErr: true,
+		},
+		"key no longer published": {
+			token:   retired,
+			wantErr: true,
+		},
+		"no key ID": {
+			token:   withoutKeyID,
+			wantErr: true,
+		},
+		"tampered claims": {
+			token:   tampered,
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			verifier := NewJWKSVerifier(keys.server.URL, time.Minute)
+			principal, err := verifier.Verify(context.Background(), tc.token)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantUser, principal.UserID)
+			assert.Equal(t, []Role{RoleUser}, principal.Roles)
+			assert.Empty(t, principal.ActorID)
+		})
+	}
+}
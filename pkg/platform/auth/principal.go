@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// Principal describes the authenticated caller of a request.
+type Principal struct {
+	UserID string
+	// SessionID identifies the login session the token belongs to. It is
+	// empty when the verifier cannot resolve sessions, e.g. offline JWT checks.
+	SessionID string
+	// Roles are the roles the auth service granted the user.
+	Roles []Role
+	// Scopes limit an API key to certain operations. They are empty for
+	// session tokens.
+	Scopes []Scope
+	// ActorID is the administrator acting as UserID through an
+	// impersonation token. It is empty when users act for themselves.
+	ActorID   string
+	ExpiresAt time.Time
+}
+
+// TokenVerifier checks a bearer token and resolves the caller it belongs to.
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (*Principal, error)
+}
+
+type principalKey struct{}
+
+type tokenKey struct{}
+
+func ContextWithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
+
+// UserIDFromContext returns the ID of the authenticated caller or an
+// unauthorized error when the request carries no principal.
+func UserIDFromContext(ctx context.Context) (string, error) {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || principal.UserID == "" {
+		return "", errors.NewUnauthorizedError("request is not authenticated")
+	}
+	return principal.UserID, nil
+}
+
+func contextWithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenKey{}, token)
+}
+
+func tokenFromContext(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(tokenKey{}).(string)
+	return token, ok && token != ""
+}
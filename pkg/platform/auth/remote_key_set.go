@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+const (
+	DefaultJWKSRefreshInterval = 5 * time.Minute
+	// minJWKSRefreshInterval throttles refetches triggered by unknown key IDs.
+	minJWKSRefreshInterval = 10 * time.Second
+)
+
+// RemoteKeySet resolves public keys by key ID from a JWKS endpoint. Keys are
+// cached and refetched periodically or when an unknown key ID is requested,
+// which is how key rotation is picked up.
+type RemoteKeySet struct {
+	url             string
+	client          *http.Client
+	refreshInterval time.Duration
+
+	mx        sync.RWMutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+func NewRemoteKeySet(url string, refreshInterval time.Duration) *RemoteKeySet {
+	if refreshInterval <= 0 {
+		refreshInterval = DefaultJWKSRefreshInterval
+	}
+
+	return &RemoteKeySet{
+		url:             url,
+		client:          &http.Client{Timeout: 5 * time.Second},
+		refreshInterval: refreshInterval,
+		keys:            make(map[string]crypto.PublicKey),
+	}
+}
+
+// Key returns the public key published under keyID.
+func (s *RemoteKeySet) Key(ctx context.Context, keyID string) (crypto.PublicKey, error) {
+	s.mx.RLock()
+	key, ok := s.keys[keyID]
+	stale := time.Since(s.fetchedAt) > s.refreshInterval
+	recent := time.Since(s.fetchedAt) < minJWKSRefreshInterval
+	s.mx.RUnlock()
+
+	if ok && !stale {
+		return key, nil
+	}
+
+	if !ok && recent {
+		return nil, fmt.Errorf("unknown key ID %q", keyID)
+	}
+
+	if err := s.refresh(ctx); err != nil {
+		if ok {
+			// Keep serving a known key if the issuer is briefly unavailable.
+			return key, nil
+		}
+		return nil, err
+	}
+
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+
+	key, ok = s.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown key ID %q", keyID)
+	}
+	return key, nil
+}
+
+func (s *RemoteKeySet) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return errors.NewInternalError(err, "failed to build JWKS request")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.NewServiceError(err, "failed to fetch JWKS")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.NewServiceError(nil, "failed to fetch JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	var set JWKSet
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return errors.NewServiceError(err, "failed to decode JWKS")
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		key, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.KeyID] = key
+	}
+
+	s.mx.Lock()
+	s.keys = keys
+	s.fetchedAt = time.Now()
+	s.mx.Unlock()
+
+	return nil
+}
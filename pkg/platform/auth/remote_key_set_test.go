@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// backdate makes the key set look fetched d ago.
+func backdate(s *RemoteKeySet, d time.Duration) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.fetchedAt = s.fetchedAt.Add(-d)
+}
+
+func TestRemoteKeySet_CachesKeys(t *testing.T) {
+	keys := newJWKSServer(t)
+	keys.addKey("key-1")
+	set := NewRemoteKeySet(keys.server.URL, time.Minute)
+
+	for range 3 {
+		_, err := set.Key(context.Background(), "key-1")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, keys.requestCount())
+}
+
+func TestRemoteKeySet_PicksUpRotatedKey(t *testing.T) {
+	ctx := context.Background()
+	keys := newJWKSServer(t)
+	keys.addKey("key-1")
+	set := NewRemoteKeySet(keys.server.URL, time.Hour)
+
+	_, err := set.Key(ctx, "key-1")
+	require.NoError(t, err)
+
+	keys.addKey("key-2")
+
+	// Unknown key IDs refetch at most once per minJWKSRefreshInterval, so
+	// made-up key IDs cannot be used to flood the auth service.
+	_, err = set.Key(ctx, "key-2")
+	assert.Error(t, err)
+	assert.Equal(t, 1, keys.requestCount())
+
+	backdate(set, minJWKSRefreshInterval)
+	_, err = set.Key(ctx, "key-2")
+	require.NoError(t, err)
+	assert.Equal(t, 2, keys.requestCount())
+
+	// The retired key stays usable while it is published.
+	_, err = set.Key(ctx, "key-1")
+	assert.NoError(t, err)
+}
+
+func TestRemoteKeySet_DropsUnpublishedKey(t *testing.T) {
+	ctx := context.Background()
+	keys := newJWKSServer(t)
+	keys.addKey("key-1")
+	keys.addKey("key-2")
+	set := NewRemoteKeySet(keys.server.URL, time.Minute)
+
+	_, err := set.Key(ctx, "key-1")
+	require.NoError(t, err)
+
+	keys.removeKey("key-1")
+	backdate(set, time.Minute+time.Second)
+
+	_, err = set.Key(ctx, "key-1")
+	assert.Error(t, err)
+	_, err = set.Key(ctx, "key-2")
+	assert.NoError(t, err)
+}
+
+func TestRemoteKeySet_IssuerUnavailable(t *testing.T) {
+	ctx := context.Background()
+	keys := newJWKSServer(t)
+	keys.addKey("key-1")
+	set := NewRemoteKeySet(keys.server.URL, time.Minute)
+
+	_, err := set.Key(ctx, "key-1")
+	require.NoError(t, err)
+
+	keys.setUnavailable(true)
+	backdate(set, time.Minute+time.Second)
+
+	// Known keys keep working through an outage, unknown ones cannot be
+	// resolved.
+	_, err = set.Key(ctx, "key-1")
+	assert.NoError(t, err)
+	_, err = set.Key(ctx, "key-2")
+	assert.Error(t, err)
+}
@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"context"
+	"slices"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// Role is a coarse-grained permission granted to a user by the auth service.
+type Role string
+
+const (
+	RoleUser      Role = "user"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+// RolesFromStrings converts role names received over the wire.
+func RolesFromStrings(names []string) []Role {
+	roles := make([]Role, 0, len(names))
+	for _, name := range names {
+		roles = append(roles, Role(name))
+	}
+	return roles
+}
+
+// HasRole reports whether the principal holds at least one of roles.
+func (p *Principal) HasRole(roles ...Role) bool {
+	for _, role := range roles {
+		if slices.Contains(p.Roles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// RequireRole returns nil when the caller holds at least one of roles. It is
+// meant to be called first thing in handlers of privileged RPCs, which keeps
+// the check in place for both gRPC and in-process gateway requests.
+func RequireRole(ctx context.Context, roles ...Role) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || principal.UserID == "" {
+		return errors.NewUnauthorizedError("request is not authenticated")
+	}
+
+	if !principal.HasRole(roles...) {
+		return errors.NewForbiddenError("caller lacks required role").
+			WithDetails("required_roles", roles)
+	}
+	return nil
+}
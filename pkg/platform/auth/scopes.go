@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"context"
+	"slices"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// Scope limits what an API key may do. Session tokens carry no scopes and
+// are not limited.
+type Scope string
+
+const (
+	ScopeChatRead     Scope = "chat:read"
+	ScopeChatWrite    Scope = "chat:write"
+	ScopeFriendsRead  Scope = "friends:read"
+	ScopeFriendsWrite Scope = "friends:write"
+	ScopeUsersWrite   Scope = "users:write"
+)
+
+var knownScopes = []Scope{ScopeChatRead, ScopeChatWrite, ScopeFriendsRead, ScopeFriendsWrite, ScopeUsersWrite}
+
+// IsKnown reports whether scope is one of the scopes above, which are the
+// only ones an API key can be created with.
+func (s Scope) IsKnown() bool {
+	return slices.Contains(knownScopes, s)
+}
+
+func (s Scope) String() string {
+	return string(s)
+}
+
+// ScopesFromStrings converts scope names received over the wire.
+func ScopesFromStrings(names []string) []Scope {
+	if len(names) == 0 {
+		return nil
+	}
+
+	scopes := make([]Scope, 0, len(names))
+	for _, name := range names {
+		scopes = append(scopes, Scope(name))
+	}
+	return scopes
+}
+
+// IsScoped reports whether the principal authenticated with a scoped
+// credential such as an API key.
+func (p *Principal) IsScoped() bool {
+	return len(p.Scopes) > 0
+}
+
+// HasScope reports whether the principal may act within scope.
+func (p *Principal) HasScope(scope Scope) bool {
+	return !p.IsScoped() || slices.Contains(p.Scopes, scope)
+}
+
+// RequireScope returns nil when the caller may act within scope. Like
+// RequireRole it is called first thing in handlers. Lookups that services
+// make on behalf of the caller, such as reading profiles or checking a
+// friendship, need no scope.
+func RequireScope(ctx context.Context, scope Scope) error {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok || principal.UserID == "" {
+		return errors.NewUnauthorizedError("request is not authenticated")
+	}
+
+	if !principal.HasScope(scope) {
+		return errors.NewForbiddenError("API key lacks required scope").
+			WithDetails("required_scope", scope)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	authorizationHeader = "authorization"
+	// gatewayAuthorizationHeader is how grpc-gateway forwards the HTTP
+	// Authorization header when it proxies to a remote gRPC endpoint.
+	gatewayAuthorizationHeader = "grpcgateway-authorization"
+	bearerScheme               = "bearer"
+	// serviceSecretHeader carries the secret a service authenticates itself
+	// with when it calls a method registered through WithServiceMethods.
+	serviceSecretHeader = "x-service-secret"
+)
+
+// TokenFromMetadata extracts the bearer token from incoming gRPC metadata.
+func TokenFromMetadata(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", errors.NewUnauthorizedError("missing metadata")
+	}
+
+	for _, key := range []string{authorizationHeader, gatewayAuthorizationHeader} {
+		if values := md.Get(key); len(values) > 0 {
+			return parseBearer(values[0])
+		}
+	}
+
+	return "", errors.NewUnauthorizedError("missing authorization header")
+}
+
+// ServiceSecretFromMetadata returns the service secret sent with the call, or
+// "" when there is none.
+func ServiceSecretFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(serviceSecretHeader); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func parseBearer(header string) (string, error) {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(header), " ")
+	if !ok || !strings.EqualFold(scheme, bearerScheme) {
+		return "", errors.NewUnauthorizedError("authorization header must use the Bearer scheme")
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", errors.NewUnauthorizedError("bearer token is empty")
+	}
+
+	return token, nil
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestParseBearer(t *testing.T) {
+	tests := map[string]struct {
+		header  string
+		want    string
+		wantErr bool
+	}{
+		"bearer token":           {header: "Bearer abc", want: "abc"},
+		"scheme is case blind":   {header: "bearer abc", want: "abc"},
+		"surrounding spaces":     {header: "  Bearer   abc  ", want: "abc"},
+		"other scheme":           {header: "Basic abc", wantErr: true},
+		"missing token":          {header: "Bearer", wantErr: true},
+		"blank token":            {header: "Bearer   ", wantErr: true},
+		"token without a scheme": {header: "abc", wantErr: true},
+		"empty header":           {header: "", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseBearer(tc.header)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestTokenFromMetadata(t *testing.T) {
+	tests := map[string]struct {
+		ctx     context.Context
+		want    string
+		wantErr bool
+	}{
+		"authorization header": {
+			ctx:  metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer abc")),
+			want: "abc",
+		},
+		"header forwarded by the gateway": {
+			ctx:  metadata.NewIncomingContext(context.Background(), metadata.Pairs("grpcgateway-authorization", "Bearer abc")),
+			want: "abc",
+		},
+		"no authorization header": {
+			ctx:     metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-other", "value")),
+			wantErr: true,
+		},
+		"no metadata": {
+			ctx:     context.Background(),
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := TokenFromMetadata(tc.ctx)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
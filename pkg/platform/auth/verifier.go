@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	// VerifierIntrospection asks the auth service about every token.
+	VerifierIntrospection = "introspection"
+	// VerifierJWKS checks signed JWTs offline against the keys published by
+	// the auth service.
+	VerifierJWKS = "jwks"
+)
+
+// VerifierConfig selects how a service verifies the tokens it receives.
+type VerifierConfig struct {
+	// Type is VerifierIntrospection, the default, or VerifierJWKS.
+	Type string
+	// JWKSURL is the JWKS endpoint of the auth service.
+	JWKSURL string
+	// JWKSRefreshInterval is how often the published keys are refetched.
+	JWKSRefreshInterval time.Duration
+	// ServiceSecret authenticates the service to the auth service when it
+	// introspects tokens.
+	ServiceSecret string
+}
+
+// NewTokenVerifier returns the verifier selected by cfg. conn reaches the
+// auth service and is used for introspection only.
+//
+// VerifierJWKS saves a round trip per request but skips everything only the
+// auth service knows. A token keeps working until it expires after logout,
+// session revocation or account suspension. Impersonation tokens, API keys
+// and opaque tokens are rejected, so the auth service must issue JWTs and
+// administrators cannot act as users of such a service.
+func NewTokenVerifier(cfg VerifierConfig, conn grpc.ClientConnInterface) (TokenVerifier, error) {
+	switch cfg.Type {
+	case "", VerifierIntrospection:
+		if cfg.ServiceSecret == "" {
+			return nil, fmt.Errorf("service secret is required for the %s verifier", VerifierIntrospection)
+		}
+		return NewIntrospectionVerifier(conn, cfg.ServiceSecret), nil
+	case VerifierJWKS:
+		if cfg.JWKSURL == "" {
+			return nil, fmt.Errorf("JWKS URL is required for the %s verifier", VerifierJWKS)
+		}
+		return NewJWKSVerifier(cfg.JWKSURL, cfg.JWKSRefreshInterval), nil
+	default:
+		return nil, fmt.Errorf("unsupported token verifier %q", cfg.Type)
+	}
+}
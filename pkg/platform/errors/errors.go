@@ -3,6 +3,7 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 var (
@@ -12,6 +13,10 @@ var (
 	ErrInvalidInput  = errors.New("invalid input")
 	ErrForbidden     = errors.New("forbidden")
 
+	// ErrConflict reports a write based on a version of a resource that has
+	// changed since it was read.
+	ErrConflict = errors.New("conflict")
+
 	ErrDatabaseConnection = errors.New("database connection error")
 	ErrDatabaseQuery      = errors.New("database query error")
 
@@ -19,6 +24,8 @@ var (
 
 	ErrTimeout = errors.New("operation timeout")
 
+	ErrResourceExhausted = errors.New("resource exhausted")
+
 	ErrTokenExpired = errors.New("token expired")
 	ErrInvalidToken = errors.New("invalid token")
 )
@@ -34,8 +41,25 @@ const (
 	CodeToken         = "TOKEN"
 	CodeService       = "SERVICE"
 	CodeForbidden     = "FORBIDDEN"
+	CodeConflict      = "CONFLICT"
+
+	CodeResourceExhausted = "RESOURCE_EXHAUSTED"
 )
 
+// DetailRetryAfter is the AppError detail holding how long a client should
+// wait before retrying, as a time.Duration.
+const DetailRetryAfter = "retry_after"
+
+// DetailFieldViolations is the AppError detail listing what is wrong with
+// the request, as a []FieldViolation.
+const DetailFieldViolations = "field_violations"
+
+// FieldViolation describes why a single request field was rejected.
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
 type AppError struct {
 	Err       error
 	Message   string
@@ -70,6 +94,10 @@ func Is(err, target error) bool {
 	return errors.Is(err, target)
 }
 
+func As(err error, target any) bool {
+	return errors.As(err, target)
+}
+
 func NewNotFoundError(format string, args ...interface{}) *AppError {
 	return &AppError{
 		Err:     ErrNotFound,
@@ -149,3 +177,21 @@ func NewForbiddenError(format string, args ...interface{}) *AppError {
 		Code:    CodeForbidden,
 	}
 }
+
+func NewConflictError(format string, args ...interface{}) *AppError {
+	return &AppError{
+		Err:     ErrConflict,
+		Message: fmt.Sprintf(format, args...),
+		Code:    CodeConflict,
+	}
+}
+
+func NewResourceExhaustedError(retryAfter time.Duration, format string, args ...interface{}) *AppError {
+	return &AppError{
+		Err:       ErrResourceExhausted,
+		Message:   fmt.Sprintf(format, args...),
+		Code:      CodeResourceExhausted,
+		Details:   map[string]interface{}{DetailRetryAfter: retryAfter},
+		Retriable: true,
+	}
+}
@@ -0,0 +1,197 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/Shopify/sarama"
+)
+
+// EventTypeHeader names the protobuf message carried by a record.
+const EventTypeHeader = "event-type"
+
+const (
+	DefaultRetryBackoff    = time.Second
+	DefaultMaxRetryBackoff = time.Minute
+)
+
+// Handler processes one record. A returned error is retried with backoff
+// and the record's offset is not committed until the handler succeeds, so
+// handlers must be idempotent. Records that can never be processed, such as
+// malformed payloads, should be logged and acknowledged by returning nil.
+type Handler func(ctx context.Context, msg *sarama.ConsumerMessage) error
+
+type ConsumerConfig struct {
+	Brokers []string
+	Group   string
+	Topics  []string
+	// InitialOffset is where a group without committed offsets starts:
+	// sarama.OffsetOldest or sarama.OffsetNewest. It defaults to
+	// OffsetOldest so that a new group does not miss earlier events.
+	InitialOffset int64
+	// RetryBackoff is the delay before the first retry of a failed record;
+	// it doubles with every further failure up to MaxRetryBackoff.
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+}
+
+// Consumer delivers the records of a consumer group to a Handler one at a
+// time per partition.
+type Consumer struct {
+	consumerGroup sarama.ConsumerGroup
+	handler       Handler
+	cfg           ConsumerConfig
+	logger        logger.Logger
+	canceller     func()
+}
+
+func NewConsumer(cfg ConsumerConfig, handler Handler, logger logger.Logger) (*Consumer, error) {
+	if cfg.InitialOffset == 0 {
+		cfg.InitialOffset = sarama.OffsetOldest
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = DefaultRetryBackoff
+	}
+	if cfg.MaxRetryBackoff <= 0 {
+		cfg.MaxRetryBackoff = DefaultMaxRetryBackoff
+	}
+
+	config := sarama.NewConfig()
+	config.Consumer.Return.Errors = true
+	config.Consumer.Offsets.Initial = cfg.InitialOffset
+	config.Consumer.Group.Rebalance.Strategy = sarama.BalanceStrategyRoundRobin
+
+	consumerGroup, err := sarama.NewConsumerGroup(cfg.Brokers, cfg.Group, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka consumer group: %w", err)
+	}
+
+	return &Consumer{
+		consumerGroup: consumerGroup,
+		handler:       handler,
+		cfg:           cfg,
+		logger:        logger.With("component", "kafka_consumer", "group", cfg.Group),
+	}, nil
+}
+
+// Start joins the consumer group and returns once the first session is set
+// up. Records are consumed in the background until ctx is cancelled or Close
+// is called.
+func (c *Consumer) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	c.canceller = cancel
+
+	ready := make(chan struct{})
+	handler := &groupHandler{consumer: c, ready: ready}
+
+	go func() {
+		for err := range c.consumerGroup.Errors() {
+			c.logger.Error("Kafka consumer error", "error", err)
+		}
+	}()
+
+	go func() {
+		for {
+			if err := c.consumerGroup.Consume(ctx, c.cfg.Topics, handler); err != nil {
+				c.logger.Error("Kafka consumer group session failed", "error", err)
+			}
+			if ctx.Err() != nil {
+				c.logger.Info("Kafka consumer stopped")
+				return
+			}
+		}
+	}()
+
+	select {
+	case <-ready:
+		c.logger.Info("Kafka consumer started", "topics", c.cfg.Topics)
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Consumer) Close() error {
+	if c.canceller != nil {
+		c.canceller()
+	}
+	return c.consumerGroup.Close()
+}
+
+// handle runs the handler until it succeeds. It returns false when the
+// session ends first, in which case the record is left unmarked and will be
+// delivered again.
+func (c *Consumer) handle(ctx context.Context, msg *sarama.ConsumerMessage) bool {
+	for attempt := 0; ; attempt++ {
+		err := c.handler(ctx, msg)
+		if err == nil {
+			return true
+		}
+
+		backoff := c.retryBackoff(attempt)
+		c.logger.Error("Failed to handle Kafka record",
+			"topic", msg.Topic, "partition", msg.Partition, "offset", msg.Offset,
+			"attempts", attempt+1, "retry_in", backoff, "error", err)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return false
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Consumer) retryBackoff(attempts int) time.Duration {
+	backoff := c.cfg.RetryBackoff
+	for i := 0; i < attempts && backoff < c.cfg.MaxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, c.cfg.MaxRetryBackoff)
+}
+
+type groupHandler struct {
+	consumer *Consumer
+	ready    chan struct{}
+	started  bool
+}
+
+func (h *groupHandler) Setup(session sarama.ConsumerGroupSession) error {
+	h.consumer.logger.Debug("Kafka consumer session set up", "member_id", session.MemberID())
+	if !h.started {
+		h.started = true
+		close(h.ready)
+	}
+	return nil
+}
+
+func (h *groupHandler) Cleanup(session sarama.ConsumerGroupSession) error {
+	h.consumer.logger.Debug("Kafka consumer session cleaned up", "member_id", session.MemberID())
+	return nil
+}
+
+func (h *groupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for msg := range claim.Messages() {
+		h.consumer.logger.Debug("Received Kafka record",
+			"topic", msg.Topic, "partition", msg.Partition, "offset", msg.Offset)
+
+		if !h.consumer.handle(session.Context(), msg) {
+			return nil
+		}
+		session.MarkMessage(msg, "")
+	}
+	return nil
+}
+
+// EventType returns the EventTypeHeader of msg, or "" without one.
+func EventType(msg *sarama.ConsumerMessage) string {
+	for _, header := range msg.Headers {
+		if header != nil && string(header.Key) == EventTypeHeader {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
@@ -10,6 +10,7 @@ import (
 type QueryEngine interface {
 	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
 	GetContext(ctx context.Context, dest any, query string, args ...any) error
+	SelectContext(ctx context.Context, dest any, query string, args ...any) error
 }
 
 type DB struct {
@@ -24,8 +25,13 @@ func (db *DB) GetContext(ctx context.Context, dest any, query string, args ...an
 	return db.DB.GetContext(ctx, dest, query, args...)
 }
 
+func (db *DB) SelectContext(ctx context.Context, dest any, query string, args ...any) error {
+	return db.DB.SelectContext(ctx, dest, query, args...)
+}
+
 type Tx struct {
 	*sqlx.Tx
+	afterCommit []func(ctx context.Context)
 }
 
 func (tx *Tx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
@@ -36,6 +42,10 @@ func (tx *Tx) GetContext(ctx context.Context, dest any, query string, args ...an
 	return tx.Tx.GetContext(ctx, dest, query, args...)
 }
 
+func (tx *Tx) SelectContext(ctx context.Context, dest any, query string, args ...any) error {
+	return tx.Tx.SelectContext(ctx, dest, query, args...)
+}
+
 func NewDB(dsn string) (*DB, error) {
 	db, err := sqlx.Connect("postgres", dsn)
 	if err != nil {
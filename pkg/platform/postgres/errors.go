@@ -0,0 +1,13 @@
+package postgres
+
+import "errors"
+
+// uniqueViolation is the SQLSTATE of a unique constraint violation.
+const uniqueViolation = "23505"
+
+// IsUniqueViolation reports whether err was caused by a unique constraint.
+// It works with any driver whose errors report their SQLSTATE.
+func IsUniqueViolation(err error) bool {
+	var state interface{ SQLState() string }
+	return errors.As(err, &state) && state.SQLState() == uniqueViolation
+}
@@ -2,4 +2,17 @@ module github.com/SamEkb/messenger-app/pkg/platform/postgres
 
 go 1.24
 
-require github.com/jmoiron/sqlx v1.4.0
+require (
+	github.com/SamEkb/messenger-app/pkg/platform/logger v0.0.0-00010101000000-000000000000
+	github.com/jmoiron/sqlx v1.4.0
+	github.com/lib/pq v1.10.9
+	github.com/stretchr/testify v1.10.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/SamEkb/messenger-app/pkg/platform/logger => ../logger
@@ -0,0 +1,276 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+)
+
+// Outbox implements the transactional outbox pattern: messages are written
+// to the outbox table in the same transaction as the state change that
+// produced them, and OutboxRelay delivers them to the broker after commit.
+// A service using it needs the following table:
+//
+//	CREATE TABLE outbox
+//	(
+//	    id              BIGSERIAL PRIMARY KEY,
+//	    topic           TEXT        NOT NULL,
+//	    key             TEXT        NOT NULL DEFAULT '',
+//	    headers         JSONB       NOT NULL DEFAULT '{}',
+//	    payload         BYTEA       NOT NULL,
+//	    attempts        INT         NOT NULL DEFAULT 0,
+//	    last_error      TEXT,
+//	    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+//	    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+//	    sent_at         TIMESTAMPTZ
+//	);
+type Outbox struct {
+	txManager *TxManager
+}
+
+// OutboxMessage is a message waiting in the outbox. Messages with the same
+// key are delivered in the order they were enqueued.
+type OutboxMessage struct {
+	ID        int64
+	Topic     string
+	Key       string
+	Headers   map[string]string
+	Payload   []byte
+	Attempts  int
+	CreatedAt time.Time
+}
+
+func NewOutbox(txManager *TxManager) *Outbox {
+	return &Outbox{txManager: txManager}
+}
+
+// Enqueue stores a message for delivery. It must be called inside RunTx for
+// the message to be committed or rolled back together with the caller's
+// changes.
+func (o *Outbox) Enqueue(ctx context.Context, msg *OutboxMessage) error {
+	headers, err := json.Marshal(msg.Headers)
+	if err != nil {
+		return fmt.Errorf("failed to encode outbox headers: %w", err)
+	}
+
+	q := o.txManager.GetQueryEngine(ctx)
+	_, err = q.ExecContext(ctx, `
+		INSERT INTO outbox (topic, key, headers, payload)
+		VALUES ($1, $2, $3, $4)
+	`, msg.Topic, msg.Key, string(headers), msg.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue outbox message: %w", err)
+	}
+	return nil
+}
+
+// OutboxPublisher delivers outbox messages to the broker.
+type OutboxPublisher interface {
+	Publish(ctx context.Context, msg *OutboxMessage) error
+}
+
+const (
+	DefaultOutboxPollInterval    = time.Second
+	DefaultOutboxBatchSize       = 100
+	DefaultOutboxRetryBackoff    = time.Second
+	DefaultOutboxMaxRetryBackoff = 5 * time.Minute
+	DefaultOutboxRetention       = 7 * 24 * time.Hour
+	DefaultOutboxPublishTimeout  = 5 * time.Second
+)
+
+type OutboxRelayConfig struct {
+	// PollInterval is how often pending messages are looked up.
+	PollInterval time.Duration
+	// BatchSize caps the messages delivered per poll.
+	BatchSize int
+	// RetryBackoff is the delay before the first retry of a failed message;
+	// it doubles with every further failure up to MaxRetryBackoff.
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+	// Retention is how long sent messages are kept before they are deleted.
+	Retention time.Duration
+	// PublishTimeout caps a single publish. A message that times out is
+	// retried like any other failed publish.
+	PublishTimeout time.Duration
+}
+
+// OutboxRelay polls the outbox and publishes pending messages. Several
+// replicas may run a relay at once; rows are claimed with SKIP LOCKED.
+//
+// A batch is published while its rows stay locked, so that a message is
+// marked sent in the same transaction that claimed it. Each publish is
+// bounded by PublishTimeout, which caps the locks at roughly BatchSize
+// times PublishTimeout per batch. The locks only make other relays skip
+// those rows: Enqueue inserts new rows and is never blocked by them.
+type OutboxRelay struct {
+	txManager *TxManager
+	publisher OutboxPublisher
+	cfg       OutboxRelayConfig
+	logger    logger.Logger
+}
+
+func NewOutboxRelay(txManager *TxManager, publisher OutboxPublisher, cfg OutboxRelayConfig, logger logger.Logger) *OutboxRelay {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultOutboxPollInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultOutboxBatchSize
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = DefaultOutboxRetryBackoff
+	}
+	if cfg.MaxRetryBackoff <= 0 {
+		cfg.MaxRetryBackoff = DefaultOutboxMaxRetryBackoff
+	}
+	if cfg.Retention <= 0 {
+		cfg.Retention = DefaultOutboxRetention
+	}
+	if cfg.PublishTimeout <= 0 {
+		cfg.PublishTimeout = DefaultOutboxPublishTimeout
+	}
+
+	return &OutboxRelay{
+		txManager: txManager,
+		publisher: publisher,
+		cfg:       cfg,
+		logger:    logger.With("component", "outbox_relay"),
+	}
+}
+
+// Run delivers pending messages until ctx is cancelled.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				claimed, err := r.relayBatch(ctx)
+				if err != nil {
+					r.logger.Error("failed to relay outbox messages", "error", err)
+					break
+				}
+				// Keep draining while full batches come back.
+				if claimed < r.cfg.BatchSize || ctx.Err() != nil {
+					break
+				}
+			}
+
+			if err := r.deleteSent(ctx); err != nil {
+				r.logger.Error("failed to delete sent outbox messages", "error", err)
+			}
+		}
+	}
+}
+
+type outboxRow struct {
+	ID        int64     `db:"id"`
+	Topic     string    `db:"topic"`
+	Key       string    `db:"key"`
+	Headers   []byte    `db:"headers"`
+	Payload   []byte    `db:"payload"`
+	Attempts  int       `db:"attempts"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// relayBatch publishes one batch of due messages and returns how many of
+// them were claimed.
+func (r *OutboxRelay) relayBatch(ctx context.Context) (int, error) {
+	var claimed int
+
+	err := r.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		q := r.txManager.GetQueryEngine(txCtx)
+
+		// A keyed message is due only when no earlier message with the same
+		// key is still pending, which keeps per-key ordering across retries.
+		var rows []outboxRow
+		err := q.SelectContext(txCtx, &rows, `
+			SELECT o.id, o.topic, o.key, o.headers, o.payload, o.attempts, o.created_at
+			FROM outbox o
+			WHERE o.sent_at IS NULL
+			  AND o.next_attempt_at <= now()
+			  AND (o.key = '' OR NOT EXISTS (
+			      SELECT 1 FROM outbox p
+			      WHERE p.key = o.key AND p.sent_at IS NULL AND p.id < o.id
+			  ))
+			ORDER BY o.id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		`, r.cfg.BatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to select outbox messages: %w", err)
+		}
+		claimed = len(rows)
+
+		for _, row := range rows {
+			msg := &OutboxMessage{
+				ID:        row.ID,
+				Topic:     row.Topic,
+				Key:       row.Key,
+				Payload:   row.Payload,
+				Attempts:  row.Attempts,
+				CreatedAt: row.CreatedAt,
+			}
+			if err := json.Unmarshal(row.Headers, &msg.Headers); err != nil {
+				r.logger.Error("invalid outbox headers", "id", row.ID, "error", err)
+			}
+
+			if publishErr := r.publish(txCtx, msg); publishErr != nil {
+				backoff := r.retryBackoff(row.Attempts)
+				r.logger.Warn("failed to publish outbox message",
+					"id", row.ID, "topic", row.Topic, "attempts", row.Attempts+1, "retry_in", backoff, "error", publishErr)
+
+				_, err = q.ExecContext(txCtx, `
+					UPDATE outbox
+					SET attempts = attempts + 1,
+					    last_error = $2,
+					    next_attempt_at = now() + make_interval(secs => $3)
+					WHERE id = $1
+				`, row.ID, publishErr.Error(), backoff.Seconds())
+				if err != nil {
+					return fmt.Errorf("failed to record outbox failure: %w", err)
+				}
+				continue
+			}
+
+			_, err = q.ExecContext(txCtx, `UPDATE outbox SET sent_at = now(), last_error = NULL WHERE id = $1`, row.ID)
+			if err != nil {
+				return fmt.Errorf("failed to mark outbox message sent: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return claimed, nil
+}
+
+func (r *OutboxRelay) publish(ctx context.Context, msg *OutboxMessage) error {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.PublishTimeout)
+	defer cancel()
+	return r.publisher.Publish(ctx, msg)
+}
+
+func (r *OutboxRelay) retryBackoff(attempts int) time.Duration {
+	backoff := r.cfg.RetryBackoff
+	for i := 0; i < attempts && backoff < r.cfg.MaxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, r.cfg.MaxRetryBackoff)
+}
+
+func (r *OutboxRelay) deleteSent(ctx context.Context) error {
+	q := r.txManager.GetQueryEngine(ctx)
+	_, err := q.ExecContext(ctx, `
+		DELETE FROM outbox
+		WHERE sent_at IS NOT NULL AND sent_at < now() - make_interval(secs => $1)
+	`, r.cfg.Retention.Seconds())
+	return err
+}
@@ -0,0 +1,296 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDSNEnv names the database the relay tests run against. They need a
+// real PostgreSQL for FOR UPDATE SKIP LOCKED and are skipped without one.
+const testDSNEnv = "POSTGRES_TEST_DSN"
+
+const outboxTable = `
+	CREATE TABLE outbox
+	(
+	    id              BIGSERIAL PRIMARY KEY,
+	    topic           TEXT        NOT NULL,
+	    key             TEXT        NOT NULL DEFAULT '',
+	    headers         JSONB       NOT NULL DEFAULT '{}',
+	    payload         BYTEA       NOT NULL,
+	    attempts        INT         NOT NULL DEFAULT 0,
+	    last_error      TEXT,
+	    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+	    sent_at         TIMESTAMPTZ
+	)
+`
+
+// newTestTxManager connects to the test database with a fresh schema that
+// holds an empty outbox table and is dropped when the test ends.
+func newTestTxManager(t *testing.T) *TxManager {
+	t.Helper()
+
+	dsn := os.Getenv(testDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testDSNEnv)
+	}
+
+	admin, err := NewDB(dsn)
+	require.NoError(t, err)
+	schema := fmt.Sprintf("outbox_test_%d", time.Now().UnixNano())
+	_, err = admin.ExecContext(context.Background(), "CREATE SCHEMA "+schema)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		admin.ExecContext(context.Background(), "DROP SCHEMA "+schema+" CASCADE")
+		admin.Close()
+	})
+
+	db, err := NewDB(withSearchPath(dsn, schema))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	_, err = db.ExecContext(context.Background(), outboxTable)
+	require.NoError(t, err)
+
+	return NewTxManager(db)
+}
+
+func withSearchPath(dsn, schema string) string {
+	if strings.Contains(dsn, "://") {
+		if strings.Contains(dsn, "?") {
+			return dsn + "&search_path=" + schema
+		}
+		return dsn + "?search_path=" + schema
+	}
+	return dsn + " search_path=" + schema
+}
+
+// fakePublisher records published messages. publish, when set, decides the
+// outcome of each publish.
+type fakePublisher struct {
+	mu        sync.Mutex
+	published []string
+	publish   func(ctx context.Context, msg *OutboxMessage) error
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, msg *OutboxMessage) error {
+	if p.publish != nil {
+		if err := p.publish(ctx, msg); err != nil {
+			return err
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.published = append(p.published, string(msg.Payload))
+	return nil
+}
+
+func (p *fakePublisher) Published() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.published...)
+}
+
+func enqueue(t *testing.T, txManager *TxManager, key, payload string) {
+	t.Helper()
+	err := NewOutbox(txManager).Enqueue(context.Background(), &OutboxMessage{
+		Topic:   "events",
+		Key:     key,
+		Headers: map[string]string{"event-type": "TestEvent"},
+		Payload: []byte(payload),
+	})
+	require.NoError(t, err)
+}
+
+func newTestRelay(txManager *TxManager, publisher OutboxPublisher, cfg OutboxRelayConfig) *OutboxRelay {
+	return NewOutboxRelay(txManager, publisher, cfg, logger.NewMockLogger())
+}
+
+func TestOutboxRelay_PublishesAndMarksSent(t *testing.T) {
+	txManager := newTestTxManager(t)
+	ctx := context.Background()
+
+	enqueue(t, txManager, "user-1", "first")
+	enqueue(t, txManager, "", "unkeyed")
+
+	publisher := &fakePublisher{}
+	relay := newTestRelay(txManager, publisher, OutboxRelayConfig{})
+
+	claimed, err := relay.relayBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, claimed)
+	assert.Equal(t, []string{"first", "unkeyed"}, publisher.Published())
+
+	claimed, err = relay.relayBatch(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, claimed)
+	assert.Len(t, publisher.Published(), 2)
+}
+
+func TestOutboxRelay_ClaimsWithSkipLocked(t *testing.T) {
+	txManager := newTestTxManager(t)
+	ctx := context.Background()
+
+	enqueue(t, txManager, "user-1", "first")
+	enqueue(t, txManager, "user-2", "second")
+
+	// The first relay claims one message and holds its lock until released.
+	claimedCh := make(chan struct{})
+	releaseCh := make(chan struct{})
+	blocking := &fakePublisher{publish: func(ctx context.Context, msg *OutboxMessage) error {
+		close(claimedCh)
+		<-releaseCh
+		return nil
+	}}
+	first := newTestRelay(txManager, blocking, OutboxRelayConfig{BatchSize: 1})
+
+	firstDone := make(chan error, 1)
+	go func() {
+		_, err := first.relayBatch(ctx)
+		firstDone <- err
+	}()
+	<-claimedCh
+
+	// A second relay must skip the locked row instead of waiting for it or
+	// publishing it again.
+	publisher := &fakePublisher{}
+	second := newTestRelay(txManager, publisher, OutboxRelayConfig{})
+	claimed, err := second.relayBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, claimed)
+	assert.Equal(t, []string{"second"}, publisher.Published())
+
+	close(releaseCh)
+	require.NoError(t, <-firstDone)
+	assert.Equal(t, []string{"first"}, blocking.Published())
+}
+
+func TestOutboxRelay_KeepsPerKeyOrder(t *testing.T) {
+	txManager := newTestTxManager(t)
+	ctx := context.Background()
+
+	enqueue(t, txManager, "user-1", "user-1 first")
+	enqueue(t, txManager, "user-1", "user-1 second")
+	enqueue(t, txManager, "user-2", "user-2 first")
+
+	publisher := &fakePublisher{}
+	relay := newTestRelay(txManager, publisher, OutboxRelayConfig{})
+
+	// Only the oldest pending message of a key is due.
+	_, err := relay.relayBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-1 first", "user-2 first"}, publisher.Published())
+
+	_, err = relay.relayBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user-1 first", "user-2 first", "user-1 second"}, publisher.Published())
+}
+
+func TestOutboxRelay_RetriesFailedPublish(t *testing.T) {
+	txManager := newTestTxManager(t)
+	ctx := context.Background()
+
+	enqueue(t, txManager, "user-1", "first")
+	enqueue(t, txManager, "user-1", "second")
+
+	failures := 1
+	publisher := &fakePublisher{publish: func(ctx context.Context, msg *OutboxMessage) error {
+		if failures > 0 {
+			failures--
+			return errors.New("broker unavailable")
+		}
+		return nil
+	}}
+	relay := newTestRelay(txManager, publisher, OutboxRelayConfig{RetryBackoff: time.Hour})
+
+	_, err := relay.relayBatch(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, publisher.Published())
+
+	var failed struct {
+		Attempts  int    `db:"attempts"`
+		LastError string `db:"last_error"`
+		Due       bool   `db:"due"`
+	}
+	err = txManager.GetQueryEngine(ctx).GetContext(ctx, &failed, `
+		SELECT attempts, last_error, next_attempt_at <= now() AS due
+		FROM outbox WHERE payload = $1
+	`, []byte("first"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, failed.Attempts)
+	assert.Equal(t, "broker unavailable", failed.LastError)
+	assert.False(t, failed.Due)
+
+	// Neither the failed message nor the one queued behind it is due before
+	// the backoff has passed.
+	claimed, err := relay.relayBatch(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, claimed)
+
+	_, err = txManager.GetQueryEngine(ctx).ExecContext(ctx, `UPDATE outbox SET next_attempt_at = now()`)
+	require.NoError(t, err)
+
+	_, err = relay.relayBatch(ctx)
+	require.NoError(t, err)
+	_, err = relay.relayBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second"}, publisher.Published())
+}
+
+func TestOutboxRelay_BoundsPublishTime(t *testing.T) {
+	txManager := newTestTxManager(t)
+	ctx := context.Background()
+
+	enqueue(t, txManager, "user-1", "first")
+
+	publisher := &fakePublisher{publish: func(ctx context.Context, msg *OutboxMessage) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+	relay := newTestRelay(txManager, publisher, OutboxRelayConfig{PublishTimeout: 50 * time.Millisecond})
+
+	start := time.Now()
+	claimed, err := relay.relayBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, claimed)
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	var attempts int
+	err = txManager.GetQueryEngine(ctx).GetContext(ctx, &attempts, `SELECT attempts FROM outbox`)
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestOutboxRelay_RetryBackoff(t *testing.T) {
+	relay := newTestRelay(nil, nil, OutboxRelayConfig{
+		RetryBackoff:    time.Second,
+		MaxRetryBackoff: 10 * time.Second,
+	})
+
+	tests := map[string]struct {
+		attempts int
+		want     time.Duration
+	}{
+		"first failure":     {attempts: 0, want: time.Second},
+		"doubles":           {attempts: 2, want: 4 * time.Second},
+		"capped":            {attempts: 4, want: 10 * time.Second},
+		"capped after many": {attempts: 100, want: 10 * time.Second},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, relay.retryBackoff(tc.attempts))
+		})
+	}
+}
@@ -33,7 +33,23 @@ func (tm *TxManager) RunTx(ctx context.Context, fn func(ctx context.Context) err
 		tx.Rollback()
 		return err
 	}
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	for _, hook := range txWrap.afterCommit {
+		hook(ctx)
+	}
+	return nil
+}
+
+// AfterCommit runs fn once the transaction in ctx has committed, and not at
+// all if it rolls back. Outside a transaction fn runs immediately.
+func (tm *TxManager) AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	if tx, ok := ctx.Value(txKey).(*Tx); ok {
+		tx.afterCommit = append(tx.afterCommit, fn)
+		return
+	}
+	fn(ctx)
 }
 
 func (tm *TxManager) GetQueryEngine(ctx context.Context) QueryEngine {
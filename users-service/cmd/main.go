@@ -3,13 +3,23 @@ package main
 import (
 	"context"
 
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
 	"github.com/SamEkb/messenger-app/pkg/platform/logger"
 	postgreslib "github.com/SamEkb/messenger-app/pkg/platform/postgres"
 	"github.com/SamEkb/messenger-app/users-service/config/env"
 	"github.com/SamEkb/messenger-app/users-service/internal/app/adapters/in/grpc"
 	"github.com/SamEkb/messenger-app/users-service/internal/app/adapters/in/kafka"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/adapters/out/blob"
+	grpcclient "github.com/SamEkb/messenger-app/users-service/internal/app/adapters/out/grpc"
+	kafkaproducer "github.com/SamEkb/messenger-app/users-service/internal/app/adapters/out/kafka"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
+	presencememory "github.com/SamEkb/messenger-app/users-service/internal/app/repositories/presence/in_memory"
+	presenceredis "github.com/SamEkb/messenger-app/users-service/internal/app/repositories/presence/redis"
 	"github.com/SamEkb/messenger-app/users-service/internal/app/repositories/user/postgres"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/usecases/presence"
 	"github.com/SamEkb/messenger-app/users-service/internal/app/usecases/user"
+	"github.com/redis/go-redis/v9"
 )
 
 func main() {
@@ -30,12 +40,48 @@ func main() {
 	}
 	txManager := postgreslib.NewTxManager(db)
 
+	blobStore, err := blob.NewLocalStore(config.Media.Dir, config.Media.BaseURL)
+	if err != nil {
+		log.Fatal("failed to create blob store", "error", err)
+	}
+
+	profileEventPublisher, err := kafkaproducer.NewProfileEventsKafkaProducer(config.Kafka, log)
+	if err != nil {
+		log.Fatal("failed to create Kafka producer", "error", err)
+	}
+	defer profileEventPublisher.Close()
+
+	profileEventOutbox := kafkaproducer.NewProfileEventsOutbox(postgreslib.NewOutbox(txManager), config.Kafka.ProfileTopic)
+	outboxRelay := postgreslib.NewOutboxRelay(txManager, profileEventPublisher, postgreslib.OutboxRelayConfig{
+		PollInterval:    config.Outbox.PollInterval,
+		BatchSize:       config.Outbox.BatchSize,
+		RetryBackoff:    config.Outbox.RetryBackoff,
+		MaxRetryBackoff: config.Outbox.MaxRetryBackoff,
+		Retention:       config.Outbox.Retention,
+		PublishTimeout:  config.Outbox.PublishTimeout,
+	}, log)
+	go outboxRelay.Run(ctx)
+
 	usersRepo := postgres.NewUserRepository(txManager, log)
-	userUseCase := user.NewUseCase(usersRepo, txManager, log)
+	userUseCase := user.NewUseCase(usersRepo, txManager, blobStore, profileEventOutbox, models.AvatarPolicy{
+		MaxBytes:     config.Media.MaxAvatarBytes,
+		MaxDimension: config.Media.MaxAvatarDimension,
+	}, log)
+
+	var presenceRepo ports.PresenceRepository = presencememory.NewPresenceRepository(log)
+	if config.Presence.Store == env.PresenceStoreRedis {
+		redisClient := redis.NewClient(&redis.Options{
+			Addr:     config.Redis.Addr,
+			Password: config.Redis.Password,
+			DB:       config.Redis.DB,
+		})
+		defer redisClient.Close()
+		presenceRepo = presenceredis.NewPresenceRepository(redisClient, log)
+	}
 
 	kafkaServer := kafka.NewUsersServiceServer(userUseCase, log)
 
-	consumer, err := kafka.NewConsumerWithConfig(kafkaServer, config.Kafka)
+	consumer, err := kafka.NewUserEventConsumer(kafkaServer, config.Kafka)
 	if err != nil {
 		log.Fatal("failed to create Kafka consumer", "error", err)
 	}
@@ -45,7 +91,35 @@ func main() {
 	}
 	defer consumer.Close()
 
-	grpcServer, err := grpc.NewServer(config.Server, userUseCase, log)
+	client := grpcclient.NewClient(config.Clients, log)
+	authConn, err := client.NewAuthServiceConn(ctx)
+	if err != nil {
+		log.Fatal("failed to connect to Auth Service", "error", err)
+	}
+	defer authConn.Close()
+	verifier, err := platformauth.NewTokenVerifier(platformauth.VerifierConfig{
+		Type:                config.TokenVerifier.Type,
+		JWKSURL:             config.TokenVerifier.JWKSURL,
+		JWKSRefreshInterval: config.TokenVerifier.JWKSRefreshInterval,
+		ServiceSecret:       config.TokenVerifier.ServiceSecret,
+	}, authConn)
+	if err != nil {
+		log.Fatal("failed to create token verifier", "error", err)
+	}
+
+	friendsClient, err := client.NewFriendsServiceClient(ctx)
+	if err != nil {
+		log.Fatal("failed to create Friends Service client", "error", err)
+	}
+	defer friendsClient.Close()
+
+	presenceUseCase := presence.NewUseCase(presenceRepo, usersRepo, friendsClient, models.PresencePolicy{
+		TTL:                       config.Presence.TTL,
+		PollInterval:              config.Presence.PollInterval,
+		VisibilityRecheckInterval: config.Presence.VisibilityRecheckInterval,
+	}, log)
+
+	grpcServer, err := grpc.NewServer(config.Server, config.Media, userUseCase, presenceUseCase, blobStore, verifier, log)
 	if err != nil {
 		log.Fatal("failed to create grpc server", "error", err)
 	}
@@ -16,16 +16,48 @@ const (
 	DefaultHTTPPort           = 8004
 	DefaultKafkaBroker        = "localhost:9092"
 	DefaultKafkaTopic         = "user-events"
+	DefaultKafkaProfileTopic  = "profile-events"
 	DefaultKafkaRetryInterval = 5 * time.Second
 	DefaultKafkaMaxRetry      = 3
+	DefaultMaxAvatarBytes     = 5 << 20
+	DefaultMaxAvatarDimension = 4096
+	DefaultPresenceStore      = PresenceStoreMemory
+	DefaultPresenceTTL        = time.Minute
+	DefaultPresencePoll       = 5 * time.Second
+	DefaultPresenceRecheck    = time.Minute
+	DefaultRedisAddr          = "localhost:6379"
+	DefaultOutboxPollInterval = time.Second
+	DefaultOutboxBatchSize    = 100
+	DefaultOutboxRetryBackoff = time.Second
+	DefaultOutboxMaxBackoff   = 5 * time.Minute
+	DefaultOutboxRetention    = 7 * 24 * time.Hour
+	DefaultOutboxSendTimeout  = 5 * time.Second
+	DefaultTokenVerifier      = "introspection"
+	DefaultJWKSURL            = "http://localhost:8001/.well-known/jwks.json"
+	DefaultJWKSRefresh        = 5 * time.Minute
+)
+
+const (
+	// PresenceStoreMemory keeps presence in process memory, visible to this
+	// replica only.
+	PresenceStoreMemory = "memory"
+	// PresenceStoreRedis keeps presence in Redis, shared by all replicas.
+	PresenceStoreRedis = "redis"
 )
 
 type Config struct {
-	AppName string
-	Debug   string
-	Server  *ServerConfig
-	Kafka   *KafkaConfig
-	DB      *DBConfig
+	AppName  string
+	Debug    string
+	Server   *ServerConfig
+	Clients  *ClientsConfig
+	Kafka    *KafkaConfig
+	DB       *DBConfig
+	Media    *MediaConfig
+	Presence *PresenceConfig
+	Redis    *RedisConfig
+	// Outbox configures the relay that publishes events from the outbox table.
+	Outbox        *OutboxConfig
+	TokenVerifier *TokenVerifierConfig
 }
 
 type ServerConfig struct {
@@ -35,12 +67,35 @@ type ServerConfig struct {
 	HTTPPort int
 }
 
+type ClientsConfig struct {
+	Auth    *ServiceClientConfig
+	Friends *ServiceClientConfig
+}
+
+// TokenVerifierConfig selects how incoming tokens are verified, see
+// platformauth.NewTokenVerifier.
+type TokenVerifierConfig struct {
+	// Type is "introspection" or "jwks".
+	Type                string
+	JWKSURL             string
+	JWKSRefreshInterval time.Duration
+	// ServiceSecret authenticates introspection calls to the auth service.
+	ServiceSecret string
+}
+
+type ServiceClientConfig struct {
+	Host string
+	Port int
+}
+
 type KafkaConfig struct {
 	Brokers       []string
 	Topic         string
 	ConsumerGroup string
 	MaxRetry      int
 	RetryInterval time.Duration
+	// ProfileTopic receives the profile events published by this service.
+	ProfileTopic string
 }
 
 type DBConfig struct {
@@ -51,6 +106,37 @@ type DBConfig struct {
 	Name     string
 }
 
+// MediaConfig configures uploaded media. Files are kept under Dir and served
+// by the HTTP server under /media/, which BaseURL must point at.
+type MediaConfig struct {
+	Dir                string
+	BaseURL            string
+	MaxAvatarBytes     int
+	MaxAvatarDimension int
+}
+
+type PresenceConfig struct {
+	Store                     string
+	TTL                       time.Duration
+	PollInterval              time.Duration
+	VisibilityRecheckInterval time.Duration
+}
+
+type OutboxConfig struct {
+	PollInterval    time.Duration
+	BatchSize       int
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+	Retention       time.Duration
+	PublishTimeout  time.Duration
+}
+
+type RedisConfig struct {
+	Addr     string
+	Password string
+	DB       int
+}
+
 func (db *DBConfig) DSN() string {
 	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable",
 		db.User, db.Password, db.Host, db.Port, db.Name)
@@ -64,6 +150,10 @@ func (s *ServerConfig) HttpAddr() string {
 	return s.HTTPHost + ":" + strconv.Itoa(s.HTTPPort)
 }
 
+func (c *ServiceClientConfig) Addr() string {
+	return c.Host + ":" + strconv.Itoa(c.Port)
+}
+
 func LoadConfig() (*Config, error) {
 	if err := godotenv.Load(".env"); err != nil {
 		log.Println("Info: .env file not found or couldn't be loaded; using environment variables")
@@ -73,8 +163,17 @@ func LoadConfig() (*Config, error) {
 		AppName: getEnv("APP_NAME", "AuthService"),
 		Debug:   getEnv("DEBUG", "dev"),
 		Server:  &ServerConfig{},
-		Kafka:   &KafkaConfig{},
-		DB:      &DBConfig{},
+		Clients: &ClientsConfig{
+			Auth:    &ServiceClientConfig{},
+			Friends: &ServiceClientConfig{},
+		},
+		Kafka:         &KafkaConfig{},
+		TokenVerifier: &TokenVerifierConfig{},
+		DB:            &DBConfig{},
+		Media:         &MediaConfig{},
+		Presence:      &PresenceConfig{},
+		Redis:         &RedisConfig{},
+		Outbox:        &OutboxConfig{},
 	}
 
 	c.Server.GRPCHost = getEnv("GRPC_HOST", "0.0.0.0")
@@ -82,8 +181,20 @@ func LoadConfig() (*Config, error) {
 	c.Server.HTTPHost = getEnv("HTTP_HOST", "0.0.0.0")
 	c.Server.HTTPPort = getEnvAsInt("HTTP_PORT", DefaultHTTPPort)
 
+	c.Clients.Auth.Host = getEnv("AUTH_SERVICE_HOST", "localhost")
+	c.Clients.Auth.Port = getEnvAsInt("AUTH_SERVICE_PORT", 9001)
+
+	c.TokenVerifier.Type = getEnv("AUTH_TOKEN_VERIFIER", DefaultTokenVerifier)
+	c.TokenVerifier.JWKSURL = getEnv("AUTH_JWKS_URL", DefaultJWKSURL)
+	c.TokenVerifier.JWKSRefreshInterval = getEnvAsDuration("AUTH_JWKS_REFRESH_INTERVAL", DefaultJWKSRefresh)
+	c.TokenVerifier.ServiceSecret = getEnv("AUTH_SERVICE_SECRET", "")
+
+	c.Clients.Friends.Host = getEnv("FRIENDS_SERVICE_HOST", "localhost")
+	c.Clients.Friends.Port = getEnvAsInt("FRIENDS_SERVICE_PORT", 9003)
+
 	c.Kafka.Brokers = getEnvAsSlice("KAFKA_BROKERS", []string{DefaultKafkaBroker})
 	c.Kafka.Topic = getEnv("KAFKA_PRODUCER_TOPIC", DefaultKafkaTopic)
+	c.Kafka.ProfileTopic = getEnv("KAFKA_PROFILE_TOPIC", DefaultKafkaProfileTopic)
 	c.Kafka.ConsumerGroup = getEnv("KAFKA_CONSUMER_GROUP", "users-service-group")
 	c.Kafka.MaxRetry = getEnvAsInt("KAFKA_MAX_RETRY", DefaultKafkaMaxRetry)
 	c.Kafka.RetryInterval = getEnvAsDuration("KAFKA_RETRY_INTERVAL", DefaultKafkaRetryInterval)
@@ -96,6 +207,31 @@ func LoadConfig() (*Config, error) {
 		Name:     getEnv("POSTGRES_DB", "users_db"),
 	}
 
+	c.Media.Dir = getEnv("MEDIA_DIR", "./media")
+	c.Media.BaseURL = getEnv("MEDIA_BASE_URL", fmt.Sprintf("http://localhost:%d/media", c.Server.HTTPPort))
+	c.Media.MaxAvatarBytes = getEnvAsInt("AVATAR_MAX_BYTES", DefaultMaxAvatarBytes)
+	c.Media.MaxAvatarDimension = getEnvAsInt("AVATAR_MAX_DIMENSION", DefaultMaxAvatarDimension)
+
+	c.Presence.Store = getEnv("PRESENCE_STORE", DefaultPresenceStore)
+	c.Presence.TTL = getEnvAsDuration("PRESENCE_TTL", DefaultPresenceTTL)
+	c.Presence.PollInterval = getEnvAsDuration("PRESENCE_POLL_INTERVAL", DefaultPresencePoll)
+	c.Presence.VisibilityRecheckInterval = getEnvAsDuration("PRESENCE_VISIBILITY_RECHECK_INTERVAL", DefaultPresenceRecheck)
+
+	if c.Presence.Store != PresenceStoreMemory && c.Presence.Store != PresenceStoreRedis {
+		return nil, fmt.Errorf("unsupported PRESENCE_STORE %q", c.Presence.Store)
+	}
+
+	c.Redis.Addr = getEnv("REDIS_ADDR", DefaultRedisAddr)
+	c.Redis.Password = getEnv("REDIS_PASSWORD", "")
+	c.Redis.DB = getEnvAsInt("REDIS_DB", 0)
+
+	c.Outbox.PollInterval = getEnvAsDuration("OUTBOX_POLL_INTERVAL", DefaultOutboxPollInterval)
+	c.Outbox.BatchSize = getEnvAsInt("OUTBOX_BATCH_SIZE", DefaultOutboxBatchSize)
+	c.Outbox.RetryBackoff = getEnvAsDuration("OUTBOX_RETRY_BACKOFF", DefaultOutboxRetryBackoff)
+	c.Outbox.MaxRetryBackoff = getEnvAsDuration("OUTBOX_MAX_RETRY_BACKOFF", DefaultOutboxMaxBackoff)
+	c.Outbox.Retention = getEnvAsDuration("OUTBOX_RETENTION", DefaultOutboxRetention)
+	c.Outbox.PublishTimeout = getEnvAsDuration("OUTBOX_PUBLISH_TIMEOUT", DefaultOutboxSendTimeout)
+
 	return c, nil
 }
 
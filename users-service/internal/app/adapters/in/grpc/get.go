@@ -22,5 +22,6 @@ func (s *UsersServiceServer) GetUserProfile(ctx context.Context, req *users.GetU
 		Email:       user.Email,
 		Description: user.Description,
 		AvatarUrl:   user.AvatarURL,
+		Version:     user.Version,
 	}, nil
 }
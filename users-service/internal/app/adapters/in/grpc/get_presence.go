@@ -0,0 +1,21 @@
+package grpc
+
+import (
+	"context"
+
+	users "github.com/SamEkb/messenger-app/pkg/api/users_service/v1"
+)
+
+func (s *UsersServiceServer) GetPresence(ctx context.Context, req *users.GetPresenceRequest) (*users.GetPresenceResponse, error) {
+	s.logger.Info("Getting presence")
+
+	presences, err := s.presenceUseCase.GetPresence(ctx, req.GetUserIds())
+	if err != nil {
+		s.logger.Error("Failed to get presence", "error", err)
+		return nil, err
+	}
+
+	return &users.GetPresenceResponse{
+		Presences: toProtoPresences(presences),
+	}, nil
+}
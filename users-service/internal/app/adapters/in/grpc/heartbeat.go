@@ -0,0 +1,30 @@
+package grpc
+
+import (
+	"context"
+
+	users "github.com/SamEkb/messenger-app/pkg/api/users_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+)
+
+func (s *UsersServiceServer) Heartbeat(ctx context.Context, req *users.HeartbeatRequest) (*users.HeartbeatResponse, error) {
+	if err := platformauth.RequireScope(ctx, platformauth.ScopeUsersWrite); err != nil {
+		s.logger.Warn("Heartbeat denied", "error", err)
+		return nil, err
+	}
+	// An administrator acting as the user must not make them look online.
+	if err := platformauth.RejectImpersonation(ctx); err != nil {
+		s.logger.Warn("Heartbeat denied", "error", err)
+		return nil, err
+	}
+
+	ttl, err := s.presenceUseCase.Heartbeat(ctx, presenceStateFromProto(req.GetState()))
+	if err != nil {
+		s.logger.Error("Failed to record heartbeat", "error", err)
+		return nil, err
+	}
+
+	return &users.HeartbeatResponse{
+		TtlSeconds: int64(ttl.Seconds()),
+	}, nil
+}
@@ -0,0 +1,36 @@
+package grpc
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// mediaPrefix is the path uploaded media is served under. MEDIA_BASE_URL
+// must point at it.
+const mediaPrefix = "/media/"
+
+// serveMedia serves blobs to anyone, as avatars are public. Keys are never
+// reused, so responses may be cached forever.
+func (s *UsersServiceServer) serveMedia(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, mediaPrefix)
+
+	data, contentType, err := s.blobStore.Get(r.Context(), key)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) || errors.Is(err, errors.ErrInvalidInput) {
+			http.NotFound(w, r)
+			return
+		}
+		s.logger.Error("Failed to serve media", "key", key, "error", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, key, time.Time{}, bytes.NewReader(data))
+}
@@ -0,0 +1,45 @@
+package grpc
+
+import (
+	users "github.com/SamEkb/messenger-app/pkg/api/users_service/v1"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
+)
+
+func presenceStateFromProto(state users.PresenceState) string {
+	switch state {
+	case users.PresenceState_PRESENCE_STATE_AWAY:
+		return string(models.PresenceAway)
+	case users.PresenceState_PRESENCE_STATE_OFFLINE:
+		return string(models.PresenceOffline)
+	default:
+		return string(models.PresenceOnline)
+	}
+}
+
+func presenceStateToProto(state string) users.PresenceState {
+	switch models.PresenceState(state) {
+	case models.PresenceOnline:
+		return users.PresenceState_PRESENCE_STATE_ONLINE
+	case models.PresenceAway:
+		return users.PresenceState_PRESENCE_STATE_AWAY
+	default:
+		return users.PresenceState_PRESENCE_STATE_OFFLINE
+	}
+}
+
+func toProtoPresences(presences []*ports.PresenceDto) []*users.UserPresence {
+	result := make([]*users.UserPresence, 0, len(presences))
+	for _, p := range presences {
+		var lastSeenAt int64
+		if !p.LastSeenAt.IsZero() {
+			lastSeenAt = p.LastSeenAt.Unix()
+		}
+		result = append(result, &users.UserPresence{
+			UserId:     p.UserID,
+			State:      presenceStateToProto(p.State),
+			LastSeenAt: lastSeenAt,
+		})
+	}
+	return result
+}
@@ -0,0 +1,42 @@
+package grpc
+
+import (
+	"context"
+
+	users "github.com/SamEkb/messenger-app/pkg/api/users_service/v1"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
+)
+
+func (s *UsersServiceServer) SearchUsers(ctx context.Context, req *users.SearchUsersRequest) (*users.SearchUsersResponse, error) {
+	s.logger.Info("Searching user profiles")
+
+	result, err := s.userUseCase.Search(ctx, &ports.SearchUsersDto{
+		Query:              req.GetQuery(),
+		IncludeDescription: req.GetIncludeDescription(),
+		Limit:              int(req.GetLimit()),
+		Offset:             int(req.GetOffset()),
+	})
+	if err != nil {
+		s.logger.Error("Failed to search user profiles", "error", err)
+		return nil, err
+	}
+
+	profiles := make([]*users.UserProfile, 0, len(result.Users))
+	for _, user := range result.Users {
+		// Search must not become a way to harvest addresses, so emails are
+		// left out.
+		profiles = append(profiles, &users.UserProfile{
+			UserId:      user.ID,
+			Nickname:    user.Nickname,
+			Description: user.Description,
+			AvatarUrl:   user.AvatarURL,
+		})
+	}
+
+	s.logger.Info("User profiles successfully found", "count", len(profiles))
+
+	return &users.SearchUsersResponse{
+		Users:   profiles,
+		HasMore: result.HasMore,
+	}, nil
+}
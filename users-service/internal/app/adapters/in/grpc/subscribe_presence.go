@@ -0,0 +1,22 @@
+package grpc
+
+import (
+	users "github.com/SamEkb/messenger-app/pkg/api/users_service/v1"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
+)
+
+func (s *UsersServiceServer) SubscribePresence(req *users.SubscribePresenceRequest, stream users.UsersService_SubscribePresenceServer) error {
+	s.logger.Info("Subscribing to presence")
+
+	err := s.presenceUseCase.SubscribePresence(stream.Context(), req.GetUserIds(), func(presences []*ports.PresenceDto) error {
+		return stream.Send(&users.SubscribePresenceResponse{
+			Presences: toProtoPresences(presences),
+		})
+	})
+	if err != nil {
+		s.logger.Error("Presence subscription failed", "error", err)
+		return err
+	}
+
+	return nil
+}
@@ -4,20 +4,34 @@ import (
 	"context"
 
 	users "github.com/SamEkb/messenger-app/pkg/api/users_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
 	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
 )
 
 func (s *UsersServiceServer) UpdateUserProfile(ctx context.Context, req *users.UpdateUserProfileRequest) (*users.UpdateUserProfileResponse, error) {
+	if err := platformauth.RequireScope(ctx, platformauth.ScopeUsersWrite); err != nil {
+		s.logger.Warn("Update user profile denied", "error", err)
+		return nil, err
+	}
+	if err := platformauth.RejectImpersonation(ctx); err != nil {
+		s.logger.Warn("Update user profile denied", "error", err)
+		return nil, err
+	}
+
 	s.logger.Info("Updating user profile")
 
-	dto := &ports.UserDto{
-		ID:          req.Profile.UserId,
-		Email:       req.Profile.Email,
-		Nickname:    req.Profile.Nickname,
-		Description: req.Profile.Description,
-		AvatarURL:   req.Profile.AvatarUrl,
+	dto := &ports.UpdateUserDto{
+		Profile: &ports.UserDto{
+			Email:       req.GetProfile().GetEmail(),
+			Nickname:    req.GetProfile().GetNickname(),
+			Description: req.GetProfile().GetDescription(),
+			AvatarURL:   req.GetProfile().GetAvatarUrl(),
+		},
+		UpdateMask:      req.GetUpdateMask().GetPaths(),
+		ExpectedVersion: req.GetExpectedVersion(),
 	}
-	if err := s.userUseCase.Update(ctx, dto); err != nil {
+	user, err := s.userUseCase.Update(ctx, dto)
+	if err != nil {
 		s.logger.Error("Failed to update user profile", "error", err)
 		return nil, err
 	}
@@ -25,5 +39,6 @@ func (s *UsersServiceServer) UpdateUserProfile(ctx context.Context, req *users.U
 	return &users.UpdateUserProfileResponse{
 		Success: true,
 		Message: "User profile updated successfully",
+		Version: user.Version,
 	}, nil
 }
@@ -0,0 +1,43 @@
+package grpc
+
+import (
+	"context"
+
+	users "github.com/SamEkb/messenger-app/pkg/api/users_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
+)
+
+func (s *UsersServiceServer) UpdatePresenceSettings(ctx context.Context, req *users.UpdatePresenceSettingsRequest) (*users.UpdatePresenceSettingsResponse, error) {
+	if err := platformauth.RequireScope(ctx, platformauth.ScopeUsersWrite); err != nil {
+		s.logger.Warn("Update presence settings denied", "error", err)
+		return nil, err
+	}
+	if err := platformauth.RejectImpersonation(ctx); err != nil {
+		s.logger.Warn("Update presence settings denied", "error", err)
+		return nil, err
+	}
+
+	s.logger.Info("Updating presence settings")
+
+	var visibility models.LastSeenVisibility
+	switch req.GetLastSeenVisibility() {
+	case users.LastSeenVisibility_LAST_SEEN_VISIBILITY_EVERYONE:
+		visibility = models.LastSeenEveryone
+	case users.LastSeenVisibility_LAST_SEEN_VISIBILITY_FRIENDS:
+		visibility = models.LastSeenFriends
+	default:
+		return nil, errors.NewValidationError("last seen visibility must be specified")
+	}
+
+	if err := s.presenceUseCase.SetLastSeenVisibility(ctx, string(visibility)); err != nil {
+		s.logger.Error("Failed to update presence settings", "error", err)
+		return nil, err
+	}
+
+	return &users.UpdatePresenceSettingsResponse{
+		Success: true,
+		Message: "Presence settings updated successfully",
+	}, nil
+}
@@ -0,0 +1,150 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	users "github.com/SamEkb/messenger-app/pkg/api/users_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
+	middlewaregrpc "github.com/SamEkb/messenger-app/users-service/internal/middleware/grpc"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+// multipartOverhead is allowed on top of the avatar size for the multipart
+// boundaries and part headers.
+const multipartOverhead = 64 << 10
+
+func (s *UsersServiceServer) UploadAvatar(stream users.UsersService_UploadAvatarServer) error {
+	ctx := stream.Context()
+	if err := authorizeAvatarUpload(ctx); err != nil {
+		s.logger.Warn("Upload avatar denied", "error", err)
+		return err
+	}
+
+	s.logger.Info("Uploading avatar")
+
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return errors.NewValidationError("avatar upload is empty")
+	}
+	if err != nil {
+		return err
+	}
+	contentType := first.GetContentType()
+	if contentType == "" {
+		return errors.NewValidationError("first message must carry the content type")
+	}
+
+	var data []byte
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if _, ok := req.GetPayload().(*users.UploadAvatarRequest_Chunk); !ok {
+			return errors.NewValidationError("content type can only be sent in the first message")
+		}
+		if len(data)+len(req.GetChunk()) > s.media.MaxAvatarBytes {
+			return errors.NewValidationError("avatar cannot be larger than %d bytes", s.media.MaxAvatarBytes)
+		}
+		data = append(data, req.GetChunk()...)
+	}
+
+	result, err := s.userUseCase.UploadAvatar(ctx, &ports.UploadAvatarDto{
+		ContentType: contentType,
+		Data:        data,
+	})
+	if err != nil {
+		s.logger.Error("Failed to upload avatar", "error", err)
+		return err
+	}
+
+	s.logger.Info("Avatar successfully uploaded")
+
+	return stream.SendAndClose(toUploadAvatarResponse(result))
+}
+
+// uploadAvatarHandler serves POST /api/v1/users/avatar, which takes the
+// image as multipart/form-data since the gateway cannot map a client stream.
+func (s *UsersServiceServer) uploadAvatarHandler(mux *runtime.ServeMux) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		ctx := r.Context()
+		_, marshaler := runtime.MarshalerForRequest(mux, r)
+		fail := func(err error) {
+			runtime.HTTPError(ctx, mux, marshaler, w, r, middlewaregrpc.StatusError(err))
+		}
+
+		if err := authorizeAvatarUpload(ctx); err != nil {
+			s.logger.Warn("Upload avatar denied", "error", err)
+			fail(err)
+			return
+		}
+
+		s.logger.Info("Uploading avatar over HTTP")
+
+		r.Body = http.MaxBytesReader(w, r.Body, int64(s.media.MaxAvatarBytes)+multipartOverhead)
+		file, header, err := r.FormFile("avatar")
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				fail(errors.NewValidationError("avatar cannot be larger than %d bytes", s.media.MaxAvatarBytes))
+				return
+			}
+			fail(errors.NewValidationError("request must be multipart/form-data with an avatar file"))
+			return
+		}
+		defer file.Close()
+
+		data, err := io.ReadAll(file)
+		if err != nil {
+			fail(errors.NewValidationError("failed to read avatar: %v", err))
+			return
+		}
+
+		result, err := s.userUseCase.UploadAvatar(ctx, &ports.UploadAvatarDto{
+			ContentType: header.Header.Get("Content-Type"),
+			Data:        data,
+		})
+		if err != nil {
+			s.logger.Error("Failed to upload avatar", "error", err)
+			fail(err)
+			return
+		}
+
+		body, err := marshaler.Marshal(toUploadAvatarResponse(result))
+		if err != nil {
+			fail(errors.NewInternalError(err, "failed to encode response"))
+			return
+		}
+
+		s.logger.Info("Avatar successfully uploaded")
+
+		w.Header().Set("Content-Type", marshaler.ContentType(nil))
+		w.Write(body)
+	}
+}
+
+func authorizeAvatarUpload(ctx context.Context) error {
+	if err := platformauth.RequireScope(ctx, platformauth.ScopeUsersWrite); err != nil {
+		return err
+	}
+	return platformauth.RejectImpersonation(ctx)
+}
+
+func toUploadAvatarResponse(result *ports.AvatarDto) *users.UploadAvatarResponse {
+	thumbnails := make(map[int32]string, len(result.ThumbnailURLs))
+	for size, url := range result.ThumbnailURLs {
+		thumbnails[int32(size)] = url
+	}
+
+	return &users.UploadAvatarResponse{
+		AvatarUrl:     result.AvatarURL,
+		ThumbnailUrls: thumbnails,
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"sync"
 
 	users "github.com/SamEkb/messenger-app/pkg/api/users_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
 	"github.com/SamEkb/messenger-app/pkg/platform/logger"
 	"github.com/SamEkb/messenger-app/users-service/config/env"
 	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
@@ -25,23 +26,39 @@ var _ users.UsersServiceServer = (*UsersServiceServer)(nil)
 
 type UsersServiceServer struct {
 	users.UnimplementedUsersServiceServer
-	userUseCase ports.UserUseCase
-	validator   protovalidate.Validator
-	cfg         *env.ServerConfig
-	logger      logger.Logger
+	userUseCase     ports.UserUseCase
+	presenceUseCase ports.PresenceUseCase
+	blobStore       ports.BlobStore
+	validator       protovalidate.Validator
+	verifier        platformauth.TokenVerifier
+	cfg             *env.ServerConfig
+	media           *env.MediaConfig
+	logger          logger.Logger
 }
 
-func NewServer(cfg *env.ServerConfig, userUseCase ports.UserUseCase, logger logger.Logger) (*UsersServiceServer, error) {
+func NewServer(
+	cfg *env.ServerConfig,
+	media *env.MediaConfig,
+	userUseCase ports.UserUseCase,
+	presenceUseCase ports.PresenceUseCase,
+	blobStore ports.BlobStore,
+	verifier platformauth.TokenVerifier,
+	logger logger.Logger,
+) (*UsersServiceServer, error) {
 	validator, err := protovalidate.New()
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize validator: %w", err)
 	}
 
 	server := &UsersServiceServer{
-		validator:   validator,
-		cfg:         cfg,
-		logger:      logger,
-		userUseCase: userUseCase,
+		validator:       validator,
+		verifier:        verifier,
+		cfg:             cfg,
+		media:           media,
+		logger:          logger,
+		userUseCase:     userUseCase,
+		presenceUseCase: presenceUseCase,
+		blobStore:       blobStore,
 	}
 
 	return server, nil
@@ -67,6 +84,11 @@ func (s *UsersServiceServer) RunServers(ctx context.Context) error {
 			grpc.ChainUnaryInterceptor(
 				protovalidatemw.UnaryServerInterceptor(s.validator),
 				middlewaregrpc.ErrorsUnaryServerInterceptor(),
+				platformauth.UnaryServerInterceptor(s.verifier),
+			),
+			grpc.ChainStreamInterceptor(
+				middlewaregrpc.ErrorsStreamServerInterceptor(),
+				platformauth.StreamServerInterceptor(s.verifier),
 			),
 		)
 		users.RegisterUsersServiceServer(grpcServer, s)
@@ -86,13 +108,19 @@ func (s *UsersServiceServer) RunServers(ctx context.Context) error {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		mux := runtime.NewServeMux()
+		mux := runtime.NewServeMux(runtime.WithMiddlewares(
+			platformauth.HTTPMiddleware(s.verifier, users.File_users_service_v1_service_proto.Services().ByName("UsersService")),
+		))
 		if err := users.RegisterUsersServiceHandlerServer(ctx, mux, s); err != nil {
 			log.Fatalf("gateway registration error: %v", err)
 		}
+		if err := mux.HandlePath(http.MethodPost, "/api/v1/users/avatar", s.uploadAvatarHandler(mux)); err != nil {
+			log.Fatalf("gateway registration error: %v", err)
+		}
 
 		root := http.NewServeMux()
 		root.Handle("/", mux)
+		root.HandleFunc("GET "+mediaPrefix, s.serveMedia)
 		root.HandleFunc("/live", liveHandler)
 		root.HandleFunc("/ready", readyHandler)
 
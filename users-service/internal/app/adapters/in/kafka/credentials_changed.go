@@ -0,0 +1,32 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/pkg/api/events/v1"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
+)
+
+func (s *UsersServiceServer) HandleUserCredentialsChanged(ctx context.Context, event *events.UserCredentialsChangedEvent) error {
+	s.logger.Info("Handling user credentials changed event",
+		"user_id", event.UserId,
+		"username", event.Username,
+		"email", event.Email)
+
+	dto := &ports.UserDto{
+		ID:       event.UserId,
+		Email:    event.Email,
+		Nickname: event.Username,
+	}
+
+	if err := s.userUseCase.UpdateCredentials(ctx, dto); err != nil {
+		s.logger.Error("Failed to update user credentials from event",
+			"error", err,
+			"user_id", event.UserId)
+		return err
+	}
+
+	s.logger.Info("User credentials successfully updated from Kafka event",
+		"user_id", event.UserId)
+	return nil
+}
@@ -0,0 +1,103 @@
+package blob
+
+import (
+	"context"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
+)
+
+var _ ports.BlobStore = (*LocalStore)(nil)
+
+// LocalStore keeps blobs as files under a directory. The service serves them
+// itself, under baseURL. The content type is derived from the key's
+// extension.
+type LocalStore struct {
+	dir     string
+	baseURL string
+}
+
+func NewLocalStore(dir, baseURL string) (*LocalStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.NewInternalError(err, "failed to create media directory")
+	}
+	return &LocalStore{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+func (s *LocalStore) Put(_ context.Context, key string, data []byte, _ string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return errors.NewInternalError(err, "failed to create blob directory")
+	}
+
+	// Write to a temporary file first so readers never see a partial blob.
+	tmp := name + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return errors.NewInternalError(err, "failed to write blob")
+	}
+	if err := os.Rename(tmp, name); err != nil {
+		os.Remove(tmp)
+		return errors.NewInternalError(err, "failed to write blob")
+	}
+
+	return nil
+}
+
+func (s *LocalStore) Get(_ context.Context, key string) ([]byte, string, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, "", errors.NewNotFoundError("blob %s not found", key)
+		}
+		return nil, "", errors.NewInternalError(err, "failed to read blob")
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return data, contentType, nil
+}
+
+func (s *LocalStore) Delete(_ context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return errors.NewInternalError(err, "failed to delete blob")
+	}
+	return nil
+}
+
+func (s *LocalStore) URL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+// path maps key to a file name, refusing keys that would escape the
+// directory.
+func (s *LocalStore) path(key string) (string, error) {
+	if !fs.ValidPath(key) || key == "." {
+		return "", errors.NewInvalidInputError("invalid blob key %q", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,57 @@
+package grpc
+
+import (
+	"context"
+
+	friends "github.com/SamEkb/messenger-app/pkg/api/friends_service/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/SamEkb/messenger-app/users-service/config/env"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type Client struct {
+	config *env.ClientsConfig
+	logger logger.Logger
+}
+
+func NewClient(config *env.ClientsConfig, logger logger.Logger) *Client {
+	return &Client{
+		config: config,
+		logger: logger,
+	}
+}
+
+// NewAuthServiceConn connects to the auth service, which verifies the
+// tokens of incoming requests through platformauth.IntrospectionVerifier.
+func (f *Client) NewAuthServiceConn(ctx context.Context) (*grpc.ClientConn, error) {
+	conn, err := grpc.DialContext(
+		ctx,
+		f.config.Auth.Addr(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return nil, errors.NewServiceError(err, "failed to connect to Auth Service")
+	}
+	return conn, nil
+}
+
+func (f *Client) NewFriendsServiceClient(ctx context.Context) (*FriendsServiceClientAdapter, error) {
+	conn, err := grpc.DialContext(
+		ctx,
+		f.config.Friends.Addr(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(platformauth.UnaryClientInterceptor()),
+	)
+	if err != nil {
+		return nil, errors.NewServiceError(err, "failed to connect to Friends Service")
+	}
+
+	client := friends.NewFriendsServiceClient(conn)
+	return &FriendsServiceClientAdapter{
+		client: client,
+		conn:   conn,
+	}, nil
+}
@@ -0,0 +1,64 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	friends "github.com/SamEkb/messenger-app/pkg/api/friends_service/v1"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
+	"google.golang.org/grpc"
+	grpcStatus "google.golang.org/grpc/status"
+)
+
+var _ ports.FriendshipChecker = (*FriendsServiceClientAdapter)(nil)
+
+type FriendsServiceClientAdapter struct {
+	client friends.FriendsServiceClient
+	conn   *grpc.ClientConn
+}
+
+func (c *FriendsServiceClientAdapter) Close() error {
+	if c.conn != nil {
+		if err := c.conn.Close(); err != nil {
+			return errors.NewServiceError(err, "failed to close connection to Friends Service")
+		}
+	}
+	return nil
+}
+
+func (c *FriendsServiceClientAdapter) FriendsOf(ctx context.Context, userID string, otherIDs []string) (map[string]bool, error) {
+	result := make(map[string]bool, len(otherIDs))
+	if len(otherIDs) == 0 {
+		return result, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.CheckFriendshipsStatus(ctx, &friends.CheckFriendshipsStatusRequest{
+		UserIds: append([]string{userID}, otherIDs...),
+	})
+	if err != nil {
+		st, ok := grpcStatus.FromError(err)
+		if ok {
+			return nil, errors.NewServiceError(err, "failed to check friendships status: %s", st.Message())
+		}
+		return nil, errors.NewServiceError(err, "failed to check friendships status")
+	}
+
+	// Only the pairs that include userID matter; the response also covers
+	// the pairs among otherIDs.
+	for _, otherID := range otherIDs {
+		result[otherID] = true
+	}
+	for _, pair := range resp.GetNonFriendPairs() {
+		switch userID {
+		case pair.GetUserId1():
+			result[pair.GetUserId2()] = false
+		case pair.GetUserId2():
+			result[pair.GetUserId1()] = false
+		}
+	}
+	return result, nil
+}
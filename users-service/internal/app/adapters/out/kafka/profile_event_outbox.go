@@ -0,0 +1,44 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/pkg/api/events/v1"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/postgres"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
+)
+
+var _ ports.ProfileEventsOutbox = (*ProfileEventsOutbox)(nil)
+
+// ProfileEventsOutbox writes profile events to the transactional outbox.
+// They are published by the outbox relay through
+// ProfileEventsKafkaProducer.Publish. Events are keyed by user ID, so the
+// events of one user keep their order on the topic.
+type ProfileEventsOutbox struct {
+	outbox *postgres.Outbox
+	topic  string
+}
+
+func NewProfileEventsOutbox(outbox *postgres.Outbox, topic string) *ProfileEventsOutbox {
+	return &ProfileEventsOutbox{outbox: outbox, topic: topic}
+}
+
+func (o *ProfileEventsOutbox) EnqueueUserProfileUpdatedEvent(ctx context.Context, event *events.UserProfileUpdatedEvent) error {
+	eventType, data, err := encodeEvent(event)
+	if err != nil {
+		return err
+	}
+
+	err = o.outbox.Enqueue(ctx, &postgres.OutboxMessage{
+		Topic:   o.topic,
+		Key:     event.GetUserId(),
+		Headers: map[string]string{EventTypeHeader: eventType},
+		Payload: data,
+	})
+	if err != nil {
+		return errors.NewInternalError(err, "failed to enqueue %s", eventType).
+			WithDetails("user_id", event.GetUserId())
+	}
+	return nil
+}
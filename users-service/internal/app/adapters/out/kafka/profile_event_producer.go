@@ -0,0 +1,115 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/SamEkb/messenger-app/pkg/platform/postgres"
+	"github.com/SamEkb/messenger-app/users-service/config/env"
+	"github.com/Shopify/sarama"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ postgres.OutboxPublisher = (*ProfileEventsKafkaProducer)(nil)
+
+// EventTypeHeader carries the protobuf message name of the event, as on the
+// user-events topic of auth-service.
+const EventTypeHeader = "event-type"
+
+// ProfileEventsKafkaProducer publishes the messages relayed from the
+// transactional outbox.
+type ProfileEventsKafkaProducer struct {
+	producer sarama.SyncProducer
+	logger   logger.Logger
+	topic    string
+}
+
+func NewProfileEventsKafkaProducer(kafkaCfg *env.KafkaConfig, logger logger.Logger) (*ProfileEventsKafkaProducer, error) {
+	if kafkaCfg == nil {
+		return nil, errors.NewInvalidInputError("kafka config is nil")
+	}
+
+	cfg := NewSaramaConfig(kafkaCfg, logger)
+
+	producer, err := sarama.NewSyncProducer(kafkaCfg.Brokers, cfg)
+	if err != nil {
+		return nil, errors.NewServiceError(err, "failed to create Kafka producer")
+	}
+
+	return &ProfileEventsKafkaProducer{
+		producer: producer,
+		logger:   logger.With("component", "kafka_producer"),
+		topic:    kafkaCfg.ProfileTopic,
+	}, nil
+}
+
+// Publish sends a message relayed from the transactional outbox.
+func (p *ProfileEventsKafkaProducer) Publish(ctx context.Context, msg *postgres.OutboxMessage) error {
+	topic := msg.Topic
+	if topic == "" {
+		topic = p.topic
+	}
+
+	headers := make([]sarama.RecordHeader, 0, len(msg.Headers))
+	for key, value := range msg.Headers {
+		headers = append(headers, sarama.RecordHeader{Key: []byte(key), Value: []byte(value)})
+	}
+
+	return p.send(ctx, msg.Key, msg.Headers[EventTypeHeader], &sarama.ProducerMessage{
+		Topic:   topic,
+		Key:     sarama.StringEncoder(msg.Key),
+		Value:   sarama.ByteEncoder(msg.Payload),
+		Headers: headers,
+	})
+}
+
+func (p *ProfileEventsKafkaProducer) send(ctx context.Context, userID, eventType string, msg *sarama.ProducerMessage) error {
+	doneCh := make(chan struct{})
+	var sendErr error
+	var partition int32
+	var offset int64
+
+	go func() {
+		defer close(doneCh)
+		partition, offset, sendErr = p.producer.SendMessage(msg)
+	}()
+
+	select {
+	case <-ctx.Done():
+		p.logger.Warn("message sending aborted", "error", ctx.Err())
+		return errors.NewTimeoutError("message sending aborted: %v", ctx.Err()).
+			WithDetails("user_id", userID)
+	case <-doneCh:
+		if sendErr != nil {
+			p.logger.Error("failed to send message", "error", sendErr)
+			return errors.NewServiceError(sendErr, "failed to send message").
+				WithDetails("user_id", userID)
+		}
+		p.logger.Info("published "+eventType,
+			"user_id", userID,
+			"partition", partition,
+			"offset", offset,
+			"topic", msg.Topic)
+		return nil
+	}
+}
+
+// encodeEvent returns the event type name and the JSON payload of an event.
+func encodeEvent(event proto.Message) (string, []byte, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return "", nil, errors.NewInternalError(err, "failed to marshal event")
+	}
+	return string(event.ProtoReflect().Descriptor().Name()), data, nil
+}
+
+func (p *ProfileEventsKafkaProducer) Close() error {
+	p.logger.Info("closing kafka producer")
+	err := p.producer.Close()
+	if err != nil {
+		return errors.NewServiceError(err, "failed to close Kafka producer")
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package models
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// AvatarPolicy limits what may be uploaded as an avatar.
+type AvatarPolicy struct {
+	MaxBytes int
+	// MaxDimension caps the width and height, checked before the image is
+	// decoded so that a small file cannot expand into a huge bitmap.
+	MaxDimension int
+}
+
+// AvatarThumbnailSizes are the edges of the square thumbnails made for every
+// avatar.
+var AvatarThumbnailSizes = []int{64, 256}
+
+var avatarContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// Avatar is a decoded avatar image. It is stored re-encoded, which drops
+// metadata such as EXIF location; GIFs become still PNGs.
+type Avatar struct {
+	image image.Image
+	jpeg  bool
+}
+
+// ParseAvatar checks an upload against policy and decodes it. The declared
+// content type must agree with the one sniffed from the data.
+func ParseAvatar(policy AvatarPolicy, contentType string, data []byte) (*Avatar, error) {
+	if len(data) == 0 {
+		return nil, errors.NewValidationError("avatar cannot be empty")
+	}
+	if len(data) > policy.MaxBytes {
+		return nil, errors.NewValidationError("avatar cannot be larger than %d bytes", policy.MaxBytes).
+			WithDetails("max_bytes", policy.MaxBytes)
+	}
+	if !avatarContentTypes[contentType] {
+		return nil, errors.NewValidationError("unsupported avatar content type %q", contentType).
+			WithDetails("content_type", contentType)
+	}
+	if sniffed := http.DetectContentType(data); sniffed != contentType {
+		return nil, errors.NewValidationError("avatar content is %s, not %s", sniffed, contentType)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.NewValidationError("avatar is not a valid image")
+	}
+	if config.Width > policy.MaxDimension || config.Height > policy.MaxDimension {
+		return nil, errors.NewValidationError("avatar cannot be larger than %dx%d pixels",
+			policy.MaxDimension, policy.MaxDimension)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.NewValidationError("avatar is not a valid image")
+	}
+
+	return &Avatar{image: img, jpeg: contentType == "image/jpeg"}, nil
+}
+
+// ContentType is the type of the encoded avatar and its thumbnails.
+func (a *Avatar) ContentType() string {
+	if a.jpeg {
+		return "image/jpeg"
+	}
+	return "image/png"
+}
+
+// Extension is the file extension matching ContentType.
+func (a *Avatar) Extension() string {
+	if a.jpeg {
+		return ".jpg"
+	}
+	return ".png"
+}
+
+// Encode returns the avatar at its original size.
+func (a *Avatar) Encode() ([]byte, error) {
+	return a.encode(a.image)
+}
+
+// Thumbnail returns the avatar cropped to its centre square and scaled to
+// size pixels.
+func (a *Avatar) Thumbnail(size int) ([]byte, error) {
+	return a.encode(resizeSquare(a.image, size))
+}
+
+func (a *Avatar) encode(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	if a.jpeg {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90})
+	} else {
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		return nil, errors.NewInternalError(err, "failed to encode avatar")
+	}
+	return buf.Bytes(), nil
+}
+
+// resizeSquare crops src to its centre square and scales it to size by
+// averaging the source pixels covered by each destination pixel.
+func resizeSquare(src image.Image, size int) image.Image {
+	bounds := src.Bounds()
+	edge := min(bounds.Dx(), bounds.Dy())
+	originX := bounds.Min.X + (bounds.Dx()-edge)/2
+	originY := bounds.Min.Y + (bounds.Dy()-edge)/2
+
+	dst := image.NewNRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		y0 := originY + y*edge/size
+		y1 := max(originY+(y+1)*edge/size, y0+1)
+		for x := 0; x < size; x++ {
+			x0 := originX + x*edge/size
+			x1 := max(originX+(x+1)*edge/size, x0+1)
+
+			var r, g, b, alpha, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					c := color.NRGBA64Model.Convert(src.At(sx, sy)).(color.NRGBA64)
+					r += uint64(c.R)
+					g += uint64(c.G)
+					b += uint64(c.B)
+					alpha += uint64(c.A)
+					n++
+				}
+			}
+			dst.Set(x, y, color.NRGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(b / n),
+				A: uint16(alpha / n),
+			})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"time"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+)
+
+// PresenceState is what a user's clients last reported.
+type PresenceState string
+
+const (
+	PresenceOnline  PresenceState = "online"
+	PresenceAway    PresenceState = "away"
+	PresenceOffline PresenceState = "offline"
+)
+
+func ParsePresenceState(s string) (PresenceState, error) {
+	switch state := PresenceState(s); state {
+	case PresenceOnline, PresenceAway, PresenceOffline:
+		return state, nil
+	default:
+		return "", errors.NewValidationError("unknown presence state %q", s).
+			WithDetails("state", s)
+	}
+}
+
+// LastSeenVisibility selects who may see when a user was last online.
+type LastSeenVisibility string
+
+const (
+	LastSeenEveryone LastSeenVisibility = "everyone"
+	LastSeenFriends  LastSeenVisibility = "friends"
+)
+
+func ParseLastSeenVisibility(s string) (LastSeenVisibility, error) {
+	switch visibility := LastSeenVisibility(s); visibility {
+	case LastSeenEveryone, LastSeenFriends:
+		return visibility, nil
+	default:
+		return "", errors.NewValidationError("unknown last seen visibility %q", s).
+			WithDetails("last_seen_visibility", s)
+	}
+}
+
+// PresencePolicy configures presence tracking.
+type PresencePolicy struct {
+	// TTL is how long a heartbeat keeps a user online or away. Clients
+	// should heartbeat well within it.
+	TTL time.Duration
+	// PollInterval is how often subscriptions look for changes.
+	PollInterval time.Duration
+	// VisibilityRecheckInterval is how often subscriptions re-check who may
+	// see the presence, so that revoked friendships and changed settings
+	// take effect without reconnecting.
+	VisibilityRecheckInterval time.Duration
+}
+
+// Presence is a user's state as seen by another user. LastSeenAt is the time
+// of the last heartbeat, zero when unknown or hidden from the viewer.
+type Presence struct {
+	userID     UserID
+	state      PresenceState
+	lastSeenAt time.Time
+}
+
+func NewPresence(userID UserID, state PresenceState, lastSeenAt time.Time) *Presence {
+	return &Presence{
+		userID:     userID,
+		state:      state,
+		lastSeenAt: lastSeenAt,
+	}
+}
+
+// OfflinePresence is the presence of a user with no heartbeat on record.
+func OfflinePresence(userID UserID) *Presence {
+	return NewPresence(userID, PresenceOffline, time.Time{})
+}
+
+func (p *Presence) UserID() UserID {
+	return p.userID
+}
+
+func (p *Presence) State() PresenceState {
+	return p.state
+}
+
+func (p *Presence) LastSeenAt() time.Time {
+	return p.lastSeenAt
+}
+
+// Hidden returns the presence as shown to viewers the user hides it from:
+// offline without a last-seen time, so that neither state changes nor
+// heartbeats give the user away.
+func (p *Presence) Hidden() *Presence {
+	return OfflinePresence(p.userID)
+}
@@ -0,0 +1,11 @@
+package models
+
+// UserSearch is a fuzzy query over user profiles. Nicknames are matched by
+// prefix and by trigram similarity, which tolerates typos; descriptions are
+// only searched when IncludeDescription is set.
+type UserSearch struct {
+	Query              string
+	IncludeDescription bool
+	Limit              int
+	Offset             int
+}
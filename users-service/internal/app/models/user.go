@@ -1,18 +1,33 @@
 package models
 
 import (
+	"strings"
+
 	"github.com/SamEkb/messenger-app/pkg/platform/errors"
 	"github.com/google/uuid"
 )
 
 type UserID uuid.UUID
 
+// AnonymizedEmailDomain is the domain of the placeholder email given to
+// anonymized profiles. The .invalid TLD cannot belong to a real address.
+const AnonymizedEmailDomain = "deleted.invalid"
+
+// InitialProfileVersion is the version of a newly created profile.
+const InitialProfileVersion int64 = 1
+
 type User struct {
 	id          UserID
 	email       string
 	nickname    string
 	description string
 	avatarUrl   string
+	// version grows by one on every saved change to the profile, so that
+	// consumers of profile events can drop the ones they have seen newer
+	// versions of.
+	version int64
+
+	lastSeenVisibility LastSeenVisibility
 }
 
 func NewUser(id UserID, email string, nickname string, description string, avatarUrl string) (*User, error) {
@@ -36,6 +51,9 @@ func NewUser(id UserID, email string, nickname string, description string, avata
 		email:       email,
 		description: description,
 		avatarUrl:   avatarUrl,
+		version:     InitialProfileVersion,
+
+		lastSeenVisibility: LastSeenEveryone,
 	}, nil
 }
 
@@ -75,3 +93,77 @@ func (u *User) Description() string {
 func (u *User) AvatarURL() string {
 	return u.avatarUrl
 }
+
+func (u *User) Version() int64 {
+	return u.version
+}
+
+// WithVersion returns a copy of the profile at the given version.
+func (u *User) WithVersion(version int64) *User {
+	c := *u
+	c.version = version
+	return &c
+}
+
+// ProfileEquals reports whether the two profiles show the same public
+// fields: nickname, description and avatar.
+func (u *User) ProfileEquals(other *User) bool {
+	return u.nickname == other.nickname &&
+		u.description == other.description &&
+		u.avatarUrl == other.avatarUrl
+}
+
+func (u *User) LastSeenVisibility() LastSeenVisibility {
+	return u.lastSeenVisibility
+}
+
+// WithLastSeenVisibility returns a copy of the profile with a new last-seen
+// privacy setting.
+func (u *User) WithLastSeenVisibility(visibility LastSeenVisibility) *User {
+	c := *u
+	c.lastSeenVisibility = visibility
+	return &c
+}
+
+// WithCredentials returns a copy of the profile with a new email and
+// nickname.
+func (u *User) WithCredentials(email, nickname string) (*User, error) {
+	return u.WithProfile(email, nickname, u.description, u.avatarUrl)
+}
+
+// WithProfile returns a copy of the profile with new editable fields. The
+// version and privacy settings are kept.
+func (u *User) WithProfile(email, nickname, description, avatarURL string) (*User, error) {
+	user, err := NewUser(u.id, email, nickname, description, avatarURL)
+	if err != nil {
+		return nil, err
+	}
+	return user.WithLastSeenVisibility(u.lastSeenVisibility).WithVersion(u.version), nil
+}
+
+// WithAvatarURL returns a copy of the profile with a new avatar.
+func (u *User) WithAvatarURL(avatarURL string) *User {
+	c := *u
+	c.avatarUrl = avatarURL
+	return &c
+}
+
+// Anonymized returns a copy of the profile with personal data removed, kept
+// so that references to the user stay valid after the account is deleted.
+// The placeholders are derived from the ID, which keeps them unique and makes
+// anonymizing twice produce the same profile.
+func (u *User) Anonymized() *User {
+	return &User{
+		id:       u.id,
+		email:    u.id.String() + "@" + AnonymizedEmailDomain,
+		nickname: "deleted-" + u.id.String(),
+		version:  u.version,
+
+		lastSeenVisibility: LastSeenEveryone,
+	}
+}
+
+// IsAnonymized reports whether the profile belongs to a deleted account.
+func (u *User) IsAnonymized() bool {
+	return strings.HasSuffix(u.email, "@"+AnonymizedEmailDomain)
+}
@@ -2,13 +2,41 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
 )
 
+type TxManager interface {
+	RunTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type UserRepository interface {
 	Create(ctx context.Context, user *models.User) (models.UserID, error)
 	Get(ctx context.Context, id models.UserID) (*models.User, error)
 	GetByNickname(ctx context.Context, nickname string) (*models.User, error)
-	Update(ctx context.Context, user *models.User) error
+	// GetByIDs returns the profiles of ids that exist, in no particular
+	// order.
+	GetByIDs(ctx context.Context, ids []models.UserID) ([]*models.User, error)
+	// Update saves the profile if the stored one is still at user.Version()
+	// and returns it as stored, one version newer; otherwise it fails with a
+	// conflict error. Privacy settings are left alone; they change through
+	// SetLastSeenVisibility only.
+	Update(ctx context.Context, user *models.User) (*models.User, error)
+	SetLastSeenVisibility(ctx context.Context, id models.UserID, visibility models.LastSeenVisibility) error
+	// Search returns profiles matching query, best matches first. Prefix
+	// matches on the nickname rank above fuzzy ones. Anonymized profiles are
+	// never returned.
+	Search(ctx context.Context, query models.UserSearch) ([]*models.User, error)
+}
+
+// PresenceRepository keeps heartbeats. A heartbeat holds its state for ttl;
+// afterwards the user reads as offline, last seen at that heartbeat.
+type PresenceRepository interface {
+	// Heartbeat records state for id. PresenceOffline ends the previous
+	// state at once.
+	Heartbeat(ctx context.Context, id models.UserID, state models.PresenceState, at time.Time, ttl time.Duration) error
+	// Get returns the presence of every id, offline with no last-seen time
+	// for users never seen.
+	Get(ctx context.Context, ids []models.UserID) ([]*models.Presence, error)
 }
@@ -0,0 +1,34 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/pkg/api/events/v1"
+)
+
+// ProfileEventsOutbox records profile events in the caller's transaction.
+// They reach Kafka after commit, so an event is published if and only if the
+// change that produced it is committed.
+type ProfileEventsOutbox interface {
+	EnqueueUserProfileUpdatedEvent(ctx context.Context, event *events.UserProfileUpdatedEvent) error
+}
+
+// BlobStore keeps uploaded media such as avatars. Keys are slash-separated
+// paths.
+type BlobStore interface {
+	// Put stores data under key, replacing what was there.
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Get returns the data stored under key and its content type.
+	Get(ctx context.Context, key string) ([]byte, string, error)
+	// Delete removes key. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+	// URL returns the address clients fetch key from.
+	URL(key string) string
+}
+
+// FriendshipChecker asks friends-service about friendships.
+type FriendshipChecker interface {
+	// FriendsOf reports for each of otherIDs whether it has an accepted
+	// friendship with userID, asking friends-service once for all of them.
+	FriendsOf(ctx context.Context, userID string, otherIDs []string) (map[string]bool, error)
+}
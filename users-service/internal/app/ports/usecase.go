@@ -2,13 +2,35 @@ package ports
 
 import (
 	"context"
+	"time"
 )
 
 type UserUseCase interface {
 	Create(ctx context.Context, dto *UserDto) (string, error)
 	Get(ctx context.Context, id string) (*UserDto, error)
 	GetByNickname(ctx context.Context, nickname string) (*UserDto, error)
-	Update(ctx context.Context, dto *UserDto) error
+	// Update changes the caller's profile and returns it as stored.
+	Update(ctx context.Context, dto *UpdateUserDto) (*UserDto, error)
+	Anonymize(ctx context.Context, id string) error
+	// UpdateCredentials copies the email and nickname of dto.ID from
+	// auth-service, which owns them.
+	UpdateCredentials(ctx context.Context, dto *UserDto) error
+	Search(ctx context.Context, dto *SearchUsersDto) (*SearchUsersResultDto, error)
+	// UploadAvatar stores a new avatar of the caller, with thumbnails, and
+	// points the profile at it.
+	UploadAvatar(ctx context.Context, dto *UploadAvatarDto) (*AvatarDto, error)
+}
+
+// PresenceUseCase tracks whether users are online. Clients heartbeat while
+// in use; users whose heartbeat lapses become offline.
+type PresenceUseCase interface {
+	// Heartbeat records the caller's state and returns how long it holds.
+	Heartbeat(ctx context.Context, state string) (time.Duration, error)
+	GetPresence(ctx context.Context, ids []string) ([]*PresenceDto, error)
+	// SubscribePresence calls send with the presence of ids, first for all of
+	// them and then for those that changed, until ctx is done.
+	SubscribePresence(ctx context.Context, ids []string, send func([]*PresenceDto) error) error
+	SetLastSeenVisibility(ctx context.Context, visibility string) error
 }
 
 type UserDto struct {
@@ -17,4 +39,47 @@ type UserDto struct {
 	Nickname    string
 	Description string
 	AvatarURL   string
+	Version     int64
+}
+
+type UpdateUserDto struct {
+	Profile *UserDto
+	// UpdateMask names the fields of Profile to update, as in the API:
+	// nickname, description and avatar_url. When empty, the non-empty fields
+	// of Profile are updated. Profile.Email is never applied.
+	UpdateMask []string
+	// ExpectedVersion is the profile version the update is based on; zero
+	// skips the check.
+	ExpectedVersion int64
+}
+
+type SearchUsersDto struct {
+	Query              string
+	IncludeDescription bool
+	Limit              int
+	Offset             int
+}
+
+type SearchUsersResultDto struct {
+	Users []*UserDto
+	// HasMore reports whether another page follows at Offset+Limit.
+	HasMore bool
+}
+
+type UploadAvatarDto struct {
+	ContentType string
+	Data        []byte
+}
+
+type AvatarDto struct {
+	AvatarURL string
+	// ThumbnailURLs maps the edge of each square thumbnail to its URL.
+	ThumbnailURLs map[int]string
+}
+
+type PresenceDto struct {
+	UserID string
+	State  string
+	// LastSeenAt is zero when unknown or hidden from the caller.
+	LastSeenAt time.Time
 }
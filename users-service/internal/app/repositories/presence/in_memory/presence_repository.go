@@ -0,0 +1,70 @@
+package in_memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
+)
+
+var _ ports.PresenceRepository = (*PresenceRepository)(nil)
+
+type presenceEntry struct {
+	state      models.PresenceState
+	lastSeenAt time.Time
+	expiresAt  time.Time
+}
+
+// PresenceRepository keeps presence in process memory. Entries expire
+// lazily on read; the last-seen time outlives them.
+type PresenceRepository struct {
+	mu      sync.RWMutex
+	entries map[models.UserID]presenceEntry
+
+	logger logger.Logger
+}
+
+func NewPresenceRepository(logger logger.Logger) *PresenceRepository {
+	return &PresenceRepository{
+		entries: make(map[models.UserID]presenceEntry),
+		logger:  logger.With("component", "presence_repository"),
+	}
+}
+
+func (r *PresenceRepository) Heartbeat(ctx context.Context, id models.UserID, state models.PresenceState, at time.Time, ttl time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry := presenceEntry{state: state, lastSeenAt: at, expiresAt: at.Add(ttl)}
+	if state == models.PresenceOffline {
+		entry.expiresAt = at
+	}
+	r.entries[id] = entry
+
+	r.logger.Debug("heartbeat recorded", "user_id", id, "state", state)
+	return nil
+}
+
+func (r *PresenceRepository) Get(ctx context.Context, ids []models.UserID) ([]*models.Presence, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	result := make([]*models.Presence, 0, len(ids))
+	for _, id := range ids {
+		entry, ok := r.entries[id]
+		switch {
+		case !ok:
+			result = append(result, models.OfflinePresence(id))
+		case now.Before(entry.expiresAt):
+			result = append(result, models.NewPresence(id, entry.state, entry.lastSeenAt))
+		default:
+			result = append(result, models.NewPresence(id, models.PresenceOffline, entry.lastSeenAt))
+		}
+	}
+
+	return result, nil
+}
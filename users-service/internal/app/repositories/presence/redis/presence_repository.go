@@ -0,0 +1,95 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ ports.PresenceRepository = (*PresenceRepository)(nil)
+
+// Presence keys: the state expires with the heartbeat, the last-seen time
+// is kept until the user is seen again.
+const (
+	stateKeyPrefix    = "users:presence:"
+	lastSeenKeyPrefix = "users:last_seen:"
+)
+
+// PresenceRepository keeps presence in Redis, shared by all replicas.
+type PresenceRepository struct {
+	client redis.UniversalClient
+	logger logger.Logger
+}
+
+func NewPresenceRepository(client redis.UniversalClient, logger logger.Logger) *PresenceRepository {
+	return &PresenceRepository{
+		client: client,
+		logger: logger.With("component", "presence_repository"),
+	}
+}
+
+func (r *PresenceRepository) Heartbeat(ctx context.Context, id models.UserID, state models.PresenceState, at time.Time, ttl time.Duration) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if state == models.PresenceOffline {
+			pipe.Del(ctx, stateKeyPrefix+id.String())
+		} else {
+			pipe.Set(ctx, stateKeyPrefix+id.String(), string(state), ttl)
+		}
+		pipe.Set(ctx, lastSeenKeyPrefix+id.String(), at.UnixMilli(), 0)
+		return nil
+	})
+	if err != nil {
+		r.logger.Error("failed to record heartbeat", "user_id", id, "error", err)
+		return errors.NewInternalError(err, "failed to record heartbeat")
+	}
+
+	r.logger.Debug("heartbeat recorded", "user_id", id, "state", state)
+	return nil
+}
+
+func (r *PresenceRepository) Get(ctx context.Context, ids []models.UserID) ([]*models.Presence, error) {
+	if len(ids) == 0 {
+		return []*models.Presence{}, nil
+	}
+
+	keys := make([]string, 0, 2*len(ids))
+	for _, id := range ids {
+		keys = append(keys, stateKeyPrefix+id.String())
+	}
+	for _, id := range ids {
+		keys = append(keys, lastSeenKeyPrefix+id.String())
+	}
+
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		r.logger.Error("failed to get presence", "error", err)
+		return nil, errors.NewInternalError(err, "failed to get presence")
+	}
+
+	result := make([]*models.Presence, 0, len(ids))
+	for i, id := range ids {
+		state := models.PresenceOffline
+		if value, ok := values[i].(string); ok {
+			if parsed, err := models.ParsePresenceState(value); err == nil {
+				state = parsed
+			}
+		}
+
+		var lastSeenAt time.Time
+		if value, ok := values[len(ids)+i].(string); ok {
+			if millis, err := strconv.ParseInt(value, 10, 64); err == nil {
+				lastSeenAt = time.UnixMilli(millis)
+			}
+		}
+
+		result = append(result, models.NewPresence(id, state, lastSeenAt))
+	}
+
+	return result, nil
+}
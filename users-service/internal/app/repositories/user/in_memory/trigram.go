@@ -0,0 +1,57 @@
+package in_memory
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Defaults of pg_trgm.similarity_threshold and
+// pg_trgm.word_similarity_threshold, so the in-memory search matches what
+// postgres returns.
+const (
+	similarityThreshold     = 0.3
+	wordSimilarityThreshold = 0.6
+)
+
+// trigrams returns the trigram set of s the way pg_trgm builds it: words of
+// letters and digits, lower-cased and padded with two spaces in front and one
+// behind.
+func trigrams(s string) map[string]struct{} {
+	set := make(map[string]struct{})
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = struct{}{}
+		}
+	}
+	return set
+}
+
+// similarity is pg_trgm's similarity(): shared trigrams over all trigrams.
+func similarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for t := range ta {
+		if _, ok := tb[t]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(ta)+len(tb)-shared)
+}
+
+// wordSimilarity approximates pg_trgm's word_similarity() with the best
+// similarity between query and a single word of text.
+func wordSimilarity(query, text string) float64 {
+	best := 0.0
+	for _, word := range strings.Fields(text) {
+		best = max(best, similarity(query, word))
+	}
+	return best
+}
@@ -2,6 +2,8 @@ package in_memory
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/SamEkb/messenger-app/pkg/platform/errors"
@@ -77,18 +79,115 @@ func (r *UserRepository) GetByNickname(ctx context.Context, nickname string) (*m
 	return nil, errors.NewNotFoundError("user with nickname %s not found", nickname)
 }
 
-func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
+func (r *UserRepository) GetByIDs(ctx context.Context, ids []models.UserID) ([]*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	r.logger.Debug("attempting to get users", "count", len(ids))
+
+	result := make([]*models.User, 0, len(ids))
+	for _, id := range ids {
+		if user, ok := r.users[id]; ok {
+			result = append(result, user)
+		}
+	}
+
+	r.logger.Info("users found", "requested", len(ids), "count", len(result))
+	return result, nil
+}
+
+func (r *UserRepository) Update(ctx context.Context, user *models.User) (*models.User, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	r.logger.Debug("attempting to update user", "user_id", user.ID(), "email", user.Email())
 
-	if _, ok := r.users[user.ID()]; !ok {
+	existing, ok := r.users[user.ID()]
+	if !ok {
 		r.logger.Error("user not found", "user_id", user.ID(), "email", user.Email())
-		return errors.NewNotFoundError("user with id %s not found", user.ID())
+		return nil, errors.NewNotFoundError("user with id %s not found", user.ID())
+	}
+	if existing.Version() != user.Version() {
+		r.logger.Warn("stale user update", "user_id", user.ID(), "version", user.Version(), "stored_version", existing.Version())
+		return nil, errors.NewConflictError("user %s has changed since version %d", user.ID(), user.Version()).
+			WithDetails("version", existing.Version())
 	}
 
-	r.users[user.ID()] = user
-	r.logger.Info("user updated", "user_id", user.ID(), "email", user.Email())
+	updated := user.
+		WithLastSeenVisibility(existing.LastSeenVisibility()).
+		WithVersion(existing.Version() + 1)
+	r.users[user.ID()] = updated
+	r.logger.Info("user updated", "user_id", user.ID(), "email", user.Email(), "version", updated.Version())
+	return updated, nil
+}
+
+func (r *UserRepository) SetLastSeenVisibility(ctx context.Context, id models.UserID, visibility models.LastSeenVisibility) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.logger.Debug("attempting to set last seen visibility", "user_id", id, "visibility", visibility)
+
+	user, ok := r.users[id]
+	if !ok {
+		r.logger.Error("user not found", "user_id", id)
+		return errors.NewNotFoundError("user with id %s not found", id)
+	}
+
+	r.users[id] = user.WithLastSeenVisibility(visibility)
+	r.logger.Info("last seen visibility set", "user_id", id, "visibility", visibility)
 	return nil
 }
+
+func (r *UserRepository) Search(ctx context.Context, query models.UserSearch) ([]*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	r.logger.Debug("attempting to search users", "query", query.Query)
+
+	type match struct {
+		user   *models.User
+		prefix bool
+		score  float64
+	}
+
+	prefix := strings.ToLower(query.Query)
+	matches := make([]match, 0)
+	for _, user := range r.users {
+		if user.IsAnonymized() {
+			continue
+		}
+
+		m := match{
+			user:   user,
+			prefix: strings.HasPrefix(strings.ToLower(user.Nickname()), prefix),
+			score:  similarity(user.Nickname(), query.Query),
+		}
+		found := m.prefix || m.score >= similarityThreshold
+		if query.IncludeDescription {
+			descScore := wordSimilarity(query.Query, user.Description())
+			m.score = max(m.score, descScore)
+			found = found || descScore >= wordSimilarityThreshold
+		}
+		if found {
+			matches = append(matches, m)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].prefix != matches[j].prefix {
+			return matches[i].prefix
+		}
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].user.Nickname() < matches[j].user.Nickname()
+	})
+
+	result := make([]*models.User, 0, query.Limit)
+	for i := query.Offset; i < len(matches) && len(result) < query.Limit; i++ {
+		result = append(result, matches[i].user)
+	}
+
+	r.logger.Info("users found", "query", query.Query, "count", len(result))
+	return result, nil
+}
@@ -2,6 +2,8 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"strings"
 
 	"github.com/SamEkb/messenger-app/pkg/platform/errors"
 	"github.com/SamEkb/messenger-app/pkg/platform/logger"
@@ -12,6 +14,48 @@ import (
 
 var _ ports.UserRepository = (*UserRepository)(nil)
 
+const userColumns = "id, email, nickname, description, avatar_url, version, last_seen_visibility"
+
+type userRow struct {
+	ID                 string `db:"id"`
+	Email              string `db:"email"`
+	Nickname           string `db:"nickname"`
+	Description        string `db:"description"`
+	AvatarURL          string `db:"avatar_url"`
+	Version            int64  `db:"version"`
+	LastSeenVisibility string `db:"last_seen_visibility"`
+}
+
+func (row userRow) toModel() (*models.User, error) {
+	userID, err := models.ParseUserID(row.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := models.NewUser(userID, row.Email, row.Nickname, row.Description, row.AvatarURL)
+	if err != nil {
+		return nil, err
+	}
+
+	visibility, err := models.ParseLastSeenVisibility(row.LastSeenVisibility)
+	if err != nil {
+		return nil, err
+	}
+	return user.WithLastSeenVisibility(visibility).WithVersion(row.Version), nil
+}
+
+func toModels(rows []userRow) ([]*models.User, error) {
+	users := make([]*models.User, 0, len(rows))
+	for _, row := range rows {
+		user, err := row.toModel()
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
 type UserRepository struct {
 	txManager *postgres.TxManager
 	logger    logger.Logger
@@ -29,9 +73,9 @@ func (r *UserRepository) Create(ctx context.Context, user *models.User) (models.
 
 	q := r.txManager.GetQueryEngine(ctx)
 	_, err := q.ExecContext(ctx, `
-		INSERT INTO users (id, email, nickname, description, avatar_url)
-		VALUES ($1, $2, $3, $4, $5)
-	`, user.ID(), user.Email(), user.Nickname(), user.Description(), user.AvatarURL())
+		INSERT INTO users (id, email, nickname, description, avatar_url, last_seen_visibility)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, user.ID(), user.Email(), user.Nickname(), user.Description(), user.AvatarURL(), user.LastSeenVisibility())
 	if err != nil {
 		r.logger.Error("failed to create user", "error", err)
 		return models.UserID{}, errors.NewAlreadyExistsError("user with nickname %s or email %s already exists",
@@ -46,33 +90,25 @@ func (r *UserRepository) Get(ctx context.Context, id models.UserID) (*models.Use
 	r.logger.Debug("attempting to get user", "user_id", id)
 
 	q := r.txManager.GetQueryEngine(ctx)
-	var user struct {
-		ID          string `db:"id"`
-		Email       string `db:"email"`
-		Nickname    string `db:"nickname"`
-		Description string `db:"description"`
-		AvatarURL   string `db:"avatar_url"`
-	}
+	var user userRow
 
 	err := q.GetContext(ctx, &user, `
-		SELECT id, email, nickname, description, avatar_url 
-		FROM users 
+		SELECT `+userColumns+`
+		FROM users
 		WHERE id = $1
 	`, id)
-	if err != nil {
-		r.logger.Error("user not found", "user_id", id, "error", err)
+	if errors.Is(err, sql.ErrNoRows) {
+		r.logger.Debug("user not found", "user_id", id)
 		return nil, errors.NewNotFoundError("user with id %s not found", id)
 	}
-
-	userID, err := models.ParseUserID(user.ID)
 	if err != nil {
-		r.logger.Error("failed to parse user ID", "id", user.ID, "error", err)
-		return nil, err
+		r.logger.Error("failed to get user", "user_id", id, "error", err)
+		return nil, errors.NewInternalError(err, "failed to get user")
 	}
 
-	result, err := models.NewUser(userID, user.Email, user.Nickname, user.Description, user.AvatarURL)
+	result, err := user.toModel()
 	if err != nil {
-		r.logger.Error("failed to create user model", "error", err)
+		r.logger.Error("failed to create user model", "id", user.ID, "error", err)
 		return nil, err
 	}
 
@@ -84,51 +120,165 @@ func (r *UserRepository) GetByNickname(ctx context.Context, nickname string) (*m
 	r.logger.Debug("attempting to get user by nickname", "nickname", nickname)
 
 	q := r.txManager.GetQueryEngine(ctx)
-	var user struct {
-		ID          string `db:"id"`
-		Email       string `db:"email"`
-		Nickname    string `db:"nickname"`
-		Description string `db:"description"`
-		AvatarURL   string `db:"avatar_url"`
-	}
+	var user userRow
 
 	err := q.GetContext(ctx, &user, `
-		SELECT id, email, nickname, description, avatar_url 
-		FROM users 
+		SELECT `+userColumns+`
+		FROM users
 		WHERE nickname = $1
 	`, nickname)
-	if err != nil {
-		r.logger.Error("user not found", "nickname", nickname, "error", err)
+	if errors.Is(err, sql.ErrNoRows) {
+		r.logger.Debug("user not found", "nickname", nickname)
 		return nil, errors.NewNotFoundError("user with nickname %s not found", nickname)
 	}
+	if err != nil {
+		r.logger.Error("failed to get user by nickname", "nickname", nickname, "error", err)
+		return nil, errors.NewInternalError(err, "failed to get user by nickname")
+	}
 
-	userID, err := models.ParseUserID(user.ID)
+	result, err := user.toModel()
 	if err != nil {
-		r.logger.Error("failed to parse user ID", "id", user.ID, "error", err)
+		r.logger.Error("failed to create user model", "id", user.ID, "error", err)
 		return nil, err
 	}
 
-	result, err := models.NewUser(userID, user.Email, user.Nickname, user.Description, user.AvatarURL)
+	r.logger.Info("user found", "nickname", nickname, "user_id", result.ID())
+	return result, nil
+}
+
+func (r *UserRepository) GetByIDs(ctx context.Context, ids []models.UserID) ([]*models.User, error) {
+	r.logger.Debug("attempting to get users", "count", len(ids))
+
+	if len(ids) == 0 {
+		return []*models.User{}, nil
+	}
+
+	idStrings := make([]string, 0, len(ids))
+	for _, id := range ids {
+		idStrings = append(idStrings, id.String())
+	}
+
+	q := r.txManager.GetQueryEngine(ctx)
+	var rows []userRow
+	err := q.SelectContext(ctx, &rows, `
+		SELECT `+userColumns+`
+		FROM users
+		WHERE id = ANY(string_to_array($1, ',')::uuid[])
+	`, strings.Join(idStrings, ","))
+	if err != nil {
+		r.logger.Error("failed to get users", "error", err)
+		return nil, errors.NewInternalError(err, "failed to get users")
+	}
+
+	result, err := toModels(rows)
 	if err != nil {
-		r.logger.Error("failed to create user model", "error", err)
+		r.logger.Error("failed to create user models", "error", err)
 		return nil, err
 	}
 
-	r.logger.Info("user found", "nickname", nickname, "user_id", userID)
+	r.logger.Info("users found", "requested", len(ids), "count", len(result))
 	return result, nil
 }
 
-func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
+func (r *UserRepository) Update(ctx context.Context, user *models.User) (*models.User, error) {
 	r.logger.Debug("attempting to update user", "user_id", user.ID(), "email", user.Email())
 
 	q := r.txManager.GetQueryEngine(ctx)
-	result, err := q.ExecContext(ctx, `
-		UPDATE users 
-		SET email = $1, nickname = $2, description = $3, avatar_url = $4 
-		WHERE id = $5
-	`, user.Email(), user.Nickname(), user.Description(), user.AvatarURL(), user.ID())
+	var row userRow
+	err := q.GetContext(ctx, &row, `
+		UPDATE users
+		SET email = $1, nickname = $2, description = $3, avatar_url = $4, version = version + 1
+		WHERE id = $5 AND version = $6
+		RETURNING `+userColumns+`
+	`, user.Email(), user.Nickname(), user.Description(), user.AvatarURL(), user.ID(), user.Version())
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, r.updateMissed(ctx, user)
+	}
 	if err != nil {
 		r.logger.Error("failed to update user", "user_id", user.ID(), "error", err)
+		return nil, errors.NewInternalError(err, "failed to update user")
+	}
+
+	result, err := row.toModel()
+	if err != nil {
+		r.logger.Error("failed to create user model", "id", row.ID, "error", err)
+		return nil, err
+	}
+
+	r.logger.Info("user updated", "user_id", user.ID(), "email", user.Email(), "version", result.Version())
+	return result, nil
+}
+
+// likeEscaper escapes LIKE wildcards so the query is matched literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (r *UserRepository) Search(ctx context.Context, query models.UserSearch) ([]*models.User, error) {
+	r.logger.Debug("attempting to search users", "query", query.Query)
+
+	q := r.txManager.GetQueryEngine(ctx)
+	var rows []userRow
+
+	// % and <% use the pg_trgm similarity thresholds and are served by the
+	// trigram indexes, as is the ILIKE prefix match.
+	err := q.SelectContext(ctx, &rows, `
+		SELECT `+userColumns+`
+		FROM users
+		WHERE email NOT LIKE $6
+		  AND (nickname ILIKE $2 OR nickname % $1 OR ($3 AND $1 <% description))
+		ORDER BY nickname ILIKE $2 DESC,
+		         GREATEST(similarity(nickname, $1), CASE WHEN $3 THEN word_similarity($1, description) ELSE 0 END) DESC,
+		         nickname
+		LIMIT $4 OFFSET $5
+	`, query.Query, likeEscaper.Replace(query.Query)+"%", query.IncludeDescription, query.Limit, query.Offset,
+		"%@"+models.AnonymizedEmailDomain)
+	if err != nil {
+		r.logger.Error("failed to search users", "query", query.Query, "error", err)
+		return nil, errors.NewInternalError(err, "failed to search users")
+	}
+
+	result, err := toModels(rows)
+	if err != nil {
+		r.logger.Error("failed to create user models", "error", err)
+		return nil, err
+	}
+
+	r.logger.Info("users found", "query", query.Query, "count", len(result))
+	return result, nil
+}
+
+// updateMissed explains why Update matched no row: the user is gone, or the
+// profile moved past the version the update was based on.
+func (r *UserRepository) updateMissed(ctx context.Context, user *models.User) error {
+	q := r.txManager.GetQueryEngine(ctx)
+	var version int64
+	err := q.GetContext(ctx, &version, `
+		SELECT version
+		FROM users
+		WHERE id = $1
+	`, user.ID())
+	if errors.Is(err, sql.ErrNoRows) {
+		r.logger.Error("user not found", "user_id", user.ID())
+		return errors.NewNotFoundError("user with id %s not found", user.ID())
+	}
+	if err != nil {
+		r.logger.Error("failed to get user version", "user_id", user.ID(), "error", err)
+		return errors.NewInternalError(err, "failed to update user")
+	}
+
+	r.logger.Warn("stale user update", "user_id", user.ID(), "version", user.Version(), "stored_version", version)
+	return errors.NewConflictError("user %s has changed since version %d", user.ID(), user.Version()).
+		WithDetails("version", version)
+}
+
+func (r *UserRepository) SetLastSeenVisibility(ctx context.Context, id models.UserID, visibility models.LastSeenVisibility) error {
+	r.logger.Debug("attempting to set last seen visibility", "user_id", id, "visibility", visibility)
+
+	q := r.txManager.GetQueryEngine(ctx)
+	result, err := q.ExecContext(ctx, `
+		UPDATE users SET last_seen_visibility = $1 WHERE id = $2
+	`, visibility, id)
+	if err != nil {
+		r.logger.Error("failed to set last seen visibility", "user_id", id, "error", err)
 		return errors.NewInternalError(err, "failed to update user")
 	}
 
@@ -139,10 +289,10 @@ func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
 	}
 
 	if rows == 0 {
-		r.logger.Error("user not found", "user_id", user.ID())
-		return errors.NewNotFoundError("user with id %s not found", user.ID())
+		r.logger.Error("user not found", "user_id", id)
+		return errors.NewNotFoundError("user with id %s not found", id)
 	}
 
-	r.logger.Info("user updated", "user_id", user.ID(), "email", user.Email())
+	r.logger.Info("last seen visibility set", "user_id", id, "visibility", visibility)
 	return nil
 }
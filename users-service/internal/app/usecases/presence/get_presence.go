@@ -0,0 +1,116 @@
+package presence
+
+import (
+	"context"
+
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
+)
+
+const maxPresenceUsers = 100
+
+func (uc *UseCase) GetPresence(ctx context.Context, ids []string) ([]*ports.PresenceDto, error) {
+	callerID, err := platformauth.UserIDFromContext(ctx)
+	if err != nil {
+		uc.logger.Warn("Unauthenticated presence request")
+		return nil, err
+	}
+
+	targets, err := parseTargets(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.Debug("Getting presence", "user_id", callerID, "count", len(targets))
+
+	visible, err := uc.presenceVisibility(ctx, callerID, targets)
+	if err != nil {
+		return nil, err
+	}
+
+	presence, err := uc.presenceRepository.Get(ctx, targets)
+	if err != nil {
+		uc.logger.Error("Failed to get presence", "error", err)
+		return nil, err
+	}
+
+	return toPresenceDtos(presence, visible), nil
+}
+
+// parseTargets parses and deduplicates the users whose presence is asked
+// for.
+func parseTargets(ids []string) ([]models.UserID, error) {
+	if len(ids) == 0 {
+		return nil, errors.NewValidationError("user ids cannot be empty")
+	}
+	if len(ids) > maxPresenceUsers {
+		return nil, errors.NewValidationError("cannot ask for more than %d users at once", maxPresenceUsers)
+	}
+
+	seen := make(map[models.UserID]bool, len(ids))
+	targets := make([]models.UserID, 0, len(ids))
+	for _, raw := range ids {
+		id, err := models.ParseUserID(raw)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[id] {
+			seen[id] = true
+			targets = append(targets, id)
+		}
+	}
+	return targets, nil
+}
+
+// presenceVisibility reports for each target whether the caller may see its
+// presence. Friendships are checked in one call; when friends-service cannot
+// answer, the presence is hidden.
+func (uc *UseCase) presenceVisibility(ctx context.Context, callerID string, targets []models.UserID) (map[models.UserID]bool, error) {
+	users, err := uc.userRepository.GetByIDs(ctx, targets)
+	if err != nil {
+		uc.logger.Error("Failed to get users", "error", err)
+		return nil, err
+	}
+
+	visible := make(map[models.UserID]bool, len(users))
+	var friendsOnly []string
+	for _, user := range users {
+		if user.ID().String() == callerID || user.LastSeenVisibility() == models.LastSeenEveryone {
+			visible[user.ID()] = true
+			continue
+		}
+		friendsOnly = append(friendsOnly, user.ID().String())
+	}
+	if len(friendsOnly) == 0 {
+		return visible, nil
+	}
+
+	friends, err := uc.friendshipChecker.FriendsOf(ctx, callerID, friendsOnly)
+	if err != nil {
+		uc.logger.Warn("Failed to check friendships, hiding presence", "error", err, "count", len(friendsOnly))
+		return visible, nil
+	}
+	for _, user := range users {
+		if friends[user.ID().String()] {
+			visible[user.ID()] = true
+		}
+	}
+	return visible, nil
+}
+
+func toPresenceDtos(presence []*models.Presence, visible map[models.UserID]bool) []*ports.PresenceDto {
+	result := make([]*ports.PresenceDto, 0, len(presence))
+	for _, p := range presence {
+		if !visible[p.UserID()] {
+			p = p.Hidden()
+		}
+		result = append(result, &ports.PresenceDto{
+			UserID:     p.UserID().String(),
+			State:      string(p.State()),
+			LastSeenAt: p.LastSeenAt(),
+		})
+	}
+	return result
+}
@@ -0,0 +1,259 @@
+package presence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/usecases/presence/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newUser(t *testing.T, id models.UserID, visibility models.LastSeenVisibility) *models.User {
+	user, err := models.NewUser(id, id.String()+"@test.ru", "user-"+id.String(), "", "")
+	assert.NoError(t, err)
+	return user.WithLastSeenVisibility(visibility)
+}
+
+func TestUseCase_GetPresence(t *testing.T) {
+	callerID := models.UserID(uuid.New())
+	targetID := models.UserID(uuid.New())
+	strangerID := models.UserID(uuid.New())
+	ctx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: callerID.String(),
+	})
+	lastSeenAt := time.Now().Add(-time.Hour)
+
+	type args struct {
+		ctx context.Context
+		ids []string
+	}
+
+	tests := map[string]struct {
+		args        args
+		want        []*ports.PresenceDto
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"last seen visible to everyone": {
+			args: args{ctx: ctx, ids: []string{targetID.String(), targetID.String()}},
+			want: []*ports.PresenceDto{{UserID: targetID.String(), State: "offline", LastSeenAt: lastSeenAt}},
+			deps: func(t *testing.T) UseCase {
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					GetByIDs(ctx, []models.UserID{targetID}).
+					Return([]*models.User{newUser(t, targetID, models.LastSeenEveryone)}, nil).
+					Once()
+
+				mockPresenceRepository := mocks.NewPresenceRepository(t)
+				mockPresenceRepository.EXPECT().
+					Get(ctx, []models.UserID{targetID}).
+					Return([]*models.Presence{models.NewPresence(targetID, models.PresenceOffline, lastSeenAt)}, nil).
+					Once()
+
+				return UseCase{
+					presenceRepository: mockPresenceRepository,
+					userRepository:     mockUserRepository,
+					logger:             logger.NewMockLogger(),
+				}
+			},
+		},
+		"last seen shown to friends": {
+			args: args{ctx: ctx, ids: []string{targetID.String()}},
+			want: []*ports.PresenceDto{{UserID: targetID.String(), State: "online", LastSeenAt: lastSeenAt}},
+			deps: func(t *testing.T) UseCase {
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					GetByIDs(ctx, []models.UserID{targetID}).
+					Return([]*models.User{newUser(t, targetID, models.LastSeenFriends)}, nil).
+					Once()
+
+				mockFriendshipChecker := mocks.NewFriendshipChecker(t)
+				mockFriendshipChecker.EXPECT().FriendsOf(ctx, callerID.String(), []string{targetID.String()}).Return(map[string]bool{targetID.String(): true}, nil).Once()
+
+				mockPresenceRepository := mocks.NewPresenceRepository(t)
+				mockPresenceRepository.EXPECT().
+					Get(ctx, []models.UserID{targetID}).
+					Return([]*models.Presence{models.NewPresence(targetID, models.PresenceOnline, lastSeenAt)}, nil).
+					Once()
+
+				return UseCase{
+					presenceRepository: mockPresenceRepository,
+					userRepository:     mockUserRepository,
+					friendshipChecker:  mockFriendshipChecker,
+					logger:             logger.NewMockLogger(),
+				}
+			},
+		},
+		"presence hidden from non-friends": {
+			args: args{ctx: ctx, ids: []string{targetID.String()}},
+			want: []*ports.PresenceDto{{UserID: targetID.String(), State: "offline"}},
+			deps: func(t *testing.T) UseCase {
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					GetByIDs(ctx, []models.UserID{targetID}).
+					Return([]*models.User{newUser(t, targetID, models.LastSeenFriends)}, nil).
+					Once()
+
+				mockFriendshipChecker := mocks.NewFriendshipChecker(t)
+				mockFriendshipChecker.EXPECT().FriendsOf(ctx, callerID.String(), []string{targetID.String()}).Return(map[string]bool{targetID.String(): false}, nil).Once()
+
+				mockPresenceRepository := mocks.NewPresenceRepository(t)
+				mockPresenceRepository.EXPECT().
+					Get(ctx, []models.UserID{targetID}).
+					Return([]*models.Presence{models.NewPresence(targetID, models.PresenceAway, lastSeenAt)}, nil).
+					Once()
+
+				return UseCase{
+					presenceRepository: mockPresenceRepository,
+					userRepository:     mockUserRepository,
+					friendshipChecker:  mockFriendshipChecker,
+					logger:             logger.NewMockLogger(),
+				}
+			},
+		},
+		"friendships checked in one call": {
+			args: args{ctx: ctx, ids: []string{targetID.String(), strangerID.String()}},
+			want: []*ports.PresenceDto{
+				{UserID: targetID.String(), State: "online", LastSeenAt: lastSeenAt},
+				{UserID: strangerID.String(), State: "offline"},
+			},
+			deps: func(t *testing.T) UseCase {
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					GetByIDs(ctx, []models.UserID{targetID, strangerID}).
+					Return([]*models.User{
+						newUser(t, targetID, models.LastSeenFriends),
+						newUser(t, strangerID, models.LastSeenFriends),
+					}, nil).
+					Once()
+
+				mockFriendshipChecker := mocks.NewFriendshipChecker(t)
+				mockFriendshipChecker.EXPECT().
+					FriendsOf(ctx, callerID.String(), []string{targetID.String(), strangerID.String()}).
+					Return(map[string]bool{targetID.String(): true, strangerID.String(): false}, nil).
+					Once()
+
+				mockPresenceRepository := mocks.NewPresenceRepository(t)
+				mockPresenceRepository.EXPECT().
+					Get(ctx, []models.UserID{targetID, strangerID}).
+					Return([]*models.Presence{
+						models.NewPresence(targetID, models.PresenceOnline, lastSeenAt),
+						models.NewPresence(strangerID, models.PresenceOnline, lastSeenAt),
+					}, nil).
+					Once()
+
+				return UseCase{
+					presenceRepository: mockPresenceRepository,
+					userRepository:     mockUserRepository,
+					friendshipChecker:  mockFriendshipChecker,
+					logger:             logger.NewMockLogger(),
+				}
+			},
+		},
+		"presence hidden when friendship unknown": {
+			args: args{ctx: ctx, ids: []string{targetID.String()}},
+			want: []*ports.PresenceDto{{UserID: targetID.String(), State: "offline"}},
+			deps: func(t *testing.T) UseCase {
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					GetByIDs(ctx, []models.UserID{targetID}).
+					Return([]*models.User{newUser(t, targetID, models.LastSeenFriends)}, nil).
+					Once()
+
+				mockFriendshipChecker := mocks.NewFriendshipChecker(t)
+				mockFriendshipChecker.EXPECT().
+					FriendsOf(ctx, callerID.String(), []string{targetID.String()}).
+					Return(nil, errors.NewServiceError(assert.AnError, "failed to check friendship status")).
+					Once()
+
+				mockPresenceRepository := mocks.NewPresenceRepository(t)
+				mockPresenceRepository.EXPECT().
+					Get(ctx, []models.UserID{targetID}).
+					Return([]*models.Presence{models.NewPresence(targetID, models.PresenceOnline, lastSeenAt)}, nil).
+					Once()
+
+				return UseCase{
+					presenceRepository: mockPresenceRepository,
+					userRepository:     mockUserRepository,
+					friendshipChecker:  mockFriendshipChecker,
+					logger:             logger.NewMockLogger(),
+				}
+			},
+		},
+		"own last seen always visible": {
+			args: args{ctx: ctx, ids: []string{callerID.String()}},
+			want: []*ports.PresenceDto{{UserID: callerID.String(), State: "online", LastSeenAt: lastSeenAt}},
+			deps: func(t *testing.T) UseCase {
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					GetByIDs(ctx, []models.UserID{callerID}).
+					Return([]*models.User{newUser(t, callerID, models.LastSeenFriends)}, nil).
+					Once()
+
+				mockPresenceRepository := mocks.NewPresenceRepository(t)
+				mockPresenceRepository.EXPECT().
+					Get(ctx, []models.UserID{callerID}).
+					Return([]*models.Presence{models.NewPresence(callerID, models.PresenceOnline, lastSeenAt)}, nil).
+					Once()
+
+				return UseCase{
+					presenceRepository: mockPresenceRepository,
+					userRepository:     mockUserRepository,
+					logger:             logger.NewMockLogger(),
+				}
+			},
+		},
+		"no user ids": {
+			args:        args{ctx: ctx},
+			wantErr:     true,
+			expectedErr: errors.NewValidationError("user ids cannot be empty"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{logger: logger.NewMockLogger()}
+			},
+		},
+		"invalid user id": {
+			args:        args{ctx: ctx, ids: []string{"not-a-uuid"}},
+			wantErr:     true,
+			expectedErr: errors.NewInvalidInputError("invalid UUID format: not-a-uuid"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{logger: logger.NewMockLogger()}
+			},
+		},
+		"unauthenticated": {
+			args:        args{ctx: context.Background(), ids: []string{targetID.String()}},
+			wantErr:     true,
+			expectedErr: errors.NewUnauthorizedError("request is not authenticated"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{logger: logger.NewMockLogger()}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			result, err := useCase.GetPresence(tc.args.ctx, tc.args.ids)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.want, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package presence
+
+import (
+	"context"
+	"time"
+
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
+)
+
+func (uc *UseCase) Heartbeat(ctx context.Context, state string) (time.Duration, error) {
+	callerID, err := platformauth.UserIDFromContext(ctx)
+	if err != nil {
+		uc.logger.Warn("Unauthenticated heartbeat")
+		return 0, err
+	}
+
+	id, err := models.ParseUserID(callerID)
+	if err != nil {
+		uc.logger.Error("Failed to parse user ID", "error", err, "user_id", callerID)
+		return 0, err
+	}
+
+	presenceState, err := models.ParsePresenceState(state)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := uc.presenceRepository.Heartbeat(ctx, id, presenceState, time.Now(), uc.policy.TTL); err != nil {
+		uc.logger.Error("Failed to record heartbeat", "error", err, "user_id", callerID)
+		return 0, err
+	}
+
+	uc.logger.Debug("Heartbeat recorded", "user_id", callerID, "state", presenceState)
+
+	return uc.policy.TTL, nil
+}
@@ -0,0 +1,105 @@
+package presence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/usecases/presence/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUseCase_Heartbeat(t *testing.T) {
+	callerID := models.UserID(uuid.New())
+	ctx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: callerID.String(),
+	})
+	policy := models.PresencePolicy{TTL: time.Minute}
+
+	type args struct {
+		ctx   context.Context
+		state string
+	}
+
+	tests := map[string]struct {
+		args        args
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"online": {
+			args: args{ctx: ctx, state: "online"},
+			deps: func(t *testing.T) UseCase {
+				mockPresenceRepository := mocks.NewPresenceRepository(t)
+				mockPresenceRepository.EXPECT().
+					Heartbeat(ctx, callerID, models.PresenceOnline, mock.AnythingOfType("time.Time"), time.Minute).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					presenceRepository: mockPresenceRepository,
+					policy:             policy,
+					logger:             logger.NewMockLogger(),
+				}
+			},
+		},
+		"unknown state": {
+			args:        args{ctx: ctx, state: "busy"},
+			wantErr:     true,
+			expectedErr: errors.NewValidationError("unknown presence state \"busy\""),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{policy: policy, logger: logger.NewMockLogger()}
+			},
+		},
+		"store failure": {
+			args:    args{ctx: ctx, state: "away"},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				mockPresenceRepository := mocks.NewPresenceRepository(t)
+				mockPresenceRepository.EXPECT().
+					Heartbeat(ctx, callerID, models.PresenceAway, mock.AnythingOfType("time.Time"), time.Minute).
+					Return(assert.AnError).
+					Once()
+
+				return UseCase{
+					presenceRepository: mockPresenceRepository,
+					policy:             policy,
+					logger:             logger.NewMockLogger(),
+				}
+			},
+		},
+		"unauthenticated": {
+			args:        args{ctx: context.Background(), state: "online"},
+			wantErr:     true,
+			expectedErr: errors.NewUnauthorizedError("request is not authenticated"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{policy: policy, logger: logger.NewMockLogger()}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			ttl, err := useCase.Heartbeat(tc.args.ctx, tc.args.state)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, policy.TTL, ttl)
+			}
+		})
+	}
+}
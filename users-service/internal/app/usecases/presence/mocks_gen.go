@@ -0,0 +1,5 @@
+package presence
+
+//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name PresenceRepository --output ./mocks --filename presence_repository_mock.go
+//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name UserRepository --output ./mocks --filename user_repository_mock.go
+//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name FriendshipChecker --output ./mocks --filename friendship_checker_mock.go
@@ -0,0 +1,36 @@
+package presence
+
+import (
+	"context"
+
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
+)
+
+func (uc *UseCase) SetLastSeenVisibility(ctx context.Context, visibility string) error {
+	callerID, err := platformauth.UserIDFromContext(ctx)
+	if err != nil {
+		uc.logger.Warn("Unauthenticated presence settings update")
+		return err
+	}
+
+	id, err := models.ParseUserID(callerID)
+	if err != nil {
+		uc.logger.Error("Failed to parse user ID", "error", err, "user_id", callerID)
+		return err
+	}
+
+	lastSeenVisibility, err := models.ParseLastSeenVisibility(visibility)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.userRepository.SetLastSeenVisibility(ctx, id, lastSeenVisibility); err != nil {
+		uc.logger.Error("Failed to set last seen visibility", "error", err, "user_id", callerID)
+		return err
+	}
+
+	uc.logger.Info("Last seen visibility updated", "user_id", callerID, "visibility", lastSeenVisibility)
+
+	return nil
+}
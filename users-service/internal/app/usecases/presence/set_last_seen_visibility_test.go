@@ -0,0 +1,92 @@
+package presence
+
+import (
+	"context"
+	"testing"
+
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/usecases/presence/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUseCase_SetLastSeenVisibility(t *testing.T) {
+	callerID := models.UserID(uuid.New())
+	ctx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: callerID.String(),
+	})
+
+	type args struct {
+		ctx        context.Context
+		visibility string
+	}
+
+	tests := map[string]struct {
+		args        args
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"friends only": {
+			args: args{ctx: ctx, visibility: "friends"},
+			deps: func(t *testing.T) UseCase {
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					SetLastSeenVisibility(ctx, callerID, models.LastSeenFriends).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					userRepository: mockUserRepository,
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"unknown visibility": {
+			args:        args{ctx: ctx, visibility: "nobody"},
+			wantErr:     true,
+			expectedErr: errors.NewValidationError("unknown last seen visibility \"nobody\""),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{logger: logger.NewMockLogger()}
+			},
+		},
+		"profile missing": {
+			args:        args{ctx: ctx, visibility: "everyone"},
+			wantErr:     true,
+			expectedErr: errors.NewNotFoundError("user with id %s not found", callerID),
+			deps: func(t *testing.T) UseCase {
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					SetLastSeenVisibility(ctx, callerID, models.LastSeenEveryone).
+					Return(errors.NewNotFoundError("user with id %s not found", callerID)).
+					Once()
+
+				return UseCase{
+					userRepository: mockUserRepository,
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			err := useCase.SetLastSeenVisibility(tc.args.ctx, tc.args.visibility)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
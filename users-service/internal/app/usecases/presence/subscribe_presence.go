@@ -0,0 +1,75 @@
+package presence
+
+import (
+	"context"
+	"time"
+
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
+)
+
+// SubscribePresence polls the presence store, so changes made through any
+// replica and heartbeats that lapse are both noticed. Who may see the
+// presence is re-checked every VisibilityRecheckInterval; a user who becomes
+// hidden from the caller turns offline in the next batch.
+func (uc *UseCase) SubscribePresence(ctx context.Context, ids []string, send func([]*ports.PresenceDto) error) error {
+	callerID, err := platformauth.UserIDFromContext(ctx)
+	if err != nil {
+		uc.logger.Warn("Unauthenticated presence subscription")
+		return err
+	}
+
+	targets, err := parseTargets(ids)
+	if err != nil {
+		return err
+	}
+
+	uc.logger.Info("Presence subscription started", "user_id", callerID, "count", len(targets))
+	defer uc.logger.Info("Presence subscription ended", "user_id", callerID)
+
+	ticker := time.NewTicker(uc.policy.PollInterval)
+	defer ticker.Stop()
+
+	var (
+		visible   map[models.UserID]bool
+		checkedAt time.Time
+	)
+	sent := make(map[string]ports.PresenceDto, len(targets))
+	for {
+		if visible == nil || time.Since(checkedAt) >= uc.policy.VisibilityRecheckInterval {
+			visible, err = uc.presenceVisibility(ctx, callerID, targets)
+			if err != nil {
+				return err
+			}
+			checkedAt = time.Now()
+		}
+
+		presence, err := uc.presenceRepository.Get(ctx, targets)
+		if err != nil {
+			uc.logger.Error("Failed to get presence", "error", err)
+			return err
+		}
+
+		var changes []*ports.PresenceDto
+		for _, dto := range toPresenceDtos(presence, visible) {
+			if previous, ok := sent[dto.UserID]; ok && previous.State == dto.State && previous.LastSeenAt.Equal(dto.LastSeenAt) {
+				continue
+			}
+			sent[dto.UserID] = *dto
+			changes = append(changes, dto)
+		}
+
+		if len(changes) > 0 {
+			if err := send(changes); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package presence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/usecases/presence/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUseCase_SubscribePresence(t *testing.T) {
+	t.Parallel()
+
+	callerID := models.UserID(uuid.New())
+	firstID := models.UserID(uuid.New())
+	secondID := models.UserID(uuid.New())
+	ctx, cancel := context.WithCancel(platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: callerID.String(),
+	}))
+	defer cancel()
+
+	lastSeenAt := time.Now().Add(-time.Hour)
+	targets := []models.UserID{firstID, secondID}
+
+	mockUserRepository := mocks.NewUserRepository(t)
+	mockUserRepository.EXPECT().
+		GetByIDs(mock.Anything, targets).
+		Return([]*models.User{
+			newUser(t, firstID, models.LastSeenEveryone),
+			newUser(t, secondID, models.LastSeenEveryone),
+		}, nil).
+		Once()
+
+	mockPresenceRepository := mocks.NewPresenceRepository(t)
+	mockPresenceRepository.EXPECT().
+		Get(mock.Anything, targets).
+		Return([]*models.Presence{
+			models.NewPresence(firstID, models.PresenceOffline, lastSeenAt),
+			models.NewPresence(secondID, models.PresenceOffline, lastSeenAt),
+		}, nil).
+		Once()
+	mockPresenceRepository.EXPECT().
+		Get(mock.Anything, targets).
+		Return([]*models.Presence{
+			models.NewPresence(firstID, models.PresenceOnline, lastSeenAt.Add(time.Hour)),
+			models.NewPresence(secondID, models.PresenceOffline, lastSeenAt),
+		}, nil)
+
+	useCase := UseCase{
+		presenceRepository: mockPresenceRepository,
+		userRepository:     mockUserRepository,
+		policy:             models.PresencePolicy{PollInterval: time.Millisecond, VisibilityRecheckInterval: time.Hour},
+		logger:             logger.NewMockLogger(),
+	}
+
+	var batches [][]*ports.PresenceDto
+	err := useCase.SubscribePresence(ctx, []string{firstID.String(), secondID.String()}, func(presences []*ports.PresenceDto) error {
+		batches = append(batches, presences)
+		if len(batches) == 2 {
+			cancel()
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, batches, 2)
+	assert.Len(t, batches[0], 2, "the first batch carries every user")
+	assert.Equal(t, []*ports.PresenceDto{
+		{UserID: firstID.String(), State: "online", LastSeenAt: lastSeenAt.Add(time.Hour)},
+	}, batches[1], "later batches carry changes only")
+}
+
+func TestUseCase_SubscribePresence_VisibilityRevoked(t *testing.T) {
+	t.Parallel()
+
+	callerID := models.UserID(uuid.New())
+	targetID := models.UserID(uuid.New())
+	ctx, cancel := context.WithCancel(platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: callerID.String(),
+	}))
+	defer cancel()
+
+	lastSeenAt := time.Now().Add(-time.Hour)
+	targets := []models.UserID{targetID}
+
+	mockUserRepository := mocks.NewUserRepository(t)
+	mockUserRepository.EXPECT().
+		GetByIDs(mock.Anything, targets).
+		Return([]*models.User{newUser(t, targetID, models.LastSeenFriends)}, nil)
+
+	mockFriendshipChecker := mocks.NewFriendshipChecker(t)
+	mockFriendshipChecker.EXPECT().
+		FriendsOf(mock.Anything, callerID.String(), []string{targetID.String()}).
+		Return(map[string]bool{targetID.String(): true}, nil).
+		Once()
+	mockFriendshipChecker.EXPECT().
+		FriendsOf(mock.Anything, callerID.String(), []string{targetID.String()}).
+		Return(map[string]bool{targetID.String(): false}, nil)
+
+	mockPresenceRepository := mocks.NewPresenceRepository(t)
+	mockPresenceRepository.EXPECT().
+		Get(mock.Anything, targets).
+		Return([]*models.Presence{models.NewPresence(targetID, models.PresenceOnline, lastSeenAt)}, nil)
+
+	useCase := UseCase{
+		presenceRepository: mockPresenceRepository,
+		userRepository:     mockUserRepository,
+		friendshipChecker:  mockFriendshipChecker,
+		policy:             models.PresencePolicy{PollInterval: time.Millisecond},
+		logger:             logger.NewMockLogger(),
+	}
+
+	var batches [][]*ports.PresenceDto
+	err := useCase.SubscribePresence(ctx, []string{targetID.String()}, func(presences []*ports.PresenceDto) error {
+		batches = append(batches, presences)
+		if len(batches) == 2 {
+			cancel()
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, [][]*ports.PresenceDto{
+		{{UserID: targetID.String(), State: "online", LastSeenAt: lastSeenAt}},
+		{{UserID: targetID.String(), State: "offline"}},
+	}, batches, "the user turns offline once the friendship is gone")
+}
@@ -0,0 +1,33 @@
+package presence
+
+import (
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
+)
+
+var _ ports.PresenceUseCase = (*UseCase)(nil)
+
+type UseCase struct {
+	presenceRepository ports.PresenceRepository
+	userRepository     ports.UserRepository
+	friendshipChecker  ports.FriendshipChecker
+	policy             models.PresencePolicy
+	logger             logger.Logger
+}
+
+func NewUseCase(
+	presenceRepository ports.PresenceRepository,
+	userRepository ports.UserRepository,
+	friendshipChecker ports.FriendshipChecker,
+	policy models.PresencePolicy,
+	logger logger.Logger,
+) *UseCase {
+	return &UseCase{
+		presenceRepository: presenceRepository,
+		userRepository:     userRepository,
+		friendshipChecker:  friendshipChecker,
+		policy:             policy,
+		logger:             logger.With("component", "presence_usecase"),
+	}
+}
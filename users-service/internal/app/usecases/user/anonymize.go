@@ -0,0 +1,43 @@
+package user
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
+)
+
+// Anonymize strips personal data from the profile of a deleted account. A
+// missing profile is not an error, so redelivered events are harmless. No
+// profile event is published: consumers learn of the deletion from
+// UserDeletedEvent and drop the profile instead.
+func (uc *UseCase) Anonymize(ctx context.Context, id string) error {
+	uc.logger.Debug("Anonymizing user", "user_id", id)
+
+	userID, err := models.ParseUserID(id)
+	if err != nil {
+		uc.logger.Error("Failed to parse user ID", "error", err, "user_id", id)
+		return err
+	}
+
+	err = uc.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		user, err := uc.userRepository.Get(txCtx, userID)
+		if err != nil {
+			return err
+		}
+		_, err = uc.userRepository.Update(txCtx, user.Anonymized())
+		return err
+	})
+	if errors.Is(err, errors.ErrNotFound) {
+		uc.logger.Debug("No profile to anonymize", "user_id", id)
+		return nil
+	}
+	if err != nil {
+		uc.logger.Error("Failed to anonymize user", "error", err, "user_id", id)
+		return err
+	}
+
+	uc.logger.Debug("User successfully anonymized", "user_id", id)
+
+	return nil
+}
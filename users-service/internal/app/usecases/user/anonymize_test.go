@@ -0,0 +1,138 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/usecases/user/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUseCase_Anonymize(t *testing.T) {
+	ctx := context.Background()
+
+	testUUID := uuid.New()
+	testUserID := models.UserID(testUUID)
+
+	testUser, err := models.NewUser(testUserID, "test@test.com", "testuser", "Test user description", "https://example.com/avatar.png")
+	assert.NoError(t, err)
+
+	type args struct {
+		ctx context.Context
+		id  string
+	}
+
+	tests := map[string]struct {
+		args    args
+		wantErr bool
+		deps    func(t *testing.T) UseCase
+	}{
+		"anonymize success": {
+			args: args{
+				ctx: ctx,
+				id:  testUUID.String(),
+			},
+			wantErr: false,
+			deps: func(t *testing.T) UseCase {
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					Get(ctx, testUserID).
+					Return(testUser, nil).
+					Once()
+				mockUserRepository.EXPECT().
+					Update(ctx, mock.MatchedBy(func(user *models.User) bool {
+						return user.ID() == testUserID &&
+							user.Nickname() == "deleted-"+testUUID.String() &&
+							user.Email() == testUUID.String()+"@deleted.invalid" &&
+							user.Description() == "" &&
+							user.AvatarURL() == ""
+					})).
+					RunAndReturn(savedProfile).
+					Once()
+
+				return UseCase{
+					userRepository: mockUserRepository,
+					txManager:      newTxManager(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"profile already gone": {
+			args: args{
+				ctx: ctx,
+				id:  testUUID.String(),
+			},
+			wantErr: false,
+			deps: func(t *testing.T) UseCase {
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					Get(ctx, testUserID).
+					Return(nil, errors.NewNotFoundError("user with id %s not found", testUserID)).
+					Once()
+
+				return UseCase{
+					userRepository: mockUserRepository,
+					txManager:      newTxManager(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"failed to update": {
+			args: args{
+				ctx: ctx,
+				id:  testUUID.String(),
+			},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					Get(ctx, testUserID).
+					Return(testUser, nil).
+					Once()
+				mockUserRepository.EXPECT().
+					Update(ctx, mock.AnythingOfType("*models.User")).
+					Return(nil, assert.AnError).
+					Once()
+
+				return UseCase{
+					userRepository: mockUserRepository,
+					txManager:      newTxManager(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"invalid id": {
+			args: args{
+				ctx: ctx,
+				id:  "not-a-uuid",
+			},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					userRepository: mocks.NewUserRepository(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			err := useCase.Anonymize(tc.args.ctx, tc.args.id)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -3,10 +3,14 @@ package user
 import (
 	"context"
 
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
 	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
 	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
 )
 
+// Create adds the profile of a newly registered account. A profile that
+// already exists under the same ID is left as it is, so redelivered events are
+// harmless.
 func (uc *UseCase) Create(ctx context.Context, dto *ports.UserDto) (string, error) {
 	uc.logger.Debug("Creating user", "username", dto.Nickname, "email", dto.Email)
 
@@ -24,7 +28,22 @@ func (uc *UseCase) Create(ctx context.Context, dto *ports.UserDto) (string, erro
 
 	var userID models.UserID
 	err = uc.txManager.RunTx(ctx, func(txCtx context.Context) error {
-		var err error
+		existing, err := uc.userRepository.Get(txCtx, parsedID)
+		if err == nil {
+			uc.logger.Debug("User already exists", "user_id", dto.ID)
+			userID = existing.ID()
+			return nil
+		}
+		if !errors.Is(err, errors.ErrNotFound) {
+			uc.logger.Error("Failed to get user", "error", err, "user_id", dto.ID)
+			return err
+		}
+
+		if err := uc.claimNickname(txCtx, parsedID, newUser.Nickname()); err != nil {
+			uc.logger.Error("Failed to claim nickname", "error", err, "user_id", dto.ID)
+			return err
+		}
+
 		userID, err = uc.userRepository.Create(txCtx, newUser)
 		if err != nil {
 			uc.logger.Error("Failed to create user", "error", err, "user_id", dto.ID)
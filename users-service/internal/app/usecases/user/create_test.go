@@ -4,6 +4,8 @@ import (
 	"context"
 	"testing"
 
+	"github.com/SamEkb/messenger-app/pkg/api/events/v1"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
 	"github.com/SamEkb/messenger-app/pkg/platform/logger"
 	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
 	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
@@ -18,6 +20,7 @@ func TestUseCase_Create(t *testing.T) {
 
 	testUUID := uuid.New()
 	testUserID := models.UserID(testUUID)
+	notFound := errors.NewNotFoundError("user with id %s not found", testUserID)
 
 	type args struct {
 		ctx context.Context
@@ -44,12 +47,112 @@ func TestUseCase_Create(t *testing.T) {
 			wantErr: false,
 			deps: func(t *testing.T) UseCase {
 				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					Get(ctx, testUserID).
+					Return(nil, notFound)
+				mockUserRepository.EXPECT().
+					GetByNickname(ctx, "testuser").
+					Return(nil, errors.NewNotFoundError("user with nickname %s not found", "testuser"))
 				mockUserRepository.EXPECT().
 					Create(ctx, mock.AnythingOfType("*models.User")).
 					Return(testUserID, nil)
 
 				return UseCase{
 					userRepository: mockUserRepository,
+					txManager:      newTxManager(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"nickname taken over from a stale profile": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.UserDto{
+					ID:       testUUID.String(),
+					Email:    "test@test.com",
+					Nickname: "testuser",
+				},
+			},
+			want:    testUUID.String(),
+			wantErr: false,
+			deps: func(t *testing.T) UseCase {
+				holderID := models.UserID(uuid.New())
+				holder, err := models.NewUser(holderID, "holder@test.com", "testuser", "", "")
+				assert.NoError(t, err)
+
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					Get(ctx, testUserID).
+					Return(nil, notFound)
+				mockUserRepository.EXPECT().
+					GetByNickname(ctx, "testuser").
+					Return(holder, nil)
+				mockUserRepository.EXPECT().
+					Update(ctx, mock.MatchedBy(func(user *models.User) bool {
+						return user.ID() == holderID && user.Nickname() == "user-"+holderID.String()
+					})).
+					RunAndReturn(savedProfile)
+				mockUserRepository.EXPECT().
+					Create(ctx, mock.AnythingOfType("*models.User")).
+					Return(testUserID, nil)
+
+				return UseCase{
+					userRepository: mockUserRepository,
+					txManager:      newTxManager(t),
+					profileEvents: expectProfileUpdated(t, func(event *events.UserProfileUpdatedEvent) bool {
+						return event.GetUserId() == holderID.String()
+					}),
+					logger: logger.NewMockLogger(),
+				}
+			},
+		},
+		"already created": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.UserDto{
+					ID:       testUUID.String(),
+					Email:    "test@test.com",
+					Nickname: "testuser",
+				},
+			},
+			want:    testUUID.String(),
+			wantErr: false,
+			deps: func(t *testing.T) UseCase {
+				existing, err := models.NewUser(testUserID, "test@test.com", "testuser", "", "")
+				assert.NoError(t, err)
+
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					Get(ctx, testUserID).
+					Return(existing, nil)
+
+				return UseCase{
+					userRepository: mockUserRepository,
+					txManager:      newTxManager(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"failed to get": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.UserDto{
+					ID:       testUUID.String(),
+					Email:    "test@test.com",
+					Nickname: "testuser",
+				},
+			},
+			want:    "",
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					Get(ctx, testUserID).
+					Return(nil, assert.AnError)
+
+				return UseCase{
+					userRepository: mockUserRepository,
+					txManager:      newTxManager(t),
 					logger:         logger.NewMockLogger(),
 				}
 			},
@@ -68,12 +171,19 @@ func TestUseCase_Create(t *testing.T) {
 			wantErr: true,
 			deps: func(t *testing.T) UseCase {
 				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					Get(ctx, testUserID).
+					Return(nil, notFound)
+				mockUserRepository.EXPECT().
+					GetByNickname(ctx, "testuser").
+					Return(nil, errors.NewNotFoundError("user with nickname %s not found", "testuser"))
 				mockUserRepository.EXPECT().
 					Create(ctx, mock.AnythingOfType("*models.User")).
 					Return(models.UserID{}, assert.AnError)
 
 				return UseCase{
 					userRepository: mockUserRepository,
+					txManager:      newTxManager(t),
 					logger:         logger.NewMockLogger(),
 				}
 			},
@@ -28,6 +28,7 @@ func (uc *UseCase) Get(ctx context.Context, id string) (*ports.UserDto, error) {
 		Nickname:    user.Nickname(),
 		Description: user.Description(),
 		AvatarURL:   user.AvatarURL(),
+		Version:     user.Version(),
 	}
 
 	uc.logger.Debug("User successfully retrieved", "user_id", id)
@@ -40,6 +40,7 @@ func TestUseCase_Get(t *testing.T) {
 				Nickname:    "testuser",
 				Description: "",
 				AvatarURL:   "",
+				Version:     models.InitialProfileVersion,
 			},
 			wantErr: false,
 			deps: func(t *testing.T) UseCase {
@@ -1,3 +1,6 @@
 package user
 
+//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name TxManager --output ./mocks --filename tx_manager_mock.go
 //go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name UserRepository --output ./mocks --filename user_repository_mock.go
+//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name BlobStore --output ./mocks --filename blob_store_mock.go
+//go:generate mockery --dir=../../ports --disable-version-string --with-expecter --name ProfileEventsOutbox --output ./mocks --filename profile_events_outbox_mock.go
@@ -0,0 +1,43 @@
+package user
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
+)
+
+// claimNickname frees nickname for the profile id. Nicknames are the
+// usernames of auth-service, which keeps them unique, so another profile
+// holding it is stale: its own rename has not arrived yet, or it kept a
+// nickname chosen before nicknames came from auth-service. That profile moves
+// to a placeholder derived from its ID until its next credentials event. It
+// must run inside a transaction.
+func (uc *UseCase) claimNickname(ctx context.Context, id models.UserID, nickname string) error {
+	holder, err := uc.userRepository.GetByNickname(ctx, nickname)
+	if errors.Is(err, errors.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if holder.ID() == id {
+		return nil
+	}
+
+	displaced, err := holder.WithCredentials(holder.Email(), placeholderNickname(holder.ID()))
+	if err != nil {
+		return err
+	}
+	if _, err := uc.saveProfile(ctx, holder, displaced); err != nil {
+		return err
+	}
+
+	uc.logger.Warn("Nickname taken over from a stale profile",
+		"nickname", nickname, "user_id", id, "previous_holder", holder.ID())
+	return nil
+}
+
+func placeholderNickname(id models.UserID) string {
+	return "user-" + id.String()
+}
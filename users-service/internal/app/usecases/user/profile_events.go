@@ -0,0 +1,40 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/SamEkb/messenger-app/pkg/api/events/v1"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// saveProfile stores user, which replaces previous, and enqueues a
+// UserProfileUpdatedEvent when the public part of the profile changed. It
+// must run inside a transaction so that the event is committed together with
+// the change. It returns the profile as stored.
+func (uc *UseCase) saveProfile(ctx context.Context, previous, user *models.User) (*models.User, error) {
+	saved, err := uc.userRepository.Update(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if saved.ProfileEquals(previous) {
+		return saved, nil
+	}
+
+	event := &events.UserProfileUpdatedEvent{
+		UserId:      saved.ID().String(),
+		Nickname:    saved.Nickname(),
+		Description: saved.Description(),
+		AvatarUrl:   saved.AvatarURL(),
+		Version:     saved.Version(),
+		UpdatedAt:   timestamppb.New(time.Now()),
+	}
+	if err := uc.profileEvents.EnqueueUserProfileUpdatedEvent(ctx, event); err != nil {
+		uc.logger.Error("Failed to enqueue profile updated event", "error", err, "user_id", saved.ID())
+		return nil, err
+	}
+
+	uc.logger.Debug("Profile updated event enqueued", "user_id", saved.ID(), "version", saved.Version())
+	return saved, nil
+}
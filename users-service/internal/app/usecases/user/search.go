@@ -0,0 +1,69 @@
+package user
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
+)
+
+const (
+	defaultSearchLimit  = 20
+	maxSearchLimit      = 100
+	maxSearchQueryRunes = 64
+)
+
+// Search finds profiles by nickname, and by description when asked to. One
+// extra row is fetched to tell whether another page follows.
+func (uc *UseCase) Search(ctx context.Context, dto *ports.SearchUsersDto) (*ports.SearchUsersResultDto, error) {
+	query := strings.TrimSpace(dto.Query)
+	if query == "" {
+		return nil, errors.NewValidationError("search query cannot be empty")
+	}
+	if utf8.RuneCountInString(query) > maxSearchQueryRunes {
+		return nil, errors.NewValidationError("search query cannot be longer than %d characters", maxSearchQueryRunes)
+	}
+	if dto.Limit < 0 || dto.Offset < 0 {
+		return nil, errors.NewValidationError("limit and offset cannot be negative")
+	}
+
+	limit := dto.Limit
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+	limit = min(limit, maxSearchLimit)
+
+	uc.logger.Debug("Searching users", "query", query, "limit", limit, "offset", dto.Offset)
+
+	found, err := uc.userRepository.Search(ctx, models.UserSearch{
+		Query:              query,
+		IncludeDescription: dto.IncludeDescription,
+		Limit:              limit + 1,
+		Offset:             dto.Offset,
+	})
+	if err != nil {
+		uc.logger.Error("Failed to search users", "error", err, "query", query)
+		return nil, err
+	}
+
+	result := &ports.SearchUsersResultDto{
+		Users:   make([]*ports.UserDto, 0, min(len(found), limit)),
+		HasMore: len(found) > limit,
+	}
+	for _, user := range found[:min(len(found), limit)] {
+		result.Users = append(result.Users, &ports.UserDto{
+			ID:          user.ID().String(),
+			Email:       user.Email(),
+			Nickname:    user.Nickname(),
+			Description: user.Description(),
+			AvatarURL:   user.AvatarURL(),
+		})
+	}
+
+	uc.logger.Debug("Users successfully found", "query", query, "count", len(result.Users))
+
+	return result, nil
+}
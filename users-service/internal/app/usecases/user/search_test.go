@@ -0,0 +1,163 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/usecases/user/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUseCase_Search(t *testing.T) {
+	ctx := context.Background()
+
+	newTestUser := func(t *testing.T, nickname string) *models.User {
+		user, err := models.NewUser(models.UserID(uuid.New()), nickname+"@test.ru", nickname, "", "")
+		assert.NoError(t, err)
+		return user
+	}
+
+	type args struct {
+		ctx context.Context
+		dto *ports.SearchUsersDto
+	}
+
+	tests := map[string]struct {
+		args        args
+		wantErr     bool
+		expectedErr error
+		want        []string
+		wantHasMore bool
+		deps        func(t *testing.T) UseCase
+	}{
+		"default limit": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.SearchUsersDto{Query: " sam ", IncludeDescription: true},
+			},
+			want: []string{"sam", "samuel"},
+			deps: func(t *testing.T) UseCase {
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					Search(ctx, models.UserSearch{Query: "sam", IncludeDescription: true, Limit: defaultSearchLimit + 1}).
+					Return([]*models.User{newTestUser(t, "sam"), newTestUser(t, "samuel")}, nil).
+					Once()
+
+				return UseCase{
+					userRepository: mockUserRepository,
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"more pages": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.SearchUsersDto{Query: "sam", Limit: 2, Offset: 4},
+			},
+			want:        []string{"sam", "samuel"},
+			wantHasMore: true,
+			deps: func(t *testing.T) UseCase {
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					Search(ctx, models.UserSearch{Query: "sam", Limit: 3, Offset: 4}).
+					Return([]*models.User{newTestUser(t, "sam"), newTestUser(t, "samuel"), newTestUser(t, "samson")}, nil).
+					Once()
+
+				return UseCase{
+					userRepository: mockUserRepository,
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"limit capped": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.SearchUsersDto{Query: "sam", Limit: 1000},
+			},
+			want: []string{},
+			deps: func(t *testing.T) UseCase {
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					Search(ctx, models.UserSearch{Query: "sam", Limit: maxSearchLimit + 1}).
+					Return([]*models.User{}, nil).
+					Once()
+
+				return UseCase{
+					userRepository: mockUserRepository,
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"empty query": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.SearchUsersDto{Query: "  "},
+			},
+			wantErr:     true,
+			expectedErr: errors.NewValidationError("search query cannot be empty"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{logger: logger.NewMockLogger()}
+			},
+		},
+		"negative offset": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.SearchUsersDto{Query: "sam", Offset: -1},
+			},
+			wantErr:     true,
+			expectedErr: errors.NewValidationError("limit and offset cannot be negative"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{logger: logger.NewMockLogger()}
+			},
+		},
+		"repository error": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.SearchUsersDto{Query: "sam"},
+			},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					Search(ctx, models.UserSearch{Query: "sam", Limit: defaultSearchLimit + 1}).
+					Return(nil, assert.AnError).
+					Once()
+
+				return UseCase{
+					userRepository: mockUserRepository,
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			result, err := useCase.Search(tc.args.ctx, tc.args.dto)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				nicknames := make([]string, 0, len(result.Users))
+				for _, user := range result.Users {
+					nicknames = append(nicknames, user.Nickname)
+				}
+				assert.Equal(t, tc.want, nicknames)
+				assert.Equal(t, tc.wantHasMore, result.HasMore)
+			}
+		})
+	}
+}
@@ -3,44 +3,121 @@ package user
 import (
 	"context"
 
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
 	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
 	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
 )
 
-func (uc *UseCase) Update(ctx context.Context, dto *ports.UserDto) error {
-	uc.logger.Debug("Updating user", "user_id", dto.ID)
+// Profile fields that UpdateUserDto.UpdateMask may name. The email address and
+// the nickname are not among them: they belong to the account and only change
+// through the auth service's ChangeEmail and ChangeUsername flows, which keep
+// them unique.
+const (
+	fieldDescription = "description"
+	fieldAvatarURL   = "avatar_url"
+)
 
-	id, err := models.ParseUserID(dto.ID)
+func (uc *UseCase) Update(ctx context.Context, dto *ports.UpdateUserDto) (*ports.UserDto, error) {
+	callerID, err := platformauth.UserIDFromContext(ctx)
 	if err != nil {
-		uc.logger.Error("Failed to parse user ID", "error", err, "user_id", dto.ID)
-		return err
+		uc.logger.Warn("Unauthenticated update attempt")
+		return nil, err
 	}
 
-	user, err := models.NewUser(
-		id,
-		dto.Email,
-		dto.Nickname,
-		dto.Description,
-		dto.AvatarURL,
-	)
+	uc.logger.Debug("Updating user", "user_id", callerID, "update_mask", dto.UpdateMask)
+
+	id, err := models.ParseUserID(callerID)
 	if err != nil {
-		uc.logger.Error("Failed to update user", "error", err, "user_id", dto.ID)
-		return err
+		uc.logger.Error("Failed to parse user ID", "error", err, "user_id", callerID)
+		return nil, err
 	}
 
+	mask, err := updateMask(dto)
+	if err != nil {
+		uc.logger.Warn("Rejected update mask", "error", err, "user_id", callerID)
+		return nil, err
+	}
+
+	var saved *models.User
 	err = uc.txManager.RunTx(ctx, func(txCtx context.Context) error {
-		if err := uc.userRepository.Update(txCtx, user); err != nil {
-			uc.logger.Error("Failed to update user", "error", err, "user_id", dto.ID)
+		previous, err := uc.userRepository.Get(txCtx, id)
+		if err != nil {
+			uc.logger.Error("Failed to get user", "error", err, "user_id", callerID)
+			return err
+		}
+		if dto.ExpectedVersion != 0 && dto.ExpectedVersion != previous.Version() {
+			return errors.NewConflictError("user %s has changed since version %d", callerID, dto.ExpectedVersion).
+				WithDetails("version", previous.Version())
+		}
+
+		user, err := applyUpdate(previous, dto.Profile, mask)
+		if err != nil {
+			uc.logger.Error("Failed to update user", "error", err, "user_id", callerID)
+			return err
+		}
+
+		saved, err = uc.saveProfile(txCtx, previous, user)
+		if err != nil {
+			uc.logger.Error("Failed to update user", "error", err, "user_id", callerID)
 			return err
 		}
 		return nil
 	})
 
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	uc.logger.Debug("User successfully updated", "user_id", callerID, "version", saved.Version())
+
+	return &ports.UserDto{
+		ID:          saved.ID().String(),
+		Email:       saved.Email(),
+		Nickname:    saved.Nickname(),
+		Description: saved.Description(),
+		AvatarURL:   saved.AvatarURL(),
+		Version:     saved.Version(),
+	}, nil
+}
+
+// updateMask returns the set of fields to update. Without an explicit mask
+// it holds the fields set in the profile, so that clients unaware of masks
+// cannot clear fields they did not send.
+func updateMask(dto *ports.UpdateUserDto) (map[string]bool, error) {
+	if dto.Profile == nil {
+		return nil, errors.NewValidationError("profile cannot be empty")
 	}
 
-	uc.logger.Debug("User successfully updated", "user_id", dto.ID)
+	mask := make(map[string]bool, len(dto.UpdateMask))
+	if len(dto.UpdateMask) == 0 {
+		mask[fieldDescription] = dto.Profile.Description != ""
+		mask[fieldAvatarURL] = dto.Profile.AvatarURL != ""
+		return mask, nil
+	}
+
+	for _, field := range dto.UpdateMask {
+		switch field {
+		case fieldDescription, fieldAvatarURL:
+			mask[field] = true
+		default:
+			return nil, errors.NewValidationError("field %q cannot be updated", field).
+				WithDetails("field", field)
+		}
+	}
+	return mask, nil
+}
+
+// applyUpdate returns previous with the masked fields taken from profile.
+func applyUpdate(previous *models.User, profile *ports.UserDto, mask map[string]bool) (*models.User, error) {
+	description, avatarURL := previous.Description(), previous.AvatarURL()
+
+	if mask[fieldDescription] {
+		description = profile.Description
+	}
+	if mask[fieldAvatarURL] {
+		avatarURL = profile.AvatarURL
+	}
 
-	return nil
+	return previous.WithProfile(previous.Email(), previous.Nickname(), description, avatarURL)
 }
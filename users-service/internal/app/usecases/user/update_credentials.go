@@ -0,0 +1,54 @@
+package user
+
+import (
+	"context"
+
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
+)
+
+// UpdateCredentials applies a username or email change made in auth-service
+// to the profile. The event carries the current values, so redelivery is
+// harmless; a missing profile belongs to a deleted account and is skipped. A
+// profile still holding the new nickname is moved aside, see claimNickname.
+func (uc *UseCase) UpdateCredentials(ctx context.Context, dto *ports.UserDto) error {
+	uc.logger.Debug("Updating user credentials", "user_id", dto.ID)
+
+	userID, err := models.ParseUserID(dto.ID)
+	if err != nil {
+		uc.logger.Error("Failed to parse user ID", "error", err, "user_id", dto.ID)
+		return err
+	}
+
+	err = uc.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		user, err := uc.userRepository.Get(txCtx, userID)
+		if err != nil {
+			return err
+		}
+
+		updated, err := user.WithCredentials(dto.Email, dto.Nickname)
+		if err != nil {
+			return err
+		}
+		if updated.Nickname() != user.Nickname() {
+			if err := uc.claimNickname(txCtx, userID, updated.Nickname()); err != nil {
+				return err
+			}
+		}
+		_, err = uc.saveProfile(txCtx, user, updated)
+		return err
+	})
+	if errors.Is(err, errors.ErrNotFound) {
+		uc.logger.Debug("No profile to update credentials of", "user_id", dto.ID)
+		return nil
+	}
+	if err != nil {
+		uc.logger.Error("Failed to update user credentials", "error", err, "user_id", dto.ID)
+		return err
+	}
+
+	uc.logger.Debug("User credentials successfully updated", "user_id", dto.ID)
+
+	return nil
+}
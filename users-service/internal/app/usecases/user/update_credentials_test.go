@@ -0,0 +1,217 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SamEkb/messenger-app/pkg/api/events/v1"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/usecases/user/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUseCase_UpdateCredentials(t *testing.T) {
+	ctx := context.Background()
+
+	testUUID := uuid.New()
+	testUserID := models.UserID(testUUID)
+
+	testUser, err := models.NewUser(testUserID, "old@test.com", "olduser", "Test user description", "https://example.com/avatar.png")
+	assert.NoError(t, err)
+
+	holderID := models.UserID(uuid.New())
+	holder, err := models.NewUser(holderID, "holder@test.com", "newuser", "", "")
+	assert.NoError(t, err)
+
+	type args struct {
+		ctx context.Context
+		dto *ports.UserDto
+	}
+
+	tests := map[string]struct {
+		args    args
+		wantErr bool
+		deps    func(t *testing.T) UseCase
+	}{
+		"update success": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.UserDto{ID: testUUID.String(), Email: "new@test.com", Nickname: "newuser"},
+			},
+			wantErr: false,
+			deps: func(t *testing.T) UseCase {
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					Get(ctx, testUserID).
+					Return(testUser, nil).
+					Once()
+				mockUserRepository.EXPECT().
+					GetByNickname(ctx, "newuser").
+					Return(nil, errors.NewNotFoundError("user with nickname %s not found", "newuser")).
+					Once()
+				mockUserRepository.EXPECT().
+					Update(ctx, mock.MatchedBy(func(user *models.User) bool {
+						return user.ID() == testUserID &&
+							user.Email() == "new@test.com" &&
+							user.Nickname() == "newuser" &&
+							user.Description() == "Test user description" &&
+							user.AvatarURL() == "https://example.com/avatar.png"
+					})).
+					RunAndReturn(savedProfile).
+					Once()
+
+				return UseCase{
+					userRepository: mockUserRepository,
+					txManager:      newTxManager(t),
+					profileEvents: expectProfileUpdated(t, func(event *events.UserProfileUpdatedEvent) bool {
+						return event.GetUserId() == testUUID.String() &&
+							event.GetNickname() == "newuser" &&
+							event.GetVersion() == testUser.Version()+1
+					}),
+					logger: logger.NewMockLogger(),
+				}
+			},
+		},
+		"stale holder of the nickname is moved aside": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.UserDto{ID: testUUID.String(), Email: "old@test.com", Nickname: "newuser"},
+			},
+			wantErr: false,
+			deps: func(t *testing.T) UseCase {
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					Get(ctx, testUserID).
+					Return(testUser, nil).
+					Once()
+				mockUserRepository.EXPECT().
+					GetByNickname(ctx, "newuser").
+					Return(holder, nil).
+					Once()
+				mockUserRepository.EXPECT().
+					Update(ctx, mock.MatchedBy(func(user *models.User) bool {
+						return user.ID() == holderID && user.Nickname() == "user-"+holderID.String()
+					})).
+					RunAndReturn(savedProfile).
+					Once()
+				mockUserRepository.EXPECT().
+					Update(ctx, mock.MatchedBy(func(user *models.User) bool {
+						return user.ID() == testUserID && user.Nickname() == "newuser"
+					})).
+					RunAndReturn(savedProfile).
+					Once()
+
+				profileEvents := mocks.NewProfileEventsOutbox(t)
+				profileEvents.EXPECT().
+					EnqueueUserProfileUpdatedEvent(ctx, mock.AnythingOfType("*events.UserProfileUpdatedEvent")).
+					Return(nil).
+					Twice()
+
+				return UseCase{
+					userRepository: mockUserRepository,
+					txManager:      newTxManager(t),
+					profileEvents:  profileEvents,
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"email change publishes no profile event": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.UserDto{ID: testUUID.String(), Email: "new@test.com", Nickname: "olduser"},
+			},
+			wantErr: false,
+			deps: func(t *testing.T) UseCase {
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					Get(ctx, testUserID).
+					Return(testUser, nil).
+					Once()
+				mockUserRepository.EXPECT().
+					Update(ctx, mock.AnythingOfType("*models.User")).
+					RunAndReturn(savedProfile).
+					Once()
+
+				return UseCase{
+					userRepository: mockUserRepository,
+					txManager:      newTxManager(t),
+					profileEvents:  mocks.NewProfileEventsOutbox(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"profile already gone": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.UserDto{ID: testUUID.String(), Email: "new@test.com", Nickname: "newuser"},
+			},
+			wantErr: false,
+			deps: func(t *testing.T) UseCase {
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					Get(ctx, testUserID).
+					Return(nil, errors.NewNotFoundError("user with id %s not found", testUserID)).
+					Once()
+
+				return UseCase{
+					userRepository: mockUserRepository,
+					txManager:      newTxManager(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"empty nickname": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.UserDto{ID: testUUID.String(), Email: "new@test.com"},
+			},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					Get(ctx, testUserID).
+					Return(testUser, nil).
+					Once()
+
+				return UseCase{
+					userRepository: mockUserRepository,
+					txManager:      newTxManager(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"invalid id": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.UserDto{ID: "not-a-uuid", Email: "new@test.com", Nickname: "newuser"},
+			},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					userRepository: mocks.NewUserRepository(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			err := useCase.UpdateCredentials(tc.args.ctx, tc.args.dto)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
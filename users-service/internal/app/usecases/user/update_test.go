@@ -4,7 +4,11 @@ import (
 	"context"
 	"testing"
 
+	"github.com/SamEkb/messenger-app/pkg/api/events/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
 	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
 	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
 	"github.com/SamEkb/messenger-app/users-service/internal/app/usecases/user/mocks"
 	"github.com/google/uuid"
@@ -13,40 +17,281 @@ import (
 )
 
 func TestUseCase_Update(t *testing.T) {
-	ctx := context.Background()
-
 	testUUID := uuid.New()
+	testUserID := models.UserID(testUUID)
+
+	testUser, err := models.NewUser(testUserID, "test@test.com", "testuser", "", "https://example.com/avatar.png")
+	assert.NoError(t, err)
+	testUser = testUser.WithVersion(4)
+
+	ctx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: testUUID.String(),
+	})
 
 	type args struct {
 		ctx context.Context
-		dto *ports.UserDto
+		dto *ports.UpdateUserDto
 	}
 
 	tests := map[string]struct {
-		args    args
-		wantErr bool
-		deps    func(t *testing.T) UseCase
+		args        args
+		want        *ports.UserDto
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
 	}{
 		"update success": {
 			args: args{
 				ctx: ctx,
-				dto: &ports.UserDto{
-					ID:          testUUID.String(),
-					Email:       "updated@test.com",
-					Nickname:    "updateduser",
-					Description: "Test user description",
+				dto: &ports.UpdateUserDto{
+					Profile: &ports.UserDto{
+						Email:       "updated@test.com",
+						Nickname:    "updateduser",
+						Description: "Test user description",
+					},
+					ExpectedVersion: 4,
+				},
+			},
+			want: &ports.UserDto{
+				ID:          testUUID.String(),
+				Email:       "test@test.com",
+				Nickname:    "testuser",
+				Description: "Test user description",
+				AvatarURL:   "https://example.com/avatar.png",
+				Version:     5,
+			},
+			deps: func(t *testing.T) UseCase {
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					Get(ctx, testUserID).
+					Return(testUser, nil).
+					Once()
+				mockUserRepository.EXPECT().
+					Update(ctx, mock.MatchedBy(func(user *models.User) bool {
+						return user.Version() == 4
+					})).
+					RunAndReturn(savedProfile).
+					Once()
+
+				return UseCase{
+					userRepository: mockUserRepository,
+					txManager:      newTxManager(t),
+					profileEvents: expectProfileUpdated(t, func(event *events.UserProfileUpdatedEvent) bool {
+						return event.GetUserId() == testUUID.String() &&
+							event.GetNickname() == "testuser" &&
+							event.GetDescription() == "Test user description" &&
+							event.GetVersion() == 5 &&
+							event.GetUpdatedAt() != nil
+					}),
+					logger: logger.NewMockLogger(),
+				}
+			},
+		},
+		"masked fields only": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.UpdateUserDto{
+					Profile: &ports.UserDto{
+						Nickname:    "ignored",
+						Description: "",
+					},
+					UpdateMask: []string{"avatar_url"},
+				},
+			},
+			want: &ports.UserDto{
+				ID:       testUUID.String(),
+				Email:    "test@test.com",
+				Nickname: "testuser",
+				Version:  5,
+			},
+			deps: func(t *testing.T) UseCase {
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					Get(ctx, testUserID).
+					Return(testUser, nil).
+					Once()
+				mockUserRepository.EXPECT().
+					Update(ctx, mock.AnythingOfType("*models.User")).
+					RunAndReturn(savedProfile).
+					Once()
+
+				return UseCase{
+					userRepository: mockUserRepository,
+					txManager:      newTxManager(t),
+					profileEvents: expectProfileUpdated(t, func(event *events.UserProfileUpdatedEvent) bool {
+						return event.GetAvatarUrl() == "" && event.GetNickname() == "testuser"
+					}),
+					logger: logger.NewMockLogger(),
+				}
+			},
+		},
+		"unset fields kept without mask": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.UpdateUserDto{
+					Profile: &ports.UserDto{Description: "only the description"},
+				},
+			},
+			want: &ports.UserDto{
+				ID:          testUUID.String(),
+				Email:       "test@test.com",
+				Nickname:    "testuser",
+				Description: "only the description",
+				AvatarURL:   "https://example.com/avatar.png",
+				Version:     5,
+			},
+			deps: func(t *testing.T) UseCase {
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					Get(ctx, testUserID).
+					Return(testUser, nil).
+					Once()
+				mockUserRepository.EXPECT().
+					Update(ctx, mock.AnythingOfType("*models.User")).
+					RunAndReturn(savedProfile).
+					Once()
+
+				return UseCase{
+					userRepository: mockUserRepository,
+					txManager:      newTxManager(t),
+					profileEvents: expectProfileUpdated(t, func(event *events.UserProfileUpdatedEvent) bool {
+						return event.GetAvatarUrl() == "https://example.com/avatar.png"
+					}),
+					logger: logger.NewMockLogger(),
+				}
+			},
+		},
+		"email is ignored and publishes no event": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.UpdateUserDto{
+					Profile: &ports.UserDto{Email: "updated@test.com"},
+				},
+			},
+			want: &ports.UserDto{
+				ID:        testUUID.String(),
+				Email:     "test@test.com",
+				Nickname:  "testuser",
+				AvatarURL: "https://example.com/avatar.png",
+				Version:   5,
+			},
+			deps: func(t *testing.T) UseCase {
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					Get(ctx, testUserID).
+					Return(testUser, nil).
+					Once()
+				mockUserRepository.EXPECT().
+					Update(ctx, mock.AnythingOfType("*models.User")).
+					RunAndReturn(savedProfile).
+					Once()
+
+				return UseCase{
+					userRepository: mockUserRepository,
+					txManager:      newTxManager(t),
+					profileEvents:  mocks.NewProfileEventsOutbox(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"stale expected version": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.UpdateUserDto{
+					Profile:         &ports.UserDto{Description: "updated description"},
+					ExpectedVersion: 3,
+				},
+			},
+			wantErr:     true,
+			expectedErr: errors.NewConflictError("user %s has changed since version %d", testUUID, 3),
+			deps: func(t *testing.T) UseCase {
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					Get(ctx, testUserID).
+					Return(testUser, nil).
+					Once()
+
+				return UseCase{
+					userRepository: mockUserRepository,
+					txManager:      newTxManager(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"concurrent write": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.UpdateUserDto{
+					Profile: &ports.UserDto{Description: "updated description"},
 				},
 			},
-			wantErr: false,
+			wantErr:     true,
+			expectedErr: errors.NewConflictError("user %s has changed since version %d", testUUID, 4),
 			deps: func(t *testing.T) UseCase {
 				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					Get(ctx, testUserID).
+					Return(testUser, nil).
+					Once()
 				mockUserRepository.EXPECT().
 					Update(ctx, mock.AnythingOfType("*models.User")).
-					Return(nil).
+					Return(nil, errors.NewConflictError("user %s has changed since version %d", testUUID, 4)).
 					Once()
 
 				return UseCase{
 					userRepository: mockUserRepository,
+					txManager:      newTxManager(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"unknown mask field": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.UpdateUserDto{
+					Profile:    &ports.UserDto{},
+					UpdateMask: []string{"last_seen_visibility"},
+				},
+			},
+			wantErr:     true,
+			expectedErr: errors.NewValidationError("field %q cannot be updated", "last_seen_visibility"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					userRepository: mocks.NewUserRepository(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"email cannot be masked": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.UpdateUserDto{
+					Profile:    &ports.UserDto{Email: "updated@test.com"},
+					UpdateMask: []string{"email"},
+				},
+			},
+			wantErr:     true,
+			expectedErr: errors.NewValidationError("field %q cannot be updated", "email"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					userRepository: mocks.NewUserRepository(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"nickname cannot be masked": {
+			args: args{
+				ctx: ctx,
+				dto: &ports.UpdateUserDto{
+					Profile:    &ports.UserDto{Nickname: "updateduser"},
+					UpdateMask: []string{"nickname"},
+				},
+			},
+			wantErr:     true,
+			expectedErr: errors.NewValidationError("field %q cannot be updated", "nickname"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					userRepository: mocks.NewUserRepository(t),
 					logger:         logger.NewMockLogger(),
 				}
 			},
@@ -54,23 +299,41 @@ func TestUseCase_Update(t *testing.T) {
 		"failed to update": {
 			args: args{
 				ctx: ctx,
-				dto: &ports.UserDto{
-					ID:          testUUID.String(),
-					Email:       "updated@test.com",
-					Nickname:    "updateduser",
-					Description: "Test user description",
+				dto: &ports.UpdateUserDto{
+					Profile: &ports.UserDto{Description: "updated description"},
 				},
 			},
 			wantErr: true,
 			deps: func(t *testing.T) UseCase {
 				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					Get(ctx, testUserID).
+					Return(testUser, nil).
+					Once()
 				mockUserRepository.EXPECT().
 					Update(ctx, mock.AnythingOfType("*models.User")).
-					Return(assert.AnError).
+					Return(nil, assert.AnError).
 					Once()
 
 				return UseCase{
 					userRepository: mockUserRepository,
+					txManager:      newTxManager(t),
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"unauthenticated": {
+			args: args{
+				ctx: context.Background(),
+				dto: &ports.UpdateUserDto{
+					Profile: &ports.UserDto{Description: "updated description"},
+				},
+			},
+			wantErr:     true,
+			expectedErr: errors.NewUnauthorizedError("request is not authenticated"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					userRepository: mocks.NewUserRepository(t),
 					logger:         logger.NewMockLogger(),
 				}
 			},
@@ -82,12 +345,21 @@ func TestUseCase_Update(t *testing.T) {
 			t.Parallel()
 
 			useCase := tc.deps(t)
-			err := useCase.Update(tc.args.ctx, tc.args.dto)
+			result, err := useCase.Update(tc.args.ctx, tc.args.dto)
 
 			if tc.wantErr {
 				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+					var expected *errors.AppError
+					if errors.As(tc.expectedErr, &expected) {
+						assert.ErrorIs(t, err, expected.Err)
+					}
+				}
+				assert.Nil(t, result)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, tc.want, result)
 			}
 		})
 	}
@@ -0,0 +1,140 @@
+package user
+
+import (
+	"context"
+	"path"
+	"strconv"
+	"strings"
+
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
+	"github.com/google/uuid"
+)
+
+// UploadAvatar stores every upload under a new key, so cached copies of the
+// old avatar never shadow the new one. The previous avatar is deleted once
+// the profile points at the new one.
+func (uc *UseCase) UploadAvatar(ctx context.Context, dto *ports.UploadAvatarDto) (*ports.AvatarDto, error) {
+	callerID, err := platformauth.UserIDFromContext(ctx)
+	if err != nil {
+		uc.logger.Warn("Unauthenticated avatar upload attempt")
+		return nil, err
+	}
+
+	uc.logger.Debug("Uploading avatar", "user_id", callerID, "content_type", dto.ContentType, "size", len(dto.Data))
+
+	id, err := models.ParseUserID(callerID)
+	if err != nil {
+		uc.logger.Error("Failed to parse user ID", "error", err, "user_id", callerID)
+		return nil, err
+	}
+
+	avatar, err := models.ParseAvatar(uc.avatarPolicy, dto.ContentType, dto.Data)
+	if err != nil {
+		uc.logger.Warn("Rejected avatar upload", "error", err, "user_id", callerID)
+		return nil, err
+	}
+
+	key := avatarKey(id, uuid.NewString(), avatar.Extension())
+	result := &ports.AvatarDto{
+		AvatarURL:     uc.blobStore.URL(key),
+		ThumbnailURLs: make(map[int]string, len(models.AvatarThumbnailSizes)),
+	}
+
+	if err := uc.storeAvatar(ctx, avatar, key); err != nil {
+		uc.logger.Error("Failed to store avatar", "error", err, "user_id", callerID)
+		uc.deleteAvatarBlobs(ctx, key)
+		return nil, err
+	}
+	for _, size := range models.AvatarThumbnailSizes {
+		result.ThumbnailURLs[size] = uc.blobStore.URL(thumbnailKey(key, size))
+	}
+
+	var previousURL string
+	err = uc.txManager.RunTx(ctx, func(txCtx context.Context) error {
+		user, err := uc.userRepository.Get(txCtx, id)
+		if err != nil {
+			return err
+		}
+		previousURL = user.AvatarURL()
+		_, err = uc.saveProfile(txCtx, user, user.WithAvatarURL(result.AvatarURL))
+		return err
+	})
+	if err != nil {
+		uc.logger.Error("Failed to set avatar", "error", err, "user_id", callerID)
+		uc.deleteAvatarBlobs(ctx, key)
+		return nil, err
+	}
+
+	if previousKey, ok := uc.ownAvatarKey(id, previousURL); ok {
+		uc.deleteAvatarBlobs(ctx, previousKey)
+	}
+
+	uc.logger.Info("Avatar successfully uploaded", "user_id", callerID)
+
+	return result, nil
+}
+
+func (uc *UseCase) storeAvatar(ctx context.Context, avatar *models.Avatar, key string) error {
+	data, err := avatar.Encode()
+	if err != nil {
+		return err
+	}
+	if err := uc.blobStore.Put(ctx, key, data, avatar.ContentType()); err != nil {
+		return err
+	}
+
+	for _, size := range models.AvatarThumbnailSizes {
+		data, err := avatar.Thumbnail(size)
+		if err != nil {
+			return err
+		}
+		if err := uc.blobStore.Put(ctx, thumbnailKey(key, size), data, avatar.ContentType()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteAvatarBlobs removes an avatar and its thumbnails. Failures only
+// leave orphaned files behind, so they are logged rather than returned.
+func (uc *UseCase) deleteAvatarBlobs(ctx context.Context, key string) {
+	keys := []string{key}
+	for _, size := range models.AvatarThumbnailSizes {
+		keys = append(keys, thumbnailKey(key, size))
+	}
+
+	for _, k := range keys {
+		if err := uc.blobStore.Delete(ctx, k); err != nil {
+			uc.logger.Warn("Failed to delete avatar blob", "error", err, "key", k)
+		}
+	}
+}
+
+// ownAvatarKey returns the key of avatarURL if it is an avatar uploaded by
+// id. Profiles may also point at URLs set through UpdateUserProfile, which
+// must never lead to deleting someone else's files.
+func (uc *UseCase) ownAvatarKey(id models.UserID, avatarURL string) (string, bool) {
+	key, ok := strings.CutPrefix(avatarURL, uc.blobStore.URL(""))
+	if !ok || !strings.HasPrefix(key, avatarPrefix(id)) || strings.Contains(key, "..") {
+		return "", false
+	}
+	return key, true
+}
+
+func avatarPrefix(id models.UserID) string {
+	return "avatars/" + id.String() + "/"
+}
+
+func avatarKey(id models.UserID, uploadID, extension string) string {
+	return avatarPrefix(id) + uploadID + extension
+}
+
+// thumbnailKey derives the key of a thumbnail from its avatar's key, e.g.
+// avatars/<user>/<upload>_64.jpg.
+func thumbnailKey(key string, size int) string {
+	extension := path.Ext(key)
+	return strings.TrimSuffix(key, extension) + "_" + strconv.Itoa(size) + extension
+}
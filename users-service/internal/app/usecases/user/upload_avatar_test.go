@@ -0,0 +1,242 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/SamEkb/messenger-app/pkg/api/events/v1"
+	platformauth "github.com/SamEkb/messenger-app/pkg/platform/auth"
+	"github.com/SamEkb/messenger-app/pkg/platform/errors"
+	"github.com/SamEkb/messenger-app/pkg/platform/logger"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/usecases/user/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const mediaURL = "http://localhost/media/"
+
+func TestUseCase_UploadAvatar(t *testing.T) {
+	testUUID := uuid.New()
+	testUserID := models.UserID(testUUID)
+	ctx := platformauth.ContextWithPrincipal(context.Background(), &platformauth.Principal{
+		UserID: testUUID.String(),
+	})
+
+	policy := models.AvatarPolicy{MaxBytes: 1 << 20, MaxDimension: 512}
+	avatarPNG := encodePNG(t, 300, 200)
+	previousKey := "avatars/" + testUUID.String() + "/previous.png"
+
+	newBlobStore := func(t *testing.T) *mocks.BlobStore {
+		blobStore := mocks.NewBlobStore(t)
+		blobStore.EXPECT().
+			URL(mock.AnythingOfType("string")).
+			RunAndReturn(func(key string) string {
+				return mediaURL + key
+			}).
+			Maybe()
+		return blobStore
+	}
+
+	type args struct {
+		ctx context.Context
+		dto *ports.UploadAvatarDto
+	}
+
+	tests := map[string]struct {
+		args        args
+		wantErr     bool
+		expectedErr error
+		deps        func(t *testing.T) UseCase
+	}{
+		"avatar replaced": {
+			args: args{ctx: ctx, dto: &ports.UploadAvatarDto{ContentType: "image/png", Data: avatarPNG}},
+			deps: func(t *testing.T) UseCase {
+				user, err := models.NewUser(testUserID, "test@test.ru", "testuser", "", mediaURL+previousKey)
+				assert.NoError(t, err)
+
+				blobStore := newBlobStore(t)
+				blobStore.EXPECT().
+					Put(ctx, mock.AnythingOfType("string"), mock.Anything, "image/png").
+					Return(nil).
+					Times(1 + len(models.AvatarThumbnailSizes))
+				blobStore.EXPECT().Delete(ctx, previousKey).Return(nil).Once()
+				for _, size := range models.AvatarThumbnailSizes {
+					blobStore.EXPECT().Delete(ctx, thumbnailKey(previousKey, size)).Return(nil).Once()
+				}
+
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().Get(ctx, testUserID).Return(user, nil).Once()
+				mockUserRepository.EXPECT().
+					Update(ctx, mock.MatchedBy(func(u *models.User) bool {
+						return strings.HasPrefix(u.AvatarURL(), mediaURL+"avatars/"+testUUID.String()+"/") &&
+							u.AvatarURL() != user.AvatarURL()
+					})).
+					RunAndReturn(savedProfile).
+					Once()
+
+				return UseCase{
+					userRepository: mockUserRepository,
+					txManager:      newTxManager(t),
+					blobStore:      blobStore,
+					profileEvents: expectProfileUpdated(t, func(event *events.UserProfileUpdatedEvent) bool {
+						return event.GetUserId() == testUUID.String() &&
+							strings.HasPrefix(event.GetAvatarUrl(), mediaURL+"avatars/"+testUUID.String()+"/") &&
+							event.GetVersion() == user.Version()+1
+					}),
+					avatarPolicy: policy,
+					logger:       logger.NewMockLogger(),
+				}
+			},
+		},
+		"foreign previous avatar kept": {
+			args: args{ctx: ctx, dto: &ports.UploadAvatarDto{ContentType: "image/png", Data: avatarPNG}},
+			deps: func(t *testing.T) UseCase {
+				otherKey := "avatars/" + uuid.NewString() + "/previous.png"
+				user, err := models.NewUser(testUserID, "test@test.ru", "testuser", "", mediaURL+otherKey)
+				assert.NoError(t, err)
+
+				blobStore := newBlobStore(t)
+				blobStore.EXPECT().
+					Put(ctx, mock.AnythingOfType("string"), mock.Anything, "image/png").
+					Return(nil).
+					Times(1 + len(models.AvatarThumbnailSizes))
+
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().Get(ctx, testUserID).Return(user, nil).Once()
+				mockUserRepository.EXPECT().Update(ctx, mock.AnythingOfType("*models.User")).RunAndReturn(savedProfile).Once()
+
+				profileEvents := mocks.NewProfileEventsOutbox(t)
+				profileEvents.EXPECT().
+					EnqueueUserProfileUpdatedEvent(ctx, mock.AnythingOfType("*events.UserProfileUpdatedEvent")).
+					Return(nil).
+					Once()
+
+				return UseCase{
+					userRepository: mockUserRepository,
+					txManager:      newTxManager(t),
+					blobStore:      blobStore,
+					profileEvents:  profileEvents,
+					avatarPolicy:   policy,
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"blobs removed when profile update fails": {
+			args:    args{ctx: ctx, dto: &ports.UploadAvatarDto{ContentType: "image/png", Data: avatarPNG}},
+			wantErr: true,
+			deps: func(t *testing.T) UseCase {
+				blobStore := newBlobStore(t)
+				blobStore.EXPECT().
+					Put(ctx, mock.AnythingOfType("string"), mock.Anything, "image/png").
+					Return(nil).
+					Times(1 + len(models.AvatarThumbnailSizes))
+				blobStore.EXPECT().
+					Delete(ctx, mock.AnythingOfType("string")).
+					Return(nil).
+					Times(1 + len(models.AvatarThumbnailSizes))
+
+				mockUserRepository := mocks.NewUserRepository(t)
+				mockUserRepository.EXPECT().
+					Get(ctx, testUserID).
+					Return(nil, errors.NewNotFoundError("user with id %s not found", testUserID)).
+					Once()
+
+				return UseCase{
+					userRepository: mockUserRepository,
+					txManager:      newTxManager(t),
+					blobStore:      blobStore,
+					avatarPolicy:   policy,
+					logger:         logger.NewMockLogger(),
+				}
+			},
+		},
+		"content type mismatch": {
+			args:        args{ctx: ctx, dto: &ports.UploadAvatarDto{ContentType: "image/jpeg", Data: avatarPNG}},
+			wantErr:     true,
+			expectedErr: errors.NewValidationError("avatar content is image/png, not image/jpeg"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{avatarPolicy: policy, logger: logger.NewMockLogger()}
+			},
+		},
+		"unsupported content type": {
+			args:        args{ctx: ctx, dto: &ports.UploadAvatarDto{ContentType: "image/svg+xml", Data: []byte("<svg/>")}},
+			wantErr:     true,
+			expectedErr: errors.NewValidationError("unsupported avatar content type"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{avatarPolicy: policy, logger: logger.NewMockLogger()}
+			},
+		},
+		"too large": {
+			args:        args{ctx: ctx, dto: &ports.UploadAvatarDto{ContentType: "image/png", Data: avatarPNG}},
+			wantErr:     true,
+			expectedErr: errors.NewValidationError("avatar cannot be larger than 16 bytes"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					avatarPolicy: models.AvatarPolicy{MaxBytes: 16, MaxDimension: 512},
+					logger:       logger.NewMockLogger(),
+				}
+			},
+		},
+		"dimensions too large": {
+			args:        args{ctx: ctx, dto: &ports.UploadAvatarDto{ContentType: "image/png", Data: avatarPNG}},
+			wantErr:     true,
+			expectedErr: errors.NewValidationError("avatar cannot be larger than 100x100 pixels"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{
+					avatarPolicy: models.AvatarPolicy{MaxBytes: 1 << 20, MaxDimension: 100},
+					logger:       logger.NewMockLogger(),
+				}
+			},
+		},
+		"unauthenticated": {
+			args:        args{ctx: context.Background(), dto: &ports.UploadAvatarDto{ContentType: "image/png", Data: avatarPNG}},
+			wantErr:     true,
+			expectedErr: errors.NewUnauthorizedError("request is not authenticated"),
+			deps: func(t *testing.T) UseCase {
+				return UseCase{logger: logger.NewMockLogger()}
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			useCase := tc.deps(t)
+			result, err := useCase.UploadAvatar(tc.args.ctx, tc.args.dto)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				if tc.expectedErr != nil {
+					assert.IsType(t, tc.expectedErr, err)
+				}
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, strings.HasSuffix(result.AvatarURL, ".png"))
+				assert.Len(t, result.ThumbnailURLs, len(models.AvatarThumbnailSizes))
+			}
+		})
+	}
+}
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
@@ -2,7 +2,7 @@ package user
 
 import (
 	"github.com/SamEkb/messenger-app/pkg/platform/logger"
-	"github.com/SamEkb/messenger-app/pkg/platform/postgres"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
 	"github.com/SamEkb/messenger-app/users-service/internal/app/ports"
 )
 
@@ -10,14 +10,27 @@ var _ ports.UserUseCase = (*UseCase)(nil)
 
 type UseCase struct {
 	userRepository ports.UserRepository
-	txManager      *postgres.TxManager
+	txManager      ports.TxManager
+	blobStore      ports.BlobStore
+	profileEvents  ports.ProfileEventsOutbox
+	avatarPolicy   models.AvatarPolicy
 	logger         logger.Logger
 }
 
-func NewUseCase(userRepository ports.UserRepository, txManager *postgres.TxManager, logger logger.Logger) *UseCase {
+func NewUseCase(
+	userRepository ports.UserRepository,
+	txManager ports.TxManager,
+	blobStore ports.BlobStore,
+	profileEvents ports.ProfileEventsOutbox,
+	avatarPolicy models.AvatarPolicy,
+	logger logger.Logger,
+) *UseCase {
 	return &UseCase{
 		userRepository: userRepository,
 		txManager:      txManager,
+		blobStore:      blobStore,
+		profileEvents:  profileEvents,
+		avatarPolicy:   avatarPolicy,
 		logger:         logger.With("component", "user_usecase"),
 	}
 }
@@ -0,0 +1,39 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SamEkb/messenger-app/pkg/api/events/v1"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/models"
+	"github.com/SamEkb/messenger-app/users-service/internal/app/usecases/user/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+// newTxManager returns a TxManager mock that runs the transaction body inline.
+func newTxManager(t *testing.T) *mocks.TxManager {
+	txManager := mocks.NewTxManager(t)
+	txManager.EXPECT().
+		RunTx(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+	return txManager
+}
+
+// savedProfile stands in for UserRepository.Update, returning the profile one
+// version newer as the repositories do.
+func savedProfile(_ context.Context, user *models.User) (*models.User, error) {
+	return user.WithVersion(user.Version() + 1), nil
+}
+
+// expectProfileUpdated returns a ProfileEventsOutbox mock expecting one
+// UserProfileUpdatedEvent that satisfies match.
+func expectProfileUpdated(t *testing.T, match func(event *events.UserProfileUpdatedEvent) bool) *mocks.ProfileEventsOutbox {
+	profileEvents := mocks.NewProfileEventsOutbox(t)
+	profileEvents.EXPECT().
+		EnqueueUserProfileUpdatedEvent(mock.Anything, mock.MatchedBy(match)).
+		Return(nil).
+		Once()
+	return profileEvents
+}
@@ -18,35 +18,60 @@ func ErrorsUnaryServerInterceptor() grpc.UnaryServerInterceptor {
 	) (resp any, err error) {
 		resp, err = handler(ctx, req)
 		if err != nil {
-			if _, ok := status.FromError(err); ok {
-				return resp, err
-			}
-
-			var code codes.Code
-			switch {
-			case errors.Is(err, errors.ErrNotFound):
-				code = codes.NotFound
-			case errors.Is(err, errors.ErrAlreadyExists):
-				code = codes.AlreadyExists
-			case errors.Is(err, errors.ErrUnauthorized):
-				code = codes.Unauthenticated
-			case errors.Is(err, errors.ErrForbidden):
-				code = codes.PermissionDenied
-			case errors.Is(err, errors.ErrInvalidInput),
-				errors.Is(err, errors.ErrValidation):
-				code = codes.InvalidArgument
-			case errors.Is(err, errors.ErrDatabaseConnection),
-				errors.Is(err, errors.ErrDatabaseQuery):
-				code = codes.Unavailable
-			case errors.Is(err, errors.ErrTimeout):
-				code = codes.DeadlineExceeded
-			default:
-				code = codes.Internal
-			}
-
-			return nil, status.Error(code, err.Error())
+			return nil, StatusError(err)
 		}
 
 		return resp, nil
 	}
 }
+
+func ErrorsStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if err := handler(srv, ss); err != nil {
+			return StatusError(err)
+		}
+
+		return nil
+	}
+}
+
+// StatusError converts an application error into a gRPC status error.
+// Errors that already carry a status are returned unchanged.
+func StatusError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var code codes.Code
+	switch {
+	case errors.Is(err, errors.ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, errors.ErrAlreadyExists):
+		code = codes.AlreadyExists
+	case errors.Is(err, errors.ErrUnauthorized):
+		code = codes.Unauthenticated
+	case errors.Is(err, errors.ErrForbidden):
+		code = codes.PermissionDenied
+	case errors.Is(err, errors.ErrConflict):
+		code = codes.Aborted
+	case errors.Is(err, errors.ErrInvalidInput),
+		errors.Is(err, errors.ErrValidation):
+		code = codes.InvalidArgument
+	case errors.Is(err, errors.ErrDatabaseConnection),
+		errors.Is(err, errors.ErrDatabaseQuery):
+		code = codes.Unavailable
+	case errors.Is(err, errors.ErrTimeout):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, errors.ErrResourceExhausted):
+		code = codes.ResourceExhausted
+	default:
+		code = codes.Internal
+	}
+
+	return status.Error(code, err.Error())
+}
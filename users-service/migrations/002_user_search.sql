@@ -0,0 +1,9 @@
+-- +goose Up
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS users_nickname_trgm_idx ON users USING GIN (nickname gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS users_description_trgm_idx ON users USING GIN (description gin_trgm_ops);
+
+-- +goose Down
+DROP INDEX IF EXISTS users_description_trgm_idx;
+DROP INDEX IF EXISTS users_nickname_trgm_idx;
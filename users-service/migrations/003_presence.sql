@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE users ADD COLUMN IF NOT EXISTS last_seen_visibility TEXT NOT NULL DEFAULT 'everyone';
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN IF EXISTS last_seen_visibility;